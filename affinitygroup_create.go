@@ -19,6 +19,7 @@ func (o *oVirtClient) CreateAffinityGroup(
 	err = retry(
 		fmt.Sprintf("creating affinity group in cluster %s", clusterID),
 		o.logger,
+		o.requestHooks("CreateAffinityGroup"),
 		retries,
 		func() error {
 			agBuilder := ovirtsdk4.NewAffinityGroupBuilder().
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetAffinityGroup(clusterID ClusterID, id AffinityGroupID,
 	err = retry(
 		fmt.Sprintf("getting affinity group %s", id),
 		o.logger,
+		o.requestHooks("GetAffinityGroup"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).AffinityGroupsService().GroupService(string(id)).Get().Send()
@@ -45,6 +46,7 @@ func (m *mockClient) GetAffinityGroup(clusterID ClusterID, id AffinityGroupID, r
 	err = retry(
 		fmt.Sprintf("getting affinity group %s from cluster %s", id, clusterID),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
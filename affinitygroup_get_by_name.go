@@ -11,6 +11,7 @@ func (o *oVirtClient) GetAffinityGroupByName(clusterID ClusterID, name string, r
 	err = retry(
 		fmt.Sprintf("getting affinity group %s", name),
 		o.logger,
+		o.requestHooks("GetAffinityGroupByName"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).AffinityGroupsService().List().Send()
@@ -63,6 +64,7 @@ func (m *mockClient) GetAffinityGroupByName(clusterID ClusterID, name string, re
 	err = retry(
 		fmt.Sprintf("getting affinity group %s from cluster %s", name, clusterID),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
@@ -12,6 +12,7 @@ func (o *oVirtClient) ListAffinityGroups(
 	err = retry(
 		fmt.Sprintf("listing affinity groups in cluster %s", clusterID),
 		o.logger,
+		o.requestHooks("ListAffinityGroups"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).AffinityGroupsService().List().Send()
@@ -7,6 +7,7 @@ func (o *oVirtClient) RemoveAffinityGroup(clusterID ClusterID, id AffinityGroupI
 	return retry(
 		fmt.Sprintf("removing affinity group %s from cluster %s", id, clusterID),
 		o.logger,
+		o.requestHooks("RemoveAffinityGroup"),
 		retries,
 		func() error {
 			_, err := o.conn.
@@ -29,6 +30,7 @@ func (m *mockClient) RemoveAffinityGroup(clusterID ClusterID, id AffinityGroupID
 	return retry(
 		fmt.Sprintf("removing affinity group %s from cluster %s", id, clusterID),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
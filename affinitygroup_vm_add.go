@@ -21,6 +21,7 @@ func (o *oVirtClient) AddVMToAffinityGroup(
 	return retry(
 		fmt.Sprintf("adding VM %s to affinity group %s", vmID, agID),
 		o.logger,
+		o.requestHooks("AddVMToAffinityGroup"),
 		retries,
 		func() error {
 			_, err := o.conn.
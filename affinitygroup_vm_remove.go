@@ -14,6 +14,7 @@ func (o *oVirtClient) RemoveVMFromAffinityGroup(
 	return retry(
 		fmt.Sprintf("adding VM %s to affinity group %s", vmID, agID),
 		o.logger,
+		o.requestHooks("RemoveVMFromAffinityGroup"),
 		retries,
 		func() error {
 			_, err := o.conn.
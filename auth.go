@@ -0,0 +1,188 @@
+package ovirtclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// AuthProvider supplies the credentials the client authenticates against the oVirt Engine with. Use PasswordAuth,
+// BearerTokenAuth or ClientCertificateAuth to create one and pass it to NewWithAuth.
+//
+// There is no Kerberos provider: the client does not perform SPNEGO negotiation itself. To use Kerberos, obtain an SSO
+// token from a Kerberos-enabled SSO login and pass it to BearerTokenAuth.
+type AuthProvider interface {
+	// Identity returns a human-readable identifier of the credentials, for example the username. It is used in log
+	// messages and as the key for storing tokens in a TokenStore.
+	Identity() string
+
+	// validate checks if the credentials are complete.
+	validate() error
+	// configureTLS adds client credentials to the TLS configuration, if the provider uses any.
+	configureTLS(config *tls.Config) error
+	// configureConnection sets the authentication options of the SDK connection.
+	configureConnection(builder *ovirtsdk4.ConnectionBuilder) *ovirtsdk4.ConnectionBuilder
+	// requestToken obtains a new SSO token from the engine.
+	requestToken(httpClient http.Client, engineURL string) (Token, error)
+}
+
+// PasswordAuth authenticates using a username and password. The username must be in the format of user@profile,
+// for example admin@internal.
+func PasswordAuth(username string, password string) AuthProvider {
+	return &passwordAuth{
+		username: username,
+		password: password,
+	}
+}
+
+type passwordAuth struct {
+	username string
+	password string
+}
+
+func (p *passwordAuth) Identity() string {
+	return p.username
+}
+
+func (p *passwordAuth) validate() error {
+	if err := validateUsername(p.username); err != nil {
+		return wrap(err, EBadArgument, "invalid username: %s", p.username)
+	}
+	if p.password == "" {
+		return newError(EBadArgument, "the password must not be empty")
+	}
+	return nil
+}
+
+func (p *passwordAuth) configureTLS(_ *tls.Config) error {
+	return nil
+}
+
+func (p *passwordAuth) configureConnection(builder *ovirtsdk4.ConnectionBuilder) *ovirtsdk4.ConnectionBuilder {
+	return builder.Username(p.username).Password(p.password)
+}
+
+func (p *passwordAuth) requestToken(httpClient http.Client, engineURL string) (Token, error) {
+	return requestSSOToken(
+		httpClient,
+		engineURL,
+		"/ovirt-engine/sso/oauth/token",
+		url.Values{
+			"grant_type": {"password"},
+			"scope":      {"ovirt-app-api"},
+			"username":   {p.username},
+			"password":   {p.password},
+		},
+	)
+}
+
+// BearerTokenAuth authenticates using an SSO access token that was obtained outside of this library, for example
+// from a Kerberos-backed SSO login. The token cannot be renewed by the client. Once it expires, calls fail with an
+// authentication error.
+func BearerTokenAuth(token string) AuthProvider {
+	return &bearerTokenAuth{
+		token: token,
+	}
+}
+
+type bearerTokenAuth struct {
+	token string
+}
+
+func (b *bearerTokenAuth) Identity() string {
+	return "bearer token"
+}
+
+func (b *bearerTokenAuth) validate() error {
+	if b.token == "" {
+		return newError(EBadArgument, "the bearer token must not be empty")
+	}
+	return nil
+}
+
+func (b *bearerTokenAuth) configureTLS(_ *tls.Config) error {
+	return nil
+}
+
+func (b *bearerTokenAuth) configureConnection(builder *ovirtsdk4.ConnectionBuilder) *ovirtsdk4.ConnectionBuilder {
+	return withoutSDKCredentials(builder)
+}
+
+func (b *bearerTokenAuth) requestToken(_ http.Client, _ string) (Token, error) {
+	return Token{AccessToken: b.token}, nil
+}
+
+// ClientCertificateAuth authenticates using a TLS client certificate. The engine must be configured to map client
+// certificates to users, in which case the client requests its SSO token using the HTTP authentication grant instead
+// of a password.
+func ClientCertificateAuth(certificate tls.Certificate) AuthProvider {
+	return &clientCertificateAuth{
+		certificate: certificate,
+	}
+}
+
+// ClientCertificateAuthFromFiles is identical to ClientCertificateAuth, but loads the PEM-encoded certificate and
+// private key from the specified files.
+func ClientCertificateAuthFromFiles(certFile string, keyFile string) (AuthProvider, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, wrap(err, EFileReadFailed, "failed to load client certificate from %s and %s", certFile, keyFile)
+	}
+	return ClientCertificateAuth(certificate), nil
+}
+
+type clientCertificateAuth struct {
+	certificate tls.Certificate
+}
+
+func (c *clientCertificateAuth) Identity() string {
+	if len(c.certificate.Certificate) == 0 {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(c.certificate.Certificate[0])
+	if err != nil {
+		return ""
+	}
+	return cert.Subject.String()
+}
+
+func (c *clientCertificateAuth) validate() error {
+	if len(c.certificate.Certificate) == 0 || c.certificate.PrivateKey == nil {
+		return newError(EBadArgument, "the client certificate must contain a certificate and a private key")
+	}
+	return nil
+}
+
+func (c *clientCertificateAuth) configureTLS(config *tls.Config) error {
+	config.Certificates = append(config.Certificates, c.certificate)
+	return nil
+}
+
+func (c *clientCertificateAuth) configureConnection(builder *ovirtsdk4.ConnectionBuilder) *ovirtsdk4.ConnectionBuilder {
+	// The token is requested by requestToken using the HTTP authentication grant, which the engine fulfills based on
+	// the client certificate.
+	return withoutSDKCredentials(builder)
+}
+
+func (c *clientCertificateAuth) requestToken(httpClient http.Client, engineURL string) (Token, error) {
+	return requestSSOToken(
+		httpClient,
+		engineURL,
+		"/ovirt-engine/sso/oauth/token-http-auth",
+		url.Values{
+			"grant_type": {"urn:ovirt:params:oauth:grant-type:http"},
+			"scope":      {"ovirt-app-api"},
+		},
+	)
+}
+
+// withoutSDKCredentials sets placeholder credentials on the connection builder. The SDK refuses to build a
+// connection without a username and password, even if they are not used for authentication. The SDK only sends them
+// to request a token if the connection has none, which does not happen because the tokenManager injects a token
+// before the connection is used. TestClientCertificateAuthDoesNotSendPlaceholderCredentials verifies this.
+func withoutSDKCredentials(builder *ovirtsdk4.ConnectionBuilder) *ovirtsdk4.ConnectionBuilder {
+	return builder.Username("unused@internal").Password("unused")
+}
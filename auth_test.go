@@ -0,0 +1,170 @@
+// This file contains tests for the internal authentication provider functionality. It is therefore excluded from the
+// testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func TestBearerTokenAuthReturnsToken(t *testing.T) {
+	t.Parallel()
+
+	auth := BearerTokenAuth("test-token")
+	if err := auth.validate(); err != nil {
+		t.Fatalf("Valid bearer token rejected (%v)", err)
+	}
+	token, err := auth.requestToken(http.Client{}, "https://localhost/ovirt-engine/api")
+	if err != nil {
+		t.Fatalf("Failed to request token (%v)", err)
+	}
+	if token.AccessToken != "test-token" || token.ExpiresWithin(time.Hour) {
+		t.Fatalf("Incorrect bearer token returned (%s, expires at %s)", token.AccessToken, token.ExpiresAt)
+	}
+}
+
+func TestClientCertificateAuth(t *testing.T) {
+	t.Parallel()
+
+	auth := ClientCertificateAuth(generateTestClientCertificate(t, "test-user"))
+	if err := auth.validate(); err != nil {
+		t.Fatalf("Valid client certificate rejected (%v)", err)
+	}
+	if identity := auth.Identity(); identity != "CN=test-user" {
+		t.Fatalf("Incorrect identity for client certificate (%s)", identity)
+	}
+	config := &tls.Config{} //nolint:gosec
+	if err := auth.configureTLS(config); err != nil {
+		t.Fatalf("Failed to configure TLS (%v)", err)
+	}
+	if len(config.Certificates) != 1 {
+		t.Fatalf("Client certificate was not added to the TLS configuration.")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ovirt-engine/sso/oauth/token-http-auth" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "urn:ovirt:params:oauth:grant-type:http" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"cert-token"}`))
+	}))
+	defer server.Close()
+	token, err := auth.requestToken(*server.Client(), server.URL+"/ovirt-engine/api")
+	if err != nil {
+		t.Fatalf("Failed to request token (%v)", err)
+	}
+	if token.AccessToken != "cert-token" {
+		t.Fatalf("Incorrect token returned (%s)", token.AccessToken)
+	}
+}
+
+func TestClientCertificateAuthDoesNotSendPlaceholderCredentials(t *testing.T) {
+	t.Parallel()
+
+	// The placeholder credentials are only needed as long as the SDK refuses to build a connection without them.
+	if _, err := ovirtsdk4.NewConnectionBuilder().URL("https://localhost/ovirt-engine/api").Build(); err == nil {
+		t.Fatalf("The SDK accepts connections without credentials, withoutSDKCredentials can be removed.")
+	}
+
+	lock := &sync.Mutex{}
+	var paths []string
+	var authorization []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/ovirt-engine/sso/oauth/token-http-auth":
+			_, _ = w.Write([]byte(`{"access_token":"cert-token"}`))
+		case "/ovirt-engine/api/datacenters":
+			authorization = append(authorization, r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<data_centers></data_centers>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewWithAuthAndVerify(
+		server.URL+"/ovirt-engine/api",
+		ClientCertificateAuth(generateTestClientCertificate(t, "test-user")),
+		TLS().Insecure(),
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client with a client certificate (%v)", err)
+	}
+	if _, err := client.ListDatacenters(); err != nil {
+		t.Fatalf("Failed to list datacenters (%v)", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, path := range paths {
+		if path == "/ovirt-engine/sso/oauth/token" {
+			t.Fatalf("The placeholder credentials were sent to the engine.")
+		}
+	}
+	if len(authorization) != 1 || authorization[0] != "Bearer cert-token" {
+		t.Fatalf("The certificate token was not used (%v)", authorization)
+	}
+}
+
+func TestNewWithAuthRejectsInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	for name, auth := range map[string]AuthProvider{
+		"nil":              nil,
+		"empty_password":   PasswordAuth("admin@internal", ""),
+		"invalid_username": PasswordAuth("admin", "password"),
+		"empty_token":      BearerTokenAuth(""),
+		"empty_cert":       ClientCertificateAuth(tls.Certificate{}),
+	} {
+		_, err := NewWithAuthAndVerify("https://localhost/ovirt-engine/api", auth, TLS().Insecure(), nil, nil, nil)
+		if !HasErrorCode(err, EBadArgument) {
+			t.Fatalf("Invalid credentials (%s) did not result in an EBadArgument error (%v)", name, err)
+		}
+	}
+}
+
+func generateTestClientCertificate(t *testing.T, commonName string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate private key (%v)", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate (%v)", err)
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
@@ -0,0 +1,466 @@
+package ovirtclient
+
+import (
+	"io"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// BackupID is the identifier for VM backups.
+type BackupID string
+
+// CheckpointID is the identifier for VM checkpoints. A checkpoint marks the point in time a backup was taken and
+// is used as the starting point of the next incremental backup.
+type CheckpointID string
+
+// BackupClient describes the functions related to VM backups based on changed block tracking.
+//
+// A backup is started with StartVMBackup. Once it reaches BackupPhaseReady, the disks can be downloaded with
+// DownloadVMBackupDisk. Finally, the backup must be finalized with FinalizeVMBackup to release the disks. Each backup
+// creates a new checkpoint, which can be passed to the next backup to only download the blocks that changed since.
+//
+// Incremental backups require disks in the qcow2 format with incremental backup enabled.
+type BackupClient interface {
+	// StartVMBackup starts a backup of the specified VM. If params contains a checkpoint ID the backup is
+	// incremental and only contains the blocks that changed since that checkpoint, otherwise it is a full backup.
+	// Use WaitForVMBackupPhase to wait for the backup to become BackupPhaseReady before downloading disks.
+	StartVMBackup(vmID VMID, params BackupParameters, retries ...RetryStrategy) (Backup, error)
+	// ListVMBackups lists all backups of the specified VM.
+	ListVMBackups(vmID VMID, retries ...RetryStrategy) ([]Backup, error)
+	// GetVMBackup returns a single backup of the specified VM.
+	GetVMBackup(vmID VMID, backupID BackupID, retries ...RetryStrategy) (Backup, error)
+	// WaitForVMBackupPhase waits for the backup to reach the desired phase.
+	WaitForVMBackupPhase(
+		vmID VMID,
+		backupID BackupID,
+		phase BackupPhase,
+		retries ...RetryStrategy,
+	) (Backup, error)
+	// DownloadVMBackupDisk downloads the contents of a disk in a ready backup and writes them to target at their
+	// offset on the disk. For full backups all extents containing data are written and zero extents are skipped,
+	// so target should be empty. For incremental backups only the extents changed since the checkpoint are
+	// written, so target should contain the disk contents as of that checkpoint. The written extents are
+	// returned.
+	//
+	// If target has a Truncate(int64) error function, it is resized to the disk size on full backups. The download
+	// is limited by the client-wide TransferBandwidthLimit.
+	DownloadVMBackupDisk(
+		vmID VMID,
+		backupID BackupID,
+		diskID DiskID,
+		target io.WriterAt,
+		retries ...RetryStrategy,
+	) ([]BackupExtent, error)
+	// FinalizeVMBackup finishes the backup and releases the disks. It must be called after all disks have been
+	// downloaded. Use WaitForVMBackupPhase to wait for the backup to reach BackupPhaseSucceeded.
+	FinalizeVMBackup(vmID VMID, backupID BackupID, retries ...RetryStrategy) error
+
+	// ListVMCheckpoints lists the checkpoints of the specified VM, starting with the oldest one.
+	ListVMCheckpoints(vmID VMID, retries ...RetryStrategy) ([]Checkpoint, error)
+	// RemoveVMCheckpoint removes a checkpoint of the specified VM. Only the oldest checkpoint can be removed.
+	// Incremental backups can no longer be started from removed checkpoints.
+	RemoveVMCheckpoint(vmID VMID, checkpointID CheckpointID, retries ...RetryStrategy) error
+}
+
+// BackupData is the core of Backup, providing only data access functions.
+type BackupData interface {
+	// ID returns the identifier of the backup.
+	ID() BackupID
+	// VMID returns the ID of the VM this backup belongs to.
+	VMID() VMID
+	// Phase returns the current phase of the backup.
+	Phase() BackupPhase
+	// FromCheckpointID returns the checkpoint the backup is incremental to, or an empty string for full backups.
+	FromCheckpointID() CheckpointID
+	// ToCheckpointID returns the checkpoint created by this backup. It can be used as the starting point of the
+	// next incremental backup.
+	ToCheckpointID() CheckpointID
+	// DiskIDs returns the disks included in the backup.
+	DiskIDs() []DiskID
+	// CreationDate returns the time the backup was started.
+	CreationDate() time.Time
+}
+
+// Backup is a backup of the disks of a VM.
+type Backup interface {
+	BackupData
+
+	// WaitForPhase waits for this backup to reach the desired phase and returns the updated backup.
+	WaitForPhase(phase BackupPhase, retries ...RetryStrategy) (Backup, error)
+	// DownloadDisk downloads a disk of this backup. See BackupClient.DownloadVMBackupDisk for details.
+	DownloadDisk(diskID DiskID, target io.WriterAt, retries ...RetryStrategy) ([]BackupExtent, error)
+	// Finalize finishes this backup. See BackupClient.FinalizeVMBackup for details.
+	Finalize(retries ...RetryStrategy) error
+}
+
+// BackupExtent is a region of a disk that was written by DownloadVMBackupDisk.
+type BackupExtent struct {
+	// Offset is the start of the region on the disk in bytes.
+	Offset uint64
+	// Length is the length of the region in bytes.
+	Length uint64
+	// Zero indicates that the region only contains zeroes. Zero extents are written as zeroes instead of being
+	// downloaded.
+	Zero bool
+}
+
+// BackupPhase is the phase of a backup.
+type BackupPhase string
+
+const (
+	// BackupPhaseInitializing indicates that the backup is being prepared.
+	BackupPhaseInitializing BackupPhase = "initializing"
+	// BackupPhaseStarting indicates that the backup is being started on the host.
+	BackupPhaseStarting BackupPhase = "starting"
+	// BackupPhaseReady indicates that the disks of the backup can be downloaded.
+	BackupPhaseReady BackupPhase = "ready"
+	// BackupPhaseFinalizing indicates that the backup is being finalized.
+	BackupPhaseFinalizing BackupPhase = "finalizing"
+	// BackupPhaseSucceeded indicates that the backup has been finalized successfully.
+	BackupPhaseSucceeded BackupPhase = "succeeded"
+	// BackupPhaseFailed indicates that the backup failed.
+	BackupPhaseFailed BackupPhase = "failed"
+)
+
+// BackupPhaseList is a list of BackupPhase.
+type BackupPhaseList []BackupPhase
+
+// BackupPhaseValues returns all possible BackupPhase values.
+func BackupPhaseValues() BackupPhaseList {
+	return []BackupPhase{
+		BackupPhaseInitializing,
+		BackupPhaseStarting,
+		BackupPhaseReady,
+		BackupPhaseFinalizing,
+		BackupPhaseSucceeded,
+		BackupPhaseFailed,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l BackupPhaseList) Strings() []string {
+	result := make([]string, len(l))
+	for i, phase := range l {
+		result[i] = string(phase)
+	}
+	return result
+}
+
+// CheckpointData is the core of Checkpoint, providing only data access functions.
+type CheckpointData interface {
+	// ID returns the identifier of the checkpoint.
+	ID() CheckpointID
+	// VMID returns the ID of the VM this checkpoint belongs to.
+	VMID() VMID
+	// ParentID returns the ID of the previous checkpoint, or an empty string for the first checkpoint.
+	ParentID() CheckpointID
+	// State returns the state of the checkpoint.
+	State() CheckpointState
+	// DiskIDs returns the disks tracked by the checkpoint.
+	DiskIDs() []DiskID
+	// CreationDate returns the time the checkpoint was created.
+	CreationDate() time.Time
+}
+
+// Checkpoint marks the point in time a backup was taken.
+type Checkpoint interface {
+	CheckpointData
+
+	// Remove removes this checkpoint. See BackupClient.RemoveVMCheckpoint for details.
+	Remove(retries ...RetryStrategy) error
+}
+
+// CheckpointState is the state of a checkpoint.
+type CheckpointState string
+
+const (
+	// CheckpointStateCreated indicates that the checkpoint can be used for incremental backups.
+	CheckpointStateCreated CheckpointState = "created"
+	// CheckpointStateInvalid indicates that the changed block information of the checkpoint was lost and it can no
+	// longer be used for incremental backups.
+	CheckpointStateInvalid CheckpointState = "invalid"
+)
+
+// CheckpointStateList is a list of CheckpointState.
+type CheckpointStateList []CheckpointState
+
+// CheckpointStateValues returns all possible CheckpointState values.
+func CheckpointStateValues() CheckpointStateList {
+	return []CheckpointState{
+		CheckpointStateCreated,
+		CheckpointStateInvalid,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l CheckpointStateList) Strings() []string {
+	result := make([]string, len(l))
+	for i, state := range l {
+		result[i] = string(state)
+	}
+	return result
+}
+
+// BackupParameters contains the optional parameters for starting a backup.
+type BackupParameters interface {
+	// FromCheckpointID returns the checkpoint an incremental backup starts from. If empty, a full backup is taken.
+	FromCheckpointID() CheckpointID
+	// DiskIDs returns the list of disks to include in the backup. If empty, all disks of the VM are included.
+	DiskIDs() []DiskID
+	// Description returns the description of the backup.
+	Description() string
+}
+
+// BuildableBackupParameters is a buildable version of BackupParameters.
+type BuildableBackupParameters interface {
+	BackupParameters
+
+	// WithFromCheckpointID makes the backup incremental from the specified checkpoint.
+	WithFromCheckpointID(checkpointID CheckpointID) (BuildableBackupParameters, error)
+	// MustWithFromCheckpointID is identical to WithFromCheckpointID, but panics instead of returning an error.
+	MustWithFromCheckpointID(checkpointID CheckpointID) BuildableBackupParameters
+	// WithDiskIDs sets the subset of disks to include in the backup.
+	WithDiskIDs(diskIDs []DiskID) (BuildableBackupParameters, error)
+	// MustWithDiskIDs is identical to WithDiskIDs, but panics instead of returning an error.
+	MustWithDiskIDs(diskIDs []DiskID) BuildableBackupParameters
+	// WithDescription sets the description of the backup.
+	WithDescription(description string) (BuildableBackupParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableBackupParameters
+}
+
+// NewBackupParams creates a buildable set of BackupParameters to pass to the StartVMBackup function.
+func NewBackupParams() BuildableBackupParameters {
+	return &backupParams{}
+}
+
+type backupParams struct {
+	fromCheckpointID CheckpointID
+	diskIDs          []DiskID
+	description      string
+}
+
+func (b *backupParams) FromCheckpointID() CheckpointID {
+	return b.fromCheckpointID
+}
+
+func (b *backupParams) DiskIDs() []DiskID {
+	return b.diskIDs
+}
+
+func (b *backupParams) Description() string {
+	return b.description
+}
+
+func (b *backupParams) WithFromCheckpointID(checkpointID CheckpointID) (BuildableBackupParameters, error) {
+	if checkpointID == "" {
+		return nil, newError(EBadArgument, "the checkpoint ID for incremental backups must not be empty")
+	}
+	b.fromCheckpointID = checkpointID
+	return b, nil
+}
+
+func (b *backupParams) MustWithFromCheckpointID(checkpointID CheckpointID) BuildableBackupParameters {
+	builder, err := b.WithFromCheckpointID(checkpointID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (b *backupParams) WithDiskIDs(diskIDs []DiskID) (BuildableBackupParameters, error) {
+	for _, diskID := range diskIDs {
+		if diskID == "" {
+			return nil, newError(EBadArgument, "disk IDs for backups must not be empty")
+		}
+	}
+	b.diskIDs = diskIDs
+	return b, nil
+}
+
+func (b *backupParams) MustWithDiskIDs(diskIDs []DiskID) BuildableBackupParameters {
+	builder, err := b.WithDiskIDs(diskIDs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (b *backupParams) WithDescription(description string) (BuildableBackupParameters, error) {
+	b.description = description
+	return b, nil
+}
+
+func (b *backupParams) MustWithDescription(description string) BuildableBackupParameters {
+	builder, err := b.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func convertSDKBackup(sdkObject *ovirtsdk.Backup, vmID VMID, client Client) (Backup, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("backup", "id")
+	}
+	phase, ok := sdkObject.Phase()
+	if !ok {
+		return nil, newFieldNotFound("backup", "phase")
+	}
+	fromCheckpointID, _ := sdkObject.FromCheckpointId()
+	// The checkpoint is only known once the backup has started.
+	toCheckpointID, _ := sdkObject.ToCheckpointId()
+	creationDate, _ := sdkObject.CreationDate()
+	if sdkVM, ok := sdkObject.Vm(); ok {
+		if sdkVMID, ok := sdkVM.Id(); ok {
+			vmID = VMID(sdkVMID)
+		}
+	}
+	var diskIDs []DiskID
+	if sdkDisks, ok := sdkObject.Disks(); ok {
+		for _, sdkDisk := range sdkDisks.Slice() {
+			if diskID, ok := sdkDisk.Id(); ok {
+				diskIDs = append(diskIDs, DiskID(diskID))
+			}
+		}
+	}
+	return &backup{
+		client:           client,
+		id:               BackupID(id),
+		vmID:             vmID,
+		phase:            BackupPhase(phase),
+		fromCheckpointID: CheckpointID(fromCheckpointID),
+		toCheckpointID:   CheckpointID(toCheckpointID),
+		diskIDs:          diskIDs,
+		creationDate:     creationDate,
+	}, nil
+}
+
+type backup struct {
+	client Client
+
+	id               BackupID
+	vmID             VMID
+	phase            BackupPhase
+	fromCheckpointID CheckpointID
+	toCheckpointID   CheckpointID
+	diskIDs          []DiskID
+	creationDate     time.Time
+}
+
+func (b *backup) ID() BackupID {
+	return b.id
+}
+
+func (b *backup) VMID() VMID {
+	return b.vmID
+}
+
+func (b *backup) Phase() BackupPhase {
+	return b.phase
+}
+
+func (b *backup) FromCheckpointID() CheckpointID {
+	return b.fromCheckpointID
+}
+
+func (b *backup) ToCheckpointID() CheckpointID {
+	return b.toCheckpointID
+}
+
+func (b *backup) DiskIDs() []DiskID {
+	return b.diskIDs
+}
+
+func (b *backup) CreationDate() time.Time {
+	return b.creationDate
+}
+
+func (b *backup) WaitForPhase(phase BackupPhase, retries ...RetryStrategy) (Backup, error) {
+	return b.client.WaitForVMBackupPhase(b.vmID, b.id, phase, retries...)
+}
+
+func (b *backup) DownloadDisk(diskID DiskID, target io.WriterAt, retries ...RetryStrategy) ([]BackupExtent, error) {
+	return b.client.DownloadVMBackupDisk(b.vmID, b.id, diskID, target, retries...)
+}
+
+func (b *backup) Finalize(retries ...RetryStrategy) error {
+	return b.client.FinalizeVMBackup(b.vmID, b.id, retries...)
+}
+
+func convertSDKCheckpoint(sdkObject *ovirtsdk.Checkpoint, vmID VMID, client Client) (Checkpoint, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("checkpoint", "id")
+	}
+	state, ok := sdkObject.State()
+	if !ok {
+		return nil, newFieldNotFound("checkpoint", "state")
+	}
+	// The first checkpoint of a VM has no parent.
+	parentID, _ := sdkObject.ParentId()
+	creationDate, _ := sdkObject.CreationDate()
+	if sdkVM, ok := sdkObject.Vm(); ok {
+		if sdkVMID, ok := sdkVM.Id(); ok {
+			vmID = VMID(sdkVMID)
+		}
+	}
+	var diskIDs []DiskID
+	if sdkDisks, ok := sdkObject.Disks(); ok {
+		for _, sdkDisk := range sdkDisks.Slice() {
+			if diskID, ok := sdkDisk.Id(); ok {
+				diskIDs = append(diskIDs, DiskID(diskID))
+			}
+		}
+	}
+	return &checkpoint{
+		client:       client,
+		id:           CheckpointID(id),
+		vmID:         vmID,
+		parentID:     CheckpointID(parentID),
+		state:        CheckpointState(state),
+		diskIDs:      diskIDs,
+		creationDate: creationDate,
+	}, nil
+}
+
+type checkpoint struct {
+	client Client
+
+	id           CheckpointID
+	vmID         VMID
+	parentID     CheckpointID
+	state        CheckpointState
+	diskIDs      []DiskID
+	creationDate time.Time
+}
+
+func (c *checkpoint) ID() CheckpointID {
+	return c.id
+}
+
+func (c *checkpoint) VMID() VMID {
+	return c.vmID
+}
+
+func (c *checkpoint) ParentID() CheckpointID {
+	return c.parentID
+}
+
+func (c *checkpoint) State() CheckpointState {
+	return c.state
+}
+
+func (c *checkpoint) DiskIDs() []DiskID {
+	return c.diskIDs
+}
+
+func (c *checkpoint) CreationDate() time.Time {
+	return c.creationDate
+}
+
+func (c *checkpoint) Remove(retries ...RetryStrategy) error {
+	return c.client.RemoveVMCheckpoint(c.vmID, c.id, retries...)
+}
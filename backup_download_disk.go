@@ -0,0 +1,225 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+func (o *oVirtClient) DownloadVMBackupDisk(
+	vmID VMID,
+	backupID BackupID,
+	diskID DiskID,
+	target io.WriterAt,
+	retries ...RetryStrategy,
+) (result []BackupExtent, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+
+	backup, err := o.GetVMBackup(vmID, backupID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBackupDiskDownloadable(backup, diskID); err != nil {
+		return nil, err
+	}
+
+	o.logger.Infof("Starting download of disk %s from backup %s of VM %s...", diskID, backupID, vmID)
+	transfer := newBackupImageTransfer(o, o.logger, diskID, backupID, retries)
+	transferURL, err := transfer.initialize()
+	defer func() {
+		err = transfer.finalize(err)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.supports("extents") {
+		return nil, newError(
+			EUnsupported,
+			"the ImageIO server does not support extents, which are required for downloading backups",
+		)
+	}
+
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Full backups contain all data extents, incremental backups only the extents changed since the checkpoint.
+	full := backup.FromCheckpointID() == ""
+	extentsContext := "zero"
+	if !full {
+		extentsContext = "dirty"
+	}
+	extents, err := getImageExtents(ctx, o, transfer, transferURL, extentsContext, retries)
+	if err != nil {
+		return nil, err
+	}
+
+	throttle := newTransferThrottle(o.transferLimiter, 0)
+	size := uint64(0)
+	for _, extent := range extents {
+		if end := extent.Start + extent.Length; end > size {
+			size = end
+		}
+		if (full && extent.Zero) || (!full && !extent.Dirty) {
+			continue
+		}
+		if extent.Zero {
+			err = writeZeroes(target, extent.Start, extent.Length)
+		} else {
+			err = o.downloadBackupExtent(ctx, transfer, transferURL, target, extent, throttle, retries)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, BackupExtent{Offset: extent.Start, Length: extent.Length, Zero: extent.Zero})
+	}
+	if full {
+		if err := truncateBackupTarget(target, size); err != nil {
+			return nil, err
+		}
+	}
+	o.logger.Infof("Downloaded %d extents of disk %s from backup %s.", len(result), diskID, backupID)
+	return result, nil
+}
+
+// downloadBackupExtent downloads a single extent of a backup in ranged requests and writes it to target at the same
+// offset. Each range is retried on its own and passes through the bandwidth limits of the throttle.
+func (o *oVirtClient) downloadBackupExtent(
+	ctx context.Context,
+	transfer imageTransfer,
+	transferURL string,
+	target io.WriterAt,
+	extent imageExtent,
+	throttle *transferThrottle,
+	retries []RetryStrategy,
+) error {
+	retries = throttle.retries(append(retries, ContextStrategy(ctx)))
+	end := extent.Start + extent.Length
+	for offset := extent.Start; offset < end; offset += downloadChunkSize {
+		length := downloadChunkSize
+		if offset+length > end {
+			length = end - offset
+		}
+		if err := retry(
+			fmt.Sprintf("downloading bytes %d-%d from %s", offset, offset+length-1, transferURL),
+			o.logger,
+			o.transferHooks("DownloadBackupExtent"),
+			retries,
+			func() error {
+				response, err := getImageRange(ctx, o, transfer, transferURL, offset, length)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = response.Body.Close()
+				}()
+				body := throttle.reader(ctx, response.Body)
+				if _, err := io.CopyN(&offsetWriter{w: target, offset: int64(offset)}, body, int64(length)); err != nil {
+					return wrap(err, EConnection, "failed to download bytes %d-%d", offset, offset+length-1)
+				}
+				return nil
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockClient) DownloadVMBackupDisk(
+	vmID VMID,
+	backupID BackupID,
+	diskID DiskID,
+	target io.WriterAt,
+	_ ...RetryStrategy,
+) ([]BackupExtent, error) {
+	m.lock.Lock()
+	b, err := m.findBackup(vmID, backupID)
+	if err != nil {
+		m.lock.Unlock()
+		return nil, err
+	}
+	if err := checkBackupDiskDownloadable(b, diskID); err != nil {
+		m.lock.Unlock()
+		return nil, err
+	}
+	disk := b.disks[diskID]
+	m.lock.Unlock()
+
+	// The backup data is not modified after the backup is taken, so it can be written without holding the lock while
+	// waiting for the bandwidth limit.
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	throttle := newTransferThrottle(m.transferLimiter, 0)
+	for _, extent := range disk.extents {
+		if extent.Zero {
+			err = writeZeroes(target, extent.Offset, extent.Length)
+		} else if _, err = io.Copy(
+			&offsetWriter{w: target, offset: int64(extent.Offset)},
+			throttle.reader(ctx, bytes.NewReader(disk.data[extent.Offset:extent.Offset+extent.Length])),
+		); err != nil {
+			err = wrap(err, ELocalIO, "failed to write bytes %d-%d", extent.Offset, extent.Offset+extent.Length-1)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if disk.full {
+		if err := truncateBackupTarget(target, uint64(len(disk.data))); err != nil {
+			return nil, err
+		}
+	}
+	return append([]BackupExtent(nil), disk.extents...), nil
+}
+
+// checkBackupDiskDownloadable checks if the backup is ready and contains the specified disk. The disks are only
+// checked if the backup lists them.
+func checkBackupDiskDownloadable(backup Backup, diskID DiskID) error {
+	if phase := backup.Phase(); phase != BackupPhaseReady {
+		return newError(
+			EConflict,
+			"backup %s is in phase %s, disks can only be downloaded in phase %s",
+			backup.ID(),
+			phase,
+			BackupPhaseReady,
+		)
+	}
+	diskIDs := backup.DiskIDs()
+	if len(diskIDs) == 0 {
+		return nil
+	}
+	for _, id := range diskIDs {
+		if id == diskID {
+			return nil
+		}
+	}
+	return newError(EBadArgument, "disk %s is not part of backup %s", diskID, backup.ID())
+}
+
+// truncateBackupTarget resizes the target of a full backup to the disk size if it supports truncating, as skipping
+// zero extents at the end of the disk does not extend it.
+func truncateBackupTarget(target io.WriterAt, size uint64) error {
+	truncater, ok := target.(interface{ Truncate(size int64) error })
+	if !ok {
+		return nil
+	}
+	if err := truncater.Truncate(int64(size)); err != nil {
+		return wrap(err, ELocalIO, "failed to resize the backup target to %d bytes", size)
+	}
+	return nil
+}
+
+// offsetWriter writes sequentially to an io.WriterAt, starting at the specified offset.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+func (o *offsetWriter) Write(p []byte) (int, error) {
+	n, err := o.w.WriteAt(p, o.offset)
+	o.offset += int64(n)
+	return n, err
+}
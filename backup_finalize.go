@@ -0,0 +1,41 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) FinalizeVMBackup(vmID VMID, backupID BackupID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("finalizing backup %s of VM %s", backupID, vmID),
+		o.logger,
+		o.requestHooks("FinalizeVMBackup"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				BackupService(string(backupID)).
+				Finalize().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) FinalizeVMBackup(vmID VMID, backupID BackupID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	b, err := m.findBackup(vmID, backupID)
+	if err != nil {
+		return err
+	}
+	if phase := b.Phase(); phase != BackupPhaseReady {
+		return newError(EConflict, "backup %s is in phase %s, not %s", backupID, phase, BackupPhaseReady)
+	}
+	b.setPhase(BackupPhaseSucceeded)
+	return nil
+}
@@ -0,0 +1,54 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetVMBackup(vmID VMID, backupID BackupID, retries ...RetryStrategy) (result Backup, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting backup %s of VM %s", backupID, vmID),
+		o.logger,
+		o.requestHooks("GetVMBackup"),
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				BackupService(string(backupID)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Backup()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no backup returned when getting backup ID %s of VM %s",
+					backupID,
+					vmID,
+				)
+			}
+			result, err = convertSDKBackup(sdkObject, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert backup %s",
+					backupID,
+				)
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) GetVMBackup(vmID VMID, backupID BackupID, _ ...RetryStrategy) (Backup, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.findBackup(vmID, backupID)
+}
@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListVMBackups(vmID VMID, retries ...RetryStrategy) (result []Backup, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Backup{}
+	err = retry(
+		fmt.Sprintf("listing backups of VM %s", vmID),
+		o.logger,
+		o.requestHooks("ListVMBackups"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).BackupsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Backups()
+			if !ok {
+				return nil
+			}
+			result = make([]Backup, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKBackup(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert backup during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListVMBackups(vmID VMID, _ ...RetryStrategy) ([]Backup, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	result := make([]Backup, len(m.backups[vmID]))
+	for i, b := range m.backups[vmID] {
+		result[i] = b
+	}
+	return result, nil
+}
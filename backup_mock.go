@@ -0,0 +1,167 @@
+package ovirtclient
+
+import (
+	"sync"
+)
+
+// mockBackupBlockSize is the granularity of the dirty bitmaps kept by the mock. It matches the default granularity of
+// the QEMU dirty bitmaps oVirt uses for changed block tracking.
+const mockBackupBlockSize = 64 * 1024
+
+// backupWithData adds the ability to store the disk contents in the backup for mocking purposes.
+type backupWithData struct {
+	backup
+	lock *sync.Mutex
+	// disks holds the contents and extents of each disk at the time the backup was started.
+	disks map[DiskID]*mockBackupDisk
+}
+
+// mockBackupDisk is the point-in-time view of a disk in a mock backup.
+type mockBackupDisk struct {
+	data    []byte
+	extents []BackupExtent
+	// full indicates that the disk is backed up in full, either because the backup is a full backup or because the
+	// disk was not tracked by the checkpoint the backup starts from.
+	full bool
+}
+
+func (b *backupWithData) Phase() BackupPhase {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.phase
+}
+
+func (b *backupWithData) setPhase(phase BackupPhase) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.phase = phase
+}
+
+// Ready changes the backup phase from initializing to ready. It has no effect on backups in other phases.
+func (b *backupWithData) Ready() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.phase == BackupPhaseInitializing {
+		b.phase = BackupPhaseReady
+	}
+}
+
+// inProgress returns true if the backup has not been finalized yet.
+func (b *backupWithData) inProgress() bool {
+	switch b.Phase() {
+	case BackupPhaseSucceeded, BackupPhaseFailed:
+		return false
+	default:
+		return true
+	}
+}
+
+// findBackup returns the backup with the specified ID on the VM. The caller must hold the mock lock.
+func (m *mockClient) findBackup(vmID VMID, backupID BackupID) (*backupWithData, error) {
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	for _, b := range m.backups[vmID] {
+		if b.id == backupID {
+			return b, nil
+		}
+	}
+	return nil, newError(ENotFound, "backup with ID %s not found on VM %s", backupID, vmID)
+}
+
+// findCheckpoint returns the checkpoint with the specified ID on the VM along with its index. The caller must hold
+// the mock lock.
+func (m *mockClient) findCheckpoint(vmID VMID, checkpointID CheckpointID) (*checkpoint, int, error) {
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, -1, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	for i, c := range m.checkpoints[vmID] {
+		if c.id == checkpointID {
+			return c, i, nil
+		}
+	}
+	return nil, -1, newError(ENotFound, "checkpoint with ID %s not found on VM %s", checkpointID, vmID)
+}
+
+// replaceData replaces the contents of the disk from the specified offset onwards and marks the replaced blocks as
+// dirty in all bitmaps of the disk.
+func (d *diskWithData) replaceData(offset uint64, data []byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	end := uint64(len(d.data))
+	d.data = append(d.data[:offset:offset], data...)
+	if newEnd := uint64(len(d.data)); newEnd > end {
+		end = newEnd
+	}
+	if end <= offset {
+		return
+	}
+	for checkpointID, bitmap := range d.dirtyBitmaps {
+		for block := offset / mockBackupBlockSize; block <= (end-1)/mockBackupBlockSize; block++ {
+			for uint64(len(bitmap)) <= block {
+				bitmap = append(bitmap, false)
+			}
+			bitmap[block] = true
+		}
+		d.dirtyBitmaps[checkpointID] = bitmap
+	}
+}
+
+// startCheckpoint returns the current contents of the disk and the blocks changed since fromCheckpointID, then
+// starts tracking changes for toCheckpointID. If fromCheckpointID is empty or the disk is not tracked by it, tracked
+// is false and the disk must be backed up in full.
+func (d *diskWithData) startCheckpoint(
+	fromCheckpointID CheckpointID,
+	toCheckpointID CheckpointID,
+) (data []byte, dirty []bool, tracked bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	data = copyMockDiskData(d.data)
+	if fromCheckpointID != "" {
+		var bitmap []bool
+		if bitmap, tracked = d.dirtyBitmaps[fromCheckpointID]; tracked {
+			dirty = append([]bool{}, bitmap...)
+		}
+	}
+	if d.dirtyBitmaps == nil {
+		d.dirtyBitmaps = map[CheckpointID][]bool{}
+	}
+	d.dirtyBitmaps[toCheckpointID] = []bool{}
+	return data, dirty, tracked
+}
+
+// removeDirtyBitmap stops tracking changes for the specified checkpoint.
+func (d *diskWithData) removeDirtyBitmap(checkpointID CheckpointID) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.dirtyBitmaps, checkpointID)
+}
+
+// mockBackupExtents returns the extents of data to include in a backup. For full backups all blocks containing data
+// are included. For incremental backups only the blocks marked in the dirty bitmap are included, and blocks that
+// only contain zeroes are returned as zero extents.
+func mockBackupExtents(data []byte, dirty []bool, full bool) []BackupExtent {
+	var extents []BackupExtent
+	for offset := uint64(0); offset < uint64(len(data)); offset += mockBackupBlockSize {
+		end := offset + mockBackupBlockSize
+		if end > uint64(len(data)) {
+			end = uint64(len(data))
+		}
+		block := offset / mockBackupBlockSize
+		zero := isZero(data[offset:end])
+		if full && zero {
+			continue
+		}
+		if !full && (block >= uint64(len(dirty)) || !dirty[block]) {
+			continue
+		}
+		if last := len(extents) - 1; last >= 0 &&
+			extents[last].Offset+extents[last].Length == offset &&
+			extents[last].Zero == zero {
+			extents[last].Length += end - offset
+			continue
+		}
+		extents = append(extents, BackupExtent{Offset: offset, Length: end - offset, Zero: zero})
+	}
+	return extents
+}
@@ -0,0 +1,75 @@
+// This file contains tests for the internal dirty block tracking of the mock backups. It is therefore excluded from
+// the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestMockBackupTracksDirtyBlocks(t *testing.T) {
+	t.Parallel()
+
+	disk := &diskWithData{
+		lock:         &sync.Mutex{},
+		data:         bytes.Repeat([]byte{1}, 4*mockBackupBlockSize),
+		dirtyBitmaps: map[CheckpointID][]bool{},
+	}
+
+	data, _, tracked := disk.startCheckpoint("", "first")
+	if tracked {
+		t.Fatalf("A backup without a checkpoint to start from was tracked.")
+	}
+	if extents := mockBackupExtents(data, nil, true); !reflect.DeepEqual(
+		extents,
+		[]BackupExtent{{Offset: 0, Length: 4 * mockBackupBlockSize}},
+	) {
+		t.Fatalf("Incorrect extents for full backup: %v", extents)
+	}
+
+	// Replace the last two blocks, only changing the third one.
+	changed := append(bytes.Repeat([]byte{2}, mockBackupBlockSize), bytes.Repeat([]byte{1}, mockBackupBlockSize)...)
+	disk.replaceData(2*mockBackupBlockSize, changed)
+
+	data, dirty, tracked := disk.startCheckpoint("first", "second")
+	if !tracked {
+		t.Fatalf("The disk was not tracked by the first checkpoint.")
+	}
+	if extents := mockBackupExtents(data, dirty, false); !reflect.DeepEqual(
+		extents,
+		[]BackupExtent{{Offset: 2 * mockBackupBlockSize, Length: 2 * mockBackupBlockSize}},
+	) {
+		t.Fatalf("Incorrect extents for incremental backup: %v", extents)
+	}
+
+	// Zero the last block.
+	disk.replaceData(3*mockBackupBlockSize, make([]byte, mockBackupBlockSize))
+
+	data, dirty, _ = disk.startCheckpoint("second", "third")
+	if extents := mockBackupExtents(data, dirty, false); !reflect.DeepEqual(
+		extents,
+		[]BackupExtent{{Offset: 3 * mockBackupBlockSize, Length: mockBackupBlockSize, Zero: true}},
+	) {
+		t.Fatalf("Incorrect extents for incremental backup with zeroed block: %v", extents)
+	}
+
+	// The first checkpoint still covers all changes since it was created.
+	data, dirty, _ = disk.startCheckpoint("first", "fourth")
+	if extents := mockBackupExtents(data, dirty, false); !reflect.DeepEqual(
+		extents,
+		[]BackupExtent{
+			{Offset: 2 * mockBackupBlockSize, Length: mockBackupBlockSize},
+			{Offset: 3 * mockBackupBlockSize, Length: mockBackupBlockSize, Zero: true},
+		},
+	) {
+		t.Fatalf("Incorrect extents for incremental backup from the first checkpoint: %v", extents)
+	}
+
+	disk.removeDirtyBitmap("first")
+	if _, _, tracked := disk.startCheckpoint("first", "fifth"); tracked {
+		t.Fatalf("The disk is still tracked by a removed checkpoint.")
+	}
+}
@@ -0,0 +1,165 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) StartVMBackup(
+	vmID VMID,
+	params BackupParameters,
+	retries ...RetryStrategy,
+) (result Backup, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewBackupParams()
+	}
+	diskIDs := params.DiskIDs()
+	if len(diskIDs) == 0 {
+		// The engine requires the disks to be listed explicitly.
+		attachments, err := o.ListDiskAttachments(vmID, retries...)
+		if err != nil {
+			return nil, err
+		}
+		for _, attachment := range attachments {
+			diskIDs = append(diskIDs, attachment.DiskID())
+		}
+	}
+	err = retry(
+		fmt.Sprintf("starting backup of VM %s", vmID),
+		o.logger,
+		o.requestHooks("StartVMBackup"),
+		retries,
+		func() error {
+			disks := make([]*ovirtsdk.Disk, len(diskIDs))
+			for i, diskID := range diskIDs {
+				disks[i] = ovirtsdk.NewDiskBuilder().Id(string(diskID)).MustBuild()
+			}
+			backupBuilder := ovirtsdk.NewBackupBuilder().DisksOfAny(disks...)
+			if fromCheckpointID := params.FromCheckpointID(); fromCheckpointID != "" {
+				backupBuilder.FromCheckpointId(string(fromCheckpointID))
+			}
+			if description := params.Description(); description != "" {
+				backupBuilder.Description(description)
+			}
+			response, e := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				BackupsService().
+				Add().
+				Backup(backupBuilder.MustBuild()).
+				Send()
+			if e != nil {
+				return e
+			}
+
+			sdkBackup, ok := response.Backup()
+			if !ok {
+				return newError(EFieldMissing, "missing backup in response")
+			}
+
+			result, err = convertSDKBackup(sdkBackup, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert backup",
+				)
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) StartVMBackup(vmID VMID, params BackupParameters, _ ...RetryStrategy) (Backup, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewBackupParams()
+	}
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	if err := m.checkVMNotLocked(vmID); err != nil {
+		return nil, err
+	}
+	for _, b := range m.backups[vmID] {
+		if b.inProgress() {
+			return nil, newError(EConflict, "VM %s already has backup %s in progress", vmID, b.id)
+		}
+	}
+	fromCheckpointID := params.FromCheckpointID()
+	if fromCheckpointID != "" {
+		if _, _, err := m.findCheckpoint(vmID, fromCheckpointID); err != nil {
+			return nil, err
+		}
+	}
+
+	diskIDs := params.DiskIDs()
+	if len(diskIDs) == 0 {
+		for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+			diskIDs = append(diskIDs, attachment.DiskID())
+		}
+	}
+	for _, diskID := range diskIDs {
+		if !m.isDiskAttachedToVM(diskID, vmID) {
+			return nil, newError(EBadArgument, "disk %s is not attached to VM %s", diskID, vmID)
+		}
+	}
+
+	toCheckpointID := CheckpointID(m.GenerateUUID())
+	disks := make(map[DiskID]*mockBackupDisk, len(diskIDs))
+	for _, diskID := range diskIDs {
+		data, dirty, tracked := m.disks[diskID].startCheckpoint(fromCheckpointID, toCheckpointID)
+		disks[diskID] = &mockBackupDisk{
+			data:    data,
+			extents: mockBackupExtents(data, dirty, !tracked),
+			full:    !tracked,
+		}
+	}
+
+	var parentID CheckpointID
+	if checkpoints := m.checkpoints[vmID]; len(checkpoints) > 0 {
+		parentID = checkpoints[len(checkpoints)-1].id
+	}
+	now := time.Now()
+	m.checkpoints[vmID] = append(m.checkpoints[vmID], &checkpoint{
+		client:       m,
+		id:           toCheckpointID,
+		vmID:         vmID,
+		parentID:     parentID,
+		state:        CheckpointStateCreated,
+		diskIDs:      diskIDs,
+		creationDate: now,
+	})
+
+	b := &backupWithData{
+		backup: backup{
+			client:           m,
+			id:               BackupID(m.GenerateUUID()),
+			vmID:             vmID,
+			phase:            BackupPhaseInitializing,
+			fromCheckpointID: fromCheckpointID,
+			toCheckpointID:   toCheckpointID,
+			diskIDs:          diskIDs,
+			creationDate:     now,
+		},
+		lock:  &sync.Mutex{},
+		disks: disks,
+	}
+	m.backups[vmID] = append(m.backups[vmID], b)
+
+	go func() {
+		// Sleep to give tests a chance to observe the initializing backup.
+		time.Sleep(time.Second)
+		b.Ready()
+	}()
+
+	return b, nil
+}
@@ -0,0 +1,187 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestVMIncrementalBackup(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	// The first half of the image contains data, the second half is empty.
+	image := make([]byte, 1024*1024)
+	for i := 0; i < len(image)/2; i++ {
+		image[i] = byte(i % 251)
+	}
+	assertCanUploadBackupTestImage(t, helper, disk, image)
+
+	fullBackup := assertCanStartVMBackup(t, helper, vm, nil)
+	if fullBackup.FromCheckpointID() != "" {
+		t.Fatalf("Full backup has a checkpoint to start from (%s).", fullBackup.FromCheckpointID())
+	}
+	full := &backupTestTarget{}
+	if _, err := fullBackup.DownloadDisk(disk.ID(), full); err != nil {
+		t.Fatalf("Failed to download disk %s from full backup %s (%v)", disk.ID(), fullBackup.ID(), err)
+	}
+	if !bytes.Equal(full.data, image) {
+		t.Fatalf("Full backup of disk %s does not match the uploaded image.", disk.ID())
+	}
+	assertCanFinalizeVMBackup(t, fullBackup)
+
+	changedImage := make([]byte, len(image))
+	copy(changedImage, image)
+	for i := len(image) - 4096; i < len(image); i++ {
+		changedImage[i] = 0xff
+	}
+	assertCanUploadBackupTestImage(t, helper, disk, changedImage)
+
+	incrementalBackup := assertCanStartVMBackup(
+		t,
+		helper,
+		vm,
+		ovirtclient.NewBackupParams().MustWithFromCheckpointID(fullBackup.ToCheckpointID()),
+	)
+	incremental := &backupTestTarget{data: append([]byte(nil), full.data...)}
+	if _, err := client.DownloadVMBackupDisk(vm.ID(), incrementalBackup.ID(), disk.ID(), incremental); err != nil {
+		t.Fatalf("Failed to download disk %s from incremental backup %s (%v)", disk.ID(), incrementalBackup.ID(), err)
+	}
+	if !bytes.Equal(incremental.data, changedImage) {
+		t.Fatalf("Applying the incremental backup of disk %s to the full backup does not result in the changed image.", disk.ID())
+	}
+	assertCanFinalizeVMBackup(t, incrementalBackup)
+
+	checkpoints, err := client.ListVMCheckpoints(vm.ID())
+	if err != nil {
+		t.Fatalf("Failed to list checkpoints of VM %s (%v)", vm.ID(), err)
+	}
+	if len(checkpoints) != 2 {
+		t.Fatalf("Incorrect number of checkpoints after two backups: %d", len(checkpoints))
+	}
+	if checkpoints[1].ParentID() != checkpoints[0].ID() {
+		t.Fatalf("The second checkpoint does not have the first checkpoint as parent.")
+	}
+}
+
+func TestVMCheckpointRemoval(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	firstBackup := assertCanStartVMBackup(t, helper, vm, nil)
+	assertCanFinalizeVMBackup(t, firstBackup)
+	secondBackup := assertCanStartVMBackup(t, helper, vm, nil)
+	assertCanFinalizeVMBackup(t, secondBackup)
+
+	err := client.RemoveVMCheckpoint(vm.ID(), secondBackup.ToCheckpointID(), ovirtclient.MaxTries(1))
+	if err == nil {
+		t.Fatalf("Removing a checkpoint other than the oldest did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Removing a checkpoint other than the oldest did not return an EConflict error (%v).", err)
+	}
+	if err := client.RemoveVMCheckpoint(vm.ID(), firstBackup.ToCheckpointID()); err != nil {
+		t.Fatalf("Failed to remove checkpoint %s (%v)", firstBackup.ToCheckpointID(), err)
+	}
+
+	_, err = client.StartVMBackup(
+		vm.ID(),
+		ovirtclient.NewBackupParams().MustWithFromCheckpointID(firstBackup.ToCheckpointID()),
+		ovirtclient.MaxTries(1),
+	)
+	if err == nil {
+		t.Fatalf("Starting an incremental backup from a removed checkpoint did not fail.")
+	}
+}
+
+func TestVMBackupConflict(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	backup := assertCanStartVMBackup(t, helper, vm, nil)
+	_, err := client.StartVMBackup(vm.ID(), nil, ovirtclient.MaxTries(1))
+	if err == nil {
+		t.Fatalf("Starting a second backup while a backup is in progress did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Starting a second backup while a backup is in progress did not return an EConflict error (%v).", err)
+	}
+	assertCanFinalizeVMBackup(t, backup)
+}
+
+func assertCanStartVMBackup(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	vm ovirtclient.VM,
+	params ovirtclient.BackupParameters,
+) ovirtclient.Backup {
+	backup, err := helper.GetClient().StartVMBackup(vm.ID(), params)
+	if err != nil {
+		t.Fatalf("Failed to start backup of VM %s (%v)", vm.ID(), err)
+	}
+	backup, err = backup.WaitForPhase(ovirtclient.BackupPhaseReady)
+	if err != nil {
+		t.Fatalf("Failed to wait for backup %s to become ready (%v)", backup.ID(), err)
+	}
+	return backup
+}
+
+func assertCanFinalizeVMBackup(t *testing.T, backup ovirtclient.Backup) {
+	if err := backup.Finalize(); err != nil {
+		t.Fatalf("Failed to finalize backup %s (%v)", backup.ID(), err)
+	}
+	if _, err := backup.WaitForPhase(ovirtclient.BackupPhaseSucceeded); err != nil {
+		t.Fatalf("Failed to wait for backup %s to succeed (%v)", backup.ID(), err)
+	}
+}
+
+func assertCanUploadBackupTestImage(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	disk ovirtclient.Disk,
+	image []byte,
+) {
+	if err := helper.GetClient().UploadToDisk(
+		disk.ID(),
+		uint64(len(image)),
+		&nopReadCloser{bytes.NewReader(image)},
+	); err != nil {
+		t.Fatalf("Failed to upload image to disk %s (%v)", disk.ID(), err)
+	}
+}
+
+// backupTestTarget is an in-memory target for backup downloads.
+type backupTestTarget struct {
+	data []byte
+}
+
+func (b *backupTestTarget) WriteAt(p []byte, off int64) (int, error) {
+	if end := int(off) + len(p); end > len(b.data) {
+		b.data = append(b.data, make([]byte, end-len(b.data))...)
+	}
+	return copy(b.data[off:], p), nil
+}
+
+func (b *backupTestTarget) Truncate(size int64) error {
+	if int(size) > len(b.data) {
+		b.data = append(b.data, make([]byte, int(size)-len(b.data))...)
+	}
+	b.data = b.data[:size]
+	return nil
+}
@@ -0,0 +1,73 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForVMBackupPhase(
+	vmID VMID,
+	backupID BackupID,
+	phase BackupPhase,
+	retries ...RetryStrategy,
+) (result Backup, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for backup %s of VM %s to enter phase \"%s\"", backupID, vmID, phase),
+		o.logger,
+		o.waitHooks("WaitForVMBackupPhase"),
+		retries,
+		func() error {
+			result, err = o.GetVMBackup(vmID, backupID, retries...)
+			if err != nil {
+				return err
+			}
+			return checkBackupPhase(result, phase)
+		})
+	return
+}
+
+func (m *mockClient) WaitForVMBackupPhase(
+	vmID VMID,
+	backupID BackupID,
+	phase BackupPhase,
+	retries ...RetryStrategy,
+) (result Backup, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for backup %s of VM %s to enter phase \"%s\"", backupID, vmID, phase),
+		m.logger,
+		nil,
+		retries,
+		func() error {
+			result, err = m.GetVMBackup(vmID, backupID, retries...)
+			if err != nil {
+				return err
+			}
+			return checkBackupPhase(result, phase)
+		})
+	return
+}
+
+// checkBackupPhase returns an EPending error if the backup has not reached the desired phase yet. A failed backup
+// never reaches another phase, so it results in a permanent error.
+func checkBackupPhase(backup Backup, phase BackupPhase) error {
+	switch backup.Phase() {
+	case phase:
+		return nil
+	case BackupPhaseFailed:
+		return newError(
+			EConflict,
+			"backup %s failed while waiting for phase \"%s\"",
+			backup.ID(),
+			phase,
+		)
+	default:
+		return newError(
+			EPending,
+			"Backup %s phase is \"%s\", not \"%s\".",
+			backup.ID(),
+			backup.Phase(),
+			phase,
+		)
+	}
+}
@@ -0,0 +1,259 @@
+package ovirtclient
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// TransferBandwidthClient controls the bandwidth used by the image transfers of a client.
+type TransferBandwidthClient interface {
+	// SetTransferBandwidthLimit limits the combined speed of all image uploads and downloads of the client to
+	// bytesPerSecond. A limit of 0 removes the limit. The limit is shared with all clients derived using WithContext
+	// and applies to transfers that are already in progress.
+	SetTransferBandwidthLimit(bytesPerSecond uint64)
+	// TransferBandwidthLimit returns the combined speed limit of all image transfers of the client in bytes per
+	// second, or 0 if there is no limit.
+	TransferBandwidthLimit() uint64
+}
+
+func (o *oVirtClient) SetTransferBandwidthLimit(bytesPerSecond uint64) {
+	o.transferLimiter.SetLimit(bytesPerSecond)
+}
+
+func (o *oVirtClient) TransferBandwidthLimit() uint64 {
+	return o.transferLimiter.Limit()
+}
+
+func (m *mockClient) SetTransferBandwidthLimit(bytesPerSecond uint64) {
+	m.transferLimiter.SetLimit(bytesPerSecond)
+}
+
+func (m *mockClient) TransferBandwidthLimit() uint64 {
+	return m.transferLimiter.Limit()
+}
+
+// maxThrottledRead is the largest number of bytes read at once while a bandwidth limit is set. Keeping the reads
+// small spreads the data evenly over time instead of sending it in bursts.
+const maxThrottledRead = 64 * 1024
+
+// maxThrottleWait is the longest time a transfer sleeps before checking the limit again, so changes to the limit
+// take effect quickly.
+const maxThrottleWait = 100 * time.Millisecond
+
+// bandwidthLimiter is a token bucket limiting the number of bytes per second passing through it. The limit can be
+// changed at any time, including while transfers are waiting for it.
+type bandwidthLimiter struct {
+	lock       *sync.Mutex
+	limit      uint64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newBandwidthLimiter creates a limiter allowing bytesPerSecond bytes per second. A limit of 0 means no limit.
+func newBandwidthLimiter(bytesPerSecond uint64) *bandwidthLimiter {
+	l := &bandwidthLimiter{
+		lock: &sync.Mutex{},
+	}
+	l.SetLimit(bytesPerSecond)
+	return l
+}
+
+// Limit returns the current limit in bytes per second, or 0 if there is no limit.
+func (l *bandwidthLimiter) Limit() uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.limit
+}
+
+// SetLimit changes the limit to bytesPerSecond. A limit of 0 removes the limit.
+func (l *bandwidthLimiter) SetLimit(bytesPerSecond uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.limit = bytesPerSecond
+	l.tokens = 0
+	l.lastRefill = time.Now()
+}
+
+// maxRead returns how many bytes may be read at once from a reader throttled by this limiter. A single read is at most
+// a tenth of the limit, so the progress is updated several times per second even at low limits.
+func (l *bandwidthLimiter) maxRead(n int) int {
+	limit := l.Limit()
+	if limit == 0 {
+		return n
+	}
+	if n > maxThrottledRead {
+		n = maxThrottledRead
+	}
+	if max := limit / 10; uint64(n) > max {
+		n = int(max)
+		if n < 1 {
+			n = 1
+		}
+	}
+	return n
+}
+
+// wait records that n bytes have been transferred and waits until the transfer is back within the limit. It returns
+// the time spent waiting, and an error if ctx is canceled while waiting.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) (time.Duration, error) {
+	start := time.Now()
+	l.lock.Lock()
+	l.refill()
+	if l.limit != 0 {
+		l.tokens -= float64(n)
+	}
+	l.lock.Unlock()
+	for {
+		l.lock.Lock()
+		l.refill()
+		if l.limit == 0 || l.tokens >= 0 {
+			l.lock.Unlock()
+			return time.Since(start), nil
+		}
+		delay := time.Duration(-l.tokens / float64(l.limit) * float64(time.Second))
+		l.lock.Unlock()
+		if delay > maxThrottleWait {
+			delay = maxThrottleWait
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return time.Since(start), wrap(ctx.Err(), ETimeout, "image transfer canceled while throttled")
+		}
+	}
+}
+
+// refill adds the tokens earned since the last refill. At most one second worth of unused bandwidth is kept, so an
+// idle transfer cannot burst far above the limit. Must be called with the lock held.
+func (l *bandwidthLimiter) refill() {
+	now := time.Now()
+	if l.limit == 0 {
+		l.tokens = 0
+		l.lastRefill = now
+		return
+	}
+	l.tokens += now.Sub(l.lastRefill).Seconds() * float64(l.limit)
+	if l.tokens > float64(l.limit) {
+		l.tokens = float64(l.limit)
+	}
+	l.lastRefill = now
+}
+
+// transferThrottle applies the bandwidth limit of a single image transfer and the limit shared by all transfers of
+// the client. It also tracks the time the transfer spent waiting for the limits, which extends the timeouts of the
+// transfer.
+type transferThrottle struct {
+	transfer *bandwidthLimiter
+	client   *bandwidthLimiter
+
+	lock      *sync.Mutex
+	throttled time.Duration
+}
+
+// newTransferThrottle creates a throttle for a transfer limited to bytesPerSecond on its own and to the client limit
+// together with all other transfers of the client. The client limiter may be nil.
+func newTransferThrottle(client *bandwidthLimiter, bytesPerSecond uint64) *transferThrottle {
+	return &transferThrottle{
+		transfer: newBandwidthLimiter(bytesPerSecond),
+		client:   client,
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Limit returns the limit of the transfer in bytes per second, or 0 if there is no limit.
+func (t *transferThrottle) Limit() uint64 {
+	return t.transfer.Limit()
+}
+
+// SetLimit changes the limit of the transfer to bytesPerSecond. A limit of 0 removes the limit.
+func (t *transferThrottle) SetLimit(bytesPerSecond uint64) {
+	t.transfer.SetLimit(bytesPerSecond)
+}
+
+// Throttled returns the total time the transfer spent waiting for the bandwidth limits.
+func (t *transferThrottle) Throttled() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.throttled
+}
+
+func (t *transferThrottle) maxRead(n int) int {
+	n = t.transfer.maxRead(n)
+	if t.client != nil {
+		n = t.client.maxRead(n)
+	}
+	return n
+}
+
+func (t *transferThrottle) wait(ctx context.Context, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	waited, err := t.transfer.wait(ctx, n)
+	if err == nil && t.client != nil {
+		var clientWaited time.Duration
+		clientWaited, err = t.client.wait(ctx, n)
+		waited += clientWaited
+	}
+	t.lock.Lock()
+	t.throttled += waited
+	t.lock.Unlock()
+	return err
+}
+
+// reader returns a reader that passes the data of r through the throttle. Reads are aborted when ctx is canceled.
+func (t *transferThrottle) reader(ctx context.Context, r io.Reader) io.Reader {
+	return &throttledReader{
+		ctx:      ctx,
+		reader:   r,
+		throttle: t,
+	}
+}
+
+// retries extends the timeouts in the retry strategies by the time the transfer spent waiting for the bandwidth
+// limits, so a slow transfer caused by a low limit does not time out.
+func (t *transferThrottle) retries(retries []RetryStrategy) []RetryStrategy {
+	result := make([]RetryStrategy, len(retries))
+	for i, strategy := range retries {
+		container, ok := strategy.(*retryStrategyContainer)
+		if !ok || !container.canTimeout {
+			result[i] = strategy
+			continue
+		}
+		factory := container.factory
+		result[i] = &retryStrategyContainer{
+			func() RetryInstance {
+				instance := factory()
+				if timeout, ok := instance.(*timeoutStrategy); ok {
+					timeout.extension = t.Throttled
+				}
+				return instance
+			},
+			container.canClassifyErrors,
+			container.canWait,
+			container.canTimeout,
+			container.canRecover,
+		}
+	}
+	return result
+}
+
+// throttledReader limits the speed data is read from the underlying reader. The data is returned to the caller only
+// after the throttle allowed it, so progress counted from the returned bytes reflects the throttled speed.
+type throttledReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	throttle *transferThrottle
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	n, err := t.reader.Read(p[:t.throttle.maxRead(len(p))])
+	if waitErr := t.throttle.wait(t.ctx, n); waitErr != nil {
+		return n, waitErr
+	}
+	return n, err
+}
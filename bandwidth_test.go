@@ -0,0 +1,228 @@
+// This file contains tests for the internal bandwidth limiter. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestBandwidthLimiterRate(t *testing.T) {
+	t.Parallel()
+
+	throttle := newTransferThrottle(nil, 100*1024)
+	startTime := time.Now()
+	data, err := ioutil.ReadAll(throttle.reader(context.Background(), bytes.NewReader(make([]byte, 50*1024))))
+	if err != nil {
+		t.Fatalf("Failed to read through the throttle (%v)", err)
+	}
+	if len(data) != 50*1024 {
+		t.Fatalf("Incorrect number of bytes read through the throttle: %d", len(data))
+	}
+	if elapsed := time.Since(startTime); elapsed < 450*time.Millisecond {
+		t.Fatalf("Bandwidth limit was not applied (50 KiB at 100 KiB/s took %s)", elapsed)
+	}
+	if throttle.Throttled() == 0 {
+		t.Fatalf("The time spent throttled was not recorded.")
+	}
+}
+
+func TestBandwidthLimiterSharedClientLimit(t *testing.T) {
+	t.Parallel()
+
+	client := newBandwidthLimiter(100 * 1024)
+	throttle := newTransferThrottle(client, 0)
+	startTime := time.Now()
+	if _, err := io.Copy(ioutil.Discard, throttle.reader(context.Background(), bytes.NewReader(make([]byte, 50*1024)))); err != nil {
+		t.Fatalf("Failed to read through the throttle (%v)", err)
+	}
+	if elapsed := time.Since(startTime); elapsed < 450*time.Millisecond {
+		t.Fatalf("Client bandwidth limit was not applied (50 KiB at 100 KiB/s took %s)", elapsed)
+	}
+}
+
+func TestBandwidthLimiterChangeWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	limiter := newBandwidthLimiter(1)
+	done := make(chan error)
+	go func() {
+		_, err := limiter.wait(context.Background(), 3600)
+		done <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	limiter.SetLimit(0)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Waiting for the bandwidth limit failed (%v)", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Removing the bandwidth limit did not end the wait.")
+	}
+}
+
+func TestBandwidthLimiterCanceled(t *testing.T) {
+	t.Parallel()
+
+	limiter := newBandwidthLimiter(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.wait(ctx, 3600); !HasErrorCode(err, ETimeout) {
+		t.Fatalf("Canceling the context did not return a timeout error (%v)", err)
+	}
+}
+
+func TestTransferThrottleExtendsTimeouts(t *testing.T) {
+	t.Parallel()
+
+	throttle := newTransferThrottle(nil, 0)
+	throttle.throttled = time.Hour
+	retries := throttle.retries([]RetryStrategy{CallTimeout(time.Millisecond)})
+	unthrottled := CallTimeout(time.Millisecond).Get()
+	extended := retries[0].Get()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := unthrottled.Continue(newError(EConnection, "test"), "testing"); err == nil {
+		t.Fatalf("The unthrottled timeout did not expire.")
+	}
+	if err := extended.Continue(newError(EConnection, "test"), "testing"); err != nil {
+		t.Fatalf("The timeout was not extended by the time spent throttled (%v)", err)
+	}
+}
+
+func TestMockTransferBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	client := NewMock()
+	disk, err := client.CreateDisk(
+		testStorageDomainID(client),
+		ImageFormatRaw,
+		1024*1024,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	image := bytes.Repeat([]byte("x"), 64*1024)
+
+	client.SetTransferBandwidthLimit(64 * 1024)
+	if limit := client.TransferBandwidthLimit(); limit != 64*1024 {
+		t.Fatalf("Incorrect client bandwidth limit: %d", limit)
+	}
+	progress, err := client.StartUploadToDiskWithParams(
+		disk.ID(),
+		uint64(len(image)),
+		readSeekNopCloser{bytes.NewReader(image)},
+		UploadParams().MustWithBandwidthLimit(16*1024),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start upload (%v)", err)
+	}
+	if limit := progress.BandwidthLimit(); limit != 16*1024 {
+		t.Fatalf("Incorrect upload bandwidth limit: %d", limit)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if uploaded := progress.UploadedBytes(); uploaded == 0 || uploaded >= uint64(len(image)) {
+		t.Fatalf("The upload progress does not follow the bandwidth limit (%d bytes after 500ms)", uploaded)
+	}
+	// Removing the limit of the upload leaves only the client limit in place.
+	progress.SetBandwidthLimit(0)
+	select {
+	case <-progress.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The upload did not speed up after removing its bandwidth limit.")
+	}
+	if err := progress.Err(); err != nil {
+		t.Fatalf("Upload failed (%v)", err)
+	}
+
+	client.SetTransferBandwidthLimit(0)
+	download, err := client.DownloadDisk(disk.ID(), ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to start download (%v)", err)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	download.SetBandwidthLimit(128 * 1024)
+	startTime := time.Now()
+	data, err := ioutil.ReadAll(download)
+	if err != nil {
+		t.Fatalf("Failed to download disk (%v)", err)
+	}
+	if !bytes.Equal(data[:len(image)], image) {
+		t.Fatalf("Incorrect data downloaded.")
+	}
+	if elapsed := time.Since(startTime); elapsed < 400*time.Millisecond {
+		t.Fatalf("Download bandwidth limit was not applied (%d bytes at 128 KiB/s took %s)", len(data), elapsed)
+	}
+}
+
+func TestMockUploadToNewDiskBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	client := NewMock()
+	image := bytes.Repeat([]byte("x"), 64*1024)
+	progress, err := client.StartUploadToNewDiskWithParams(
+		testStorageDomainID(client),
+		ImageFormatRaw,
+		uint64(len(image)),
+		nil,
+		readSeekNopCloser{bytes.NewReader(image)},
+		UploadParams().MustWithBandwidthLimit(16*1024),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start upload (%v)", err)
+	}
+	if limit := progress.BandwidthLimit(); limit != 16*1024 {
+		t.Fatalf("Incorrect upload bandwidth limit: %d", limit)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if uploaded := progress.UploadedBytes(); uploaded == 0 || uploaded >= uint64(len(image)) {
+		t.Fatalf("The upload progress does not follow the bandwidth limit (%d bytes after 500ms)", uploaded)
+	}
+	progress.SetBandwidthLimit(0)
+	select {
+	case <-progress.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("The upload did not speed up after removing its bandwidth limit.")
+	}
+	if err := progress.Err(); err != nil {
+		t.Fatalf("Upload failed (%v)", err)
+	}
+
+	_, err = client.StartUploadToNewDiskWithParams(
+		testStorageDomainID(client),
+		ImageFormatRaw,
+		uint64(len(image)),
+		nil,
+		readSeekNopCloser{bytes.NewReader(image)},
+		UploadParams().MustWithKeepTransferOnFailure(true),
+	)
+	if err == nil || !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Upload to a new disk was started with a transfer kept for resuming (%v)", err)
+	}
+}
+
+// readSeekNopCloser adds a no-op Close function to an io.ReadSeeker.
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error {
+	return nil
+}
+
+// testStorageDomainID returns the ID of the first storage domain of the mock client.
+func testStorageDomainID(client MockClient) StorageDomainID {
+	storageDomains, err := client.ListStorageDomains()
+	if err != nil || len(storageDomains) == 0 {
+		panic("no storage domains in mock client")
+	}
+	return storageDomains[0].ID()
+}
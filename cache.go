@@ -0,0 +1,220 @@
+package ovirtclient
+
+import (
+	"sync"
+	"time"
+)
+
+// CachingClient is a Client that caches objects that change rarely, such as clusters, datacenters, networks, VNIC
+// profiles, instance types and the blank template. All other calls are passed through to the underlying client.
+//
+// Cached objects are invalidated when they expire, when Invalidate is called, or when a mutating call is made through
+// the CachingClient, for example RemoveNetwork or CreateVNICProfile. Changes made through other clients, or through
+// the convenience methods on the returned objects (e.g. VNICProfile.Remove()), are not tracked and are only visible
+// after the objects expire or are invalidated explicitly.
+//
+// Clients created using WithContext share the cache with the client they were created from.
+type CachingClient interface {
+	Client
+
+	// Invalidate removes the cached objects of the specified types. If no types are passed, the entire cache is
+	// cleared.
+	Invalidate(objectTypes ...CacheObjectType)
+}
+
+// NewCachingClient wraps the specified client, which may also be a MockClient, in a read-through cache. If params is
+// nil the default TTL of DefaultCacheTTL applies to all object types.
+func NewCachingClient(client Client, params CacheParameters) CachingClient {
+	if params == nil {
+		params = NewCacheParams()
+	}
+	ttls := map[CacheObjectType]time.Duration{}
+	for _, objectType := range CacheObjectTypeValues() {
+		ttls[objectType] = params.TTL(objectType)
+	}
+	return &cachingClient{
+		Client: client,
+		cache: &clientCache{
+			lock:        &sync.Mutex{},
+			ttls:        ttls,
+			items:       map[CacheObjectType]map[string]cacheEntry{},
+			generations: map[CacheObjectType]uint64{},
+		},
+	}
+}
+
+// DefaultCacheTTL is the time objects are cached for if no TTL is set for their type.
+const DefaultCacheTTL = time.Minute
+
+// CacheObjectType is the type of object cached by the CachingClient.
+type CacheObjectType string
+
+const (
+	// CacheObjectCluster caches clusters.
+	CacheObjectCluster CacheObjectType = "cluster"
+	// CacheObjectDatacenter caches datacenters and the list of clusters in them.
+	CacheObjectDatacenter CacheObjectType = "datacenter"
+	// CacheObjectNetwork caches networks.
+	CacheObjectNetwork CacheObjectType = "network"
+	// CacheObjectVNICProfile caches VNIC profiles.
+	CacheObjectVNICProfile CacheObjectType = "vnic_profile"
+	// CacheObjectInstanceType caches instance types.
+	CacheObjectInstanceType CacheObjectType = "instance_type"
+	// CacheObjectBlankTemplate caches the blank template returned by GetBlankTemplate.
+	CacheObjectBlankTemplate CacheObjectType = "blank_template"
+)
+
+// CacheObjectTypeList is a list of CacheObjectType.
+type CacheObjectTypeList []CacheObjectType
+
+// CacheObjectTypeValues returns all possible CacheObjectType values.
+func CacheObjectTypeValues() CacheObjectTypeList {
+	return []CacheObjectType{
+		CacheObjectCluster,
+		CacheObjectDatacenter,
+		CacheObjectNetwork,
+		CacheObjectVNICProfile,
+		CacheObjectInstanceType,
+		CacheObjectBlankTemplate,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l CacheObjectTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, objectType := range l {
+		result[i] = string(objectType)
+	}
+	return result
+}
+
+// Validate returns an error if the object type is not valid.
+func (c CacheObjectType) Validate() error {
+	for _, objectType := range CacheObjectTypeValues() {
+		if objectType == c {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid cache object type: %s, must be one of: %v",
+		c,
+		CacheObjectTypeValues().Strings(),
+	)
+}
+
+// CacheParameters contains the settings of the CachingClient.
+type CacheParameters interface {
+	// TTL returns the time objects of the specified type are cached for. A TTL of 0 disables caching for the type.
+	TTL(objectType CacheObjectType) time.Duration
+}
+
+// BuildableCacheParameters is a buildable version of CacheParameters.
+type BuildableCacheParameters interface {
+	CacheParameters
+
+	// WithTTL sets the time objects of the specified type are cached for. A TTL of 0 disables caching for the type.
+	WithTTL(objectType CacheObjectType, ttl time.Duration) (BuildableCacheParameters, error)
+	// MustWithTTL is identical to WithTTL, but panics instead of returning an error.
+	MustWithTTL(objectType CacheObjectType, ttl time.Duration) BuildableCacheParameters
+}
+
+// NewCacheParams creates a new set of cache parameters with the DefaultCacheTTL applied to all object types.
+func NewCacheParams() BuildableCacheParameters {
+	return &cacheParams{
+		ttls: map[CacheObjectType]time.Duration{},
+	}
+}
+
+type cacheParams struct {
+	ttls map[CacheObjectType]time.Duration
+}
+
+func (c *cacheParams) TTL(objectType CacheObjectType) time.Duration {
+	if ttl, ok := c.ttls[objectType]; ok {
+		return ttl
+	}
+	return DefaultCacheTTL
+}
+
+func (c *cacheParams) WithTTL(objectType CacheObjectType, ttl time.Duration) (BuildableCacheParameters, error) {
+	if err := objectType.Validate(); err != nil {
+		return nil, err
+	}
+	if ttl < 0 {
+		return nil, newError(EBadArgument, "the cache TTL must not be negative (%s)", ttl)
+	}
+	c.ttls[objectType] = ttl
+	return c, nil
+}
+
+func (c *cacheParams) MustWithTTL(objectType CacheObjectType, ttl time.Duration) BuildableCacheParameters {
+	builder, err := c.WithTTL(objectType, ttl)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// clientCache stores cached objects by type and key. The key is the ID of the object, or a fixed string for lists.
+//
+// Every invalidation increments the generation of the object type. A read passes the generation it saw before
+// fetching from the engine to set, so a result fetched concurrently with a write is not cached after the write
+// invalidated the type.
+type clientCache struct {
+	lock        *sync.Mutex
+	ttls        map[CacheObjectType]time.Duration
+	items       map[CacheObjectType]map[string]cacheEntry
+	generations map[CacheObjectType]uint64
+}
+
+// get returns the cached object and the current generation of the object type. The generation must be passed to set
+// when caching the result of a fetch.
+func (c *clientCache) get(objectType CacheObjectType, key string) (interface{}, uint64, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	generation := c.generations[objectType]
+	entry, ok := c.items[objectType][key]
+	if !ok {
+		return nil, generation, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.items[objectType], key)
+		return nil, generation, false
+	}
+	return entry.value, generation, true
+}
+
+// set caches the object unless the object type has been invalidated since the specified generation.
+func (c *clientCache) set(objectType CacheObjectType, generation uint64, key string, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ttl := c.ttls[objectType]
+	if ttl == 0 || c.generations[objectType] != generation {
+		return
+	}
+	if _, ok := c.items[objectType]; !ok {
+		c.items[objectType] = map[string]cacheEntry{}
+	}
+	c.items[objectType][key] = cacheEntry{
+		value:   value,
+		expires: time.Now().Add(ttl),
+	}
+}
+
+func (c *clientCache) invalidate(objectTypes ...CacheObjectType) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(objectTypes) == 0 {
+		objectTypes = CacheObjectTypeValues()
+	}
+	for _, objectType := range objectTypes {
+		delete(c.items, objectType)
+		c.generations[objectType]++
+	}
+}
@@ -0,0 +1,250 @@
+package ovirtclient
+
+import (
+	"context"
+	"io"
+)
+
+const (
+	// cacheListKey is the key under which the list of all objects of a type is cached.
+	cacheListKey = "list"
+	// cacheBlankTemplateKey is the key under which the blank template is cached.
+	cacheBlankTemplateKey = "blank"
+)
+
+type cachingClient struct {
+	Client
+
+	cache *clientCache
+}
+
+func (c *cachingClient) WithContext(ctx context.Context) Client {
+	return &cachingClient{
+		Client: c.Client.WithContext(ctx),
+		cache:  c.cache,
+	}
+}
+
+// Close closes the wrapped client if it implements io.Closer.
+func (c *cachingClient) Close() error {
+	if closer, ok := c.Client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (c *cachingClient) Invalidate(objectTypes ...CacheObjectType) {
+	c.cache.invalidate(objectTypes...)
+}
+
+func (c *cachingClient) ListClusters(retries ...RetryStrategy) ([]Cluster, error) {
+	cached, generation, ok := c.cache.get(CacheObjectCluster, cacheListKey)
+	if ok {
+		return append([]Cluster(nil), cached.([]Cluster)...), nil
+	}
+	result, err := c.Client.ListClusters(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectCluster, generation, cacheListKey, append([]Cluster(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) GetCluster(id ClusterID, retries ...RetryStrategy) (Cluster, error) {
+	cached, generation, ok := c.cache.get(CacheObjectCluster, string(id))
+	if ok {
+		return cached.(Cluster), nil
+	}
+	result, err := c.Client.GetCluster(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectCluster, generation, string(id), result)
+	return result, nil
+}
+
+func (c *cachingClient) ListDatacenters(retries ...RetryStrategy) ([]Datacenter, error) {
+	cached, generation, ok := c.cache.get(CacheObjectDatacenter, cacheListKey)
+	if ok {
+		return append([]Datacenter(nil), cached.([]Datacenter)...), nil
+	}
+	result, err := c.Client.ListDatacenters(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectDatacenter, generation, cacheListKey, append([]Datacenter(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) GetDatacenter(id DatacenterID, retries ...RetryStrategy) (Datacenter, error) {
+	cached, generation, ok := c.cache.get(CacheObjectDatacenter, string(id))
+	if ok {
+		return cached.(Datacenter), nil
+	}
+	result, err := c.Client.GetDatacenter(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectDatacenter, generation, string(id), result)
+	return result, nil
+}
+
+func (c *cachingClient) ListDatacenterClusters(id DatacenterID, retries ...RetryStrategy) ([]Cluster, error) {
+	key := "clusters/" + string(id)
+	cached, generation, ok := c.cache.get(CacheObjectDatacenter, key)
+	if ok {
+		return append([]Cluster(nil), cached.([]Cluster)...), nil
+	}
+	result, err := c.Client.ListDatacenterClusters(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectDatacenter, generation, key, append([]Cluster(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) ListNetworks(retries ...RetryStrategy) ([]Network, error) {
+	cached, generation, ok := c.cache.get(CacheObjectNetwork, cacheListKey)
+	if ok {
+		return append([]Network(nil), cached.([]Network)...), nil
+	}
+	result, err := c.Client.ListNetworks(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectNetwork, generation, cacheListKey, append([]Network(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) GetNetwork(id NetworkID, retries ...RetryStrategy) (Network, error) {
+	cached, generation, ok := c.cache.get(CacheObjectNetwork, string(id))
+	if ok {
+		return cached.(Network), nil
+	}
+	result, err := c.Client.GetNetwork(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectNetwork, generation, string(id), result)
+	return result, nil
+}
+
+func (c *cachingClient) CreateNetwork(
+	dataCenterID DatacenterID,
+	name string,
+	description string,
+	comment string,
+	vlanID int,
+	retries ...RetryStrategy,
+) (Network, error) {
+	// The engine also creates a default VNIC profile for the new network.
+	defer c.cache.invalidate(CacheObjectNetwork, CacheObjectVNICProfile)
+	return c.Client.CreateNetwork(dataCenterID, name, description, comment, vlanID, retries...)
+}
+
+func (c *cachingClient) UpdateNetwork(
+	id NetworkID,
+	dataCenterID DatacenterID,
+	name string,
+	description string,
+	comment string,
+	vlanID int,
+	retries ...RetryStrategy,
+) (Network, error) {
+	defer c.cache.invalidate(CacheObjectNetwork)
+	return c.Client.UpdateNetwork(id, dataCenterID, name, description, comment, vlanID, retries...)
+}
+
+func (c *cachingClient) RemoveNetwork(id NetworkID, retries ...RetryStrategy) error {
+	// Removing a network also removes its VNIC profiles.
+	defer c.cache.invalidate(CacheObjectNetwork, CacheObjectVNICProfile)
+	return c.Client.RemoveNetwork(id, retries...)
+}
+
+func (c *cachingClient) ListVNICProfiles(retries ...RetryStrategy) ([]VNICProfile, error) {
+	cached, generation, ok := c.cache.get(CacheObjectVNICProfile, cacheListKey)
+	if ok {
+		return append([]VNICProfile(nil), cached.([]VNICProfile)...), nil
+	}
+	result, err := c.Client.ListVNICProfiles(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectVNICProfile, generation, cacheListKey, append([]VNICProfile(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) GetVNICProfile(id VNICProfileID, retries ...RetryStrategy) (VNICProfile, error) {
+	cached, generation, ok := c.cache.get(CacheObjectVNICProfile, string(id))
+	if ok {
+		return cached.(VNICProfile), nil
+	}
+	result, err := c.Client.GetVNICProfile(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectVNICProfile, generation, string(id), result)
+	return result, nil
+}
+
+func (c *cachingClient) CreateVNICProfile(
+	name string,
+	networkID NetworkID,
+	params OptionalVNICProfileParameters,
+	retries ...RetryStrategy,
+) (VNICProfile, error) {
+	defer c.cache.invalidate(CacheObjectVNICProfile)
+	return c.Client.CreateVNICProfile(name, networkID, params, retries...)
+}
+
+func (c *cachingClient) RemoveVNICProfile(id VNICProfileID, retries ...RetryStrategy) error {
+	defer c.cache.invalidate(CacheObjectVNICProfile)
+	return c.Client.RemoveVNICProfile(id, retries...)
+}
+
+func (c *cachingClient) ListInstanceTypes(retries ...RetryStrategy) ([]InstanceType, error) {
+	cached, generation, ok := c.cache.get(CacheObjectInstanceType, cacheListKey)
+	if ok {
+		return append([]InstanceType(nil), cached.([]InstanceType)...), nil
+	}
+	result, err := c.Client.ListInstanceTypes(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectInstanceType, generation, cacheListKey, append([]InstanceType(nil), result...))
+	return result, nil
+}
+
+func (c *cachingClient) GetInstanceType(id InstanceTypeID, retries ...RetryStrategy) (InstanceType, error) {
+	cached, generation, ok := c.cache.get(CacheObjectInstanceType, string(id))
+	if ok {
+		return cached.(InstanceType), nil
+	}
+	result, err := c.Client.GetInstanceType(id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectInstanceType, generation, string(id), result)
+	return result, nil
+}
+
+func (c *cachingClient) GetBlankTemplate(retries ...RetryStrategy) (Template, error) {
+	cached, generation, ok := c.cache.get(CacheObjectBlankTemplate, cacheBlankTemplateKey)
+	if ok {
+		return cached.(Template), nil
+	}
+	result, err := c.Client.GetBlankTemplate(retries...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.set(CacheObjectBlankTemplate, generation, cacheBlankTemplateKey, result)
+	return result, nil
+}
+
+func (c *cachingClient) RemoveTemplate(templateID TemplateID, retries ...RetryStrategy) error {
+	cached, _, ok := c.cache.get(CacheObjectBlankTemplate, cacheBlankTemplateKey)
+	if ok && cached.(Template).ID() == templateID {
+		defer c.cache.invalidate(CacheObjectBlankTemplate)
+	}
+	return c.Client.RemoveTemplate(templateID, retries...)
+}
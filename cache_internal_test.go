@@ -0,0 +1,30 @@
+// This file contains tests for the internal cache storage. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestClientCacheDropsResultsFetchedBeforeInvalidation(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCachingClient(nil, nil).(*cachingClient).cache
+
+	_, generation, ok := cache.get(CacheObjectVNICProfile, cacheListKey)
+	if ok {
+		t.Fatalf("Empty cache returned an object.")
+	}
+	// A write invalidates the cache while the read is still fetching from the engine.
+	cache.invalidate(CacheObjectVNICProfile)
+	cache.set(CacheObjectVNICProfile, generation, cacheListKey, []VNICProfile{})
+	if _, _, ok := cache.get(CacheObjectVNICProfile, cacheListKey); ok {
+		t.Fatalf("Result fetched before the invalidation was cached.")
+	}
+
+	_, generation, _ = cache.get(CacheObjectVNICProfile, cacheListKey)
+	cache.set(CacheObjectVNICProfile, generation, cacheListKey, []VNICProfile{})
+	if _, _, ok := cache.get(CacheObjectVNICProfile, cacheListKey); !ok {
+		t.Fatalf("Result fetched after the invalidation was not cached.")
+	}
+}
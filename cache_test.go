@@ -0,0 +1,87 @@
+package ovirtclient_test
+
+import (
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestCachingClientInvalidatesOnOwnMutations(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := ovirtclient.NewCachingClient(helper.GetClient(), nil)
+
+	vnicProfile, err := client.GetVNICProfile(helper.GetVNICProfileID())
+	if err != nil {
+		t.Fatalf("Failed to fetch test VNIC profile (%v)", err)
+	}
+	profiles := assertCanListVNICProfiles(t, client)
+
+	newVNICProfile, err := client.CreateVNICProfile(
+		fmt.Sprintf("client_test_%s", helper.GenerateRandomID(5)),
+		vnicProfile.NetworkID(),
+		ovirtclient.CreateVNICProfileParams(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create VNIC profile (%v)", err)
+	}
+	t.Cleanup(func() {
+		if err := client.RemoveVNICProfile(newVNICProfile.ID()); err != nil {
+			t.Fatalf("Failed to clean up test VNIC profile ID %s (%v)", newVNICProfile.ID(), err)
+		}
+	})
+
+	if newProfiles := assertCanListVNICProfiles(t, client); len(newProfiles) != len(profiles)+1 {
+		t.Fatalf(
+			"Cached VNIC profile list was not invalidated after creation (expected %d profiles, got %d)",
+			len(profiles)+1,
+			len(newProfiles),
+		)
+	}
+}
+
+func TestCachingClientExplicitInvalidation(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := ovirtclient.NewCachingClient(helper.GetClient(), nil)
+
+	profiles := assertCanListVNICProfiles(t, client)
+	// Create the profile through the underlying client, bypassing the cache.
+	newVNICProfile := assertCanCreateVNICProfile(t, helper)
+
+	if cachedProfiles := assertCanListVNICProfiles(t, client); len(cachedProfiles) != len(profiles) {
+		t.Fatalf("VNIC profile list was not served from the cache.")
+	}
+	client.Invalidate(ovirtclient.CacheObjectVNICProfile)
+	newProfiles := assertCanListVNICProfiles(t, client)
+	for _, profile := range newProfiles {
+		if profile.ID() == newVNICProfile.ID() {
+			return
+		}
+	}
+	t.Fatalf("VNIC profile %s not found after invalidating the cache.", newVNICProfile.ID())
+}
+
+func TestCachingClientZeroTTLDisablesCaching(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := ovirtclient.NewCachingClient(
+		helper.GetClient(),
+		ovirtclient.NewCacheParams().MustWithTTL(ovirtclient.CacheObjectVNICProfile, 0),
+	)
+
+	profiles := assertCanListVNICProfiles(t, client)
+	assertCanCreateVNICProfile(t, helper)
+	if newProfiles := assertCanListVNICProfiles(t, client); len(newProfiles) != len(profiles)+1 {
+		t.Fatalf("VNIC profile list was cached despite a TTL of 0.")
+	}
+}
+
+func assertCanListVNICProfiles(t *testing.T, client ovirtclient.Client) []ovirtclient.VNICProfile {
+	profiles, err := client.ListVNICProfiles()
+	if err != nil {
+		t.Fatalf("Failed to list VNIC profiles (%v)", err)
+	}
+	return profiles
+}
@@ -0,0 +1,160 @@
+package ovirtclient
+
+import (
+	"strings"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// CdromID is the identifier of a CD-ROM drive of a VM.
+type CdromID string
+
+// CdromMediaID identifies the media inserted into a CD-ROM drive. For ISO images uploaded to a data domain this is
+// the ID of the disk, which can be converted using CdromMediaID(diskID). For images on an ISO domain this is the file
+// name of the image.
+type CdromMediaID string
+
+// CdromClient lists the methods to access and change the CD-ROM drives of VMs.
+type CdromClient interface {
+	// ListCdroms lists the CD-ROM drives of a VM with the media inserted persistently.
+	ListCdroms(vmID VMID, retries ...RetryStrategy) ([]Cdrom, error)
+	// GetCdrom returns a single CD-ROM drive of a VM with the media inserted in the specified scope.
+	GetCdrom(vmID VMID, id CdromID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error)
+	// InsertCdromMedia inserts the specified media into an empty CD-ROM drive. It returns an error with the EConflict
+	// code if media is already inserted in the specified scope. Use ChangeCdromMedia to replace the media.
+	InsertCdromMedia(
+		vmID VMID,
+		id CdromID,
+		mediaID CdromMediaID,
+		scope CdromScope,
+		retries ...RetryStrategy,
+	) (Cdrom, error)
+	// ChangeCdromMedia inserts the specified media into a CD-ROM drive, replacing the media already inserted.
+	ChangeCdromMedia(
+		vmID VMID,
+		id CdromID,
+		mediaID CdromMediaID,
+		scope CdromScope,
+		retries ...RetryStrategy,
+	) (Cdrom, error)
+	// EjectCdromMedia removes the media from a CD-ROM drive. Ejecting an empty drive is not an error.
+	EjectCdromMedia(vmID VMID, id CdromID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error)
+}
+
+// CdromScope determines if a change to a CD-ROM drive applies to the running VM or to the VM configuration.
+type CdromScope string
+
+const (
+	// CdromScopePersistent reads or changes the media in the VM configuration, which is used when the VM is started
+	// the next time. A running VM is not affected.
+	CdromScopePersistent CdromScope = "persistent"
+	// CdromScopeCurrent reads or changes the media in the running VM only. The change is lost when the VM is stopped.
+	// The VM must be running.
+	CdromScopeCurrent CdromScope = "current"
+)
+
+// CdromScopeList is a list of CdromScope values.
+type CdromScopeList []CdromScope
+
+// CdromScopeValues returns all possible CdromScope values.
+func CdromScopeValues() CdromScopeList {
+	return []CdromScope{
+		CdromScopePersistent,
+		CdromScopeCurrent,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l CdromScopeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, scope := range l {
+		result[i] = string(scope)
+	}
+	return result
+}
+
+// Validate returns an error if the scope doesn't have a valid value.
+func (s CdromScope) Validate() error {
+	for _, scope := range CdromScopeValues() {
+		if scope == s {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid CD-ROM scope: %s must be one of: %s",
+		s,
+		strings.Join(CdromScopeValues().Strings(), ", "),
+	)
+}
+
+// CdromData is the core of Cdrom, providing only data access functions.
+type CdromData interface {
+	// ID returns the identifier of the CD-ROM drive.
+	ID() CdromID
+	// VMID returns the ID of the VM the CD-ROM drive belongs to.
+	VMID() VMID
+	// MediaID returns the media inserted into the CD-ROM drive, or an empty string if the drive is empty.
+	MediaID() CdromMediaID
+}
+
+// Cdrom is a CD-ROM drive of a VM.
+type Cdrom interface {
+	CdromData
+
+	// Insert inserts the specified media into the empty CD-ROM drive.
+	Insert(mediaID CdromMediaID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error)
+	// Change inserts the specified media into the CD-ROM drive, replacing the media already inserted.
+	Change(mediaID CdromMediaID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error)
+	// Eject removes the media from the CD-ROM drive.
+	Eject(scope CdromScope, retries ...RetryStrategy) (Cdrom, error)
+}
+
+func convertSDKCdrom(sdkObject *ovirtsdk.Cdrom, vmID VMID, client Client) (Cdrom, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("CD-ROM", "id")
+	}
+	mediaID := ""
+	if file, ok := sdkObject.File(); ok {
+		mediaID, _ = file.Id()
+	}
+	return &cdrom{
+		client:  client,
+		id:      CdromID(id),
+		vmID:    vmID,
+		mediaID: CdromMediaID(mediaID),
+	}, nil
+}
+
+type cdrom struct {
+	client Client
+
+	id      CdromID
+	vmID    VMID
+	mediaID CdromMediaID
+}
+
+func (c *cdrom) ID() CdromID {
+	return c.id
+}
+
+func (c *cdrom) VMID() VMID {
+	return c.vmID
+}
+
+func (c *cdrom) MediaID() CdromMediaID {
+	return c.mediaID
+}
+
+func (c *cdrom) Insert(mediaID CdromMediaID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error) {
+	return c.client.InsertCdromMedia(c.vmID, c.id, mediaID, scope, retries...)
+}
+
+func (c *cdrom) Change(mediaID CdromMediaID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error) {
+	return c.client.ChangeCdromMedia(c.vmID, c.id, mediaID, scope, retries...)
+}
+
+func (c *cdrom) Eject(scope CdromScope, retries ...RetryStrategy) (Cdrom, error) {
+	return c.client.EjectCdromMedia(c.vmID, c.id, scope, retries...)
+}
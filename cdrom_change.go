@@ -0,0 +1,196 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) InsertCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	retries ...RetryStrategy,
+) (Cdrom, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if mediaID == "" {
+		return nil, newError(EBadArgument, "the media ID to insert must not be empty, use EjectCdromMedia instead")
+	}
+	existing, err := o.GetCdrom(vmID, id, scope, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if existing.MediaID() != "" {
+		return nil, newError(
+			EConflict,
+			"CD-ROM %s on VM %s already contains %s, use ChangeCdromMedia to replace it",
+			id,
+			vmID,
+			existing.MediaID(),
+		)
+	}
+	return o.updateCdromMedia(vmID, id, mediaID, scope, retries)
+}
+
+func (o *oVirtClient) ChangeCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	retries ...RetryStrategy,
+) (Cdrom, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if mediaID == "" {
+		return nil, newError(EBadArgument, "the media ID to insert must not be empty, use EjectCdromMedia instead")
+	}
+	return o.updateCdromMedia(vmID, id, mediaID, scope, retries)
+}
+
+func (o *oVirtClient) EjectCdromMedia(vmID VMID, id CdromID, scope CdromScope, retries ...RetryStrategy) (Cdrom, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return o.updateCdromMedia(vmID, id, "", scope, retries)
+}
+
+// updateCdromMedia sets the file of a CD-ROM drive. An empty media ID ejects the media.
+func (o *oVirtClient) updateCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	retries []RetryStrategy,
+) (result Cdrom, err error) {
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+	sdkCdrom, err := ovirtsdk.NewCdromBuilder().
+		File(ovirtsdk.NewFileBuilder().Id(string(mediaID)).MustBuild()).
+		Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build CD-ROM object")
+	}
+	err = retry(
+		fmt.Sprintf("changing media of CD-ROM %s on VM %s to %q (%s)", id, vmID, mediaID, scope),
+		o.logger,
+		o.requestHooks("UpdateCdromMedia"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				CdromsService().
+				CdromService(string(id)).
+				Update().
+				Cdrom(sdkCdrom).
+				Current(scope == CdromScopeCurrent).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Cdrom()
+			if !ok {
+				return newError(EFieldMissing, "no CD-ROM returned when changing CD-ROM %s on VM %s", id, vmID)
+			}
+			result, e = convertSDKCdrom(sdkObject, vmID, o)
+			if e != nil {
+				return wrap(e, EBug, "failed to convert CD-ROM %s on VM %s", id, vmID)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) InsertCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	_ ...RetryStrategy,
+) (Cdrom, error) {
+	if mediaID == "" {
+		return nil, newError(EBadArgument, "the media ID to insert must not be empty, use EjectCdromMedia instead")
+	}
+	return m.updateCdromMedia(vmID, id, mediaID, scope, false)
+}
+
+func (m *mockClient) ChangeCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	_ ...RetryStrategy,
+) (Cdrom, error) {
+	if mediaID == "" {
+		return nil, newError(EBadArgument, "the media ID to insert must not be empty, use EjectCdromMedia instead")
+	}
+	return m.updateCdromMedia(vmID, id, mediaID, scope, true)
+}
+
+func (m *mockClient) EjectCdromMedia(vmID VMID, id CdromID, scope CdromScope, _ ...RetryStrategy) (Cdrom, error) {
+	return m.updateCdromMedia(vmID, id, "", scope, true)
+}
+
+// updateCdromMedia changes the media of a CD-ROM drive in the mock. If replace is false, the drive must be empty.
+func (m *mockClient) updateCdromMedia(
+	vmID VMID,
+	id CdromID,
+	mediaID CdromMediaID,
+	scope CdromScope,
+	replace bool,
+) (Cdrom, error) {
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c, err := m.findCdrom(vmID, id)
+	if err != nil {
+		return nil, err
+	}
+	running := m.vms[vmID].status != VMStatusDown
+	if scope == CdromScopeCurrent && !running {
+		return nil, newError(EConflict, "VM %s is not running, the CD-ROM media can only be changed persistently", vmID)
+	}
+	if !replace && c.mediaID(scope) != "" {
+		return nil, newError(
+			EConflict,
+			"CD-ROM %s on VM %s already contains %s, use ChangeCdromMedia to replace it",
+			id,
+			vmID,
+			c.mediaID(scope),
+		)
+	}
+	if mediaID != "" {
+		if err := m.checkCdromMedia(mediaID); err != nil {
+			return nil, err
+		}
+	}
+	c.setMediaID(mediaID, scope, running)
+	return m.convertCdrom(c, scope), nil
+}
+
+// checkCdromMedia checks that the media is an ISO disk. The mock has no ISO domains, so file names are not accepted.
+// The caller must hold the mock lock.
+func (m *mockClient) checkCdromMedia(mediaID CdromMediaID) error {
+	disk, ok := m.disks[DiskID(mediaID)]
+	if !ok {
+		return newError(ENotFound, "ISO disk with ID %s not found", mediaID)
+	}
+	if disk.contentType != DiskContentTypeISO {
+		return newError(
+			EBadArgument,
+			"disk %s has the content type %s, only disks with the %s content type can be inserted into a CD-ROM",
+			mediaID,
+			disk.contentType,
+			DiskContentTypeISO,
+		)
+	}
+	if disk.status != DiskStatusOK {
+		return newError(EDiskLocked, "disk %s is in status %s", mediaID, disk.status)
+	}
+	return nil
+}
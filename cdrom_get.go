@@ -0,0 +1,60 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetCdrom(vmID VMID, id CdromID, scope CdromScope, retries ...RetryStrategy) (
+	result Cdrom,
+	err error,
+) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+	err = retry(
+		fmt.Sprintf("getting CD-ROM %s for VM %s", id, vmID),
+		o.logger,
+		o.requestHooks("GetCdrom"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				CdromsService().
+				CdromService(string(id)).
+				Get().
+				Current(scope == CdromScopeCurrent).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Cdrom()
+			if !ok {
+				return newError(ENotFound, "no CD-ROM returned when getting CD-ROM %s on VM %s", id, vmID)
+			}
+			result, e = convertSDKCdrom(sdkObject, vmID, o)
+			if e != nil {
+				return wrap(e, EBug, "failed to convert CD-ROM %s on VM %s", id, vmID)
+			}
+			return nil
+		},
+	)
+	return
+}
+
+func (m *mockClient) GetCdrom(vmID VMID, id CdromID, scope CdromScope, _ ...RetryStrategy) (Cdrom, error) {
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c, err := m.findCdrom(vmID, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.convertCdrom(c, scope), nil
+}
@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListCdroms(vmID VMID, retries ...RetryStrategy) (result []Cdrom, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing CD-ROMs for VM %s", vmID),
+		o.logger,
+		o.requestHooks("ListCdroms"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).CdromsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Cdroms()
+			if !ok {
+				return nil
+			}
+			result = make([]Cdrom, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKCdrom(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert CD-ROM during listing item #%d", i)
+				}
+			}
+			return nil
+		},
+	)
+	return
+}
+
+func (m *mockClient) ListCdroms(vmID VMID, _ ...RetryStrategy) ([]Cdrom, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	result := make([]Cdrom, len(m.cdromsByVM[vmID]))
+	for i, c := range m.cdromsByVM[vmID] {
+		result[i] = m.convertCdrom(c, CdromScopePersistent)
+	}
+	return result, nil
+}
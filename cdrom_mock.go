@@ -0,0 +1,71 @@
+package ovirtclient
+
+// mockCdrom is the state of a CD-ROM drive in the mock client. The media of the running VM is only tracked separately
+// once it differs from the persistent media.
+type mockCdrom struct {
+	id                CdromID
+	vmID              VMID
+	persistentMediaID CdromMediaID
+	// currentMediaID is the media inserted in the running VM, or nil if it is the same as the persistent media. It is
+	// reset when the VM is started.
+	currentMediaID *CdromMediaID
+}
+
+func (c *mockCdrom) mediaID(scope CdromScope) CdromMediaID {
+	if scope == CdromScopeCurrent && c.currentMediaID != nil {
+		return *c.currentMediaID
+	}
+	return c.persistentMediaID
+}
+
+// setMediaID changes the media in the specified scope. Changing the persistent media does not affect the running VM.
+func (c *mockCdrom) setMediaID(mediaID CdromMediaID, scope CdromScope, running bool) {
+	if scope == CdromScopeCurrent {
+		c.currentMediaID = &mediaID
+		return
+	}
+	if running && c.currentMediaID == nil {
+		current := c.persistentMediaID
+		c.currentMediaID = &current
+	}
+	c.persistentMediaID = mediaID
+}
+
+// addCdroms adds the CD-ROM drive oVirt creates for every VM.
+func (m *mockClient) addCdroms(vm *vm) {
+	m.cdromsByVM[vm.id] = []*mockCdrom{
+		{
+			id:   CdromID(m.GenerateUUID()),
+			vmID: vm.id,
+		},
+	}
+}
+
+// resetCdroms discards the media changes made for the current run of the VM. The caller must hold the mock lock.
+func (m *mockClient) resetCdroms(vmID VMID) {
+	for _, c := range m.cdromsByVM[vmID] {
+		c.currentMediaID = nil
+	}
+}
+
+// findCdrom returns the CD-ROM drive of a VM. The caller must hold the mock lock.
+func (m *mockClient) findCdrom(vmID VMID, id CdromID) (*mockCdrom, error) {
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	for _, c := range m.cdromsByVM[vmID] {
+		if c.id == id {
+			return c, nil
+		}
+	}
+	return nil, newError(ENotFound, "CD-ROM with ID %s not found on VM %s", id, vmID)
+}
+
+func (m *mockClient) convertCdrom(c *mockCdrom, scope CdromScope) Cdrom {
+	return &cdrom{
+		client:  m,
+		id:      c.id,
+		vmID:    c.vmID,
+		mediaID: c.mediaID(scope),
+	}
+}
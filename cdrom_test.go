@@ -0,0 +1,122 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestCdromMediaLifecycle(t *testing.T) {
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	iso := assertCanUploadISO(t, helper)
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	cdrom := assertCanGetFirstCdrom(t, client, vm.ID())
+	if cdrom.MediaID() != "" {
+		t.Fatalf("CD-ROM %s on new VM %s is not empty (%s).", cdrom.ID(), vm.ID(), cdrom.MediaID())
+	}
+
+	if _, err := cdrom.Insert(ovirtclient.CdromMediaID(iso.ID()), ovirtclient.CdromScopeCurrent); err == nil {
+		t.Fatalf("Inserting media for the current run of a stopped VM did not fail.")
+	}
+
+	cdrom, err := cdrom.Insert(ovirtclient.CdromMediaID(iso.ID()), ovirtclient.CdromScopePersistent)
+	if err != nil {
+		t.Fatalf("Failed to insert ISO %s into CD-ROM %s (%v)", iso.ID(), cdrom.ID(), err)
+	}
+	if cdrom.MediaID() != ovirtclient.CdromMediaID(iso.ID()) {
+		t.Fatalf("Incorrect media ID after insert (expected: %s, got: %s)", iso.ID(), cdrom.MediaID())
+	}
+
+	_, err = cdrom.Insert(ovirtclient.CdromMediaID(iso.ID()), ovirtclient.CdromScopePersistent)
+	if err == nil {
+		t.Fatalf("Inserting media into a full CD-ROM drive did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EConflict) {
+		t.Fatalf("Inserting media into a full CD-ROM drive did not return an EConflict error (%v)", err)
+	}
+
+	cdrom, err = cdrom.Change(ovirtclient.CdromMediaID(iso.ID()), ovirtclient.CdromScopePersistent)
+	if err != nil {
+		t.Fatalf("Failed to change media in CD-ROM %s (%v)", cdrom.ID(), err)
+	}
+
+	cdrom, err = cdrom.Eject(ovirtclient.CdromScopePersistent)
+	if err != nil {
+		t.Fatalf("Failed to eject media from CD-ROM %s (%v)", cdrom.ID(), err)
+	}
+	cdrom, err = client.GetCdrom(vm.ID(), cdrom.ID(), ovirtclient.CdromScopePersistent)
+	if err != nil {
+		t.Fatalf("Failed to fetch CD-ROM %s after eject (%v)", cdrom.ID(), err)
+	}
+	if cdrom.MediaID() != "" {
+		t.Fatalf("CD-ROM %s still contains %s after eject.", cdrom.ID(), cdrom.MediaID())
+	}
+}
+
+func TestCdromRejectsNonISODisk(t *testing.T) {
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	cdrom := assertCanGetFirstCdrom(t, client, vm.ID())
+	if _, err := cdrom.Insert(ovirtclient.CdromMediaID(disk.ID()), ovirtclient.CdromScopePersistent); err == nil {
+		t.Fatalf("Inserting a data disk into a CD-ROM drive did not fail.")
+	}
+}
+
+func assertCanGetFirstCdrom(t *testing.T, client ovirtclient.Client, vmID ovirtclient.VMID) ovirtclient.Cdrom {
+	cdroms, err := client.ListCdroms(vmID)
+	if err != nil {
+		t.Fatalf("Failed to list CD-ROM drives of VM %s (%v)", vmID, err)
+	}
+	if len(cdroms) == 0 {
+		t.Fatalf("VM %s has no CD-ROM drives.", vmID)
+	}
+	return cdroms[0]
+}
+
+// assertCanUploadISO uploads a minimal ISO 9660 image and checks that the resulting disk has the ISO content type.
+func assertCanUploadISO(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.Disk {
+	client := helper.GetClient()
+	image := make([]byte, 64*1024)
+	copy(image[32769:], "CD001")
+
+	result, err := client.UploadToNewDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatRaw,
+		uint64(len(image)),
+		ovirtclient.CreateDiskParams().MustWithAlias(helper.GenerateTestResourceName(t)),
+		nopReadCloser{bytes.NewReader(image)},
+	)
+	if err != nil {
+		t.Fatalf("Failed to upload ISO image (%v)", err)
+	}
+	disk := result.Disk()
+	t.Cleanup(func() {
+		if err := client.RemoveDisk(disk.ID()); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove ISO disk %s (%v)", disk.ID(), err)
+		}
+	})
+	if disk.ContentType() != ovirtclient.DiskContentTypeISO {
+		t.Fatalf(
+			"Incorrect content type for uploaded ISO (expected: %s, got: %s)",
+			ovirtclient.DiskContentTypeISO,
+			disk.ContentType(),
+		)
+	}
+	return disk
+}
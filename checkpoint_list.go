@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListVMCheckpoints(vmID VMID, retries ...RetryStrategy) (result []Checkpoint, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Checkpoint{}
+	err = retry(
+		fmt.Sprintf("listing checkpoints of VM %s", vmID),
+		o.logger,
+		o.requestHooks("ListVMCheckpoints"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).CheckpointsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Checkpoints()
+			if !ok {
+				return nil
+			}
+			result = make([]Checkpoint, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKCheckpoint(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert checkpoint during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListVMCheckpoints(vmID VMID, _ ...RetryStrategy) ([]Checkpoint, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	result := make([]Checkpoint, len(m.checkpoints[vmID]))
+	for i, c := range m.checkpoints[vmID] {
+		result[i] = c
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveVMCheckpoint(vmID VMID, checkpointID CheckpointID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("removing checkpoint %s of VM %s", checkpointID, vmID),
+		o.logger,
+		o.requestHooks("RemoveVMCheckpoint"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				CheckpointsService().
+				CheckpointService(string(checkpointID)).
+				Remove().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RemoveVMCheckpoint(vmID VMID, checkpointID CheckpointID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c, i, err := m.findCheckpoint(vmID, checkpointID)
+	if err != nil {
+		return err
+	}
+	if i != 0 {
+		return newError(
+			EConflict,
+			"checkpoint %s is not the oldest checkpoint of VM %s, only the oldest checkpoint can be removed",
+			checkpointID,
+			vmID,
+		)
+	}
+	for _, diskID := range c.diskIDs {
+		if disk, ok := m.disks[diskID]; ok {
+			disk.removeDirtyBitmap(checkpointID)
+		}
+	}
+	checkpoints := m.checkpoints[vmID]
+	m.checkpoints[vmID] = checkpoints[1:]
+	if len(m.checkpoints[vmID]) > 0 {
+		// The next checkpoint becomes the first one and no longer has a parent.
+		first := *m.checkpoints[vmID][0]
+		first.parentID = ""
+		m.checkpoints[vmID][0] = &first
+	}
+	return nil
+}
@@ -16,7 +16,8 @@ import (
 type Client interface {
 	// GetURL returns the oVirt engine base URL.
 	GetURL() string
-	// Reconnect triggers the client to reauthenticate against the oVirt Engine.
+	// Reconnect triggers the client to reauthenticate against the oVirt Engine. If a TokenStore is configured, the
+	// stored token is discarded and a new one is requested.
 	Reconnect() (err error)
 	// WithContext creates a subclient with the specified context applied.
 	WithContext(ctx context.Context) Client
@@ -44,6 +45,16 @@ type Client interface {
 	ClusterNetworkClient
 	NetworkAttachmentClient
 	HostNICClient
+	SnapshotClient
+	EventClient
+	JobClient
+	BackupClient
+	CdromClient
+	DiskProfileClient
+	StorageQoSClient
+	TransferBandwidthClient
+	OVFClient
+	ExternalVMImportClient
 }
 
 // ClientWithLegacySupport is an extension of Client that also offers the ability to retrieve the underlying
@@ -66,12 +77,15 @@ type oVirtClient struct {
 	httpClient      http.Client
 	logger          Logger
 	url             string
-	username        string
-	password        string
+	auth            AuthProvider
 	tlsConfig       *tls.Config
 	extraSettings   ExtraSettings
 	nonSecureRandom *rand.Rand
 	verify          func(connection Client) error
+	observer        RequestObserver
+	limiter         *requestLimiter
+	tokens          *tokenManager
+	transferLimiter *bandwidthLimiter
 }
 
 func (o *oVirtClient) WithContext(ctx context.Context) Client {
@@ -82,12 +96,59 @@ func (o *oVirtClient) WithContext(ctx context.Context) Client {
 		o.httpClient,
 		o.logger.WithContext(ctx),
 		o.url,
-		o.username,
-		o.password,
+		o.auth,
 		o.tlsConfig,
 		o.extraSettings,
 		o.nonSecureRandom,
 		o.verify,
+		o.observer,
+		o.limiter,
+		o.tokens,
+		o.transferLimiter,
+	}
+}
+
+// requestHooks returns the configured request observer and limiter bound to the context of the client, or nil if
+// neither is configured. The operation is the fixed name the observer reports the attempts under, usually the name of
+// the calling function.
+func (o *oVirtClient) requestHooks(operation string) *requestHooks {
+	if o.observer == nil && o.limiter == nil && o.tokens == nil {
+		return nil
+	}
+	return &requestHooks{
+		ctx:       o.ctx,
+		operation: operation,
+		observer:  o.observer,
+		limiter:   o.limiter,
+		tokens:    o.tokens,
+	}
+}
+
+// waitHooks returns the request hooks for retry loops that wait by calling other client functions. These loops are
+// observed, but not limited: the nested calls are limited individually, and holding a concurrency slot while waiting
+// for them would deadlock.
+func (o *oVirtClient) waitHooks(operation string) *requestHooks {
+	if o.observer == nil {
+		return nil
+	}
+	return &requestHooks{
+		ctx:       o.ctx,
+		operation: operation,
+		observer:  o.observer,
+	}
+}
+
+// transferHooks returns the request hooks for the requests sent to ImageIO during image transfers. These requests are
+// observed, but not limited: a single request may stream gigabytes of data, and holding a concurrency slot for its
+// whole duration would starve all other engine calls. The engine calls setting up the transfer are limited as usual.
+func (o *oVirtClient) transferHooks(operation string) *requestHooks {
+	if o.observer == nil {
+		return nil
+	}
+	return &requestHooks{
+		ctx:       o.ctx,
+		operation: operation,
+		observer:  o.observer,
 	}
 }
 
@@ -96,12 +157,29 @@ func (o *oVirtClient) GetContext() context.Context {
 }
 
 func (o *oVirtClient) Reconnect() error {
+	return o.connect(true)
+}
+
+// connect creates the underlying SDK connection. If a TokenStore is configured, reauthenticate controls if a stored
+// token may be reused or a new token must be requested.
+func (o *oVirtClient) connect(reauthenticate bool) error {
+	if err := o.buildConnection(reauthenticate); err != nil {
+		return err
+	}
+	// The verification runs without holding the reconnect lock because it may trigger a reconnect itself if a stored
+	// token is rejected by the engine.
+	if o.verify != nil {
+		if err := o.verify(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *oVirtClient) buildConnection(reauthenticate bool) error {
 	o.reconnectLock.Lock()
 	defer o.reconnectLock.Unlock()
-	connBuilder := ovirtsdk4.NewConnectionBuilder().
-		URL(o.url).
-		Username(o.username).
-		Password(o.password).
+	connBuilder := o.auth.configureConnection(ovirtsdk4.NewConnectionBuilder().URL(o.url)).
 		TLSConfig(o.tlsConfig)
 	if err := processExtraSettings(o.extraSettings, connBuilder); err != nil {
 		return err
@@ -113,17 +191,18 @@ func (o *oVirtClient) Reconnect() error {
 	}
 	if o.conn == nil {
 		o.conn = conn
-	} else {
-		// Replace the structure under the pointer to make all instances update.
-		*o.conn = *conn
 	}
+	// The token manager replaces the structure under the pointer to make all instances update.
+	return o.tokens.connect(o.conn, conn, reauthenticate)
+}
 
-	if o.verify != nil {
-		if err := o.verify(o); err != nil {
-			return err
-		}
-	}
-	return nil
+// Close ends the SSO session of the client by revoking its token, and removes the token from the TokenStore. Clients
+// sharing the session, including those created using WithContext, return an EClientClosed error afterwards. Do not
+// call Close if the session should be reused by a later process.
+//
+// Close is not part of the Client interface. Use the io.Closer interface to call it.
+func (o *oVirtClient) Close() error {
+	return o.tokens.revoke()
 }
 
 func (o *oVirtClient) GetSDKClient() *ovirtsdk4.Connection {
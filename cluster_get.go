@@ -11,6 +11,7 @@ func (o *oVirtClient) GetCluster(id ClusterID, retries ...RetryStrategy) (result
 	err = retry(
 		fmt.Sprintf("getting cluster %s", id),
 		o.logger,
+		o.requestHooks("GetCluster"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().ClustersService().ClusterService(string(id)).Get().Send()
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListClusters(retries ...RetryStrategy) (result []Cluster,
 	err = retry(
 		"listing clusters",
 		o.logger,
+		o.requestHooks("ListClusters"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().ClustersService().List().Send()
@@ -16,6 +16,7 @@ func (o *oVirtClient) CreateClusterNetwork(
 	err = retry(
 		fmt.Sprintf("adding network %s to cluster %s", networkID, clusterID),
 		o.logger,
+		o.requestHooks("CreateClusterNetwork"),
 		retries,
 		func() error {
 			req := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).NetworksService().Add()
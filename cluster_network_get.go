@@ -9,6 +9,7 @@ func (o *oVirtClient) ClusterNetworkGet(clusterID ClusterID, networkID NetworkID
 	err = retry(
 		fmt.Sprintf("getting network %s from cluster %s", networkID, clusterID),
 		o.logger,
+		o.requestHooks("ClusterNetworkGet"),
 		retries,
 		func() error {
 			sdkClusterNetwork, err := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).NetworksService().NetworkService(string(networkID)).Get().Send()
@@ -10,6 +10,7 @@ func (o *oVirtClient) ClusterNetworkList(clusterID ClusterID, retries ...RetrySt
 	err = retry(
 		fmt.Sprintf("getting cluster networks from cluster %s", clusterID),
 		o.logger,
+		o.requestHooks("ClusterNetworkList"),
 		retries,
 		func() error {
 			sdkClusterNetworks, err := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).NetworksService().List().Send()
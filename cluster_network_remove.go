@@ -13,6 +13,7 @@ func (o *oVirtClient) RemoveClusterNetwork(
 	err = retry(
 		fmt.Sprintf("removing network %s from cluster %s", networkID, clusterID),
 		o.logger,
+		o.requestHooks("RemoveClusterNetwork"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().ClustersService().ClusterService(string(clusterID)).NetworksService().NetworkService(string(networkID)).Remove().Send()
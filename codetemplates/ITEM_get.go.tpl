@@ -11,6 +11,7 @@ func (o *oVirtClient) Get{{ .Object }}(id {{ .IDType }}, retries ...RetryStrateg
 	err = retry(
 		fmt.Sprintf("getting {{ .Name }} %s", id),
 		o.logger,
+		o.requestHooks(),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().{{ .ID }}sService().{{ .SecondaryID }}Service({{ if eq .IDType "string" }}id{{ else }}string(id){{ end }}).Get().Send()
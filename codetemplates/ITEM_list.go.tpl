@@ -8,6 +8,7 @@ func (o *oVirtClient) List{{ .Object }}s(retries ...RetryStrategy) (result []{{
 	err = retry(
 		"listing {{ .Name }}s",
 		o.logger,
+		o.requestHooks(),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().{{ .ID }}sService().List().Send()
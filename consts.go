@@ -1,7 +0,0 @@
-package ovirtclient
-
-const (
-	qcowHeaderSize    = 32
-	qcowMagicBytes    = "QFI\xfb"
-	qcowSizeStartByte = 24
-)
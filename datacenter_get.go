@@ -11,6 +11,7 @@ func (o *oVirtClient) GetDatacenter(id DatacenterID, retries ...RetryStrategy) (
 	err = retry(
 		fmt.Sprintf("getting datacenter %s", id),
 		o.logger,
+		o.requestHooks("GetDatacenter"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().DataCentersService().DataCenterService(string(id)).Get().Send()
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListDatacenters(retries ...RetryStrategy) (result []Datace
 	err = retry(
 		"listing datacenters",
 		o.logger,
+		o.requestHooks("ListDatacenters"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().DataCentersService().List().Send()
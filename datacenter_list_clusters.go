@@ -10,6 +10,7 @@ func (o *oVirtClient) ListDatacenterClusters(id DatacenterID, retries ...RetrySt
 	err = retry(
 		fmt.Sprintf("listing datacenters %s clusters", id),
 		o.logger,
+		o.requestHooks("ListDatacenterClusters"),
 		retries,
 		func() error {
 			response, e := o.conn.
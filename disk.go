@@ -1,6 +1,8 @@
 package ovirtclient
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"strings"
 	"sync"
@@ -110,6 +112,32 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (UploadImageResult, error)
 
+	// StartUploadToNewDiskWithParams is identical to StartUploadToNewDisk, but allows for tuning the upload using the
+	// uploadParams, for example to limit its bandwidth. Use UploadParams() to obtain a buildable structure. As the
+	// created disk is removed if the upload fails, the upload cannot be kept for resuming with
+	// WithKeepTransferOnFailure or WithResume.
+	StartUploadToNewDiskWithParams(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		size uint64,
+		params CreateDiskOptionalParameters,
+		reader io.ReadSeekCloser,
+		uploadParams UploadParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
+	// UploadToNewDiskWithParams is identical to StartUploadToNewDiskWithParams, but waits until the upload is
+	// complete. It returns the disk ID as a result, or the error if one happened.
+	UploadToNewDiskWithParams(
+		storageDomainID StorageDomainID,
+		format ImageFormat,
+		size uint64,
+		params CreateDiskOptionalParameters,
+		reader io.ReadSeekCloser,
+		uploadParams UploadParameters,
+		retries ...RetryStrategy,
+	) (UploadImageResult, error)
+
 	// StartUploadToDisk uploads a disk image to an existing disk. The actual upload takes place in the background
 	// and can be tracked using the returned UploadImageProgress object. Parameters are as follows:
 	//
@@ -137,6 +165,39 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (UploadImageProgress, error)
 
+	// StartUploadToDiskWithParams is identical to StartUploadToDisk, but allows for tuning the upload and resuming an
+	// interrupted upload using the params. Use UploadParams() to obtain a buildable structure.
+	//
+	// To resume an upload, keep the image transfer on failure using WithKeepTransferOnFailure, then pass the
+	// TransferID() and ConfirmedOffset() of the failed upload to WithResume:
+	//
+	//     progress, err := cli.StartUploadToDiskWithParams(
+	//         diskID,
+	//         size,
+	//         reader,
+	//         ovirtclient.UploadParams().MustWithKeepTransferOnFailure(true),
+	//     )
+	//     //...
+	//     <-progress.Done()
+	//     if err := progress.Err(); err != nil {
+	//         progress, err = cli.StartUploadToDiskWithParams(
+	//             diskID,
+	//             size,
+	//             reader,
+	//             ovirtclient.UploadParams().
+	//                 MustWithKeepTransferOnFailure(true).
+	//                 MustWithResume(progress.TransferID(), progress.ConfirmedOffset()),
+	//         )
+	//         //...
+	//     }
+	StartUploadToDiskWithParams(
+		diskID DiskID,
+		size uint64,
+		reader io.ReadSeekCloser,
+		params UploadParameters,
+		retries ...RetryStrategy,
+	) (UploadImageProgress, error)
+
 	// UploadToDisk runs StartUploadDisk and then waits for the upload to complete. It returns an error if the upload
 	// failed despite retries.
 	//
@@ -154,6 +215,16 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) error
 
+	// UploadToDiskWithParams runs StartUploadToDiskWithParams and then waits for the upload to complete. It returns
+	// an error if the upload failed despite retries.
+	UploadToDiskWithParams(
+		diskID DiskID,
+		size uint64,
+		reader io.ReadSeekCloser,
+		params UploadParameters,
+		retries ...RetryStrategy,
+	) error
+
 	// StartImageDownload starts the download of the image file of a specific disk.
 	// The caller can then wait for the initialization using the Initialized() call:
 	//
@@ -204,6 +275,23 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (ImageDownloadReader, error)
 
+	// DownloadDiskToFile downloads the image of a disk in the specified format to the file at path. The image is
+	// first written to a temporary file with the ".part" suffix next to path, and the disk ID, format and size of the
+	// image are recorded in a file with the ".part.json" suffix. If these files exist from an interrupted download of
+	// the same image, the download resumes from the current size of the temporary file. Otherwise, the temporary
+	// file is discarded and the download starts from the beginning. Once complete, the image is verified against the
+	// checksum reported by the image server, or against the expected checksum in params if the server does not
+	// support checksums, and renamed to path. If the verification fails, the temporary files are removed and an
+	// error with the EChecksumMismatch code is returned. Optional parameters can be created using
+	// DownloadToFileParams().
+	DownloadDiskToFile(
+		diskID DiskID,
+		format ImageFormat,
+		path string,
+		params DownloadToFileParameters,
+		retries ...RetryStrategy,
+	) (DownloadToFileResult, error)
+
 	// StartCreateDisk starts creating an empty disk with the specified parameters and returns a DiskCreation object,
 	// which can be queried for completion. Optional parameters can be created using CreateDiskParams().
 	StartCreateDisk(
@@ -228,6 +316,16 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (Disk, error)
 
+	// CreateLUNDisk creates a disk that is backed directly by an iSCSI or FCP LUN instead of an image on a storage
+	// domain. For iSCSI LUNs that are not yet visible to the hosts, the connection details can be passed in the
+	// optional parameters, which can be created using CreateLUNDiskParams().
+	CreateLUNDisk(
+		lunID string,
+		storageType LUNStorageType,
+		params CreateLUNDiskOptionalParameters,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
 	// StartUpdateDisk sends the disk update request to the oVirt API and returns a DiskUpdate
 	// object, which can be used to wait for the update to complete. Use UpdateDiskParams to
 	// obtain a builder for the parameters structure.
@@ -245,6 +343,42 @@ type DiskClient interface {
 		retries ...RetryStrategy,
 	) (Disk, error)
 
+	// StartMoveDisk starts moving the disk to the specified storage domain and returns a DiskUpdate object, which
+	// can be used to wait for the move to complete. Disks attached to running VMs are migrated live.
+	StartMoveDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		retries ...RetryStrategy,
+	) (DiskUpdate, error)
+	// MoveDisk is a shorthand for calling StartMoveDisk, and then waiting for the move to complete.
+	MoveDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		retries ...RetryStrategy,
+	) (Disk, error)
+	// StartCopyDisk starts copying the disk to a new disk on the specified storage domain and returns a DiskUpdate
+	// object, which can be used to wait for the copy to complete. Use CopyDiskParams to set the alias and format of
+	// the new disk.
+	//
+	// The oVirt Engine does not return the new disk when starting the copy, so the new disk is created with the
+	// temporary alias "<alias>_<random suffix>" unique to the copy, and Wait renames it to the requested alias once
+	// the copy is complete. The Disk function of the returned DiskUpdate returns nil until then. Wait must be called,
+	// otherwise the new disk keeps the temporary alias. If Wait fails, the new disk is removed.
+	StartCopyDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		params CopyDiskParameters,
+		retries ...RetryStrategy,
+	) (DiskUpdate, error)
+	// CopyDisk is a shorthand for calling StartCopyDisk, and then waiting for the copy to complete. It returns the
+	// new disk.
+	CopyDisk(
+		diskID DiskID,
+		storageDomainID StorageDomainID,
+		params CopyDiskParameters,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
 	// ListDisks lists all disks.
 	ListDisks(retries ...RetryStrategy) ([]Disk, error)
 	// GetDisk fetches a disk with a specific ID from the oVirt Engine.
@@ -269,6 +403,9 @@ type UpdateDiskParameters interface {
 	// ProvisionedSize returns the disk provisioned size to set.
 	// It can return nil to leave the provisioned size unchanged.
 	ProvisionedSize() *uint64
+	// DiskProfileID returns the disk profile to assign to the disk. It can return nil to leave the disk profile
+	// unchanged.
+	DiskProfileID() *DiskProfileID
 }
 
 // BuildableUpdateDiskParameters is a buildable version of UpdateDiskParameters.
@@ -286,11 +423,18 @@ type BuildableUpdateDiskParameters interface {
 	WithProvisionedSize(size uint64) (BuildableUpdateDiskParameters, error)
 	// MustWithProvisionedSize is identical to WithProvisionedSize, but panics instead of returning an error.
 	MustWithProvisionedSize(size uint64) BuildableUpdateDiskParameters
+
+	// WithDiskProfileID changes the params structure to assign the specified disk profile. The disk profile must
+	// belong to the storage domain of the disk.
+	WithDiskProfileID(diskProfileID DiskProfileID) (BuildableUpdateDiskParameters, error)
+	// MustWithDiskProfileID is identical to WithDiskProfileID, but panics instead of returning an error.
+	MustWithDiskProfileID(diskProfileID DiskProfileID) BuildableUpdateDiskParameters
 }
 
 type updateDiskParams struct {
 	alias           *string
 	provisionedSize *uint64
+	diskProfileID   *DiskProfileID
 }
 
 func (u *updateDiskParams) Alias() *string {
@@ -332,6 +476,98 @@ func (u *updateDiskParams) MustWithProvisionedSize(size uint64) BuildableUpdateD
 	return builder
 }
 
+func (u *updateDiskParams) DiskProfileID() *DiskProfileID {
+	return u.diskProfileID
+}
+
+func (u *updateDiskParams) WithDiskProfileID(diskProfileID DiskProfileID) (BuildableUpdateDiskParameters, error) {
+	if diskProfileID == "" {
+		return u, newError(EBadArgument, "the disk profile ID cannot be empty")
+	}
+	u.diskProfileID = &diskProfileID
+	return u, nil
+}
+
+func (u *updateDiskParams) MustWithDiskProfileID(diskProfileID DiskProfileID) BuildableUpdateDiskParameters {
+	builder, err := u.WithDiskProfileID(diskProfileID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// CopyDiskParams creates a builder for the params for copying a disk.
+func CopyDiskParams() BuildableCopyDiskParameters {
+	return &copyDiskParams{}
+}
+
+// CopyDiskParameters describes the optional parameters for copying a disk.
+type CopyDiskParameters interface {
+	// Alias returns the alias of the new disk. It can return nil to use the alias of the source disk.
+	Alias() *string
+	// Format returns the format of the new disk. It can return nil to use the format of the source disk.
+	Format() *ImageFormat
+}
+
+// BuildableCopyDiskParameters is a buildable version of CopyDiskParameters.
+type BuildableCopyDiskParameters interface {
+	CopyDiskParameters
+
+	// WithAlias changes the params structure to set the alias of the new disk to the specified value. It returns an
+	// error if the alias is invalid.
+	WithAlias(alias string) (BuildableCopyDiskParameters, error)
+	// MustWithAlias is identical to WithAlias, but panics instead of returning an error.
+	MustWithAlias(alias string) BuildableCopyDiskParameters
+
+	// WithFormat changes the params structure to set the format of the new disk. It returns an error if the format
+	// is invalid.
+	WithFormat(format ImageFormat) (BuildableCopyDiskParameters, error)
+	// MustWithFormat is identical to WithFormat, but panics instead of returning an error.
+	MustWithFormat(format ImageFormat) BuildableCopyDiskParameters
+}
+
+type copyDiskParams struct {
+	alias  *string
+	format *ImageFormat
+}
+
+func (c *copyDiskParams) Alias() *string {
+	return c.alias
+}
+
+func (c *copyDiskParams) Format() *ImageFormat {
+	return c.format
+}
+
+func (c *copyDiskParams) WithAlias(alias string) (BuildableCopyDiskParameters, error) {
+	c.alias = &alias
+	return c, nil
+}
+
+func (c *copyDiskParams) MustWithAlias(alias string) BuildableCopyDiskParameters {
+	builder, err := c.WithAlias(alias)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *copyDiskParams) WithFormat(format ImageFormat) (BuildableCopyDiskParameters, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	c.format = &format
+	return c, nil
+}
+
+func (c *copyDiskParams) MustWithFormat(format ImageFormat) BuildableCopyDiskParameters {
+	builder, err := c.WithFormat(format)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // CreateDiskOptionalParameters is a structure that serves to hold the optional parameters for DiskClient.CreateDisk.
 type CreateDiskOptionalParameters interface {
 	// Alias is a secondary name for the disk.
@@ -342,6 +578,18 @@ type CreateDiskOptionalParameters interface {
 
 	// InitialSize is the initially reserved disk space when creating the disk.
 	InitialSize() *uint64
+
+	// ContentType returns the content type of the disk. If it returns nil, the default will be used. When uploading
+	// an image to a new disk, ISO images default to DiskContentTypeISO.
+	ContentType() *DiskContentType
+
+	// DiskProfileID returns the disk profile of the disk. If it returns nil, the default disk profile of the storage
+	// domain will be used.
+	DiskProfileID() *DiskProfileID
+
+	// Shareable indicates that the disk can be attached to multiple VMs at the same time. If it returns nil, the disk
+	// is not shareable.
+	Shareable() *bool
 }
 
 // BuildableCreateDiskParameters is a buildable version of CreateDiskOptionalParameters.
@@ -362,6 +610,23 @@ type BuildableCreateDiskParameters interface {
 	WithInitialSize(size uint64) (BuildableCreateDiskParameters, error)
 	// MustWithInitialSize is the same as WithInitialSize, but panics instead of returning an error.
 	MustWithInitialSize(size uint64) BuildableCreateDiskParameters
+
+	// WithContentType sets the content type of the disk. Disks with DiskContentTypeISO must use the raw format.
+	WithContentType(contentType DiskContentType) (BuildableCreateDiskParameters, error)
+	// MustWithContentType is the same as WithContentType, but panics instead of returning an error.
+	MustWithContentType(contentType DiskContentType) BuildableCreateDiskParameters
+
+	// WithDiskProfileID sets the disk profile of the disk. The disk profile must belong to the storage domain the
+	// disk is created on.
+	WithDiskProfileID(diskProfileID DiskProfileID) (BuildableCreateDiskParameters, error)
+	// MustWithDiskProfileID is the same as WithDiskProfileID, but panics instead of returning an error.
+	MustWithDiskProfileID(diskProfileID DiskProfileID) BuildableCreateDiskParameters
+
+	// WithShareable sets if the disk can be attached to multiple VMs at the same time. Shareable disks must use the
+	// raw format.
+	WithShareable(shareable bool) (BuildableCreateDiskParameters, error)
+	// MustWithShareable is the same as WithShareable, but panics instead of returning an error.
+	MustWithShareable(shareable bool) BuildableCreateDiskParameters
 }
 
 // CreateDiskParams creates a buildable set of CreateDiskOptionalParameters for use with
@@ -371,9 +636,12 @@ func CreateDiskParams() BuildableCreateDiskParameters {
 }
 
 type createDiskParams struct {
-	alias       string
-	sparse      *bool
-	initialSize *uint64
+	alias         string
+	sparse        *bool
+	initialSize   *uint64
+	contentType   *DiskContentType
+	diskProfileID *DiskProfileID
+	shareable     *bool
 }
 
 func (c *createDiskParams) Alias() string {
@@ -427,6 +695,63 @@ func (c *createDiskParams) MustWithInitialSize(size uint64) BuildableCreateDiskP
 	return builder
 }
 
+func (c *createDiskParams) ContentType() *DiskContentType {
+	return c.contentType
+}
+
+func (c *createDiskParams) WithContentType(contentType DiskContentType) (BuildableCreateDiskParameters, error) {
+	if err := contentType.Validate(); err != nil {
+		return nil, err
+	}
+	c.contentType = &contentType
+	return c, nil
+}
+
+func (c *createDiskParams) MustWithContentType(contentType DiskContentType) BuildableCreateDiskParameters {
+	builder, err := c.WithContentType(contentType)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createDiskParams) DiskProfileID() *DiskProfileID {
+	return c.diskProfileID
+}
+
+func (c *createDiskParams) WithDiskProfileID(diskProfileID DiskProfileID) (BuildableCreateDiskParameters, error) {
+	if diskProfileID == "" {
+		return nil, newError(EBadArgument, "the disk profile ID cannot be empty")
+	}
+	c.diskProfileID = &diskProfileID
+	return c, nil
+}
+
+func (c *createDiskParams) MustWithDiskProfileID(diskProfileID DiskProfileID) BuildableCreateDiskParameters {
+	builder, err := c.WithDiskProfileID(diskProfileID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createDiskParams) Shareable() *bool {
+	return c.shareable
+}
+
+func (c *createDiskParams) WithShareable(shareable bool) (BuildableCreateDiskParameters, error) {
+	c.shareable = &shareable
+	return c, nil
+}
+
+func (c *createDiskParams) MustWithShareable(shareable bool) BuildableCreateDiskParameters {
+	builder, err := c.WithShareable(shareable)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // DiskCreation is a process object that lets you query the status of the disk creation.
 type DiskCreation interface {
 	// Disk returns the disk that has been created, even if it is not yet ready.
@@ -461,10 +786,20 @@ type ImageDownloadReader interface {
 	// Size returns the size of the disk image in bytes. This is ONLY available after the initialization is complete and
 	// MAY return 0 before.
 	Size() uint64
+	// BandwidthLimit returns the speed limit of the download in bytes per second, or 0 if there is no limit. The
+	// download is additionally limited by the client-wide TransferBandwidthLimit.
+	BandwidthLimit() uint64
+	// SetBandwidthLimit changes the speed limit of the download to bytesPerSecond while it is in progress. A limit of
+	// 0 removes the limit.
+	SetBandwidthLimit(bytesPerSecond uint64)
 }
 
 // ImageDownload represents an image download in progress. The caller MUST
 // close the image download when it is finished otherwise the disk will not be unlocked.
+//
+// When the download is copied to a seekable destination, such as a newly created file, using io.Copy, the client
+// skips the zero regions reported by the image server instead of downloading them, which keeps the destination
+// sparse.
 type ImageDownload interface {
 	ImageDownloadReader
 
@@ -476,6 +811,21 @@ type ImageDownload interface {
 	Initialized() <-chan struct{}
 }
 
+// DownloadToFileResult represents a completed download of a disk image to a file.
+type DownloadToFileResult interface {
+	// Path returns the path of the downloaded file.
+	Path() string
+	// Size returns the size of the downloaded image in bytes.
+	Size() uint64
+	// ResumedFrom returns the offset the download was resumed from, or 0 if the download started from the beginning.
+	ResumedFrom() uint64
+	// SHA256 returns the hex-encoded SHA-256 hash of the downloaded file.
+	SHA256() string
+	// Verified returns true if the file was verified against the checksum of the image server or the expected
+	// checksum passed in the parameters. If neither was available, the file is not verified.
+	Verified() bool
+}
+
 // UploadImageResult represents the completed image upload.
 type UploadImageResult interface {
 	// Disk returns the disk that has been created as the result of the image upload.
@@ -497,13 +847,25 @@ type DiskData interface {
 	// Format is the format of the image.
 	Format() ImageFormat
 	// StorageDomainIDs returns a list of storage domains this disk is present on. This will typically be a single
-	// disk, but may have multiple disk when the disk has been copied over to other storage domains. The disk is always
-	// present on at least one disk, so this list will never be empty.
+	// disk, but may have multiple disk when the disk has been copied over to other storage domains. Image disks are
+	// always present on at least one disk, so this list is only empty for LUN disks.
 	StorageDomainIDs() []StorageDomainID
 	// Status returns the status the disk is in.
 	Status() DiskStatus
 	// Sparse indicates sparse provisioning on the disk.
 	Sparse() bool
+	// ContentType returns what the disk is used for, for example DiskContentTypeISO for ISO images.
+	ContentType() DiskContentType
+	// DiskProfileID returns the disk profile of the disk, which determines the storage QoS limits applied to it. It
+	// returns nil if the engine did not report a disk profile.
+	DiskProfileID() *DiskProfileID
+	// Shareable indicates that the disk can be attached to multiple VMs at the same time.
+	Shareable() bool
+	// StorageType returns where the disk data is stored. Disks created by CreateLUNDisk have the
+	// DiskStorageTypeLUN storage type.
+	StorageType() DiskStorageType
+	// LUNID returns the ID of the LUN backing the disk, or an empty string if the disk is not a LUN disk.
+	LUNID() string
 }
 
 // Disk is a disk in oVirt.
@@ -557,6 +919,17 @@ type Disk interface {
 		retries ...RetryStrategy,
 	) (Disk, error)
 
+	// Move moves the current disk to the specified storage domain and waits for the move to complete.
+	Move(storageDomainID StorageDomainID, retries ...RetryStrategy) (Disk, error)
+
+	// Copy copies the current disk to a new disk on the specified storage domain and returns the new disk.
+	// Use CopyDiskParams() to obtain a buildable structure.
+	Copy(
+		storageDomainID StorageDomainID,
+		params CopyDiskParameters,
+		retries ...RetryStrategy,
+	) (Disk, error)
+
 	// StorageDomains will fetch and return the storage domains associated with this disk.
 	StorageDomains(retries ...RetryStrategy) ([]StorageDomain, error)
 
@@ -599,23 +972,404 @@ func (l DiskStatusList) Strings() []string {
 	return result
 }
 
+// DiskContentType describes what a disk is used for. Disks uploaded as ISO images can be inserted into the CD-ROM
+// drive of a VM, the other content types are used by the oVirt Engine internally.
+type DiskContentType string
+
+const (
+	// DiskContentTypeData is a disk that can be attached to VMs. This is the default for new disks.
+	DiskContentTypeData DiskContentType = "data"
+	// DiskContentTypeISO is an ISO image that can be inserted into the CD-ROM drive of a VM.
+	DiskContentTypeISO DiskContentType = "iso"
+	// DiskContentTypeBackupScratch is a scratch disk created for a VM backup.
+	DiskContentTypeBackupScratch DiskContentType = "backup_scratch"
+	// DiskContentTypeHostedEngine is the disk of the hosted engine VM.
+	DiskContentTypeHostedEngine DiskContentType = "hosted_engine"
+	// DiskContentTypeHostedEngineConfiguration holds the configuration of the hosted engine.
+	DiskContentTypeHostedEngineConfiguration DiskContentType = "hosted_engine_configuration"
+	// DiskContentTypeHostedEngineMetadata holds the metadata of the hosted engine.
+	DiskContentTypeHostedEngineMetadata DiskContentType = "hosted_engine_metadata"
+	// DiskContentTypeHostedEngineSanlock holds the sanlock lockspace of the hosted engine.
+	DiskContentTypeHostedEngineSanlock DiskContentType = "hosted_engine_sanlock"
+	// DiskContentTypeMemoryDumpVolume holds the memory of a VM snapshot.
+	DiskContentTypeMemoryDumpVolume DiskContentType = "memory_dump_volume"
+	// DiskContentTypeMemoryMetadataVolume holds the metadata of the memory of a VM snapshot.
+	DiskContentTypeMemoryMetadataVolume DiskContentType = "memory_metadata_volume"
+	// DiskContentTypeOVFStore holds the OVF descriptors of the VMs and templates on a storage domain.
+	DiskContentTypeOVFStore DiskContentType = "ovf_store"
+)
+
+// DiskContentTypeList is a list of DiskContentType values.
+type DiskContentTypeList []DiskContentType
+
+// DiskContentTypeValues returns all possible values for DiskContentType.
+func DiskContentTypeValues() DiskContentTypeList {
+	return []DiskContentType{
+		DiskContentTypeData,
+		DiskContentTypeISO,
+		DiskContentTypeBackupScratch,
+		DiskContentTypeHostedEngine,
+		DiskContentTypeHostedEngineConfiguration,
+		DiskContentTypeHostedEngineMetadata,
+		DiskContentTypeHostedEngineSanlock,
+		DiskContentTypeMemoryDumpVolume,
+		DiskContentTypeMemoryMetadataVolume,
+		DiskContentTypeOVFStore,
+	}
+}
+
+// Strings returns a list of strings.
+func (l DiskContentTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, contentType := range l {
+		result[i] = string(contentType)
+	}
+	return result
+}
+
+// Validate returns an error if the content type doesn't have a valid value.
+func (c DiskContentType) Validate() error {
+	for _, contentType := range DiskContentTypeValues() {
+		if contentType == c {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid disk content type: %s must be one of: %s",
+		c,
+		strings.Join(DiskContentTypeValues().Strings(), ", "),
+	)
+}
+
 // UploadImageProgress is a tracker for the upload progress happening in the background.
 type UploadImageProgress interface {
 	// Disk returns the disk created as part of the upload process once the upload is complete. Before the upload
 	// is complete it will return nil.
 	Disk() Disk
-	// UploadedBytes returns the number of bytes already uploaded.
+	// UploadedBytes returns the number of bytes already uploaded, including the bytes of chunks that are currently
+	// being sent.
 	//
-	// Caution! This number may decrease or reset to 0 if the upload has to be retried.
+	// Caution! This number may decrease if a chunk has to be retried, as the bytes sent by the failed attempt are
+	// subtracted.
 	UploadedBytes() uint64
+	// ConfirmedOffset returns the offset in the image before which every byte has been confirmed by the image
+	// server. An interrupted upload can be resumed from this offset using UploadParameters.
+	ConfirmedOffset() uint64
+	// TransferID returns the ID of the image transfer in the oVirt Engine. It returns an empty string until the
+	// transfer has been created.
+	TransferID() ImageTransferID
 	// TotalBytes returns the total number of bytes to be uploaded.
 	TotalBytes() uint64
+	// BandwidthLimit returns the speed limit of the upload in bytes per second, or 0 if there is no limit. The upload
+	// is additionally limited by the client-wide TransferBandwidthLimit.
+	BandwidthLimit() uint64
+	// SetBandwidthLimit changes the speed limit of the upload to bytesPerSecond while it is in progress. A limit of 0
+	// removes the limit.
+	SetBandwidthLimit(bytesPerSecond uint64)
 	// Err returns the error of the upload once the upload is complete or errored.
 	Err() error
 	// Done returns a channel that will be closed when the upload is complete.
 	Done() <-chan struct{}
 }
 
+// ImageTransferID is the identifier of an image transfer in the oVirt Engine.
+type ImageTransferID string
+
+// DefaultUploadChunkSize is the size of a single upload request if no chunk size is specified in UploadParameters.
+const DefaultUploadChunkSize uint64 = 64 * 1024 * 1024
+
+// DefaultUploadParallelism is the number of parallel upload requests if no parallelism is specified in
+// UploadParameters.
+const DefaultUploadParallelism uint = 4
+
+// minUploadChunkSize is the smallest chunk size accepted in UploadParameters.
+const minUploadChunkSize uint64 = 1024 * 1024
+
+// UploadParameters holds the optional parameters for DiskClient.StartUploadToDiskWithParams and
+// DiskClient.StartUploadToNewDiskWithParams.
+type UploadParameters interface {
+	// ChunkSize returns the maximum number of bytes sent in a single upload request. Each chunk is retried on its
+	// own if the request fails.
+	ChunkSize() uint64
+	// Parallelism returns the number of chunks uploaded at the same time.
+	Parallelism() uint
+	// KeepTransferOnFailure returns true if the image transfer should be paused instead of canceled if the upload
+	// fails, so it can be resumed later.
+	KeepTransferOnFailure() bool
+	// ResumeTransferID returns the ID of the image transfer to resume, or an empty string to start a new transfer.
+	ResumeTransferID() ImageTransferID
+	// ResumeOffset returns the offset in the image to resume the upload from.
+	ResumeOffset() uint64
+	// BandwidthLimit returns the initial speed limit of the upload in bytes per second, or 0 if there is no limit.
+	BandwidthLimit() uint64
+}
+
+// BuildableUploadParameters is a buildable version of UploadParameters.
+type BuildableUploadParameters interface {
+	UploadParameters
+
+	// WithChunkSize sets the maximum number of bytes sent in a single upload request. It must be at least 1 MiB.
+	WithChunkSize(chunkSize uint64) (BuildableUploadParameters, error)
+	// MustWithChunkSize is the same as WithChunkSize, but panics instead of returning an error.
+	MustWithChunkSize(chunkSize uint64) BuildableUploadParameters
+
+	// WithParallelism sets the number of chunks uploaded at the same time. It must be at least 1.
+	WithParallelism(parallelism uint) (BuildableUploadParameters, error)
+	// MustWithParallelism is the same as WithParallelism, but panics instead of returning an error.
+	MustWithParallelism(parallelism uint) BuildableUploadParameters
+
+	// WithKeepTransferOnFailure sets if the image transfer should be paused instead of canceled if the upload
+	// fails. The disk stays locked until the transfer is resumed and completed, or canceled in the oVirt Engine.
+	WithKeepTransferOnFailure(keep bool) (BuildableUploadParameters, error)
+	// MustWithKeepTransferOnFailure is the same as WithKeepTransferOnFailure, but panics instead of returning an
+	// error.
+	MustWithKeepTransferOnFailure(keep bool) BuildableUploadParameters
+
+	// WithResume sets the image transfer to resume and the offset to resume it from. Use the TransferID() and
+	// ConfirmedOffset() of the interrupted upload.
+	WithResume(transferID ImageTransferID, offset uint64) (BuildableUploadParameters, error)
+	// MustWithResume is the same as WithResume, but panics instead of returning an error.
+	MustWithResume(transferID ImageTransferID, offset uint64) BuildableUploadParameters
+
+	// WithBandwidthLimit limits the speed of the upload to bytesPerSecond. The limit can be changed while the upload
+	// is in progress using UploadImageProgress.SetBandwidthLimit.
+	WithBandwidthLimit(bytesPerSecond uint64) (BuildableUploadParameters, error)
+	// MustWithBandwidthLimit is the same as WithBandwidthLimit, but panics instead of returning an error.
+	MustWithBandwidthLimit(bytesPerSecond uint64) BuildableUploadParameters
+}
+
+// UploadParams creates a buildable set of UploadParameters for use with DiskClient.StartUploadToDiskWithParams.
+func UploadParams() BuildableUploadParameters {
+	return &uploadParams{
+		chunkSize:   DefaultUploadChunkSize,
+		parallelism: DefaultUploadParallelism,
+	}
+}
+
+type uploadParams struct {
+	chunkSize             uint64
+	parallelism           uint
+	keepTransferOnFailure bool
+	resumeTransferID      ImageTransferID
+	resumeOffset          uint64
+	bandwidthLimit        uint64
+}
+
+func (u *uploadParams) ChunkSize() uint64 {
+	return u.chunkSize
+}
+
+func (u *uploadParams) WithChunkSize(chunkSize uint64) (BuildableUploadParameters, error) {
+	if chunkSize < minUploadChunkSize {
+		return nil, newError(
+			EBadArgument,
+			"the chunk size must be at least %d bytes (%d given)",
+			minUploadChunkSize,
+			chunkSize,
+		)
+	}
+	u.chunkSize = chunkSize
+	return u, nil
+}
+
+func (u *uploadParams) MustWithChunkSize(chunkSize uint64) BuildableUploadParameters {
+	builder, err := u.WithChunkSize(chunkSize)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadParams) Parallelism() uint {
+	return u.parallelism
+}
+
+func (u *uploadParams) WithParallelism(parallelism uint) (BuildableUploadParameters, error) {
+	if parallelism < 1 {
+		return nil, newError(EBadArgument, "the parallelism must be at least 1")
+	}
+	u.parallelism = parallelism
+	return u, nil
+}
+
+func (u *uploadParams) MustWithParallelism(parallelism uint) BuildableUploadParameters {
+	builder, err := u.WithParallelism(parallelism)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadParams) KeepTransferOnFailure() bool {
+	return u.keepTransferOnFailure
+}
+
+func (u *uploadParams) WithKeepTransferOnFailure(keep bool) (BuildableUploadParameters, error) {
+	u.keepTransferOnFailure = keep
+	return u, nil
+}
+
+func (u *uploadParams) MustWithKeepTransferOnFailure(keep bool) BuildableUploadParameters {
+	builder, err := u.WithKeepTransferOnFailure(keep)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadParams) ResumeTransferID() ImageTransferID {
+	return u.resumeTransferID
+}
+
+func (u *uploadParams) ResumeOffset() uint64 {
+	return u.resumeOffset
+}
+
+func (u *uploadParams) WithResume(transferID ImageTransferID, offset uint64) (BuildableUploadParameters, error) {
+	if transferID == "" {
+		return nil, newError(EBadArgument, "the image transfer ID to resume must not be empty")
+	}
+	u.resumeTransferID = transferID
+	u.resumeOffset = offset
+	return u, nil
+}
+
+func (u *uploadParams) MustWithResume(transferID ImageTransferID, offset uint64) BuildableUploadParameters {
+	builder, err := u.WithResume(transferID, offset)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (u *uploadParams) BandwidthLimit() uint64 {
+	return u.bandwidthLimit
+}
+
+func (u *uploadParams) WithBandwidthLimit(bytesPerSecond uint64) (BuildableUploadParameters, error) {
+	u.bandwidthLimit = bytesPerSecond
+	return u, nil
+}
+
+func (u *uploadParams) MustWithBandwidthLimit(bytesPerSecond uint64) BuildableUploadParameters {
+	builder, err := u.WithBandwidthLimit(bytesPerSecond)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// DownloadProgressCallback is called while downloading a disk image to a file with the number of bytes already
+// written to the file and the total size of the image. The total size is 0 if it is not known yet.
+type DownloadProgressCallback func(bytesDownloaded uint64, totalBytes uint64)
+
+// DownloadToFileParameters holds the optional parameters for DiskClient.DownloadDiskToFile.
+type DownloadToFileParameters interface {
+	// ProgressCallback returns the function to call when the download progresses, or nil if no progress should be
+	// reported.
+	ProgressCallback() DownloadProgressCallback
+	// ExpectedSHA256 returns the hex-encoded SHA-256 hash the downloaded file is verified against if the image server
+	// does not support checksums, or an empty string if none is set.
+	ExpectedSHA256() string
+	// BandwidthLimit returns the speed limit of the download in bytes per second, or 0 if there is no limit.
+	BandwidthLimit() uint64
+}
+
+// BuildableDownloadToFileParameters is a buildable version of DownloadToFileParameters.
+type BuildableDownloadToFileParameters interface {
+	DownloadToFileParameters
+
+	// WithProgressCallback sets the function to call when the download progresses. The function is called from the
+	// goroutine running the download and should return quickly.
+	WithProgressCallback(callback DownloadProgressCallback) (BuildableDownloadToFileParameters, error)
+	// MustWithProgressCallback is the same as WithProgressCallback, but panics instead of returning an error.
+	MustWithProgressCallback(callback DownloadProgressCallback) BuildableDownloadToFileParameters
+
+	// WithExpectedSHA256 sets the hex-encoded SHA-256 hash to verify the downloaded file against if the image server
+	// does not support checksums.
+	WithExpectedSHA256(checksum string) (BuildableDownloadToFileParameters, error)
+	// MustWithExpectedSHA256 is the same as WithExpectedSHA256, but panics instead of returning an error.
+	MustWithExpectedSHA256(checksum string) BuildableDownloadToFileParameters
+
+	// WithBandwidthLimit limits the speed of the download to bytesPerSecond. The download is additionally limited by
+	// the client-wide TransferBandwidthLimit, which can be changed while the download is in progress.
+	WithBandwidthLimit(bytesPerSecond uint64) (BuildableDownloadToFileParameters, error)
+	// MustWithBandwidthLimit is the same as WithBandwidthLimit, but panics instead of returning an error.
+	MustWithBandwidthLimit(bytesPerSecond uint64) BuildableDownloadToFileParameters
+}
+
+// DownloadToFileParams creates a buildable set of DownloadToFileParameters for use with
+// DiskClient.DownloadDiskToFile.
+func DownloadToFileParams() BuildableDownloadToFileParameters {
+	return &downloadToFileParams{}
+}
+
+type downloadToFileParams struct {
+	progressCallback DownloadProgressCallback
+	expectedSHA256   string
+	bandwidthLimit   uint64
+}
+
+func (d *downloadToFileParams) ProgressCallback() DownloadProgressCallback {
+	return d.progressCallback
+}
+
+func (d *downloadToFileParams) WithProgressCallback(
+	callback DownloadProgressCallback,
+) (BuildableDownloadToFileParameters, error) {
+	d.progressCallback = callback
+	return d, nil
+}
+
+func (d *downloadToFileParams) MustWithProgressCallback(
+	callback DownloadProgressCallback,
+) BuildableDownloadToFileParameters {
+	builder, err := d.WithProgressCallback(callback)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadToFileParams) ExpectedSHA256() string {
+	return d.expectedSHA256
+}
+
+func (d *downloadToFileParams) WithExpectedSHA256(checksum string) (BuildableDownloadToFileParameters, error) {
+	decoded, err := hex.DecodeString(checksum)
+	if err != nil || len(decoded) != sha256.Size {
+		return nil, newError(EBadArgument, "invalid hex-encoded SHA-256 hash: %s", checksum)
+	}
+	d.expectedSHA256 = strings.ToLower(checksum)
+	return d, nil
+}
+
+func (d *downloadToFileParams) MustWithExpectedSHA256(checksum string) BuildableDownloadToFileParameters {
+	builder, err := d.WithExpectedSHA256(checksum)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *downloadToFileParams) BandwidthLimit() uint64 {
+	return d.bandwidthLimit
+}
+
+func (d *downloadToFileParams) WithBandwidthLimit(bytesPerSecond uint64) (BuildableDownloadToFileParameters, error) {
+	d.bandwidthLimit = bytesPerSecond
+	return d, nil
+}
+
+func (d *downloadToFileParams) MustWithBandwidthLimit(bytesPerSecond uint64) BuildableDownloadToFileParameters {
+	builder, err := d.WithBandwidthLimit(bytesPerSecond)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // ImageFormat is a constant for representing the format that images can be in. This is relevant
 // for both image uploads and image downloads, as the oVirt engine has the capability of converting
 // between these formats.
@@ -674,6 +1428,13 @@ func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
 	if !ok {
 		return nil, newError(EFieldMissing, "disk does not contain an ID")
 	}
+	storageType := DiskStorageTypeImage
+	if sdkStorageType, ok := sdkDisk.StorageType(); ok {
+		storageType = DiskStorageType(sdkStorageType)
+	}
+	if storageType == DiskStorageTypeLUN {
+		return convertSDKLUNDisk(sdkDisk, client)
+	}
 	var storageDomainIDs []StorageDomainID
 	if sdkStorageDomain, ok := sdkDisk.StorageDomain(); ok {
 		storageDomainID, _ := sdkStorageDomain.Id()
@@ -712,6 +1473,19 @@ func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
 	if !ok {
 		return nil, newError(EFieldMissing, "disk %s has no sparse field", id)
 	}
+	// Older engines do not report the content type.
+	contentType := DiskContentTypeData
+	if sdkContentType, ok := sdkDisk.ContentType(); ok {
+		contentType = DiskContentType(sdkContentType)
+	}
+	shareable, _ := sdkDisk.Shareable()
+	var diskProfileID *DiskProfileID
+	if sdkDiskProfile, ok := sdkDisk.DiskProfile(); ok {
+		if id, ok := sdkDiskProfile.Id(); ok {
+			profileID := DiskProfileID(id)
+			diskProfileID = &profileID
+		}
+	}
 	return &disk{
 		client: client,
 
@@ -723,6 +1497,10 @@ func convertSDKDisk(sdkDisk *ovirtsdk4.Disk, client Client) (Disk, error) {
 		storageDomainIDs: storageDomainIDs,
 		status:           DiskStatus(status),
 		sparse:           sparse,
+		contentType:      contentType,
+		diskProfileID:    diskProfileID,
+		shareable:        shareable,
+		storageType:      storageType,
 	}, nil
 }
 
@@ -737,6 +1515,11 @@ type disk struct {
 	status           DiskStatus
 	totalSize        uint64
 	sparse           bool
+	contentType      DiskContentType
+	diskProfileID    *DiskProfileID
+	shareable        bool
+	storageType      DiskStorageType
+	lunID            string
 }
 
 func (d *disk) WaitForOK(retries ...RetryStrategy) (Disk, error) {
@@ -767,10 +1550,42 @@ func (d *disk) StartUpdate(params UpdateDiskParameters, retries ...RetryStrategy
 	return d.client.StartUpdateDisk(d.id, params, retries...)
 }
 
+func (d *disk) Move(storageDomainID StorageDomainID, retries ...RetryStrategy) (Disk, error) {
+	return d.client.MoveDisk(d.id, storageDomainID, retries...)
+}
+
+func (d *disk) Copy(
+	storageDomainID StorageDomainID,
+	params CopyDiskParameters,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	return d.client.CopyDisk(d.id, storageDomainID, params, retries...)
+}
+
 func (d *disk) Sparse() bool {
 	return d.sparse
 }
 
+func (d *disk) ContentType() DiskContentType {
+	return d.contentType
+}
+
+func (d *disk) DiskProfileID() *DiskProfileID {
+	return d.diskProfileID
+}
+
+func (d *disk) Shareable() bool {
+	return d.shareable
+}
+
+func (d *disk) StorageType() DiskStorageType {
+	return d.storageType
+}
+
+func (d *disk) LUNID() string {
+	return d.lunID
+}
+
 func (d *disk) AttachToVM(
 	vmID VMID,
 	diskInterface DiskInterface,
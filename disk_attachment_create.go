@@ -2,6 +2,7 @@ package ovirtclient
 
 import (
 	"fmt"
+	"strings"
 
 	ovirtsdk "github.com/ovirt/go-ovirt"
 )
@@ -20,6 +21,7 @@ func (o *oVirtClient) CreateDiskAttachment(
 	err = retry(
 		fmt.Sprintf("attaching disk %s to vm %s", diskID, vmID),
 		o.logger,
+		o.requestHooks("CreateDiskAttachment"),
 		retries,
 		func() error {
 			attachmentBuilder := ovirtsdk.NewDiskAttachmentBuilder()
@@ -109,17 +111,21 @@ func (m *mockClient) CreateDiskAttachment(
 		}
 	}
 
-	if diskAttachment, ok := m.vmDiskAttachmentsByDisk[disk.ID()]; ok {
+	if existing := m.vmDiskAttachmentsByDisk[disk.ID()]; len(existing) > 0 && !disk.shareable {
+		var otherVMIDs []string
+		for _, diskAttachment := range existing {
+			otherVMIDs = append(otherVMIDs, string(diskAttachment.VMID()))
+		}
 		return nil, newError(
 			EConflict,
-			"cannot attach disk %s to VM %s, already attached to VM %s",
+			"cannot attach disk %s to VM %s, already attached to VM %s and the disk is not shareable",
 			diskID,
 			vmID,
-			diskAttachment.VMID(),
+			strings.Join(otherVMIDs, ", "),
 		)
 	}
 
-	m.vmDiskAttachmentsByDisk[disk.ID()] = attachment
+	m.addDiskAttachmentByDisk(disk.ID(), attachment)
 	m.vmDiskAttachmentsByVM[vm.ID()][attachment.ID()] = attachment
 
 	return attachment, nil
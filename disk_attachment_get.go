@@ -13,6 +13,7 @@ func (o *oVirtClient) GetDiskAttachment(
 	err = retry(
 		fmt.Sprintf("getting disk attachment %s on VM %s", id, vmid),
 		o.logger,
+		o.requestHooks("GetDiskAttachment"),
 		retries,
 		func() error {
 			response, err := o.conn.
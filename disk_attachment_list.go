@@ -14,6 +14,7 @@ func (o *oVirtClient) ListDiskAttachments(
 	err = retry(
 		fmt.Sprintf("listing disk attachments on VM %s", vmid),
 		o.logger,
+		o.requestHooks("ListDiskAttachments"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().VmsService().VmService(string(vmid)).DiskAttachmentsService().List().Send()
@@ -0,0 +1,29 @@
+package ovirtclient
+
+// addDiskAttachmentByDisk records a VM disk attachment in the per-disk index. Shareable disks can have multiple
+// attachments. The caller must hold the mock lock.
+func (m *mockClient) addDiskAttachmentByDisk(diskID DiskID, attachment *diskAttachment) {
+	if _, ok := m.vmDiskAttachmentsByDisk[diskID]; !ok {
+		m.vmDiskAttachmentsByDisk[diskID] = map[DiskAttachmentID]*diskAttachment{}
+	}
+	m.vmDiskAttachmentsByDisk[diskID][attachment.id] = attachment
+}
+
+// removeDiskAttachmentByDisk removes a VM disk attachment from the per-disk index. The caller must hold the mock lock.
+func (m *mockClient) removeDiskAttachmentByDisk(attachment *diskAttachment) {
+	attachments := m.vmDiskAttachmentsByDisk[attachment.diskID]
+	delete(attachments, attachment.id)
+	if len(attachments) == 0 {
+		delete(m.vmDiskAttachmentsByDisk, attachment.diskID)
+	}
+}
+
+// isDiskAttachedToVM returns true if the disk is attached to the specified VM. The caller must hold the mock lock.
+func (m *mockClient) isDiskAttachedToVM(diskID DiskID, vmID VMID) bool {
+	for _, attachment := range m.vmDiskAttachmentsByDisk[diskID] {
+		if attachment.vmid == vmID {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveDiskAttachment(vmID VMID, diskAttachmentID DiskAttac
 	return retry(
 		fmt.Sprintf("removing disk attachment %s on VM %s", diskAttachmentID, vmID),
 		o.logger,
+		o.requestHooks("RemoveDiskAttachment"),
 		retries,
 		func() error {
 			_, err := o.conn.
@@ -38,7 +39,7 @@ func (m *mockClient) RemoveDiskAttachment(vmID VMID, diskAttachmentID DiskAttach
 		return newError(ENotFound, "Disk attachment %s not found on VM %s", diskAttachmentID, vmID)
 	}
 
-	delete(m.vmDiskAttachmentsByDisk, diskAttachment.DiskID())
+	m.removeDiskAttachmentByDisk(diskAttachment)
 	delete(m.vmDiskAttachmentsByVM[vmID], diskAttachmentID)
 
 	return nil
@@ -55,6 +55,82 @@ func TestDiskAttachmentCannotBeAttachedToSecondVM(t *testing.T) {
 	assertCannotAttachDisk(t, vm2, disk, ovirtclient.EConflict)
 }
 
+func TestShareableDiskCanBeAttachedToMultipleVMs(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm1 := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("disk_attachment_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	vm2 := assertCanCreateVM(
+		t,
+		helper,
+		fmt.Sprintf("disk_attachment_test_%s", helper.GenerateRandomID(5)),
+		ovirtclient.CreateVMParams(),
+	)
+	disk := assertCanCreateDiskWithParameters(
+		t,
+		helper,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.CreateDiskParams().MustWithShareable(true),
+	)
+	if !disk.Shareable() {
+		t.Fatalf("Disk %s created as shareable is not shareable.", disk.ID())
+	}
+	attachment1 := assertCanAttachDisk(t, vm1, disk)
+	attachment2 := assertCanAttachDisk(t, vm2, disk)
+	assertCanDetachDisk(t, attachment1)
+	assertCanDetachDisk(t, attachment2)
+}
+
+func TestShareableDiskIsKeptOnVMRemoval(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm, err := client.CreateVM(
+		helper.GetClusterID(),
+		helper.GetBlankTemplateID(),
+		fmt.Sprintf("disk_attachment_test_%s", helper.GenerateRandomID(5)),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test VM (%v)", err)
+	}
+	disk := assertCanCreateDiskWithParameters(
+		t,
+		helper,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.CreateDiskParams().MustWithShareable(true),
+	)
+	assertCanAttachDisk(t, vm, disk)
+
+	if err := client.RemoveVM(vm.ID()); err != nil {
+		t.Fatalf("Failed to remove test VM %s (%v)", vm.ID(), err)
+	}
+	if _, err := client.GetDisk(disk.ID()); err != nil {
+		t.Fatalf("Shareable disk %s was removed with the VM (%v)", disk.ID(), err)
+	}
+}
+
+func TestShareableDiskMustBeRaw(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	_, err := helper.GetClient().CreateDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatCow,
+		1048576,
+		ovirtclient.CreateDiskParams().MustWithShareable(true),
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Creating a shareable QCOW2 disk did not return an EBadArgument error (%v)", err)
+	}
+}
+
 func assertCanCreateDisk(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.Disk {
 	return assertCanCreateDiskWithParameters(t, helper, ovirtclient.ImageFormatRaw, nil)
 }
@@ -0,0 +1,259 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	params CopyDiskParameters,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := o.StartCopyDisk(diskID, storageDomainID, params, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (o *oVirtClient) StartCopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	params CopyDiskParameters,
+	retries ...RetryStrategy,
+) (DiskUpdate, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = CopyDiskParams()
+	}
+	correlationID, err := correlationIDFor(o.ctx, "disk_copy_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sourceDisk, err := o.GetDisk(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	alias := sourceDisk.Alias()
+	if a := params.Alias(); a != nil {
+		alias = *a
+	}
+	// The engine does not return the new disk, so the copy is created with a temporary alias that is unique to this
+	// copy. The disk is found by this alias and renamed once the copy is complete. Finding it by its final alias would
+	// mix up concurrent copies with the same alias.
+	copyAlias := fmt.Sprintf("%s_%s", alias, generateRandomID(10, o.nonSecureRandom))
+
+	sdkDisk := ovirtsdk.NewDiskBuilder().Alias(copyAlias)
+	if format := params.Format(); format != nil {
+		sdkDisk.Format(ovirtsdk.DiskFormat(*format))
+	}
+	err = retry(
+		fmt.Sprintf("copying disk %s to storage domain %s", diskID, storageDomainID),
+		o.logger,
+		o.requestHooks("StartCopyDisk"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				Copy().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Disk(sdkDisk.MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &diskCopyWait{
+		client:          o,
+		sourceDiskID:    diskID,
+		alias:           alias,
+		copyAlias:       copyAlias,
+		storageDomainID: storageDomainID,
+		correlationID:   correlationID,
+		lock:            &sync.Mutex{},
+	}, nil
+}
+
+// diskCopyWait waits for a disk copy to complete and finds the new disk.
+type diskCopyWait struct {
+	client       *oVirtClient
+	sourceDiskID DiskID
+	alias        string
+	// copyAlias is the temporary alias the new disk is created with.
+	copyAlias       string
+	storageDomainID StorageDomainID
+	correlationID   string
+	lock            *sync.Mutex
+	disk            Disk
+}
+
+func (d *diskCopyWait) Disk() Disk {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.disk
+}
+
+func (d *diskCopyWait) Wait(retries ...RetryStrategy) (Disk, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(d.client))
+	disk, err := d.wait(retries)
+	if err != nil {
+		d.removeCopy(err)
+		return nil, err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.disk = disk
+	return disk, nil
+}
+
+// wait waits for the copy to complete and renames it to the requested alias.
+func (d *diskCopyWait) wait(retries []RetryStrategy) (Disk, error) {
+	if err := d.client.waitForJobFinished(d.correlationID, retries); err != nil {
+		return nil, err
+	}
+
+	disks, err := d.client.ListDisksByAlias(d.copyAlias, retries...)
+	if err != nil {
+		return nil, err
+	}
+	switch len(disks) {
+	case 0:
+		return nil, newError(
+			ENotFound,
+			"copy of disk %s with temporary alias %s not found on storage domain %s",
+			d.sourceDiskID,
+			d.copyAlias,
+			d.storageDomainID,
+		)
+	case 1:
+	default:
+		return nil, newError(
+			EMultipleResults,
+			"multiple disks with the temporary alias %s of the copy of disk %s found",
+			d.copyAlias,
+			d.sourceDiskID,
+		)
+	}
+
+	if _, err := d.client.WaitForDiskOK(disks[0].ID(), retries...); err != nil {
+		return nil, err
+	}
+	return d.client.UpdateDisk(disks[0].ID(), UpdateDiskParams().MustWithAlias(d.alias), retries...)
+}
+
+// removeCopy removes the disks with the temporary alias of the copy after the copy failed, so no disk is left behind
+// with an alias the caller did not ask for. The retries of the failed wait may be exhausted, so the removal uses the
+// default retries.
+func (d *diskCopyWait) removeCopy(cause error) {
+	d.client.logger.Infof("Copying disk %s failed, removing the copy (%v)", d.sourceDiskID, cause)
+	disks, err := d.client.ListDisksByAlias(d.copyAlias)
+	if err != nil {
+		d.client.logger.Warningf(
+			"Failed to find the copy of disk %s with the temporary alias %s after the copy failed, please remove "+
+				"it manually. (%v)",
+			d.sourceDiskID,
+			d.copyAlias,
+			err,
+		)
+		return
+	}
+	for _, disk := range disks {
+		if err := disk.Remove(); err != nil && !HasErrorCode(err, ENotFound) {
+			d.client.logger.Warningf(
+				"Failed to remove copy %s of disk %s after the copy failed, please remove it manually. (%v)",
+				disk.ID(),
+				d.sourceDiskID,
+				err,
+			)
+		}
+	}
+}
+
+func (m *mockClient) CopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	params CopyDiskParameters,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := m.StartCopyDisk(diskID, storageDomainID, params, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (m *mockClient) StartCopyDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	params CopyDiskParameters,
+	_ ...RetryStrategy,
+) (DiskUpdate, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = CopyDiskParams()
+	}
+	correlationID, err := correlationIDFor(m.ctx, "disk_copy_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := m.disks[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	if source.storageType != DiskStorageTypeImage {
+		return nil, newError(EBadArgument, "disk %s is a %s disk, only image disks can be copied", diskID, source.storageType)
+	}
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	if err := m.checkStorageDomainSpace(storageDomainID, source.totalSize); err != nil {
+		return nil, err
+	}
+	if err := source.Lock(); err != nil {
+		return nil, err
+	}
+
+	diskProfileID, _ := m.diskProfileFor([]StorageDomainID{storageDomainID}, nil)
+	newDisk := source.clone(nil).
+		withStorageDomainIDs([]StorageDomainID{storageDomainID}).
+		withDiskProfileID(diskProfileID)
+	newDisk.data = copyMockDiskData(source.data)
+	if alias := params.Alias(); alias != nil {
+		newDisk.alias = *alias
+	}
+	if format := params.Format(); format != nil && *format != source.format {
+		m.logger.Warningf(
+			"the disk copy requested a conversion from %s to %s; the mock library does not support this and the"+
+				" source image data will be used unmodified which may lead to errors",
+			source.format,
+			*format,
+		)
+		newDisk.format = *format
+	}
+	m.disks[newDisk.id] = newDisk
+
+	job := m.startJob(fmt.Sprintf("Copying Disk %s to storage domain %s", source.alias, storageDomainID), correlationID)
+	diskCopy := &mockDiskStorageOperation{
+		client: m,
+		source: source,
+		disk:   newDisk,
+		done:   make(chan struct{}),
+	}
+	go diskCopy.do(job, func() {})
+	return diskCopy, nil
+}
@@ -0,0 +1,61 @@
+// This file contains tests for the internal cleanup of failed disk copies. It is therefore excluded from the
+// testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestDiskCopyWaitRemovesFailedCopy(t *testing.T) {
+	t.Parallel()
+
+	lock := &sync.Mutex{}
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/xml")
+		disk := `<disk id="copy-1"><alias>test_0123456789</alias><provisioned_size>1048576</provisioned_size>` +
+			`<total_size>1048576</total_size><format>raw</format><status>illegal</status><sparse>false</sparse>` +
+			`<storage_domains><storage_domain id="sd-1"/></storage_domains></disk>`
+		switch {
+		case r.URL.Path == "/ovirt-engine/api/jobs":
+			_, _ = w.Write([]byte(`<jobs><job id="job-1"><status>finished</status></job></jobs>`))
+		case r.URL.Path == "/ovirt-engine/api/disks" && r.URL.Query().Get("search") == "name=test_0123456789":
+			_, _ = w.Write([]byte(`<disks>` + disk + `</disks>`))
+		case r.URL.Path == "/ovirt-engine/api/disks/copy-1" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(disk))
+		case r.URL.Path == "/ovirt-engine/api/disks/copy-1" && r.Method == http.MethodDelete:
+			removed = append(removed, "copy-1")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	diskCopy := &diskCopyWait{
+		client:          newTestEngineClient(t, server),
+		sourceDiskID:    "source-1",
+		alias:           "test",
+		copyAlias:       "test_0123456789",
+		storageDomainID: "sd-1",
+		correlationID:   "disk_copy_test",
+		lock:            &sync.Mutex{},
+	}
+	if _, err := diskCopy.Wait(MaxTries(2), ExponentialBackoff(1)); err == nil {
+		t.Fatalf("Waiting for a copy with an illegal disk did not fail.")
+	}
+	if diskCopy.Disk() != nil {
+		t.Fatalf("The failed copy was returned.")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(removed) != 1 {
+		t.Fatalf("The failed copy was not removed (removed: %v)", removed)
+	}
+}
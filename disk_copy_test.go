@@ -0,0 +1,76 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestDiskCopy(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	secondarySD := helper.GetSecondaryStorageDomainID(t)
+
+	disk := assertCanCreateDisk(t, helper)
+	image := bytes.Repeat([]byte{0x42}, 1024*1024)
+	assertCanUploadBackupTestImage(t, helper, disk, image)
+	targetAvailable := assertCanGetStorageDomainAvailable(t, helper, secondarySD)
+
+	alias := helper.GenerateTestResourceName(t)
+	t.Logf("Copying disk %s to storage domain %s as %s", disk.ID(), secondarySD, alias)
+	newDisk, err := disk.Copy(secondarySD, ovirtclient.CopyDiskParams().MustWithAlias(alias))
+	if newDisk != nil {
+		t.Cleanup(func() {
+			if err := newDisk.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+				t.Fatalf("Failed to remove disk copy %s (%v)", newDisk.ID(), err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Failed to copy disk %s to storage domain %s (%v)", disk.ID(), secondarySD, err)
+	}
+	if newDisk.ID() == disk.ID() {
+		t.Fatalf("The disk copy has the same ID as the source disk.")
+	}
+	if newDisk.Alias() != alias {
+		t.Fatalf("Incorrect alias on disk copy (%s instead of %s).", newDisk.Alias(), alias)
+	}
+	assertCanGetDiskFromStorageDomain(t, helper, secondarySD, newDisk)
+
+	sourceDisk, err := client.GetDisk(disk.ID())
+	if err != nil {
+		t.Fatalf("Failed to get source disk %s after the copy (%v)", disk.ID(), err)
+	}
+	if sourceDisk.Status() != ovirtclient.DiskStatusOK {
+		t.Fatalf("The source disk is in status %s after the copy.", sourceDisk.Status())
+	}
+
+	data := downloadDiskCopyTestImage(t, helper, newDisk)
+	if !bytes.Equal(data[:len(image)], image) {
+		t.Fatalf("The data of the disk copy does not match the source disk.")
+	}
+
+	if _, ok := client.(ovirtclient.MockClient); ok {
+		if available := assertCanGetStorageDomainAvailable(t, helper, secondarySD); available != targetAvailable-newDisk.TotalSize() {
+			t.Fatalf("Incorrect available space on the target storage domain after the copy: %d", available)
+		}
+	}
+}
+
+func downloadDiskCopyTestImage(t *testing.T, helper ovirtclient.TestHelper, disk ovirtclient.Disk) []byte {
+	download, err := helper.GetClient().DownloadDisk(disk.ID(), ovirtclient.ImageFormatRaw)
+	if err != nil {
+		t.Fatalf("Failed to start download of disk %s (%v)", disk.ID(), err)
+	}
+	defer func() {
+		_ = download.Close()
+	}()
+	data, err := io.ReadAll(download)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s (%v)", disk.ID(), err)
+	}
+	return data
+}
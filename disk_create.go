@@ -16,20 +16,23 @@ func (o *oVirtClient) StartCreateDisk(
 ) (DiskCreation, error) {
 	retries = defaultRetries(retries, defaultWriteTimeouts(o))
 
-	if err := validateDiskCreationParameters(format, size); err != nil {
+	if err := validateDiskCreationParameters(format, size, params); err != nil {
 		return nil, err
 	}
 
 	var result *diskWait
 	processName := "creating disk"
-	correlationID := ""
 	if params != nil && params.Alias() != "" {
 		processName = fmt.Sprintf("creating disk %s", params.Alias())
 	}
-	correlationID = fmt.Sprintf("disk_create_%s", generateRandomID(5, o.nonSecureRandom))
-	err := retry(
+	correlationID, err := correlationIDFor(o.ctx, "disk_create_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	err = retry(
 		processName,
 		o.logger,
+		o.requestHooks("StartCreateDisk"),
 		retries,
 		func() error {
 			addResponse, err := o.createDisk(storageDomainID, size, format, correlationID, params)
@@ -63,10 +66,19 @@ func (o *oVirtClient) StartCreateDisk(
 	return result, nil
 }
 
-func validateDiskCreationParameters(format ImageFormat, size uint64) error {
+func validateDiskCreationParameters(format ImageFormat, size uint64, params CreateDiskOptionalParameters) error {
 	if err := format.Validate(); err != nil {
 		return err
 	}
+	if params != nil {
+		if contentType := params.ContentType(); contentType != nil && *contentType == DiskContentTypeISO &&
+			format != ImageFormatRaw {
+			return newError(EBadArgument, "disks with the %s content type must use the %s format", *contentType, ImageFormatRaw)
+		}
+		if shareable := params.Shareable(); shareable != nil && *shareable && format != ImageFormatRaw {
+			return newError(EBadArgument, "shareable disks must use the %s format", ImageFormatRaw)
+		}
+	}
 	return validateDiskSize(size)
 }
 
@@ -130,6 +142,15 @@ func (o *oVirtClient) buildDiskObjectForCreation(
 		if initialSize := params.InitialSize(); initialSize != nil {
 			diskBuilder.InitialSize(int64(*initialSize))
 		}
+		if contentType := params.ContentType(); contentType != nil {
+			diskBuilder.ContentType(ovirtsdk4.DiskContentType(*contentType))
+		}
+		if diskProfileID := params.DiskProfileID(); diskProfileID != nil {
+			diskBuilder.DiskProfile(ovirtsdk4.NewDiskProfileBuilder().Id(string(*diskProfileID)).MustBuild())
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			diskBuilder.Shareable(*shareable)
+		}
 	}
 	return diskBuilder.Build()
 }
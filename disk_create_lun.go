@@ -0,0 +1,136 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateLUNDisk(
+	lunID string,
+	storageType LUNStorageType,
+	params CreateLUNDiskOptionalParameters,
+	retries ...RetryStrategy,
+) (result Disk, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = CreateLUNDiskParams()
+	}
+	if err := validateLUNDiskCreationParameters(lunID, storageType, params); err != nil {
+		return nil, err
+	}
+	sdkDisk, err := buildSDKLUNDisk(lunID, storageType, params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build LUN disk object")
+	}
+	err = retry(
+		fmt.Sprintf("creating disk for %s LUN %s", storageType, lunID),
+		o.logger,
+		o.requestHooks("CreateLUNDisk"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().DisksService().Add().Disk(sdkDisk).Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Disk()
+			if !ok {
+				return newFieldNotFound("response from LUN disk creation", "disk")
+			}
+			result, e = convertSDKDisk(sdkObject, o)
+			if e != nil {
+				return wrap(e, EBug, "failed to convert LUN disk")
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func buildSDKLUNDisk(
+	lunID string,
+	storageType LUNStorageType,
+	params CreateLUNDiskOptionalParameters,
+) (*ovirtsdk.Disk, error) {
+	logicalUnit := ovirtsdk.NewLogicalUnitBuilder().Id(lunID)
+	if connection := params.ISCSIConnection(); connection != nil {
+		logicalUnit.
+			Address(connection.Address).
+			Port(int64(connection.Port)).
+			Target(connection.Target)
+		if connection.Username != "" {
+			logicalUnit.Username(connection.Username).Password(connection.Password)
+		}
+	}
+	lunStorage, err := ovirtsdk.NewHostStorageBuilder().
+		Type(ovirtsdk.StorageType(storageType)).
+		LogicalUnitsBuilderOfAny(*logicalUnit).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	diskBuilder := ovirtsdk.NewDiskBuilder().LunStorage(lunStorage)
+	if alias := params.Alias(); alias != "" {
+		diskBuilder.Alias(alias)
+	}
+	if shareable := params.Shareable(); shareable != nil {
+		diskBuilder.Shareable(*shareable)
+	}
+	return diskBuilder.Build()
+}
+
+// mockLUNSize is the size reported for LUN disks by the mock, as it has no LUNs to query.
+const mockLUNSize = 10 * 1024 * 1024 * 1024
+
+func (m *mockClient) CreateLUNDisk(
+	lunID string,
+	storageType LUNStorageType,
+	params CreateLUNDiskOptionalParameters,
+	_ ...RetryStrategy,
+) (Disk, error) {
+	if params == nil {
+		params = CreateLUNDiskParams()
+	}
+	if err := validateLUNDiskCreationParameters(lunID, storageType, params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, existing := range m.disks {
+		if existing.storageType == DiskStorageTypeLUN && existing.lunID == lunID {
+			return nil, newError(EConflict, "LUN %s is already used by disk %s", lunID, existing.id)
+		}
+	}
+	disk := &diskWithData{
+		disk: disk{
+			client:          m,
+			id:              DiskID(m.GenerateUUID()),
+			alias:           params.Alias(),
+			provisionedSize: mockLUNSize,
+			totalSize:       mockLUNSize,
+			format:          ImageFormatRaw,
+			status:          DiskStatusOK,
+			contentType:     DiskContentTypeData,
+			storageType:     DiskStorageTypeLUN,
+			lunID:           lunID,
+		},
+		lock:         &sync.Mutex{},
+		dirtyBitmaps: map[CheckpointID][]bool{},
+	}
+	if shareable := params.Shareable(); shareable != nil {
+		disk.shareable = *shareable
+	}
+	m.disks[disk.id] = disk
+	diskID := disk.id
+	m.emitEvent(
+		EventCodeDiskAdded,
+		EventSeverityNormal,
+		mockEventRefs{diskID: &diskID},
+		"The disk %s was successfully added.",
+		disk.alias,
+	)
+	return disk, nil
+}
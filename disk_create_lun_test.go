@@ -0,0 +1,75 @@
+// This file contains tests for LUN disks in the mock client, as the live tests cannot rely on a LUN being available.
+// It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestMockLUNDiskCreation(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+
+	disk, err := client.CreateLUNDisk(
+		"36001405abcdef",
+		LUNStorageTypeISCSI,
+		CreateLUNDiskParams().
+			MustWithAlias("quorum").
+			MustWithShareable(true).
+			MustWithISCSIConnection(ISCSIConnection{Address: "192.0.2.1", Target: "iqn.2003-01.org.example:quorum"}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create LUN disk (%v)", err)
+	}
+	if disk.StorageType() != DiskStorageTypeLUN {
+		t.Fatalf("Incorrect storage type for LUN disk: %s", disk.StorageType())
+	}
+	if disk.LUNID() != "36001405abcdef" {
+		t.Fatalf("Incorrect LUN ID for LUN disk: %s", disk.LUNID())
+	}
+	if len(disk.StorageDomainIDs()) != 0 {
+		t.Fatalf("LUN disk has storage domains: %v", disk.StorageDomainIDs())
+	}
+	if !disk.Shareable() {
+		t.Fatalf("LUN disk created as shareable is not shareable.")
+	}
+
+	_, err = client.CreateLUNDisk("36001405abcdef", LUNStorageTypeISCSI, nil)
+	if !HasErrorCode(err, EConflict) {
+		t.Fatalf("Creating a second disk for the same LUN did not return an EConflict error (%v)", err)
+	}
+}
+
+func TestMockLUNDiskRejectsISCSIConnectionForFCP(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+
+	_, err := client.CreateLUNDisk(
+		"3600a0b80001234",
+		LUNStorageTypeFCP,
+		CreateLUNDiskParams().MustWithISCSIConnection(
+			ISCSIConnection{Address: "192.0.2.1", Target: "iqn.2003-01.org.example:quorum"},
+		),
+	)
+	if !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Passing iSCSI connection details for an FCP LUN did not return an EBadArgument error (%v)", err)
+	}
+}
+
+func TestMockLUNDiskCannotBeMoved(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+
+	disk, err := client.CreateLUNDisk("3600a0b80001234", LUNStorageTypeFCP, nil)
+	if err != nil {
+		t.Fatalf("Failed to create LUN disk (%v)", err)
+	}
+	storageDomains, err := client.ListStorageDomains()
+	if err != nil {
+		t.Fatalf("Failed to list storage domains (%v)", err)
+	}
+	if _, err := client.StartMoveDisk(disk.ID(), storageDomains[0].ID()); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Moving a LUN disk did not return an EBadArgument error (%v)", err)
+	}
+}
@@ -1,6 +1,7 @@
 package ovirtclient
 
 import (
+	"fmt"
 	"sync"
 	"time"
 )
@@ -15,10 +16,15 @@ func (m *mockClient) StartCreateDisk(
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	correlationID, err := correlationIDFor(m.ctx, "disk_create_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	disk, err := m.createDisk(storageDomainID, format, size, params)
 	if err != nil {
 		return nil, err
 	}
+	creationJob := m.startJob(fmt.Sprintf("Adding Disk %s", disk.alias), correlationID)
 
 	creation := &mockDiskCreation{
 		client: m,
@@ -26,6 +32,7 @@ func (m *mockClient) StartCreateDisk(
 		done:   make(chan struct{}),
 	}
 	creation.do()
+	m.endJob(creationJob, JobStatusFinished)
 	return creation, nil
 }
 
@@ -35,13 +42,25 @@ func (m *mockClient) createDisk(
 	size uint64,
 	params CreateDiskOptionalParameters,
 ) (*diskWithData, error) {
-	if err := validateDiskCreationParameters(format, size); err != nil {
+	if err := validateDiskCreationParameters(format, size, params); err != nil {
 		return nil, err
 	}
 
-	if _, ok := m.storageDomains[storageDomainID]; !ok {
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
 		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
 	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	var requestedDiskProfileID *DiskProfileID
+	if params != nil {
+		requestedDiskProfileID = params.DiskProfileID()
+	}
+	diskProfileID, err := m.diskProfileFor([]StorageDomainID{storageDomainID}, requestedDiskProfileID)
+	if err != nil {
+		return nil, err
+	}
 
 	disk := &diskWithData{
 		disk: disk{
@@ -52,9 +71,13 @@ func (m *mockClient) createDisk(
 			totalSize:        size,
 			storageDomainIDs: []StorageDomainID{storageDomainID},
 			status:           DiskStatusLocked,
+			contentType:      DiskContentTypeData,
+			diskProfileID:    diskProfileID,
+			storageType:      DiskStorageTypeImage,
 		},
-		lock: &sync.Mutex{},
-		data: nil,
+		lock:         &sync.Mutex{},
+		data:         nil,
+		dirtyBitmaps: map[CheckpointID][]bool{},
 	}
 
 	if params != nil {
@@ -64,9 +87,23 @@ func (m *mockClient) createDisk(
 		if sparse := params.Sparse(); sparse != nil {
 			disk.disk.sparse = *sparse
 		}
+		if contentType := params.ContentType(); contentType != nil {
+			disk.disk.contentType = *contentType
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			disk.disk.shareable = *shareable
+		}
 	}
 
 	m.disks[disk.id] = disk
+	diskID := disk.id
+	m.emitEvent(
+		EventCodeDiskAdded,
+		EventSeverityNormal,
+		mockEventRefs{diskID: &diskID},
+		"The disk %s was successfully added.",
+		disk.alias,
+	)
 
 	return disk, nil
 }
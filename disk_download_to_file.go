@@ -0,0 +1,611 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) DownloadDiskToFile(
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	params DownloadToFileParameters,
+	retries ...RetryStrategy,
+) (result DownloadToFileResult, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if params == nil {
+		params = DownloadToFileParams()
+	}
+
+	o.logger.Infof("Starting download of disk %s to %s...", diskID, path)
+	transfer := newImageTransfer(
+		o,
+		o.logger,
+		diskID,
+		"",
+		retries,
+		ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+		ovirtsdk4.DiskFormat(format),
+		func(disk Disk) {},
+	)
+	transferURL, err := transfer.initialize()
+	defer func() {
+		err = transfer.finalize(err)
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return downloadToFile(
+		o.logger,
+		diskID,
+		format,
+		path,
+		params,
+		&imageTransferFileSource{
+			cli:         o,
+			ctx:         ctx,
+			transfer:    transfer,
+			transferURL: transferURL,
+			retries:     retries,
+			throttle:    newTransferThrottle(o.transferLimiter, params.BandwidthLimit()),
+		},
+	)
+}
+
+// imageTransferFileSource downloads an image for DownloadDiskToFile from an ImageIO transfer URL.
+type imageTransferFileSource struct {
+	cli         *oVirtClient
+	ctx         context.Context
+	transfer    imageTransfer
+	transferURL string
+	retries     []RetryStrategy
+	throttle    *transferThrottle
+}
+
+func (i *imageTransferFileSource) downloadTo(target *downloadToFileTarget) error {
+	return retry(
+		fmt.Sprintf("downloading image from %s", i.transferURL),
+		i.cli.logger,
+		i.cli.transferHooks("DownloadImageToFile"),
+		i.throttle.retries(append(i.retries, ContextStrategy(i.ctx))),
+		func() error {
+			return i.attemptDownloadTo(target)
+		},
+	)
+}
+
+// attemptDownloadTo requests the image from the current offset of the target to the end. Each attempt resumes where
+// the previous one stopped.
+func (i *imageTransferFileSource) attemptDownloadTo(target *downloadToFileTarget) error {
+	req, err := http.NewRequestWithContext(i.ctx, http.MethodGet, i.transferURL, nil)
+	if err != nil {
+		return wrap(err, EBug, "failed to create HTTP request to %s", i.transferURL)
+	}
+	offset := target.Offset()
+	if offset > 0 {
+		req.Header.Add("range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	response, err := i.cli.httpClient.Do(req)
+	if err != nil {
+		return wrap(err, EConnection, "HTTP request to image transfer URL %s failed", i.transferURL)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	switch response.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is at least as large as the image.
+		size, err := parseContentRangeSize(response.Header.Get("content-range"))
+		if err != nil {
+			return err
+		}
+		if err := target.SetSize(size); err != nil {
+			return err
+		}
+		if target.Offset() != offset {
+			return i.attemptDownloadTo(target)
+		}
+		if offset == size {
+			return nil
+		}
+		i.cli.logger.Warningf(
+			"The partial download is larger than the image (%d bytes instead of %d), restarting the download.",
+			offset,
+			size,
+		)
+		if err := target.Reset(); err != nil {
+			return err
+		}
+		return i.attemptDownloadTo(target)
+	case http.StatusPartialContent:
+		size, err := parseContentRangeSize(response.Header.Get("content-range"))
+		if err != nil {
+			return err
+		}
+		if err := target.SetSize(size); err != nil {
+			return err
+		}
+		if target.Offset() != offset {
+			// The partial download was discarded, the response does not start at the new offset.
+			return i.attemptDownloadTo(target)
+		}
+	case http.StatusOK:
+		if offset > 0 {
+			i.cli.logger.Warningf("The image server does not support range requests, restarting the download.")
+			if err := target.Reset(); err != nil {
+				return err
+			}
+		}
+		if contentLength := response.Header.Get("content-length"); contentLength != "" {
+			size, err := strconv.ParseUint(contentLength, 10, 64)
+			if err != nil {
+				return wrap(err, EBug, "invalid content-length header received from the image server: %s", contentLength)
+			}
+			if err := target.SetSize(size); err != nil {
+				return err
+			}
+		}
+	default:
+		return i.transfer.checkStatusCode(response.StatusCode)
+	}
+
+	if _, err := io.Copy(target, i.throttle.reader(i.ctx, response.Body)); err != nil {
+		return wrap(err, EConnection, "failed to download image from %s", i.transferURL)
+	}
+	if size := target.Size(); size != 0 && target.Offset() != size {
+		return newError(
+			EConnection,
+			"the image download ended after %d bytes instead of %d bytes",
+			target.Offset(),
+			size,
+		)
+	}
+	return nil
+}
+
+func (i *imageTransferFileSource) checksum() (result *imageChecksum, err error) {
+	checksumURL := fmt.Sprintf("%s/checksum?algorithm=%s", i.transferURL, imageChecksumAlgorithm)
+	return result, retry(
+		fmt.Sprintf("fetching image checksum from %s", checksumURL),
+		i.cli.logger,
+		i.cli.transferHooks("GetImageChecksum"),
+		i.retries,
+		func() error {
+			result = nil
+			req, err := http.NewRequestWithContext(i.ctx, http.MethodGet, checksumURL, nil)
+			if err != nil {
+				return wrap(err, EBug, "failed to create HTTP request to %s", checksumURL)
+			}
+			response, err := i.cli.httpClient.Do(req)
+			if err != nil {
+				return wrap(err, EConnection, "HTTP request to %s failed", checksumURL)
+			}
+			defer func() {
+				_ = response.Body.Close()
+			}()
+			switch response.StatusCode {
+			case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusBadRequest:
+				// Older ImageIO versions do not have the checksum API or the algorithm parameter.
+				i.cli.logger.Debugf("The image server does not support checksums (status code %d).", response.StatusCode)
+				return nil
+			}
+			if err := i.transfer.checkStatusCode(response.StatusCode); err != nil {
+				return err
+			}
+			checksum := &imageChecksum{}
+			if err := json.NewDecoder(response.Body).Decode(checksum); err != nil {
+				return wrap(err, EBug, "failed to decode image checksum from %s", checksumURL)
+			}
+			if checksum.Algorithm != imageChecksumAlgorithm {
+				return newError(
+					EBug,
+					"the image server returned a %s checksum instead of %s",
+					checksum.Algorithm,
+					imageChecksumAlgorithm,
+				)
+			}
+			result = checksum
+			return nil
+		},
+	)
+}
+
+// parseContentRangeSize returns the total size from a content-range header in the format of "bytes 0-99/100" or
+// "bytes */100".
+func parseContentRangeSize(contentRange string) (uint64, error) {
+	parts := strings.SplitN(contentRange, "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "bytes ") {
+		return 0, newError(EBug, "invalid content-range header received from the image server: %s", contentRange)
+	}
+	size, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, wrap(err, EBug, "invalid content-range header received from the image server: %s", contentRange)
+	}
+	return size, nil
+}
+
+func (m *mockClient) DownloadDiskToFile(
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	params DownloadToFileParameters,
+	_ ...RetryStrategy,
+) (DownloadToFileResult, error) {
+	if params == nil {
+		params = DownloadToFileParams()
+	}
+
+	m.lock.Lock()
+	disk, ok := m.disks[diskID]
+	if !ok {
+		m.lock.Unlock()
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	if disk.format != format {
+		m.logger.Warningf(
+			"the image download client requested a conversion from %s to %s; the mock library does not support this"+
+				" and the source image data will be used unmodified which may lead to errors",
+			disk.format,
+			format,
+		)
+	}
+	data := copyMockDiskData(disk.data)
+	m.lock.Unlock()
+
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return downloadToFile(
+		m.logger,
+		diskID,
+		format,
+		path,
+		params,
+		&mockFileSource{
+			ctx:      ctx,
+			data:     data,
+			throttle: newTransferThrottle(m.transferLimiter, params.BandwidthLimit()),
+		},
+	)
+}
+
+// mockFileSource provides the image data of a mock disk for DownloadDiskToFile.
+type mockFileSource struct {
+	ctx      context.Context
+	data     []byte
+	throttle *transferThrottle
+}
+
+func (m *mockFileSource) downloadTo(target *downloadToFileTarget) error {
+	size := uint64(len(m.data))
+	if err := target.SetSize(size); err != nil {
+		return err
+	}
+	if target.Offset() > size {
+		if err := target.Reset(); err != nil {
+			return err
+		}
+	}
+	_, err := io.Copy(target, m.throttle.reader(m.ctx, bytes.NewReader(m.data[target.Offset():])))
+	return err
+}
+
+func (m *mockFileSource) checksum() (*imageChecksum, error) {
+	checksum := newBlockChecksum(defaultImageChecksumBlockSize)
+	_, _ = checksum.Write(m.data)
+	return &imageChecksum{
+		Algorithm: imageChecksumAlgorithm,
+		BlockSize: defaultImageChecksumBlockSize,
+		Checksum:  checksum.Checksum(),
+	}, nil
+}
+
+// downloadToFileSource is the source of the image data for DownloadDiskToFile.
+type downloadToFileSource interface {
+	// downloadTo writes the image from the current offset of the target to the end.
+	downloadTo(target *downloadToFileTarget) error
+	// checksum returns the checksum of the image reported by the image server, or nil if the server does not support
+	// checksums.
+	checksum() (*imageChecksum, error)
+}
+
+// downloadToFile downloads the image from source to a temporary file next to path, resuming a previous download if
+// the temporary file exists and belongs to the same image, verifies it and renames it to path.
+func downloadToFile(
+	logger Logger,
+	diskID DiskID,
+	format ImageFormat,
+	path string,
+	params DownloadToFileParameters,
+	source downloadToFileSource,
+) (DownloadToFileResult, error) {
+	partPath := path + ".part"
+	statePath := partPath + ".json"
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, wrap(err, ELocalIO, "failed to open %s", partPath)
+	}
+	closeFile := func() {
+		_ = file.Close()
+	}
+	resumedFrom, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		closeFile()
+		return nil, wrap(err, ELocalIO, "failed to seek to the end of %s", partPath)
+	}
+	state, err := loadDownloadToFileState(statePath)
+	if err != nil {
+		closeFile()
+		return nil, err
+	}
+	if resumedFrom > 0 && (state == nil || state.DiskID != diskID || state.Format != format) {
+		logger.Warningf(
+			"The partial download %s does not belong to the %s image of disk %s, restarting the download.",
+			partPath,
+			format,
+			diskID,
+		)
+		if err := file.Truncate(0); err != nil {
+			closeFile()
+			return nil, wrap(err, ELocalIO, "failed to truncate %s", partPath)
+		}
+		if resumedFrom, err = file.Seek(0, io.SeekStart); err != nil {
+			closeFile()
+			return nil, wrap(err, ELocalIO, "failed to seek to the start of %s", partPath)
+		}
+	}
+	if resumedFrom == 0 {
+		state = &downloadToFileState{DiskID: diskID, Format: format}
+	} else {
+		logger.Infof("Resuming download of disk %s from byte %d of %s...", diskID, resumedFrom, partPath)
+	}
+
+	target := &downloadToFileTarget{
+		file:        file,
+		statePath:   statePath,
+		state:       *state,
+		offset:      uint64(resumedFrom),
+		resumedFrom: uint64(resumedFrom),
+		progress:    params.ProgressCallback(),
+	}
+	if err := target.saveState(); err != nil {
+		closeFile()
+		return nil, err
+	}
+	// The temporary file is kept if the download fails so the download can be resumed later.
+	if err := source.downloadTo(target); err != nil {
+		closeFile()
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		closeFile()
+		return nil, wrap(err, ELocalIO, "failed to sync %s", partPath)
+	}
+
+	result, err := verifyDownloadedFile(logger, file, target.Offset(), params, source)
+	closeFile()
+	if err != nil {
+		if HasErrorCode(err, EChecksumMismatch) {
+			_ = os.Remove(partPath)
+			_ = os.Remove(statePath)
+		}
+		return nil, err
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to rename %s to %s", partPath, path)
+	}
+	_ = os.Remove(statePath)
+	result.path = path
+	result.resumedFrom = target.resumedFrom
+	logger.Infof("Downloaded disk %s to %s.", diskID, path)
+	return result, nil
+}
+
+// downloadToFileState is stored next to the temporary file of DownloadDiskToFile. A partial download is only resumed
+// if it belongs to the same image.
+type downloadToFileState struct {
+	DiskID DiskID      `json:"disk_id"`
+	Format ImageFormat `json:"format"`
+	// Size is the size of the image, or 0 if the image server has not reported it yet.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// loadDownloadToFileState reads the state of a partial download. It returns nil if there is no readable state.
+func loadDownloadToFileState(statePath string) (*downloadToFileState, error) {
+	data, err := ioutil.ReadFile(statePath) //nolint:gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, wrap(err, ELocalIO, "failed to read %s", statePath)
+	}
+	state := &downloadToFileState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		// A corrupt state is treated like a missing one, which discards the partial download.
+		return nil, nil //nolint:nilerr
+	}
+	return state, nil
+}
+
+// verifyDownloadedFile hashes the downloaded file and compares the result against the checksum of the image server
+// or the expected checksum in params.
+func verifyDownloadedFile(
+	logger Logger,
+	file *os.File,
+	size uint64,
+	params DownloadToFileParameters,
+	source downloadToFileSource,
+) (*downloadToFileResult, error) {
+	serverChecksum, err := source.checksum()
+	if err != nil {
+		return nil, err
+	}
+	blockSize := uint64(defaultImageChecksumBlockSize)
+	if serverChecksum != nil && serverChecksum.BlockSize != 0 {
+		blockSize = serverChecksum.BlockSize
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to seek to the start of %s", file.Name())
+	}
+	blockHash := newBlockChecksum(blockSize)
+	fileHash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(blockHash, fileHash), file); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to read %s", file.Name())
+	}
+	result := &downloadToFileResult{
+		size:   size,
+		sha256: hex.EncodeToString(fileHash.Sum(nil)),
+	}
+
+	switch {
+	case serverChecksum != nil:
+		if checksum := blockHash.Checksum(); checksum != strings.ToLower(serverChecksum.Checksum) {
+			return nil, newError(
+				EChecksumMismatch,
+				"the checksum of %s (%s) does not match the checksum reported by the image server (%s)",
+				file.Name(),
+				checksum,
+				serverChecksum.Checksum,
+			)
+		}
+	case params.ExpectedSHA256() != "":
+		if result.sha256 != params.ExpectedSHA256() {
+			return nil, newError(
+				EChecksumMismatch,
+				"the SHA-256 hash of %s (%s) does not match the expected hash (%s)",
+				file.Name(),
+				result.sha256,
+				params.ExpectedSHA256(),
+			)
+		}
+	default:
+		logger.Warningf(
+			"The image server does not support checksums and no expected checksum was given, %s is not verified.",
+			file.Name(),
+		)
+		return result, nil
+	}
+	result.verified = true
+	return result, nil
+}
+
+// downloadToFileTarget writes the downloaded image to the temporary file and reports the progress.
+type downloadToFileTarget struct {
+	file      *os.File
+	statePath string
+	state     downloadToFileState
+	offset    uint64
+	// resumedFrom is the offset the download was resumed from. It is reset to 0 if the download is restarted.
+	resumedFrom uint64
+	progress    DownloadProgressCallback
+}
+
+func (d *downloadToFileTarget) Write(p []byte) (int, error) {
+	n, err := d.file.Write(p)
+	d.offset += uint64(n)
+	if d.progress != nil {
+		d.progress(d.offset, d.state.Size)
+	}
+	if err != nil {
+		return n, wrap(err, ELocalIO, "failed to write to %s", d.file.Name())
+	}
+	return n, nil
+}
+
+// Offset returns the number of bytes already written to the file.
+func (d *downloadToFileTarget) Offset() uint64 {
+	return d.offset
+}
+
+// Size returns the size of the image, or 0 if it is not known yet.
+func (d *downloadToFileTarget) Size() uint64 {
+	return d.state.Size
+}
+
+// SetSize sets the size of the image once the image server reports it. If a resumed partial download was started for
+// an image of a different size, it is discarded and the offset is reset to 0.
+func (d *downloadToFileTarget) SetSize(size uint64) error {
+	if d.state.Size == size {
+		return nil
+	}
+	if d.state.Size != 0 && d.offset > 0 {
+		if err := d.Reset(); err != nil {
+			return err
+		}
+	}
+	d.state.Size = size
+	return d.saveState()
+}
+
+// Reset truncates the file to restart the download from the beginning.
+func (d *downloadToFileTarget) Reset() error {
+	if err := d.file.Truncate(0); err != nil {
+		return wrap(err, ELocalIO, "failed to truncate %s", d.file.Name())
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return wrap(err, ELocalIO, "failed to seek to the start of %s", d.file.Name())
+	}
+	d.offset = 0
+	d.resumedFrom = 0
+	return nil
+}
+
+// saveState writes the state of the download next to the temporary file.
+func (d *downloadToFileTarget) saveState() error {
+	data, err := json.Marshal(d.state)
+	if err != nil {
+		return wrap(err, EBug, "failed to encode the download state")
+	}
+	if err := ioutil.WriteFile(d.statePath, data, 0o600); err != nil {
+		return wrap(err, ELocalIO, "failed to write %s", d.statePath)
+	}
+	return nil
+}
+
+type downloadToFileResult struct {
+	path        string
+	size        uint64
+	resumedFrom uint64
+	sha256      string
+	verified    bool
+}
+
+func (d *downloadToFileResult) Path() string {
+	return d.path
+}
+
+func (d *downloadToFileResult) Size() uint64 {
+	return d.size
+}
+
+func (d *downloadToFileResult) ResumedFrom() uint64 {
+	return d.resumedFrom
+}
+
+func (d *downloadToFileResult) SHA256() string {
+	return d.sha256
+}
+
+func (d *downloadToFileResult) Verified() bool {
+	return d.verified
+}
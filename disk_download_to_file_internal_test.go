@@ -0,0 +1,107 @@
+// This file contains tests for resuming partial downloads, which requires writing the internal download state. It is
+// therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadToFileResumesMatchingPartialDownload(t *testing.T) {
+	t.Parallel()
+
+	image := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	writeTestPartialDownload(t, path, image[:len(image)/3], downloadToFileState{DiskID: "disk-1", Format: ImageFormatRaw})
+
+	result := assertDownloadToFile(t, "disk-1", path, image)
+	if result.ResumedFrom() != uint64(len(image)/3) {
+		t.Fatalf("The download was resumed from byte %d instead of %d.", result.ResumedFrom(), len(image)/3)
+	}
+}
+
+func TestDownloadToFileDiscardsPartialDownloadOfOtherImage(t *testing.T) {
+	t.Parallel()
+
+	image := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	for name, state := range map[string]downloadToFileState{
+		"disk":   {DiskID: "disk-2", Format: ImageFormatRaw},
+		"format": {DiskID: "disk-1", Format: ImageFormatCow},
+		"size":   {DiskID: "disk-1", Format: ImageFormatRaw, Size: uint64(len(image)) * 2},
+	} {
+		path := filepath.Join(t.TempDir(), "disk.raw")
+		writeTestPartialDownload(t, path, bytes.Repeat([]byte{0xff}, len(image)/3), state)
+
+		if result := assertDownloadToFile(t, "disk-1", path, image); result.ResumedFrom() != 0 {
+			t.Fatalf("A partial download with a different %s was resumed from byte %d.", name, result.ResumedFrom())
+		}
+	}
+}
+
+func TestDownloadToFileChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	image := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	writeTestPartialDownload(t, path, bytes.Repeat([]byte{0xff}, 4096), downloadToFileState{
+		DiskID: "disk-1",
+		Format: ImageFormatRaw,
+	})
+
+	_, err := downloadToFile(&noopLogger{}, "disk-1", ImageFormatRaw, path, DownloadToFileParams(), &mockFileSource{
+		ctx:      context.Background(),
+		data:     image,
+		throttle: newTransferThrottle(nil, 0),
+	})
+	if err == nil || !HasErrorCode(err, EChecksumMismatch) {
+		t.Fatalf("Resuming a download from a corrupted partial file did not return an EChecksumMismatch error (%v).", err)
+	}
+	for _, leftover := range []string{path + ".part", path + ".part.json", path} {
+		if _, err := os.Stat(leftover); err == nil {
+			t.Fatalf("%s exists after a failed verification.", leftover)
+		}
+	}
+
+	assertDownloadToFile(t, "disk-1", path, image)
+}
+
+func writeTestPartialDownload(t *testing.T, path string, data []byte, state downloadToFileState) {
+	if err := ioutil.WriteFile(path+".part", data, 0o600); err != nil {
+		t.Fatalf("Failed to write partial download (%v)", err)
+	}
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to encode download state (%v)", err)
+	}
+	if err := ioutil.WriteFile(path+".part.json", encoded, 0o600); err != nil {
+		t.Fatalf("Failed to write download state (%v)", err)
+	}
+}
+
+func assertDownloadToFile(t *testing.T, diskID DiskID, path string, image []byte) DownloadToFileResult {
+	result, err := downloadToFile(&noopLogger{}, diskID, ImageFormatRaw, path, DownloadToFileParams(), &mockFileSource{
+		ctx:      context.Background(),
+		data:     image,
+		throttle: newTransferThrottle(nil, 0),
+	})
+	if err != nil {
+		t.Fatalf("Failed to download image to %s (%v)", path, err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read downloaded file %s (%v)", path, err)
+	}
+	if !bytes.Equal(data, image) {
+		t.Fatalf("The downloaded file %s does not match the image.", path)
+	}
+	if _, err := os.Stat(path + ".part.json"); err == nil {
+		t.Fatalf("The state of the partial download still exists after the download.")
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestDownloadDiskToFile(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	image := downloadToFileTestImage()
+	assertCanUploadBackupTestImage(t, helper, disk, image)
+
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	lastProgress := uint64(0)
+	result, err := client.DownloadDiskToFile(
+		disk.ID(),
+		ovirtclient.ImageFormatRaw,
+		path,
+		ovirtclient.DownloadToFileParams().MustWithProgressCallback(
+			func(bytesDownloaded uint64, totalBytes uint64) {
+				lastProgress = bytesDownloaded
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s to %s (%v)", disk.ID(), path, err)
+	}
+	if result.ResumedFrom() != 0 {
+		t.Fatalf("A new download was resumed from byte %d.", result.ResumedFrom())
+	}
+	if lastProgress != result.Size() {
+		t.Fatalf("The last progress report (%d) does not match the download size (%d).", lastProgress, result.Size())
+	}
+	assertDownloadedFileMatches(t, path, result, image)
+}
+
+func TestDownloadDiskToFileDiscardsUnknownPartialDownload(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	disk := assertCanCreateDisk(t, helper)
+	image := downloadToFileTestImage()
+	assertCanUploadBackupTestImage(t, helper, disk, image)
+
+	// Without the state written next to it by an interrupted download, the partial file may belong to a different
+	// image, so it must not be resumed.
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	if err := ioutil.WriteFile(path+".part", bytes.Repeat([]byte{0xff}, 4096), 0o600); err != nil {
+		t.Fatalf("Failed to write partial download (%v)", err)
+	}
+
+	result, err := client.DownloadDiskToFile(disk.ID(), ovirtclient.ImageFormatRaw, path, nil)
+	if err != nil {
+		t.Fatalf("Failed to download disk %s to %s (%v)", disk.ID(), path, err)
+	}
+	if result.ResumedFrom() != 0 {
+		t.Fatalf("An unknown partial download was resumed from byte %d.", result.ResumedFrom())
+	}
+	assertDownloadedFileMatches(t, path, result, image)
+}
+
+func downloadToFileTestImage() []byte {
+	image := make([]byte, 1024*1024)
+	for i := range image {
+		image[i] = byte(i % 251)
+	}
+	return image
+}
+
+func assertDownloadedFileMatches(
+	t *testing.T,
+	path string,
+	result ovirtclient.DownloadToFileResult,
+	image []byte,
+) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read downloaded file %s (%v)", path, err)
+	}
+	// For raw images the disk may be larger than the uploaded image.
+	if len(data) < len(image) || !bytes.Equal(data[:len(image)], image) {
+		t.Fatalf("The downloaded file %s does not match the uploaded image.", path)
+	}
+	if result.Path() != path {
+		t.Fatalf("Incorrect path in the download result (%s instead of %s).", result.Path(), path)
+	}
+	if result.Size() != uint64(len(data)) {
+		t.Fatalf("Incorrect size in the download result (%d instead of %d).", result.Size(), len(data))
+	}
+	hash := sha256.Sum256(data)
+	if result.SHA256() != hex.EncodeToString(hash[:]) {
+		t.Fatalf("Incorrect SHA-256 hash in the download result.")
+	}
+	if _, err := ioutil.ReadFile(path + ".part"); err == nil {
+		t.Fatalf("The partial file still exists after the download.")
+	}
+	if _, err := ioutil.ReadFile(path + ".part.json"); err == nil {
+		t.Fatalf("The state of the partial download still exists after the download.")
+	}
+}
@@ -3,8 +3,10 @@ package ovirtclient
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"sync"
@@ -13,6 +15,10 @@ import (
 	ovirtsdk4 "github.com/ovirt/go-ovirt"
 )
 
+// downloadChunkSize is the size of a single ranged request when only the data extents of a disk or a backup are
+// downloaded.
+const downloadChunkSize uint64 = 64 * 1024 * 1024
+
 // Deprecated: use StartDownloadDisk instead.
 func (o *oVirtClient) StartImageDownload(diskID DiskID, format ImageFormat, retries ...RetryStrategy) (ImageDownload, error) {
 	o.logger.Warningf("Using StartImageDownload is deprecated, please use StartDownloadDisk instead.")
@@ -48,6 +54,7 @@ func (o *oVirtClient) StartDownloadDisk(diskID DiskID, format ImageFormat, retri
 		logger:     o.logger,
 		retries:    retries,
 		format:     format,
+		throttle:   newTransferThrottle(o.transferLimiter, 0),
 	}
 	go dl.poll()
 	return dl, nil
@@ -91,15 +98,17 @@ type imageDownload struct {
 	conn      *ovirtsdk4.Connection
 	done      chan struct{}
 
-	reader     io.ReadCloser
-	httpClient http.Client
-	createReq  *ovirtsdk4.ImageTransfersServiceAddRequest
-	transfer   imageTransfer
-	cli        *oVirtClient
-	logger     Logger
-	retries    []RetryStrategy
-	format     ImageFormat
-	disk       Disk
+	reader      io.ReadCloser
+	transferURL string
+	httpClient  http.Client
+	createReq   *ovirtsdk4.ImageTransfersServiceAddRequest
+	transfer    imageTransfer
+	cli         *oVirtClient
+	logger      Logger
+	retries     []RetryStrategy
+	format      ImageFormat
+	disk        Disk
+	throttle    *transferThrottle
 }
 
 // poll polls the oVirt API for the status of the transfer and initializes the HTTP request to
@@ -131,6 +140,7 @@ func (i *imageDownload) poll() {
 		return
 	}
 	i.reader = httpResponse.Body
+	i.transferURL = transferURL
 }
 
 // updateDisk is a helper function that updates the internally-stored disk object when the transfer updates it.
@@ -145,6 +155,7 @@ func (i *imageDownload) transferImage(transferURL string) (httpResponse *http.Re
 	return httpResponse, retry(
 		fmt.Sprintf("transferring image from %s", transferURL),
 		i.logger,
+		i.cli.transferHooks("DownloadImage"),
 		i.retries,
 		func() error {
 			response, err := i.attemptTransferImage(transferURL) //nolint:bodyclose
@@ -240,7 +251,7 @@ func (i *imageDownload) Read(p []byte) (n int, err error) {
 	if i.lastError != nil {
 		return 0, i.lastError
 	}
-	n, err = i.reader.Read(p)
+	n, err = i.throttle.reader(i.ctx, i.reader).Read(p)
 	i.lock.Lock()
 	defer i.lock.Unlock()
 	i.bytesRead += uint64(n)
@@ -254,6 +265,243 @@ func (i *imageDownload) Read(p []byte) (n int, err error) {
 	return n, err
 }
 
+// WriteTo implements io.WriterTo, so io.Copy uses it to write the image to w. If w is seekable, ends at its current
+// position, and ImageIO supports the extents API, only the data regions of the image are downloaded and zero regions
+// are skipped by seeking, which keeps a sparse destination file sparse. Otherwise, for example if w is a pipe or a
+// reused file with data after the current position, the image is copied as it is read.
+func (i *imageDownload) WriteTo(w io.Writer) (int64, error) {
+	<-i.done
+	if i.lastError != nil {
+		return 0, i.lastError
+	}
+	i.lock.Lock()
+	sparse := i.bytesRead == 0 && i.transfer.supports("extents")
+	i.lock.Unlock()
+	if sparse {
+		if seeker, base, ok := sparseDestination(w); ok {
+			extents, err := i.getExtents()
+			if err == nil {
+				return i.writeSparse(seeker, base, extents)
+			}
+			i.logger.Warningf("Failed to fetch image extents, downloading the full image instead. (%v)", err)
+		}
+	}
+	// Wrap the download so io.Copy does not call WriteTo again.
+	return io.Copy(w, struct{ io.Reader }{i})
+}
+
+// getExtents fetches the list of data and zero extents of the image from ImageIO.
+func (i *imageDownload) getExtents() ([]imageExtent, error) {
+	return getImageExtents(i.ctx, i.cli, i.transfer, i.transferURL, "zero", i.retries)
+}
+
+// getImageExtents fetches the extents of an image from the ImageIO extents API. The extentsContext selects the kind
+// of extents returned: "zero" returns data and zero extents, "dirty" returns the extents changed since the checkpoint
+// of an incremental backup.
+func getImageExtents(
+	ctx context.Context,
+	cli *oVirtClient,
+	transfer imageTransfer,
+	transferURL string,
+	extentsContext string,
+	retries []RetryStrategy,
+) (extents []imageExtent, err error) {
+	extentsURL := fmt.Sprintf("%s/extents?context=%s", transferURL, extentsContext)
+	return extents, retry(
+		fmt.Sprintf("fetching image extents from %s", extentsURL),
+		cli.logger,
+		cli.transferHooks("GetImageExtents"),
+		retries,
+		func() error {
+			extents = nil
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, extentsURL, nil)
+			if err != nil {
+				return wrap(err, EBug, "failed to create HTTP request to %s", extentsURL)
+			}
+			response, err := cli.httpClient.Do(req)
+			if err != nil {
+				return wrap(err, EConnection, "HTTP request to %s failed", extentsURL)
+			}
+			defer func() {
+				_ = response.Body.Close()
+			}()
+			if err := transfer.checkStatusCode(response.StatusCode); err != nil {
+				return err
+			}
+			if err := json.NewDecoder(response.Body).Decode(&extents); err != nil {
+				return wrap(err, EBug, "failed to decode image extents from %s", extentsURL)
+			}
+			return nil
+		},
+	)
+}
+
+// sparseDestination returns w as an io.WriteSeeker and its current position if zero extents can be skipped when
+// writing to it. This requires w to be seekable and to have no data after the current position, as the skipped
+// regions would otherwise keep their previous content. The position of w is left unchanged.
+func sparseDestination(w io.Writer) (io.WriteSeeker, int64, bool) {
+	seeker, ok := w.(io.WriteSeeker)
+	if !ok {
+		return nil, 0, false
+	}
+	// Pipes and sockets implement io.Seeker via *os.File, but fail when seeking.
+	base, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, 0, false
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, 0, false
+	}
+	if _, err := seeker.Seek(base, io.SeekStart); err != nil {
+		return nil, 0, false
+	}
+	return seeker, base, end == base
+}
+
+// writeSparse downloads the data extents of the image in ranged requests and writes them to w at the offset base,
+// skipping zero extents. Each range is retried on its own.
+func (i *imageDownload) writeSparse(w io.WriteSeeker, base int64, extents []imageExtent) (int64, error) {
+	// The full download started during initialization is not needed.
+	i.lock.Lock()
+	if i.reader != nil {
+		_ = i.reader.Close()
+		i.reader = ioutil.NopCloser(bytes.NewReader(nil))
+	}
+	i.lock.Unlock()
+
+	retries := i.throttle.retries(append(i.retries, ContextStrategy(i.ctx)))
+	for _, extent := range extents {
+		if extent.Zero {
+			i.addBytesRead(int64(extent.Length))
+			continue
+		}
+		for offset := extent.Start; offset < extent.Start+extent.Length; offset += downloadChunkSize {
+			length := downloadChunkSize
+			if offset+length > extent.Start+extent.Length {
+				length = extent.Start + extent.Length - offset
+			}
+			if err := retry(
+				fmt.Sprintf("downloading bytes %d-%d from %s", offset, offset+length-1, i.transferURL),
+				i.logger,
+				i.cli.transferHooks("DownloadImageChunk"),
+				retries,
+				func() error {
+					return i.downloadRange(w, base, offset, length)
+				},
+			); err != nil {
+				return int64(i.BytesRead()), err
+			}
+		}
+	}
+	if err := extendTo(w, base+int64(i.size)); err != nil {
+		return int64(i.BytesRead()), err
+	}
+
+	if i.BytesRead() == i.size {
+		go func() {
+			_ = i.Close()
+		}()
+	}
+	return int64(i.BytesRead()), nil
+}
+
+// downloadRange downloads a single range of the image and writes it to w at the same offset relative to base. The
+// bytes written by a failed attempt are subtracted from the progress.
+func (i *imageDownload) downloadRange(w io.WriteSeeker, base int64, offset uint64, length uint64) (err error) {
+	written := int64(0)
+	defer func() {
+		if err != nil {
+			i.addBytesRead(-written)
+		}
+	}()
+	response, err := getImageRange(i.ctx, i.cli, i.transfer, i.transferURL, offset, length)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if _, err := w.Seek(base+int64(offset), io.SeekStart); err != nil {
+		return wrap(err, ELocalIO, "failed to seek to byte %d of the download destination", base+int64(offset))
+	}
+	body := i.throttle.reader(i.ctx, response.Body)
+	for written < int64(length) {
+		n, err := io.CopyN(w, body, int64(length)-written)
+		written += n
+		i.addBytesRead(n)
+		if err != nil {
+			return wrap(err, EConnection, "failed to download bytes %d-%d", offset, offset+length-1)
+		}
+	}
+	return nil
+}
+
+// getImageRange sends a ranged GET request for a part of the image to ImageIO. The caller must close the body of the
+// returned response.
+func getImageRange(
+	ctx context.Context,
+	cli *oVirtClient,
+	transfer imageTransfer,
+	transferURL string,
+	offset uint64,
+	length uint64,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, transferURL, nil)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to create HTTP request to %s", transferURL)
+	}
+	req.Header.Add("range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	response, err := cli.httpClient.Do(req)
+	if err != nil {
+		return nil, wrap(err, EConnection, "HTTP request to image transfer URL %s failed", transferURL)
+	}
+	if err := transfer.checkStatusCode(response.StatusCode); err != nil {
+		_ = response.Body.Close()
+		return nil, err
+	}
+	return response, nil
+}
+
+// addBytesRead adjusts the number of bytes read for the download progress.
+func (i *imageDownload) addBytesRead(n int64) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.bytesRead = uint64(int64(i.bytesRead) + n)
+}
+
+// extendTo makes sure the destination is at least size bytes long, as skipping a zero region at the end of the image
+// does not extend it. Files are extended by truncating them, other destinations by writing the last byte.
+func extendTo(w io.WriteSeeker, size int64) error {
+	end, err := w.Seek(0, io.SeekEnd)
+	if err != nil {
+		return wrap(err, ELocalIO, "failed to seek to the end of the download destination")
+	}
+	if end >= size {
+		_, err = w.Seek(size, io.SeekStart)
+		if err != nil {
+			return wrap(err, ELocalIO, "failed to seek to the end of the download")
+		}
+		return nil
+	}
+	if truncater, ok := w.(interface{ Truncate(size int64) error }); ok {
+		if err := truncater.Truncate(size); err != nil {
+			return wrap(err, ELocalIO, "failed to extend the download destination to %d bytes", size)
+		}
+		if _, err := w.Seek(size, io.SeekStart); err != nil {
+			return wrap(err, ELocalIO, "failed to seek to the end of the download")
+		}
+		return nil
+	}
+	if _, err := w.Seek(size-1, io.SeekStart); err != nil {
+		return wrap(err, ELocalIO, "failed to seek to the end of the download")
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		return wrap(err, ELocalIO, "failed to extend the download destination to %d bytes", size)
+	}
+	return nil
+}
+
 // Close is an implementation of the required function in io.ReadCloser and is responsible for
 // closing the HTTP reader body and finalizing the image download. This is important so the disk
 // does not stay locked.
@@ -274,6 +522,8 @@ func (i *imageDownload) Close() error {
 
 // BytesRead returns the number of bytes already read from the download reader.
 func (i *imageDownload) BytesRead() uint64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	return i.bytesRead
 }
 
@@ -283,6 +533,14 @@ func (i *imageDownload) Size() uint64 {
 	return i.size
 }
 
+func (i *imageDownload) BandwidthLimit() uint64 {
+	return i.throttle.Limit()
+}
+
+func (i *imageDownload) SetBandwidthLimit(bytesPerSecond uint64) {
+	i.throttle.SetLimit(bytesPerSecond)
+}
+
 // Deprecated: use StartDownloadDisk instead.
 func (m *mockClient) StartImageDownload(diskID DiskID, format ImageFormat, retries ...RetryStrategy) (
 	ImageDownload,
@@ -312,6 +570,11 @@ func (m *mockClient) StartDownloadDisk(diskID DiskID, format ImageFormat, _ ...R
 		lastError: nil,
 		lock:      &sync.Mutex{},
 		reader:    bytes.NewReader(disk.data),
+		ctx:       m.ctx,
+		throttle:  newTransferThrottle(m.transferLimiter, 0),
+	}
+	if dl.ctx == nil {
+		dl.ctx = context.Background()
 	}
 	go dl.prepare()
 
@@ -349,6 +612,8 @@ type mockImageDownload struct {
 	lastError error
 	lock      *sync.Mutex
 	reader    io.Reader
+	ctx       context.Context
+	throttle  *transferThrottle
 }
 
 func (m *mockImageDownload) Err() error {
@@ -365,7 +630,7 @@ func (m *mockImageDownload) Read(p []byte) (n int, err error) {
 		return 0, m.lastError
 	}
 
-	n, err = m.reader.Read(p)
+	n, err = m.throttle.reader(m.ctx, m.reader).Read(p)
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -402,6 +667,14 @@ func (m *mockImageDownload) Size() uint64 {
 	return m.size
 }
 
+func (m *mockImageDownload) BandwidthLimit() uint64 {
+	return m.throttle.Limit()
+}
+
+func (m *mockImageDownload) SetBandwidthLimit(bytesPerSecond uint64) {
+	m.throttle.SetLimit(bytesPerSecond)
+}
+
 func (m *mockImageDownload) prepare() {
 	// Sleep one second to trigger possible race condition with determining size.
 	time.Sleep(time.Second)
@@ -0,0 +1,207 @@
+// This file contains tests for the internal sparse download handling. It is therefore excluded from the testpackage
+// check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// testImageTransfer is an image transfer that only records finalization, without calling the engine.
+type testImageTransfer struct {
+	imageTransferImpl
+
+	finalized chan struct{}
+}
+
+func (t *testImageTransfer) finalize(err error) error {
+	close(t.finalized)
+	return err
+}
+
+func TestImageDownloadWriteToSkipsZeroExtents(t *testing.T) {
+	t.Parallel()
+
+	image := testSparseImage()
+	download, ranges := newTestImageDownload(t, image)
+	fh := createTestDownloadFile(t, nil)
+
+	n, err := io.Copy(fh, download)
+	if err != nil {
+		t.Fatalf("Failed to download image (%v)", err)
+	}
+	if n != int64(len(image)) {
+		t.Fatalf("Incorrect number of bytes copied: %d", n)
+	}
+	if requested := ranges(); len(requested) != 2 || requested[0] != "bytes=0-9" || requested[1] != "bytes=30-39" {
+		t.Fatalf("Incorrect ranges requested: %v", requested)
+	}
+	assertDownloadedFile(t, fh, image)
+	<-download.transfer.(*testImageTransfer).finalized
+}
+
+func TestImageDownloadWriteToStreamsIntoReusedFile(t *testing.T) {
+	t.Parallel()
+
+	image := testSparseImage()
+	download, ranges := newTestImageDownload(t, image)
+	// The stale content must be overwritten by the zero extents.
+	fh := createTestDownloadFile(t, bytes.Repeat([]byte("x"), len(image)))
+
+	if _, err := io.Copy(fh, download); err != nil {
+		t.Fatalf("Failed to download image (%v)", err)
+	}
+	if requested := ranges(); len(requested) != 0 {
+		t.Fatalf("Ranges requested for a file with stale content: %v", requested)
+	}
+	assertDownloadedFile(t, fh, image)
+}
+
+func TestImageDownloadWriteToStreamsIntoPipe(t *testing.T) {
+	t.Parallel()
+
+	image := testSparseImage()
+	download, ranges := newTestImageDownload(t, image)
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe (%v)", err)
+	}
+	defer func() {
+		_ = pipeReader.Close()
+	}()
+	result := make(chan []byte)
+	go func() {
+		data, _ := ioutil.ReadAll(pipeReader)
+		result <- data
+	}()
+
+	_, err = io.Copy(pipeWriter, download)
+	_ = pipeWriter.Close()
+	if err != nil {
+		t.Fatalf("Failed to download image into a pipe (%v)", err)
+	}
+	if data := <-result; !bytes.Equal(data, image) {
+		t.Fatalf("Incorrect data downloaded: %v", data)
+	}
+	if requested := ranges(); len(requested) != 0 {
+		t.Fatalf("Ranges requested for a pipe: %v", requested)
+	}
+}
+
+// testSparseImage returns an image with two data and two zero extents, matching the extents newTestImageDownload
+// reports.
+func testSparseImage() []byte {
+	image := append(append([]byte("0123456789"), make([]byte, 20)...), []byte("abcdefghij")...)
+	return append(image, make([]byte, 10)...)
+}
+
+// newTestImageDownload creates an image download from a test ImageIO server serving the specified image. The full
+// image is available as the initial stream. The returned function returns the ranges requested so far.
+func newTestImageDownload(t *testing.T, image []byte) (*imageDownload, func() []string) {
+	lock := &sync.Mutex{}
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.URL.Path == "/extents" {
+			_, _ = fmt.Fprint(
+				w,
+				`[{"start":0,"length":10,"zero":false,"hole":false},`+
+					`{"start":10,"length":20,"zero":true,"hole":true},`+
+					`{"start":30,"length":10,"zero":false,"hole":false},`+
+					`{"start":40,"length":10,"zero":true,"hole":true}]`,
+			)
+			return
+		}
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("range"), "bytes=%d-%d", &start, &end); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ranges = append(ranges, r.Header.Get("range"))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(image[start : end+1])
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan struct{})
+	close(done)
+	download := &imageDownload{
+		lock:        &sync.Mutex{},
+		size:        uint64(len(image)),
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        done,
+		reader:      ioutil.NopCloser(bytes.NewReader(image)),
+		transferURL: server.URL,
+		httpClient:  *server.Client(),
+		transfer: &testImageTransfer{
+			imageTransferImpl: imageTransferImpl{
+				direction: ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+				features:  []string{"extents"},
+			},
+			finalized: make(chan struct{}),
+		},
+		cli: &oVirtClient{
+			httpClient: *server.Client(),
+			logger:     &noopLogger{},
+		},
+		logger:   &noopLogger{},
+		retries:  []RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(3)},
+		throttle: newTransferThrottle(nil, 0),
+	}
+	return download, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), ranges...)
+	}
+}
+
+// createTestDownloadFile creates a temporary file with the specified content, positioned at the start of the file.
+func createTestDownloadFile(t *testing.T, content []byte) *os.File {
+	dir, err := ioutil.TempDir("", "ovirtclient-download-")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory (%v)", err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	fh, err := os.Create(filepath.Join(dir, "disk.img"))
+	if err != nil {
+		t.Fatalf("Failed to create download file (%v)", err)
+	}
+	t.Cleanup(func() {
+		_ = fh.Close()
+	})
+	if _, err := fh.Write(content); err != nil {
+		t.Fatalf("Failed to write download file (%v)", err)
+	}
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Failed to seek in download file (%v)", err)
+	}
+	return fh
+}
+
+func assertDownloadedFile(t *testing.T, fh *os.File, image []byte) {
+	data, err := ioutil.ReadFile(fh.Name())
+	if err != nil {
+		t.Fatalf("Failed to read downloaded file (%v)", err)
+	}
+	if !bytes.Equal(data, image) {
+		t.Fatalf("Incorrect data downloaded: %v", data)
+	}
+}
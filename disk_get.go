@@ -11,6 +11,7 @@ func (o *oVirtClient) GetDisk(id DiskID, retries ...RetryStrategy) (result Disk,
 	err = retry(
 		fmt.Sprintf("getting disk %s", id),
 		o.logger,
+		o.requestHooks("GetDisk"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().DisksService().DiskService(string(id)).Get().Send()
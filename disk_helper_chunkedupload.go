@@ -0,0 +1,362 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// newChunkedUpload creates a helper that uploads an image to an ImageIO transfer URL in ranged PUT requests over
+// multiple parallel connections. It must be passed the following parameters:
+//
+//   - cli is the calling client library.
+//   - transferURL is the ImageIO URL the image is uploaded to.
+//   - reader is the source of the image data. The reader is only accessed by one goroutine at a time.
+//   - size is the total size of the image in bytes.
+//   - startOffset is the offset the upload starts at. Bytes before this offset are assumed to be already uploaded.
+//   - chunkSize is the maximum size of a single PUT request.
+//   - parallelism is the number of chunks uploaded at the same time.
+//   - zeroSupported indicates that ImageIO supports zero requests. If true, holes in sparse files and chunks that
+//     only contain zeroes are sent as zero requests instead of transferring the data.
+//   - retries is a list of retry strategies used for each chunk.
+//   - throttle limits the bandwidth of the upload. The timeouts in retries are extended by the time spent throttled.
+//   - checkStatusCode verifies the status code of each ImageIO response.
+func newChunkedUpload(
+	cli *oVirtClient,
+	transferURL string,
+	reader io.ReadSeeker,
+	size uint64,
+	startOffset uint64,
+	chunkSize uint64,
+	parallelism uint,
+	zeroSupported bool,
+	retries []RetryStrategy,
+	throttle *transferThrottle,
+	checkStatusCode func(statusCode int) error,
+) *chunkedUpload {
+	var holes []imageExtent
+	if zeroSupported {
+		holes = fileHoles(reader, startOffset, size)
+	}
+	var segments []uploadSegment
+	for offset := startOffset; offset < size; offset += chunkSize {
+		length := chunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+		zero := inHole(holes, offset, length)
+		if last := len(segments) - 1; zero && last >= 0 && segments[last].zero {
+			// Holes are sent as a single zero request regardless of the chunk size.
+			segments[last].length += length
+			continue
+		}
+		segments = append(segments, uploadSegment{offset: offset, length: length, zero: zero})
+	}
+	return &chunkedUpload{
+		cli:             cli,
+		transferURL:     transferURL,
+		reader:          reader,
+		readerLock:      &sync.Mutex{},
+		size:            size,
+		startOffset:     startOffset,
+		chunkSize:       chunkSize,
+		parallelism:     parallelism,
+		retries:         throttle.retries(retries),
+		throttle:        throttle,
+		zeroSupported:   zeroSupported,
+		checkStatusCode: checkStatusCode,
+		segments:        segments,
+		lock:            &sync.Mutex{},
+		completed:       make([]bool, len(segments)),
+		uploadedBytes:   startOffset,
+		confirmedOffset: startOffset,
+	}
+}
+
+// uploadSegment is a part of the image that is sent in a single request.
+type uploadSegment struct {
+	offset uint64
+	length uint64
+	// zero indicates that the segment is a known hole and is sent as a zero request without reading it.
+	zero bool
+}
+
+// chunkedUpload uploads an image in ranged PUT requests. Each chunk is retried on its own, so a failure halfway
+// through a large image only repeats the affected chunk.
+type chunkedUpload struct {
+	cli             *oVirtClient
+	transferURL     string
+	reader          io.ReadSeeker
+	readerLock      *sync.Mutex
+	size            uint64
+	startOffset     uint64
+	chunkSize       uint64
+	parallelism     uint
+	zeroSupported   bool
+	retries         []RetryStrategy
+	throttle        *transferThrottle
+	checkStatusCode func(statusCode int) error
+	segments        []uploadSegment
+
+	// lock protects the fields below.
+	lock *sync.Mutex
+	// completed records which segments have been confirmed by ImageIO.
+	completed []bool
+	// uploadedBytes contains the confirmed bytes and the bytes sent by the requests currently in flight.
+	uploadedBytes uint64
+	// confirmedOffset is the offset before which every byte has been confirmed by ImageIO.
+	confirmedOffset uint64
+}
+
+// UploadedBytes returns the number of bytes uploaded so far, including the bytes of requests in flight.
+func (c *chunkedUpload) UploadedBytes() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.uploadedBytes
+}
+
+// ConfirmedOffset returns the offset before which every byte has been confirmed by ImageIO. An interrupted upload
+// can be resumed from this offset.
+func (c *chunkedUpload) ConfirmedOffset() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.confirmedOffset
+}
+
+// run uploads all chunks and flushes the written data. It returns the first error that happened, in which case
+// the remaining chunks are not uploaded.
+func (c *chunkedUpload) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan int)
+	errs := make(chan error, c.parallelism)
+	wg := &sync.WaitGroup{}
+	for i := uint(0); i < c.parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.worker(ctx, chunks); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+
+	go func() {
+		defer close(chunks)
+		for chunk := range c.segments {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return wrap(err, ETimeout, "image upload canceled")
+	}
+	return retry(
+		fmt.Sprintf("flushing image upload to %s", c.transferURL),
+		c.cli.logger,
+		c.cli.transferHooks("FlushImageUpload"),
+		c.retries,
+		func() error {
+			return c.flushRequest(ctx)
+		},
+	)
+}
+
+// worker uploads chunks received from the channel until the channel is closed or an error happens.
+func (c *chunkedUpload) worker(ctx context.Context, chunks <-chan int) error {
+	var buf []byte
+	for chunk := range chunks {
+		segment := c.segments[chunk]
+		offset := segment.offset
+		length := segment.length
+		zero := segment.zero
+		var data []byte
+		if !zero {
+			if buf == nil {
+				buf = make([]byte, c.chunkSize)
+			}
+			data = buf[:length]
+			if err := c.readChunk(offset, data); err != nil {
+				return err
+			}
+			zero = c.zeroSupported && isZero(data)
+		}
+		var err error
+		if zero {
+			err = retry(
+				fmt.Sprintf("zeroing bytes %d-%d on %s", offset, offset+length-1, c.transferURL),
+				c.cli.logger,
+				c.cli.transferHooks("ZeroImageChunk"),
+				append(c.retries, ContextStrategy(ctx)),
+				func() error {
+					return c.zeroRequest(ctx, offset, length)
+				},
+			)
+			if err == nil {
+				c.addUploadedBytes(int64(length))
+			}
+		} else {
+			err = retry(
+				fmt.Sprintf("uploading bytes %d-%d to %s", offset, offset+length-1, c.transferURL),
+				c.cli.logger,
+				c.cli.transferHooks("UploadImageChunk"),
+				append(c.retries, ContextStrategy(ctx)),
+				func() error {
+					return c.putRequest(ctx, offset, data)
+				},
+			)
+		}
+		if err != nil {
+			return err
+		}
+		c.markCompleted(chunk)
+	}
+	return nil
+}
+
+// readChunk reads the chunk at the specified offset into data. The reader is shared between the workers, so the seek
+// and read happen under a lock.
+func (c *chunkedUpload) readChunk(offset uint64, data []byte) error {
+	c.readerLock.Lock()
+	defer c.readerLock.Unlock()
+	if _, err := c.reader.Seek(int64(offset), io.SeekStart); err != nil {
+		return wrap(err, ELocalIO, "could not seek to byte %d of the disk image", offset)
+	}
+	if _, err := io.ReadFull(c.reader, data); err != nil {
+		return wrap(
+			err,
+			ELocalIO,
+			"could not read %d bytes at offset %d of the disk image (is the specified size correct?)",
+			len(data),
+			offset,
+		)
+	}
+	return nil
+}
+
+// putRequest uploads a single chunk. This can be called multiple times to retry the chunk. The bytes sent by a failed
+// attempt are subtracted from the progress, so the progress does not count retried bytes twice.
+func (c *chunkedUpload) putRequest(ctx context.Context, offset uint64, data []byte) (err error) {
+	body := &chunkProgressReader{
+		reader: c.throttle.reader(ctx, bytes.NewReader(data)),
+		upload: c,
+	}
+	defer func() {
+		if err != nil {
+			c.addUploadedBytes(-int64(body.read))
+		}
+	}()
+
+	putRequest, err := http.NewRequestWithContext(ctx, http.MethodPut, c.transferURL+"?flush=n", body)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to create HTTP request")
+	}
+	putRequest.Header.Add("content-type", "application/octet-stream")
+	putRequest.Header.Add("content-range", fmt.Sprintf("bytes %d-%d/*", offset, offset+uint64(len(data))-1))
+	putRequest.ContentLength = int64(len(data))
+	response, err := c.cli.httpClient.Do(putRequest)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to upload bytes %d-%d", offset, offset+uint64(len(data))-1)
+	}
+	if err := c.checkStatusCode(response.StatusCode); err != nil {
+		_ = response.Body.Close()
+		return err
+	}
+	if err := response.Body.Close(); err != nil {
+		return wrap(err, EUnidentified, "failed to close response body while uploading image")
+	}
+	return nil
+}
+
+// zeroRequest asks ImageIO to zero a region of the image instead of sending the zeroes.
+func (c *chunkedUpload) zeroRequest(ctx context.Context, offset uint64, length uint64) error {
+	return c.patchRequest(
+		ctx,
+		fmt.Sprintf(`{"op":"zero","offset":%d,"size":%d,"flush":false}`, offset, length),
+		"zero bytes %d-%d",
+		offset,
+		offset+length-1,
+	)
+}
+
+// flushRequest asks ImageIO to flush the uploaded data to the storage.
+func (c *chunkedUpload) flushRequest(ctx context.Context) error {
+	return c.patchRequest(ctx, `{"op":"flush"}`, "flush uploaded image")
+}
+
+// patchRequest sends an ImageIO PATCH request with the specified JSON body. The description is used in error
+// messages.
+func (c *chunkedUpload) patchRequest(ctx context.Context, body string, description string, args ...interface{}) error {
+	patchRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPatch,
+		c.transferURL,
+		strings.NewReader(body),
+	)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to create HTTP request")
+	}
+	patchRequest.Header.Add("content-type", "application/json")
+	response, err := c.cli.httpClient.Do(patchRequest)
+	if err != nil {
+		return wrap(err, EUnidentified, "failed to %s", fmt.Sprintf(description, args...))
+	}
+	if err := c.checkStatusCode(response.StatusCode); err != nil {
+		_ = response.Body.Close()
+		return err
+	}
+	if err := response.Body.Close(); err != nil {
+		return wrap(err, EUnidentified, "failed to close response body after request to %s", fmt.Sprintf(description, args...))
+	}
+	return nil
+}
+
+func (c *chunkedUpload) addUploadedBytes(n int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.uploadedBytes = uint64(int64(c.uploadedBytes) + n)
+}
+
+// markCompleted records a confirmed segment and advances the confirmed offset past all contiguous confirmed
+// segments.
+func (c *chunkedUpload) markCompleted(chunk int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.completed[chunk] = true
+	for i, completed := range c.completed {
+		if !completed {
+			break
+		}
+		c.confirmedOffset = c.segments[i].offset + c.segments[i].length
+	}
+}
+
+// chunkProgressReader counts the bytes read from a chunk so the progress can be updated while the request is sent. The
+// bytes are counted after they pass the bandwidth limit, so the progress follows the throttled speed.
+type chunkProgressReader struct {
+	reader io.Reader
+	upload *chunkedUpload
+	read   uint64
+}
+
+func (r *chunkProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += uint64(n)
+	r.upload.addUploadedBytes(int64(n))
+	return n, err
+}
@@ -0,0 +1,311 @@
+// This file contains tests for the internal chunked upload helper. It is therefore excluded from the testpackage
+// check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// imageIOServer is a minimal stand-in for the ImageIO ranged PUT, zero and flush API. It fails the first attempt of
+// the chunks listed in failOnce after reading the request body.
+type imageIOServer struct {
+	lock     *sync.Mutex
+	data     []byte
+	failOnce map[string]bool
+	puts     int
+	zeroes   int
+	flushed  bool
+}
+
+func (s *imageIOServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		contentRange := r.Header.Get("content-range")
+		var start, end uint64
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/*", &start, &end); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if s.failOnce[contentRange] {
+			delete(s.failOnce, contentRange)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.puts++
+		copy(s.data[start:end+1], body)
+	case http.MethodPatch:
+		op := struct {
+			Op     string `json:"op"`
+			Offset uint64 `json:"offset"`
+			Size   uint64 `json:"size"`
+		}{}
+		if err := json.Unmarshal(body, &op); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch op.Op {
+		case "zero":
+			s.zeroes++
+			copy(s.data[op.Offset:op.Offset+op.Size], make([]byte, op.Size))
+		case "flush":
+			s.flushed = true
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newChunkedUploadTestClient(server *httptest.Server) *oVirtClient {
+	return &oVirtClient{
+		httpClient: *server.Client(),
+		logger:     &noopLogger{},
+	}
+}
+
+func checkChunkedUploadTestStatusCode(statusCode int) error {
+	if statusCode < 300 {
+		return nil
+	}
+	return newError(EConnection, "status code %d", statusCode)
+}
+
+func TestChunkedUploadRetriesFailedChunks(t *testing.T) {
+	t.Parallel()
+
+	image := []byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJ")
+	handler := &imageIOServer{
+		lock: &sync.Mutex{},
+		data: make([]byte, len(image)),
+		failOnce: map[string]bool{
+			"bytes 10-19/*": true,
+			"bytes 40-45/*": true,
+		},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	upload := newChunkedUpload(
+		newChunkedUploadTestClient(server),
+		server.URL,
+		bytes.NewReader(image),
+		uint64(len(image)),
+		0,
+		10,
+		3,
+		false,
+		[]RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(3)},
+		newTransferThrottle(nil, 0),
+		checkChunkedUploadTestStatusCode,
+	)
+	if err := upload.run(context.Background()); err != nil {
+		t.Fatalf("Chunked upload failed (%v)", err)
+	}
+
+	if !bytes.Equal(handler.data, image) {
+		t.Fatalf("Incorrect data uploaded: %s", handler.data)
+	}
+	if handler.puts != 5 {
+		t.Fatalf("Incorrect number of successful PUT requests: %d", handler.puts)
+	}
+	if !handler.flushed {
+		t.Fatalf("The upload was not flushed.")
+	}
+	if uploaded := upload.UploadedBytes(); uploaded != uint64(len(image)) {
+		t.Fatalf("Incorrect number of uploaded bytes after retries: %d", uploaded)
+	}
+	if confirmed := upload.ConfirmedOffset(); confirmed != uint64(len(image)) {
+		t.Fatalf("Incorrect confirmed offset: %d", confirmed)
+	}
+}
+
+func TestChunkedUploadResumesFromOffset(t *testing.T) {
+	t.Parallel()
+
+	image := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	handler := &imageIOServer{
+		lock:     &sync.Mutex{},
+		data:     make([]byte, len(image)),
+		failOnce: map[string]bool{},
+	}
+	copy(handler.data, image[:20])
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	upload := newChunkedUpload(
+		newChunkedUploadTestClient(server),
+		server.URL,
+		bytes.NewReader(image),
+		uint64(len(image)),
+		20,
+		10,
+		2,
+		false,
+		[]RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(3)},
+		newTransferThrottle(nil, 0),
+		checkChunkedUploadTestStatusCode,
+	)
+	if confirmed := upload.ConfirmedOffset(); confirmed != 20 {
+		t.Fatalf("Incorrect confirmed offset before resuming: %d", confirmed)
+	}
+	if err := upload.run(context.Background()); err != nil {
+		t.Fatalf("Chunked upload failed (%v)", err)
+	}
+
+	if !bytes.Equal(handler.data, image) {
+		t.Fatalf("Incorrect data uploaded: %s", handler.data)
+	}
+	if handler.puts != 2 {
+		t.Fatalf("Incorrect number of PUT requests when resuming: %d", handler.puts)
+	}
+	if confirmed := upload.ConfirmedOffset(); confirmed != uint64(len(image)) {
+		t.Fatalf("Incorrect confirmed offset: %d", confirmed)
+	}
+}
+
+func TestChunkedUploadAppliesBandwidthLimit(t *testing.T) {
+	t.Parallel()
+
+	image := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	handler := &imageIOServer{
+		lock:     &sync.Mutex{},
+		data:     make([]byte, len(image)),
+		failOnce: map[string]bool{},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	throttle := newTransferThrottle(nil, 100)
+	upload := newChunkedUpload(
+		newChunkedUploadTestClient(server),
+		server.URL,
+		bytes.NewReader(image),
+		uint64(len(image)),
+		0,
+		10,
+		2,
+		false,
+		[]RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(3)},
+		throttle,
+		checkChunkedUploadTestStatusCode,
+	)
+	startTime := time.Now()
+	if err := upload.run(context.Background()); err != nil {
+		t.Fatalf("Chunked upload failed (%v)", err)
+	}
+
+	if !bytes.Equal(handler.data, image) {
+		t.Fatalf("Incorrect data uploaded: %s", handler.data)
+	}
+	// The parallel chunks share the limit of the upload, so 36 bytes at 100 bytes per second take at least 300ms.
+	if elapsed := time.Since(startTime); elapsed < 300*time.Millisecond {
+		t.Fatalf("Bandwidth limit was not applied (36 bytes at 100 bytes/s took %s)", elapsed)
+	}
+	if throttle.Throttled() == 0 {
+		t.Fatalf("The time spent throttled was not recorded.")
+	}
+}
+
+func TestChunkedUploadKeepsConfirmedOffsetOnFailure(t *testing.T) {
+	t.Parallel()
+
+	image := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	handler := &imageIOServer{
+		lock:     &sync.Mutex{},
+		data:     make([]byte, len(image)),
+		failOnce: map[string]bool{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("content-range") == "bytes 20-29/*" {
+			_, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	upload := newChunkedUpload(
+		newChunkedUploadTestClient(server),
+		server.URL,
+		bytes.NewReader(image),
+		uint64(len(image)),
+		0,
+		10,
+		1,
+		false,
+		[]RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(2)},
+		newTransferThrottle(nil, 0),
+		checkChunkedUploadTestStatusCode,
+	)
+	if err := upload.run(context.Background()); err == nil {
+		t.Fatalf("Chunked upload did not fail.")
+	}
+	if confirmed := upload.ConfirmedOffset(); confirmed != 20 {
+		t.Fatalf("Incorrect confirmed offset after failure: %d", confirmed)
+	}
+	if uploaded := upload.UploadedBytes(); uploaded != 20 {
+		t.Fatalf("Failed attempts were counted as uploaded bytes: %d", uploaded)
+	}
+}
+
+func TestChunkedUploadSendsZeroRequests(t *testing.T) {
+	t.Parallel()
+
+	image := append(append([]byte("0123456789"), make([]byte, 20)...), []byte("abcdefghij")...)
+	handler := &imageIOServer{
+		lock:     &sync.Mutex{},
+		data:     bytes.Repeat([]byte{'x'}, len(image)),
+		failOnce: map[string]bool{},
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	upload := newChunkedUpload(
+		newChunkedUploadTestClient(server),
+		server.URL,
+		bytes.NewReader(image),
+		uint64(len(image)),
+		0,
+		10,
+		2,
+		true,
+		[]RetryStrategy{AutoRetry(), ExponentialBackoff(1), MaxTries(3)},
+		newTransferThrottle(nil, 0),
+		checkChunkedUploadTestStatusCode,
+	)
+	if err := upload.run(context.Background()); err != nil {
+		t.Fatalf("Chunked upload failed (%v)", err)
+	}
+
+	if !bytes.Equal(handler.data, image) {
+		t.Fatalf("Incorrect data uploaded: %v", handler.data)
+	}
+	if handler.puts != 2 {
+		t.Fatalf("Incorrect number of PUT requests: %d", handler.puts)
+	}
+	if handler.zeroes != 2 {
+		t.Fatalf("Incorrect number of zero requests: %d", handler.zeroes)
+	}
+	if uploaded := upload.UploadedBytes(); uploaded != uint64(len(image)) {
+		t.Fatalf("Incorrect number of uploaded bytes: %d", uploaded)
+	}
+}
@@ -1,6 +1,7 @@
 package ovirtclient
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -68,6 +69,32 @@ func newImageTransfer(
 	}
 }
 
+// newBackupImageTransfer creates a new image transfer for downloading a disk from a VM backup. The disk is always
+// transferred in the raw format. See newImageTransfer for the parameters.
+func newBackupImageTransfer(
+	cli *oVirtClient,
+	logger Logger,
+	diskID DiskID,
+	backupID BackupID,
+	retries []RetryStrategy,
+) imageTransfer {
+	return &imageTransferImpl{
+		retries:         retries,
+		diskID:          diskID,
+		backupID:        backupID,
+		cli:             cli,
+		logger:          logger,
+		correlationID:   generateCorrelationID("backup_download_"),
+		conn:            cli.conn,
+		transfer:        nil,
+		transferService: nil,
+		httpClient:      cli.httpClient,
+		direction:       ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD,
+		format:          ovirtsdk4.DISKFORMAT_RAW,
+		updateDisk:      func(disk Disk) {},
+	}
+}
+
 // imageTransfer is an internal helper to facilitate image transfers from/to the oVirt Engine. It should not be reused
 // for multiple transfers.
 type imageTransfer interface {
@@ -76,15 +103,28 @@ type imageTransfer interface {
 	// transfer and returns an error. In any case, the calling party MUST call finalize to correctly
 	// finalize the image transfer.
 	initialize() (transferURL string, err error)
+	// resume attaches to an existing image transfer, for example one left behind by an interrupted upload, and
+	// resumes it if it is paused. If successful, it returns the URL the image needs to be transferred to/from. If
+	// not, the transfer is left as it is so the resume can be attempted again. In any case, the calling party MUST
+	// call finalize or pause.
+	resume(transferID ImageTransferID) (transferURL string, err error)
 	// finalize cleans up the image transfer. It must be called regardless if an error happened or not, and the error
 	// must be passed to it so it can determine how to best clean up the image transfer.
 	//
 	// If the finalize function is not called the disk may potentially stay in locked status indefinitely.
 	finalize(err error) error
+	// pause pauses the image transfer instead of aborting it, so it can be resumed later. It must be passed the
+	// error that caused the transfer to stop and returns it.
+	pause(err error) error
+	// id returns the ID of the image transfer, or an empty string if no transfer has been created yet.
+	id() ImageTransferID
 
 	// checkStatusCode checks an ImageIO status code for correctness and returns an error if it is
 	// not correct.
 	checkStatusCode(statusCode int) error
+	// supports returns true if the ImageIO server of the transfer URL reported the specified feature, for example
+	// "extents" or "zero". It is only accurate after the transfer URL has been determined.
+	supports(feature string) bool
 }
 
 // imageTransferImpl is the implementation of the imageTransfer interface.
@@ -93,6 +133,8 @@ type imageTransferImpl struct {
 	retries []RetryStrategy
 	// diskID is the ID of the disk used for this transfer.
 	diskID DiskID
+	// backupID is the ID of the VM backup the disk is downloaded from. It is empty for regular image transfers.
+	backupID BackupID
 	// cli is the calling client library.
 	cli *oVirtClient
 	// logger is the go-ovirt-client-log logger
@@ -119,6 +161,19 @@ type imageTransferImpl struct {
 	transferService *ovirtsdk4.ImageTransferService
 	// transferURL is the URL that is found for the transfer. It is set after findTransferURL is called.
 	transferURL string
+	// features is the list of features the ImageIO server of the transfer URL supports. It is set after
+	// findTransferURL is called.
+	features []string
+}
+
+// supports returns true if the ImageIO server of the transfer URL reported the specified feature.
+func (i *imageTransferImpl) supports(feature string) bool {
+	for _, f := range i.features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
 }
 
 // checkStatusCode takes a HTTP status code from the ImageIO endpoint and verifies it.
@@ -178,6 +233,27 @@ func (i *imageTransferImpl) initialize() (transferURL string, err error) {
 	return i.transferURL, nil
 }
 
+// resume attaches to an existing image transfer and resumes it if it is paused. If successful, it returns the URL the
+// image needs to be transferred to/from using a HTTP request. Unlike initialize it does not abort the transfer on
+// failure, as the transfer may still be resumed later.
+func (i *imageTransferImpl) resume(transferID ImageTransferID) (transferURL string, err error) {
+	steps := []func() error{
+		func() error {
+			return i.attachImageTransfer(transferID)
+		},
+		i.resumeImageTransfer,
+		i.waitForImageTransferReady,
+		i.findTransferURL,
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return "", err
+		}
+	}
+	return i.transferURL, nil
+}
+
 // finalize finalizes or aborts the image transfer, depending on if an error happened. The calling
 // party must pass any error that happened so that the finalize function can make the correct decision.
 func (i *imageTransferImpl) finalize(err error) error {
@@ -199,12 +275,55 @@ func (i *imageTransferImpl) finalize(err error) error {
 	return nil
 }
 
+// pause pauses the image transfer so it can be resumed later. The image transfer is left as it is if pausing fails,
+// the engine pauses inactive transfers on its own.
+func (i *imageTransferImpl) pause(err error) error {
+	if i.transferService == nil {
+		return err
+	}
+	if pauseErr := retry(
+		fmt.Sprintf("pausing image transfer for disk %s", i.diskID),
+		i.logger,
+		i.cli.requestHooks("PauseImageTransfer"),
+		i.retries,
+		i.attemptPauseTransfer,
+	); pauseErr != nil {
+		i.logger.Warningf(
+			"failed to pause image transfer %s for disk %s (%v)",
+			i.id(),
+			i.diskID,
+			pauseErr,
+		)
+	}
+	return err
+}
+
+// attemptPauseTransfer attempts to pause an image transfer with the oVirt Engine API.
+func (i *imageTransferImpl) attemptPauseTransfer() error {
+	_, err := i.transferService.Pause().Send()
+	return err
+}
+
+// id returns the ID of the image transfer.
+func (i *imageTransferImpl) id() ImageTransferID {
+	if i.transfer == nil {
+		return ""
+	}
+	transferID, _ := i.transfer.Id()
+	return ImageTransferID(transferID)
+}
+
 // waitForTransferOk waits for a disk to be in the OK status, then additionally queries the job that was in progress with
 // the correlation ID. This is necessary because the disk returns OK status before the job has actually finished,
 // resulting in a "disk locked" error on subsequent operations. It uses checkDiskOk as an underlying function.
 //
 // This function also calls the updateDisk hook to update the disk on the calling side.
 func (i *imageTransferImpl) waitForTransferOk() (err error) {
+	if i.backupID != "" {
+		// The disks of a VM may stay locked for the duration of the backup, so only the job is waited for.
+		return i.cli.waitForJobFinished(i.correlationID, i.retries)
+	}
+
 	disk, err := i.cli.WaitForDiskOK(i.diskID, i.retries...)
 
 	if err != nil {
@@ -225,16 +344,20 @@ func (i *imageTransferImpl) buildImageTransferRequest() (
 	*ovirtsdk4.ImageTransfersService,
 ) {
 	imageTransfersService := i.conn.SystemService().ImageTransfersService()
-	image := ovirtsdk4.NewImageBuilder().Id(string(i.diskID)).MustBuild()
-	transfer := ovirtsdk4.
+	transferBuilder := ovirtsdk4.
 		NewImageTransferBuilder().
-		Image(image).
 		Direction(i.direction).
-		Format(i.format).
-		MustBuild()
+		Format(i.format)
+	if i.backupID != "" {
+		transferBuilder.
+			Backup(ovirtsdk4.NewBackupBuilder().Id(string(i.backupID)).MustBuild()).
+			Disk(ovirtsdk4.NewDiskBuilder().Id(string(i.diskID)).MustBuild())
+	} else {
+		transferBuilder.Image(ovirtsdk4.NewImageBuilder().Id(string(i.diskID)).MustBuild())
+	}
 	transferReq := imageTransfersService.
 		Add().
-		ImageTransfer(transfer).
+		ImageTransfer(transferBuilder.MustBuild()).
 		Query("correlation_id", i.correlationID)
 	return transferReq, imageTransfersService
 }
@@ -246,6 +369,7 @@ func (i *imageTransferImpl) createImageTransfer() (err error) {
 	return retry(
 		fmt.Sprintf("starting image transfer for disk %s", i.diskID),
 		i.logger,
+		i.cli.requestHooks("CreateImageTransfer"),
 		i.retries,
 		i.attemptCreateImageTransfer,
 	)
@@ -279,6 +403,76 @@ func (i *imageTransferImpl) attemptCreateImageTransfer() error {
 	return nil
 }
 
+// attachImageTransfer fetches an existing image transfer and checks if it belongs to the disk being transferred.
+// This function will set the i.transfer and i.transferService variables.
+func (i *imageTransferImpl) attachImageTransfer(transferID ImageTransferID) error {
+	return retry(
+		fmt.Sprintf("fetching image transfer %s for disk %s", transferID, i.diskID),
+		i.logger,
+		i.cli.requestHooks("AttachImageTransfer"),
+		i.retries,
+		func() error {
+			return i.attemptAttachImageTransfer(transferID)
+		},
+	)
+}
+
+// attemptAttachImageTransfer fetches an existing image transfer once.
+func (i *imageTransferImpl) attemptAttachImageTransfer(transferID ImageTransferID) error {
+	transferService := i.conn.SystemService().ImageTransfersService().ImageTransferService(string(transferID))
+	response, err := transferService.Get().Send()
+	if err != nil {
+		return err
+	}
+	transfer, ok := response.ImageTransfer()
+	if !ok {
+		return newError(EFieldMissing, "fetching image transfer %s did not return an image transfer", transferID)
+	}
+	if image, ok := transfer.Image(); ok {
+		if imageID, ok := image.Id(); ok && imageID != string(i.diskID) {
+			return newError(
+				EBadArgument,
+				"image transfer %s belongs to disk %s instead of %s",
+				transferID,
+				imageID,
+				i.diskID,
+			)
+		}
+	}
+	if direction, ok := transfer.Direction(); ok && direction != i.direction {
+		return newError(
+			EBadArgument,
+			"image transfer %s is an %s instead of an %s",
+			transferID,
+			direction,
+			i.direction,
+		)
+	}
+	i.transfer = transfer
+	i.transferService = transferService
+	return nil
+}
+
+// resumeImageTransfer resumes the attached image transfer if it has been paused by the user or the system.
+func (i *imageTransferImpl) resumeImageTransfer() error {
+	phase, _ := i.transfer.Phase()
+	switch phase {
+	case ovirtsdk4.IMAGETRANSFERPHASE_PAUSED_USER, ovirtsdk4.IMAGETRANSFERPHASE_PAUSED_SYSTEM:
+	default:
+		return nil
+	}
+	return retry(
+		fmt.Sprintf("resuming image transfer for disk %s", i.diskID),
+		i.logger,
+		i.cli.requestHooks("ResumeImageTransfer"),
+		i.retries,
+		func() error {
+			_, err := i.transferService.Resume().Send()
+			return err
+		},
+	)
+}
+
 // waitForImageTransferReady repeatedly calls checkImageTransferReady until it returns successfully or the retries are
 // exhausted.
 //
@@ -290,6 +484,7 @@ func (i *imageTransferImpl) waitForImageTransferReady() (err error) {
 			i.diskID,
 		),
 		i.logger,
+		i.cli.requestHooks("WaitForImageTransferReady"),
 		i.retries,
 		i.checkImageTransferReady,
 	)
@@ -317,7 +512,7 @@ func (i *imageTransferImpl) checkImageTransferReady() error {
 		)
 	}
 	switch phase {
-	case ovirtsdk4.IMAGETRANSFERPHASE_INITIALIZING:
+	case ovirtsdk4.IMAGETRANSFERPHASE_INITIALIZING, ovirtsdk4.IMAGETRANSFERPHASE_RESUMING:
 		return newError(
 			EPending,
 			"image transfer is in phase %s instead of transferring",
@@ -342,6 +537,7 @@ func (i *imageTransferImpl) finalizeTransfer() error {
 	return retry(
 		fmt.Sprintf("finalizing image for disk %s", i.diskID),
 		i.logger,
+		i.cli.requestHooks("FinalizeTransfer"),
 		i.retries,
 		i.attemptFinalizeTransfer,
 	)
@@ -360,6 +556,7 @@ func (i *imageTransferImpl) waitForTransferFinalize() error {
 	return retry(
 		fmt.Sprintf("waiting for finalizing image transfer for disk %s", i.diskID),
 		i.logger,
+		i.cli.requestHooks("WaitForTransferFinalize"),
 		i.retries,
 		i.attemptWaitForTransferFinalize,
 	)
@@ -380,6 +577,7 @@ func (i *imageTransferImpl) waitForTransferAbort() error {
 	return retry(
 		fmt.Sprintf("waiting for aborting image transfer for disk %s", i.diskID),
 		i.logger,
+		i.cli.requestHooks("WaitForTransferAbort"),
 		i.retries,
 		i.attemptWaitForTransferAbort,
 	)
@@ -482,6 +680,7 @@ func (i *imageTransferImpl) verifyTransferURL(transferURL string) error {
 	return retry(
 		fmt.Sprintf("sending OPTIONS request to %s", transferURL),
 		i.logger,
+		i.cli.transferHooks("VerifyTransferURL"),
 		append(i.retries, MaxTries(3)),
 		func() error {
 			return i.optionsRequest(parsedTransferURL)
@@ -512,6 +711,14 @@ func (i *imageTransferImpl) optionsRequest(parsedTransferURL *url.URL) error {
 			parsedTransferURL.String(),
 		)
 	case statusCode < 399:
+		// ImageIO reports the supported features in the response body. Older versions don't, in which case we
+		// don't use any optional features.
+		options := imageIOOptions{}
+		if err := json.NewDecoder(res.Body).Decode(&options); err == nil {
+			i.features = options.Features
+		} else {
+			i.features = nil
+		}
 		return nil
 	case statusCode < 499:
 		return newError(
@@ -530,6 +737,11 @@ func (i *imageTransferImpl) optionsRequest(parsedTransferURL *url.URL) error {
 	}
 }
 
+// imageIOOptions is the response of ImageIO to an OPTIONS request.
+type imageIOOptions struct {
+	Features []string `json:"features"`
+}
+
 // abortTransfer cancels an image transfer with the oVirt Engine API. It calls the abort repeatedly until it succeeds or
 // the retries are exhausted.
 func (i *imageTransferImpl) abortTransfer() {
@@ -538,6 +750,7 @@ func (i *imageTransferImpl) abortTransfer() {
 		if err := retry(
 			fmt.Sprintf("canceling transfer for disk %s", i.diskID),
 			i.logger,
+			i.cli.requestHooks("AbortTransfer"),
 			i.retries,
 			i.attemptAbortTransfer,
 		); err != nil {
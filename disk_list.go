@@ -8,6 +8,7 @@ func (o *oVirtClient) ListDisks(retries ...RetryStrategy) (result []Disk, err er
 	err = retry(
 		"listing disks",
 		o.logger,
+		o.requestHooks("ListDisks"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().DisksService().List().Send()
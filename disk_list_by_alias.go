@@ -10,6 +10,7 @@ func (o *oVirtClient) ListDisksByAlias(alias string, retries ...RetryStrategy) (
 	err = retry(
 		fmt.Sprintf("listing disk by alias %s", alias),
 		o.logger,
+		o.requestHooks("ListDisksByAlias"),
 		retries,
 		func() error {
 			searchString := fmt.Sprintf("name=%s", alias)
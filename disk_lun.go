@@ -0,0 +1,284 @@
+package ovirtclient
+
+import (
+	"strings"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// DiskStorageType describes where the data of a disk is stored.
+type DiskStorageType string
+
+const (
+	// DiskStorageTypeImage is a disk stored as an image on a storage domain. This is the default.
+	DiskStorageTypeImage DiskStorageType = "image"
+	// DiskStorageTypeLUN is a disk backed directly by an iSCSI or FCP LUN.
+	DiskStorageTypeLUN DiskStorageType = "lun"
+	// DiskStorageTypeCinder is a disk stored in OpenStack Cinder.
+	DiskStorageTypeCinder DiskStorageType = "cinder"
+	// DiskStorageTypeManagedBlockStorage is a disk stored on a managed block storage domain.
+	DiskStorageTypeManagedBlockStorage DiskStorageType = "managed_block_storage"
+)
+
+// DiskStorageTypeList is a list of DiskStorageType.
+type DiskStorageTypeList []DiskStorageType
+
+// DiskStorageTypeValues returns all possible DiskStorageType values.
+func DiskStorageTypeValues() DiskStorageTypeList {
+	return []DiskStorageType{
+		DiskStorageTypeImage,
+		DiskStorageTypeLUN,
+		DiskStorageTypeCinder,
+		DiskStorageTypeManagedBlockStorage,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l DiskStorageTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, storageType := range l {
+		result[i] = string(storageType)
+	}
+	return result
+}
+
+// Validate returns an error if the disk storage type is not valid.
+func (d DiskStorageType) Validate() error {
+	for _, storageType := range DiskStorageTypeValues() {
+		if storageType == d {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid disk storage type: %s must be one of: %s",
+		d,
+		strings.Join(DiskStorageTypeValues().Strings(), ", "),
+	)
+}
+
+// LUNStorageType is the transport used to access the LUN of a LUN disk.
+type LUNStorageType string
+
+const (
+	// LUNStorageTypeISCSI is a LUN accessed over iSCSI.
+	LUNStorageTypeISCSI LUNStorageType = "iscsi"
+	// LUNStorageTypeFCP is a LUN accessed over Fibre Channel.
+	LUNStorageTypeFCP LUNStorageType = "fcp"
+)
+
+// LUNStorageTypeList is a list of LUNStorageType.
+type LUNStorageTypeList []LUNStorageType
+
+// LUNStorageTypeValues returns all possible LUNStorageType values.
+func LUNStorageTypeValues() LUNStorageTypeList {
+	return []LUNStorageType{
+		LUNStorageTypeISCSI,
+		LUNStorageTypeFCP,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l LUNStorageTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, storageType := range l {
+		result[i] = string(storageType)
+	}
+	return result
+}
+
+// Validate returns an error if the LUN storage type is not valid.
+func (l LUNStorageType) Validate() error {
+	for _, storageType := range LUNStorageTypeValues() {
+		if storageType == l {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid LUN storage type: %s must be one of: %s",
+		l,
+		strings.Join(LUNStorageTypeValues().Strings(), ", "),
+	)
+}
+
+// ISCSIConnection contains the details of the iSCSI target a LUN is exported from.
+type ISCSIConnection struct {
+	// Address is the host name or IP address of the iSCSI portal.
+	Address string
+	// Port is the port of the iSCSI portal. If it is 0, the default port 3260 is used.
+	Port uint16
+	// Target is the IQN of the iSCSI target.
+	Target string
+	// Username is the CHAP username. It is empty if the target does not require authentication.
+	Username string
+	// Password is the CHAP password.
+	Password string
+}
+
+// CreateLUNDiskOptionalParameters contains the optional parameters for DiskClient.CreateLUNDisk.
+type CreateLUNDiskOptionalParameters interface {
+	// Alias is a secondary name for the disk.
+	Alias() string
+	// Shareable indicates that the disk can be attached to multiple VMs at the same time. If it returns nil, the disk
+	// is not shareable.
+	Shareable() *bool
+	// ISCSIConnection returns the iSCSI target the LUN is exported from. It is only used for iSCSI LUNs and may be nil
+	// if the LUN is already visible to the hosts.
+	ISCSIConnection() *ISCSIConnection
+}
+
+// BuildableCreateLUNDiskParameters is a buildable version of CreateLUNDiskOptionalParameters.
+type BuildableCreateLUNDiskParameters interface {
+	CreateLUNDiskOptionalParameters
+
+	// WithAlias sets the alias of the disk.
+	WithAlias(alias string) (BuildableCreateLUNDiskParameters, error)
+	// MustWithAlias is the same as WithAlias, but panics instead of returning an error.
+	MustWithAlias(alias string) BuildableCreateLUNDiskParameters
+
+	// WithShareable sets if the disk can be attached to multiple VMs at the same time.
+	WithShareable(shareable bool) (BuildableCreateLUNDiskParameters, error)
+	// MustWithShareable is the same as WithShareable, but panics instead of returning an error.
+	MustWithShareable(shareable bool) BuildableCreateLUNDiskParameters
+
+	// WithISCSIConnection sets the iSCSI target the LUN is exported from. The address and target are required.
+	WithISCSIConnection(connection ISCSIConnection) (BuildableCreateLUNDiskParameters, error)
+	// MustWithISCSIConnection is the same as WithISCSIConnection, but panics instead of returning an error.
+	MustWithISCSIConnection(connection ISCSIConnection) BuildableCreateLUNDiskParameters
+}
+
+// CreateLUNDiskParams creates a buildable set of CreateLUNDiskOptionalParameters for use with Client.CreateLUNDisk.
+func CreateLUNDiskParams() BuildableCreateLUNDiskParameters {
+	return &createLUNDiskParams{}
+}
+
+type createLUNDiskParams struct {
+	alias           string
+	shareable       *bool
+	iscsiConnection *ISCSIConnection
+}
+
+func (c *createLUNDiskParams) Alias() string {
+	return c.alias
+}
+
+func (c *createLUNDiskParams) WithAlias(alias string) (BuildableCreateLUNDiskParameters, error) {
+	c.alias = alias
+	return c, nil
+}
+
+func (c *createLUNDiskParams) MustWithAlias(alias string) BuildableCreateLUNDiskParameters {
+	builder, err := c.WithAlias(alias)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createLUNDiskParams) Shareable() *bool {
+	return c.shareable
+}
+
+func (c *createLUNDiskParams) WithShareable(shareable bool) (BuildableCreateLUNDiskParameters, error) {
+	c.shareable = &shareable
+	return c, nil
+}
+
+func (c *createLUNDiskParams) MustWithShareable(shareable bool) BuildableCreateLUNDiskParameters {
+	builder, err := c.WithShareable(shareable)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createLUNDiskParams) ISCSIConnection() *ISCSIConnection {
+	return c.iscsiConnection
+}
+
+func (c *createLUNDiskParams) WithISCSIConnection(connection ISCSIConnection) (BuildableCreateLUNDiskParameters, error) {
+	if connection.Address == "" {
+		return nil, newError(EBadArgument, "the iSCSI portal address cannot be empty")
+	}
+	if connection.Target == "" {
+		return nil, newError(EBadArgument, "the iSCSI target cannot be empty")
+	}
+	if connection.Port == 0 {
+		connection.Port = defaultISCSIPort
+	}
+	c.iscsiConnection = &connection
+	return c, nil
+}
+
+func (c *createLUNDiskParams) MustWithISCSIConnection(connection ISCSIConnection) BuildableCreateLUNDiskParameters {
+	builder, err := c.WithISCSIConnection(connection)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// defaultISCSIPort is the port iSCSI portals listen on by default.
+const defaultISCSIPort = 3260
+
+func validateLUNDiskCreationParameters(
+	lunID string,
+	storageType LUNStorageType,
+	params CreateLUNDiskOptionalParameters,
+) error {
+	if lunID == "" {
+		return newError(EBadArgument, "the LUN ID cannot be empty")
+	}
+	if err := storageType.Validate(); err != nil {
+		return err
+	}
+	if storageType != LUNStorageTypeISCSI && params.ISCSIConnection() != nil {
+		return newError(EBadArgument, "iSCSI connection details can only be set for %s LUNs", LUNStorageTypeISCSI)
+	}
+	return nil
+}
+
+// convertSDKLUNDisk converts a disk backed by a LUN. LUN disks have no storage domain and the engine does not report
+// the image fields for them.
+func convertSDKLUNDisk(sdkDisk *ovirtsdk.Disk, client Client) (Disk, error) {
+	id, ok := sdkDisk.Id()
+	if !ok {
+		return nil, newError(EFieldMissing, "disk does not contain an ID")
+	}
+	alias, _ := sdkDisk.Alias()
+	shareable, _ := sdkDisk.Shareable()
+	status, ok := sdkDisk.Status()
+	if !ok {
+		// The engine does not track a status for LUN disks, they are usable as soon as they are created.
+		status = ovirtsdk.DISKSTATUS_OK
+	}
+	sdkLUNStorage, ok := sdkDisk.LunStorage()
+	if !ok {
+		return nil, newError(EFieldMissing, "LUN disk %s has no LUN storage", id)
+	}
+	sdkLogicalUnits, ok := sdkLUNStorage.LogicalUnits()
+	if !ok || len(sdkLogicalUnits.Slice()) == 0 {
+		return nil, newError(EFieldMissing, "LUN disk %s has no logical units", id)
+	}
+	sdkLogicalUnit := sdkLogicalUnits.Slice()[0]
+	lunID, ok := sdkLogicalUnit.Id()
+	if !ok {
+		return nil, newError(EFieldMissing, "the logical unit of LUN disk %s has no ID", id)
+	}
+	size, _ := sdkLogicalUnit.Size()
+	return &disk{
+		client: client,
+
+		id:              DiskID(id),
+		alias:           alias,
+		provisionedSize: uint64(size),
+		totalSize:       uint64(size),
+		format:          ImageFormatRaw,
+		status:          DiskStatus(status),
+		contentType:     DiskContentTypeData,
+		shareable:       shareable,
+		storageType:     DiskStorageTypeLUN,
+		lunID:           lunID,
+	}, nil
+}
@@ -11,6 +11,8 @@ type diskWithData struct {
 	disk
 	lock *sync.Mutex
 	data []byte
+	// dirtyBitmaps records the blocks changed since each checkpoint that tracks this disk.
+	dirtyBitmaps map[CheckpointID][]bool
 }
 
 func (d *diskWithData) Lock() error {
@@ -30,6 +32,16 @@ func (d *diskWithData) Unlock() {
 	d.status = DiskStatusOK
 }
 
+// onStorageDomain returns true if the disk has an image on the specified storage domain.
+func (d *diskWithData) onStorageDomain(id StorageDomainID) bool {
+	for _, storageDomainID := range d.storageDomainIDs {
+		if storageDomainID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *diskWithData) WithAlias(alias *string) *diskWithData {
 	return &diskWithData{
 		disk{
@@ -42,9 +54,15 @@ func (d *diskWithData) WithAlias(alias *string) *diskWithData {
 			status:           d.status,
 			totalSize:        d.totalSize,
 			sparse:           d.sparse,
+			contentType:      d.contentType,
+			diskProfileID:    d.diskProfileID,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lunID:            d.lunID,
 		},
 		d.lock,
 		d.data,
+		d.dirtyBitmaps,
 	}
 }
 
@@ -66,12 +84,68 @@ func (d *diskWithData) withProvisionedSize(ps uint64) (*diskWithData, error) {
 			status:           d.status,
 			totalSize:        ps,
 			sparse:           d.sparse,
+			contentType:      d.contentType,
+			diskProfileID:    d.diskProfileID,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lunID:            d.lunID,
 		},
 		d.lock,
 		d.data,
+		d.dirtyBitmaps,
 	}, nil
 }
 
+// withStorageDomainIDs returns a copy of the disk placed on the specified storage domains.
+func (d *diskWithData) withStorageDomainIDs(storageDomainIDs []StorageDomainID) *diskWithData {
+	return &diskWithData{
+		disk{
+			client:           d.client,
+			id:               d.id,
+			alias:            d.alias,
+			provisionedSize:  d.provisionedSize,
+			format:           d.format,
+			storageDomainIDs: storageDomainIDs,
+			status:           d.status,
+			totalSize:        d.totalSize,
+			sparse:           d.sparse,
+			contentType:      d.contentType,
+			diskProfileID:    d.diskProfileID,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lunID:            d.lunID,
+		},
+		d.lock,
+		d.data,
+		d.dirtyBitmaps,
+	}
+}
+
+// withDiskProfileID returns a copy of the disk using the specified disk profile.
+func (d *diskWithData) withDiskProfileID(diskProfileID *DiskProfileID) *diskWithData {
+	return &diskWithData{
+		disk{
+			client:           d.client,
+			id:               d.id,
+			alias:            d.alias,
+			provisionedSize:  d.provisionedSize,
+			format:           d.format,
+			storageDomainIDs: d.storageDomainIDs,
+			status:           d.status,
+			totalSize:        d.totalSize,
+			sparse:           d.sparse,
+			contentType:      d.contentType,
+			diskProfileID:    diskProfileID,
+			shareable:        d.shareable,
+			storageType:      d.storageType,
+			lunID:            d.lunID,
+		},
+		d.lock,
+		d.data,
+		d.dirtyBitmaps,
+	}
+}
+
 // clone is an internal function that makes a copy of the disk object with a new UUID.
 func (d *diskWithData) clone(sparse *bool) *diskWithData {
 	if sparse == nil {
@@ -88,8 +162,44 @@ func (d *diskWithData) clone(sparse *bool) *diskWithData {
 			d.status,
 			d.totalSize,
 			*sparse,
+			d.contentType,
+			d.diskProfileID,
+			d.shareable,
+			d.storageType,
+			d.lunID,
 		},
 		&sync.Mutex{},
 		d.data,
+		map[CheckpointID][]bool{},
+	}
+}
+
+// diskProfileFor returns the disk profile for a disk on the specified storage domains. If no disk profile is
+// requested, the default disk profile of the first storage domain is returned, which may be nil. The caller must hold
+// the mock lock.
+func (m *mockClient) diskProfileFor(
+	storageDomainIDs []StorageDomainID,
+	requested *DiskProfileID,
+) (*DiskProfileID, error) {
+	if requested == nil {
+		if diskProfileID, ok := m.defaultDiskProfiles[storageDomainIDs[0]]; ok {
+			return &diskProfileID, nil
+		}
+		return nil, nil
+	}
+	profile, ok := m.diskProfiles[*requested]
+	if !ok {
+		return nil, newError(ENotFound, "disk profile with ID %s not found", *requested)
+	}
+	for _, storageDomainID := range storageDomainIDs {
+		if profile.storageDomainID == storageDomainID {
+			return requested, nil
+		}
 	}
+	return nil, newError(
+		EBadArgument,
+		"disk profile %s belongs to storage domain %s, not to the storage domain of the disk",
+		*requested,
+		profile.storageDomainID,
+	)
 }
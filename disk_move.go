@@ -0,0 +1,198 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) MoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := o.StartMoveDisk(diskID, storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (o *oVirtClient) StartMoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (DiskUpdate, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID, err := correlationIDFor(o.ctx, "disk_move_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	disk, err := o.GetDisk(diskID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	storageDomain, err := o.GetStorageDomain(storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = retry(
+		fmt.Sprintf("moving disk %s to storage domain %s", diskID, storageDomainID),
+		o.logger,
+		o.requestHooks("StartMoveDisk"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DisksService().
+				DiskService(string(diskID)).
+				Move().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &storageDomainDiskWait{
+		client:        o,
+		disk:          disk,
+		storageDomain: storageDomain,
+		correlationID: correlationID,
+		lock:          &sync.Mutex{},
+	}, nil
+}
+
+func (m *mockClient) MoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Disk, error) {
+	progress, err := m.StartMoveDisk(diskID, storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return progress.Wait(retries...)
+}
+
+func (m *mockClient) StartMoveDisk(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) (DiskUpdate, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "disk_move_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	disk, ok := m.disks[diskID]
+	if !ok {
+		return nil, newError(ENotFound, "disk with ID %s not found", diskID)
+	}
+	if disk.storageType != DiskStorageTypeImage {
+		return nil, newError(EBadArgument, "disk %s is a %s disk, only image disks can be moved", diskID, disk.storageType)
+	}
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	for _, sdID := range disk.storageDomainIDs {
+		if sdID == storageDomainID {
+			return nil, newError(EConflict, "disk %s is already on storage domain %s", diskID, storageDomainID)
+		}
+	}
+	if err := m.checkStorageDomainSpace(storageDomainID, disk.totalSize); err != nil {
+		return nil, err
+	}
+	if err := disk.Lock(); err != nil {
+		return nil, err
+	}
+
+	job := m.startJob(fmt.Sprintf("Moving Disk %s to storage domain %s", disk.alias, storageDomainID), correlationID)
+	move := &mockDiskStorageOperation{
+		client: m,
+		source: disk,
+		disk:   disk,
+		done:   make(chan struct{}),
+	}
+	go move.do(job, func() {
+		current, ok := m.disks[diskID]
+		if !ok {
+			return
+		}
+		// The engine switches the disk to the default disk profile of the target storage domain.
+		diskProfileID, _ := m.diskProfileFor([]StorageDomainID{storageDomainID}, nil)
+		m.disks[diskID] = current.
+			withStorageDomainIDs([]StorageDomainID{storageDomainID}).
+			withDiskProfileID(diskProfileID)
+	})
+	return move, nil
+}
+
+// mockDiskStorageOperation simulates a move or copy of a disk between storage domains.
+type mockDiskStorageOperation struct {
+	client *mockClient
+	// source is the disk being moved or copied. It is locked while the operation is in progress.
+	source *diskWithData
+	// disk is the resulting disk. It is identical to source for moves.
+	disk *diskWithData
+	done chan struct{}
+}
+
+func (c *mockDiskStorageOperation) Disk() Disk {
+	c.client.lock.Lock()
+	defer c.client.lock.Unlock()
+
+	return c.disk
+}
+
+func (c *mockDiskStorageOperation) Wait(_ ...RetryStrategy) (Disk, error) {
+	<-c.done
+
+	return c.client.GetDisk(c.disk.ID())
+}
+
+// do finishes the operation after a delay by calling apply with the mock lock held and then unlocking the disks.
+func (c *mockDiskStorageOperation) do(job *mockJob, apply func()) {
+	// Sleep to trigger potential race conditions / improper status handling.
+	time.Sleep(time.Second)
+
+	c.client.lock.Lock()
+	defer c.client.lock.Unlock()
+	apply()
+	// The disks may have been replaced by apply, so the current versions are unlocked.
+	for _, diskID := range []DiskID{c.source.ID(), c.disk.ID()} {
+		if disk, ok := c.client.disks[diskID]; ok {
+			disk.Unlock()
+		}
+	}
+	c.client.endJob(job, JobStatusFinished)
+	close(c.done)
+}
+
+// checkStorageDomainSpace returns an error if the storage domain would have less free space than its
+// CriticalSpaceActionBlocker after adding the specified number of bytes. The caller must hold the mock lock.
+func (m *mockClient) checkStorageDomainSpace(storageDomainID StorageDomainID, size uint64) error {
+	sd := m.storageDomainWithUsage(m.storageDomains[storageDomainID])
+	if sd.available < size || sd.available-size < sd.criticalSpaceActionBlocker {
+		return newError(
+			ENoSpace,
+			"storage domain %s only has %d bytes available, %d bytes and %d bytes of free space are required",
+			storageDomainID,
+			sd.available,
+			size,
+			sd.criticalSpaceActionBlocker,
+		)
+	}
+	return nil
+}
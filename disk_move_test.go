@@ -0,0 +1,57 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestDiskMove(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+	secondarySD := helper.GetSecondaryStorageDomainID(t)
+
+	disk := assertCanCreateDisk(t, helper)
+	sourceAvailable := assertCanGetStorageDomainAvailable(t, helper, helper.GetStorageDomainID())
+	targetAvailable := assertCanGetStorageDomainAvailable(t, helper, secondarySD)
+
+	t.Logf("Moving disk %s to storage domain %s", disk.ID(), secondarySD)
+	movedDisk, err := disk.Move(secondarySD)
+	if err != nil {
+		t.Fatalf("Failed to move disk %s to storage domain %s (%v)", disk.ID(), secondarySD, err)
+	}
+	if movedDisk.ID() != disk.ID() {
+		t.Fatalf("The disk ID changed during the move (%s instead of %s).", movedDisk.ID(), disk.ID())
+	}
+	storageDomainIDs := movedDisk.StorageDomainIDs()
+	if len(storageDomainIDs) != 1 || storageDomainIDs[0] != secondarySD {
+		t.Fatalf("Incorrect storage domains after moving disk %s: %v", disk.ID(), storageDomainIDs)
+	}
+
+	if _, ok := client.(ovirtclient.MockClient); ok {
+		if available := assertCanGetStorageDomainAvailable(t, helper, helper.GetStorageDomainID()); available != sourceAvailable+disk.TotalSize() {
+			t.Fatalf("Incorrect available space on the source storage domain after the move: %d", available)
+		}
+		if available := assertCanGetStorageDomainAvailable(t, helper, secondarySD); available != targetAvailable-disk.TotalSize() {
+			t.Fatalf("Incorrect available space on the target storage domain after the move: %d", available)
+		}
+	}
+
+	_, err = client.MoveDisk(disk.ID(), secondarySD, ovirtclient.MaxTries(1))
+	if err == nil {
+		t.Fatalf("Moving disk %s to the storage domain it is already on did not fail.", disk.ID())
+	}
+}
+
+func assertCanGetStorageDomainAvailable(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	storageDomainID ovirtclient.StorageDomainID,
+) uint64 {
+	storageDomain, err := helper.GetClient().GetStorageDomain(storageDomainID)
+	if err != nil {
+		t.Fatalf("Failed to get storage domain %s (%v)", storageDomainID, err)
+	}
+	return storageDomain.Available()
+}
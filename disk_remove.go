@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveDisk(diskID DiskID, retries ...RetryStrategy) error
 	return retry(
 		fmt.Sprintf("removing disk %s", diskID),
 		o.logger,
+		o.requestHooks("RemoveDisk"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().DisksService().DiskService(string(diskID)).Remove().Send()
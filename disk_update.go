@@ -32,6 +32,9 @@ func (o *oVirtClient) StartUpdateDisk(id DiskID, params UpdateDiskParameters, re
 	if provisionedSize := params.ProvisionedSize(); provisionedSize != nil {
 		sdkDisk.ProvisionedSize(int64(*provisionedSize))
 	}
+	if diskProfileID := params.DiskProfileID(); diskProfileID != nil {
+		sdkDisk.DiskProfile(ovirtsdk.NewDiskProfileBuilder().Id(string(*diskProfileID)).MustBuild())
+	}
 	correlationID := fmt.Sprintf("disk_update_%s", generateRandomID(5, o.nonSecureRandom))
 
 	var disk Disk
@@ -39,6 +42,7 @@ func (o *oVirtClient) StartUpdateDisk(id DiskID, params UpdateDiskParameters, re
 	err := retry(
 		fmt.Sprintf("updating disk %s", id),
 		o.logger,
+		o.requestHooks("StartUpdateDisk"),
 		retries,
 		func() error {
 			response, err := o.conn.
@@ -97,6 +101,11 @@ func (m *mockClient) StartUpdateDisk(id DiskID, params UpdateDiskParameters, _ .
 	if !ok {
 		return nil, newError(ENotFound, "disk with ID %s not found", id)
 	}
+	if diskProfileID := params.DiskProfileID(); diskProfileID != nil {
+		if _, err := m.diskProfileFor(disk.storageDomainIDs, diskProfileID); err != nil {
+			return nil, err
+		}
+	}
 	if err := disk.Lock(); err != nil {
 		return nil, err
 	}
@@ -109,6 +118,9 @@ func (m *mockClient) StartUpdateDisk(id DiskID, params UpdateDiskParameters, _ .
 			return nil, err
 		}
 	}
+	if diskProfileID := params.DiskProfileID(); diskProfileID != nil {
+		disk = disk.withDiskProfileID(diskProfileID)
+	}
 	update := &mockDiskUpdate{
 		client: m,
 		disk:   disk,
@@ -6,9 +6,9 @@ package ovirtclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"sync"
 
 	ovirtsdk4 "github.com/ovirt/go-ovirt"
@@ -54,6 +54,35 @@ func (o *oVirtClient) UploadToNewDisk(
 	return progress, nil
 }
 
+func (o *oVirtClient) UploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadParameters,
+	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartUploadToNewDiskWithParams(
+		storageDomainID,
+		format,
+		size,
+		params,
+		reader,
+		uploadParams,
+		retries...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
 // Deprecated: use StartUploadToNewDisk instead.
 func (o *oVirtClient) StartImageUpload(
 	alias string,
@@ -89,20 +118,49 @@ func (o *oVirtClient) UploadToDisk(
 	return progress.Err()
 }
 
+func (o *oVirtClient) UploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	params UploadParameters,
+	retries ...RetryStrategy,
+) error {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	progress, err := o.StartUploadToDiskWithParams(diskID, size, reader, params, retries...)
+	if err != nil {
+		return err
+	}
+	<-progress.Done()
+	return progress.Err()
+}
+
 func (o *oVirtClient) StartUploadToDisk(
 	diskID DiskID,
 	size uint64,
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return o.StartUploadToDiskWithParams(diskID, size, reader, UploadParams(), retries...)
+}
+
+func (o *oVirtClient) StartUploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	params UploadParameters,
+	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
 	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if err := validateUploadParams(params, size); err != nil {
+		return nil, err
+	}
 	o.logger.Infof("Starting disk image upload...")
 	disk, err := o.GetDisk(diskID, retries...)
 	if err != nil {
 		return nil, err
 	}
 
-	format, qcowSize, err := extractQCOWParameters(size, reader)
+	format, qcowSize, err := extractImageParameters(size, reader)
 	if err != nil {
 		return nil, err
 	}
@@ -130,26 +188,66 @@ func (o *oVirtClient) StartUploadToDisk(
 		qcowSize:      qcowSize,
 		reader:        reader,
 		retries:       retries,
+		params:        params,
+		throttle:      newTransferThrottle(o.transferLimiter, params.BandwidthLimit()),
 	}
 	go progress.Do()
 	return progress, nil
 }
 
+// validateUploadParams checks if the upload parameters are usable for an image of the specified size.
+func validateUploadParams(params UploadParameters, size uint64) error {
+	if params == nil {
+		return newError(EBadArgument, "the upload parameters must not be nil, use UploadParams() to create them")
+	}
+	if params.ChunkSize() == 0 || params.Parallelism() == 0 {
+		return newError(EBadArgument, "the upload chunk size and parallelism must not be 0")
+	}
+	if params.ResumeOffset() > size {
+		return newError(
+			EBadArgument,
+			"the resume offset (%d bytes) is larger than the image (%d bytes)",
+			params.ResumeOffset(),
+			size,
+		)
+	}
+	return nil
+}
+
+// validateUploadToNewDiskParams checks if the upload parameters are usable for an upload to a new disk. The created
+// disk is removed if the upload fails, so the upload cannot be resumed.
+func validateUploadToNewDiskParams(params UploadParameters, size uint64) error {
+	if err := validateUploadParams(params, size); err != nil {
+		return err
+	}
+	if params.KeepTransferOnFailure() || params.ResumeTransferID() != "" {
+		return newError(
+			EBadArgument,
+			"uploads to a new disk cannot be resumed as the disk is removed on failure, upload to an existing disk "+
+				"instead",
+		)
+	}
+	return nil
+}
+
 type uploadToDiskProgress struct {
-	client           *oVirtClient
-	lock             *sync.Mutex
-	done             chan struct{}
-	ctx              context.Context
-	cancel           func()
-	disk             Disk
-	correlationID    string
-	reader           io.ReadSeekCloser
-	retries          []RetryStrategy
-	transferredBytes uint64
-	totalBytes       uint64
-	err              error
-	format           ImageFormat
-	qcowSize         uint64
+	client        *oVirtClient
+	lock          *sync.Mutex
+	done          chan struct{}
+	ctx           context.Context
+	cancel        func()
+	disk          Disk
+	correlationID string
+	reader        io.ReadSeekCloser
+	retries       []RetryStrategy
+	params        UploadParameters
+	throttle      *transferThrottle
+	upload        *chunkedUpload
+	transferID    ImageTransferID
+	totalBytes    uint64
+	err           error
+	format        ImageFormat
+	qcowSize      uint64
 }
 
 func (u *uploadToDiskProgress) Close() error {
@@ -188,73 +286,49 @@ func (u *uploadToDiskProgress) transfer() error {
 	)
 	transferURL := ""
 	var err error
-	if transferURL, err = transfer.initialize(); err != nil {
+	if transferID := u.params.ResumeTransferID(); transferID != "" {
+		if transferURL, err = transfer.resume(transferID); err != nil {
+			return u.finish(transfer, err)
+		}
+	} else if transferURL, err = transfer.initialize(); err != nil {
+		// initialize has already aborted the transfer, there is nothing left to keep.
 		return transfer.finalize(err)
 	}
+	u.lock.Lock()
+	u.transferID = transfer.id()
+	u.lock.Unlock()
 	err = u.transferImage(transfer, transferURL)
+	return u.finish(transfer, err)
+}
+
+// finish finalizes the image transfer, or pauses it on failure if the caller asked to keep it for resuming.
+func (u *uploadToDiskProgress) finish(transfer imageTransfer, err error) error {
+	if err != nil && u.params.KeepTransferOnFailure() {
+		return transfer.pause(err)
+	}
 	return transfer.finalize(err)
 }
 
-// transferImage does an HTTP request to transfer the image to the specified transfer URL.
+// transferImage uploads the image to the specified transfer URL in chunks over parallel HTTP requests. Each chunk
+// is retried on its own.
 func (u *uploadToDiskProgress) transferImage(transfer imageTransfer, transferURL string) error {
-	return retry(
-		fmt.Sprintf(
-			"transferring image for disk %s via HTTP request to %s",
-			u.disk.ID(),
-			transferURL,
-		),
-		u.client.logger,
+	upload := newChunkedUpload(
+		u.client,
+		transferURL,
+		u.reader,
+		u.totalBytes,
+		u.params.ResumeOffset(),
+		u.params.ChunkSize(),
+		u.params.Parallelism(),
+		transfer.supports("zero"),
 		u.retries,
-		func() error {
-			return u.putRequest(transferURL, transfer)
-		},
+		u.throttle,
+		transfer.checkStatusCode,
 	)
-}
-
-// putRequest performs a single HTTP put request to upload an image. This can be called multiple times to retry an
-// upload.
-func (u *uploadToDiskProgress) putRequest(transferURL string, transfer imageTransfer) error {
-	// We ensure that the reader is at the first byte before attempting a PUT request, otherwise we may upload an
-	// incomplete image.
-	if _, err := u.reader.Seek(0, io.SeekStart); err != nil {
-		return wrap(
-			err,
-			ELocalIO,
-			"could not seek to the first byte of the disk image before upload",
-		)
-	}
-
 	u.lock.Lock()
-	u.transferredBytes = 0
+	u.upload = upload
 	u.lock.Unlock()
-
-	putRequest, err := http.NewRequest(http.MethodPut, transferURL, u)
-	if err != nil {
-		return wrap(err, EUnidentified, "failed to create HTTP request")
-	}
-	putRequest.Header.Add("content-type", "application/octet-stream")
-	putRequest.ContentLength = int64(u.totalBytes)
-	putRequest.Body = u
-	response, err := u.client.httpClient.Do(putRequest)
-	if err != nil {
-		return wrap(
-			err,
-			EUnidentified,
-			"failed to upload image",
-		)
-	}
-	if err := transfer.checkStatusCode(response.StatusCode); err != nil {
-		_ = response.Body.Close()
-		return err
-	}
-	if err := response.Body.Close(); err != nil {
-		return wrap(
-			err,
-			EUnidentified,
-			"failed to close response body while uploading image",
-		)
-	}
-	return nil
+	return upload.run(u.ctx)
 }
 
 func (u *uploadToDiskProgress) Disk() Disk {
@@ -264,15 +338,43 @@ func (u *uploadToDiskProgress) Disk() Disk {
 }
 
 func (u *uploadToDiskProgress) UploadedBytes() uint64 {
+	u.lock.Lock()
+	upload := u.upload
+	u.lock.Unlock()
+	if upload == nil {
+		return u.params.ResumeOffset()
+	}
+	return upload.UploadedBytes()
+}
+
+func (u *uploadToDiskProgress) ConfirmedOffset() uint64 {
+	u.lock.Lock()
+	upload := u.upload
+	u.lock.Unlock()
+	if upload == nil {
+		return u.params.ResumeOffset()
+	}
+	return upload.ConfirmedOffset()
+}
+
+func (u *uploadToDiskProgress) TransferID() ImageTransferID {
 	u.lock.Lock()
 	defer u.lock.Unlock()
-	return u.transferredBytes
+	return u.transferID
 }
 
 func (u *uploadToDiskProgress) TotalBytes() uint64 {
 	return u.totalBytes
 }
 
+func (u *uploadToDiskProgress) BandwidthLimit() uint64 {
+	return u.throttle.Limit()
+}
+
+func (u *uploadToDiskProgress) SetBandwidthLimit(bytesPerSecond uint64) {
+	u.throttle.SetLimit(bytesPerSecond)
+}
+
 func (u *uploadToDiskProgress) Err() error {
 	u.lock.Lock()
 	defer u.lock.Unlock()
@@ -283,33 +385,38 @@ func (u *uploadToDiskProgress) Done() <-chan struct{} {
 	return u.done
 }
 
-func (u *uploadToDiskProgress) Read(p []byte) (n int, err error) {
-	select {
-	case <-u.ctx.Done():
-		return 0, newError(ETimeout, "timeout while uploading image")
-	default:
-	}
-	n, err = u.reader.Read(p)
-	u.transferredBytes += uint64(n)
-	return
+func (o *oVirtClient) StartUploadToNewDisk(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return o.StartUploadToNewDiskWithParams(storageDomainID, format, size, params, reader, UploadParams(), retries...)
 }
 
-func (o *oVirtClient) StartUploadToNewDisk(
+func (o *oVirtClient) StartUploadToNewDiskWithParams(
 	storageDomainID StorageDomainID,
 	format ImageFormat,
 	size uint64,
 	params CreateDiskOptionalParameters,
 	reader io.ReadSeekCloser,
+	uploadParams UploadParameters,
 	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
 	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	if err := validateUploadToNewDiskParams(uploadParams, size); err != nil {
+		return nil, err
+	}
 
 	o.logger.Infof("Starting disk image upload...")
 
-	imageFormat, qcowSize, err := extractQCOWParameters(size, reader)
+	imageInfo, err := inspectImage(reader, size)
 	if err != nil {
 		return nil, err
 	}
+	imageFormat, qcowSize := imageInfo.Format(), imageInfo.VirtualSize()
 
 	if format == "" {
 		format = imageFormat
@@ -317,15 +424,13 @@ func (o *oVirtClient) StartUploadToNewDisk(
 		return nil, err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-
-	diskCreateParams := CreateDiskParams().
-		MustWithAlias(params.Alias()).
-		MustWithInitialSize(size)
-	if params.Sparse() != nil {
-		diskCreateParams.MustWithSparse(*params.Sparse())
+	diskCreateParams, err := uploadDiskParams(params, size, imageInfo)
+	if err != nil {
+		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	progress := &uploadToNewDiskProgress{
 		uploadToDiskProgress: uploadToDiskProgress{
 			client:        o,
@@ -340,6 +445,8 @@ func (o *oVirtClient) StartUploadToNewDisk(
 			qcowSize:      qcowSize,
 			reader:        reader,
 			retries:       retries,
+			params:        uploadParams,
+			throttle:      newTransferThrottle(o.transferLimiter, uploadParams.BandwidthLimit()),
 		},
 
 		storageDomainID: storageDomainID,
@@ -351,6 +458,40 @@ func (o *oVirtClient) StartUploadToNewDisk(
 	return progress, nil
 }
 
+// uploadDiskParams creates the parameters for the disk created by an upload to a new disk. ISO images are uploaded to
+// disks with the ISO content type, unless the caller set a content type.
+func uploadDiskParams(
+	params CreateDiskOptionalParameters,
+	size uint64,
+	imageInfo ImageInfo,
+) (BuildableCreateDiskParameters, error) {
+	diskCreateParams := CreateDiskParams().MustWithInitialSize(size)
+	var contentType *DiskContentType
+	if params != nil {
+		diskCreateParams.MustWithAlias(params.Alias())
+		if params.Sparse() != nil {
+			diskCreateParams.MustWithSparse(*params.Sparse())
+		}
+		contentType = params.ContentType()
+		if diskProfileID := params.DiskProfileID(); diskProfileID != nil {
+			diskCreateParams.MustWithDiskProfileID(*diskProfileID)
+		}
+		if shareable := params.Shareable(); shareable != nil {
+			diskCreateParams.MustWithShareable(*shareable)
+		}
+	}
+	if contentType == nil && imageInfo.FileFormat() == ImageFileFormatISO {
+		iso := DiskContentTypeISO
+		contentType = &iso
+	}
+	if contentType != nil {
+		if _, err := diskCreateParams.WithContentType(*contentType); err != nil {
+			return nil, err
+		}
+	}
+	return diskCreateParams, nil
+}
+
 type uploadToNewDiskProgress struct {
 	uploadToDiskProgress
 
@@ -445,12 +586,26 @@ func (m *mockClient) StartUploadToDisk(
 	reader io.ReadSeekCloser,
 	retries ...RetryStrategy,
 ) (UploadImageProgress, error) {
+	return m.StartUploadToDiskWithParams(diskID, size, reader, UploadParams(), retries...)
+}
+
+func (m *mockClient) StartUploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	params UploadParameters,
+	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	if err := validateUploadParams(params, size); err != nil {
+		return nil, err
+	}
+
 	disk, err := m.getDisk(diskID, retries...)
 	if err != nil {
 		return nil, err
 	}
 
-	imageFormat, qcowSize, err := extractQCOWParameters(size, reader)
+	imageFormat, qcowSize, err := extractImageParameters(size, reader)
 	if err != nil {
 		return nil, err
 	}
@@ -475,13 +630,32 @@ func (m *mockClient) StartUploadToDisk(
 		)
 	}
 
+	offset := params.ResumeOffset()
+	if params.ResumeTransferID() == "" {
+		offset = 0
+	} else if offset > uint64(len(disk.data)) {
+		return nil, newError(
+			EBadArgument,
+			"cannot resume upload to disk %s at offset %d, only %d bytes have been uploaded",
+			diskID,
+			offset,
+			len(disk.data),
+		)
+	}
+
 	progress := &mockImageUploadProgress{
-		err:    nil,
-		disk:   disk,
-		client: m,
-		reader: reader,
-		size:   size,
-		done:   make(chan struct{}),
+		err:             nil,
+		lock:            &sync.Mutex{},
+		disk:            disk,
+		client:          m,
+		reader:          reader,
+		size:            size,
+		offset:          offset,
+		transferID:      ImageTransferID(m.GenerateUUID()),
+		uploadedBytes:   offset,
+		confirmedOffset: offset,
+		throttle:        newTransferThrottle(m.transferLimiter, params.BandwidthLimit()),
+		done:            make(chan struct{}),
 	}
 
 	// Lock the disk to simulate the upload being initialized.
@@ -503,14 +677,45 @@ func (m *mockClient) UploadToDisk(diskID DiskID, size uint64, reader io.ReadSeek
 	return progress.Err()
 }
 
+func (m *mockClient) UploadToDiskWithParams(
+	diskID DiskID,
+	size uint64,
+	reader io.ReadSeekCloser,
+	params UploadParameters,
+	retries ...RetryStrategy,
+) error {
+	progress, err := m.StartUploadToDiskWithParams(diskID, size, reader, params, retries...)
+	if err != nil {
+		return err
+	}
+	<-progress.Done()
+	return progress.Err()
+}
+
 func (m *mockClient) StartUploadToNewDisk(
 	storageDomainID StorageDomainID,
 	format ImageFormat,
 	size uint64,
 	params CreateDiskOptionalParameters,
 	reader io.ReadSeekCloser,
+	retries ...RetryStrategy,
+) (UploadImageProgress, error) {
+	return m.StartUploadToNewDiskWithParams(storageDomainID, format, size, params, reader, UploadParams(), retries...)
+}
+
+func (m *mockClient) StartUploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadParameters,
 	_ ...RetryStrategy,
 ) (UploadImageProgress, error) {
+	if err := validateUploadToNewDiskParams(uploadParams, size); err != nil {
+		return nil, err
+	}
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -518,10 +723,11 @@ func (m *mockClient) StartUploadToNewDisk(
 		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
 	}
 
-	imageFormat, qcowSize, err := extractQCOWParameters(size, reader)
+	imageInfo, err := inspectImage(reader, size)
 	if err != nil {
 		return nil, err
 	}
+	imageFormat, qcowSize := imageInfo.Format(), imageInfo.VirtualSize()
 
 	if imageFormat != format {
 		return nil, newError(
@@ -537,7 +743,11 @@ func (m *mockClient) StartUploadToNewDisk(
 		qcowSize = 1024 * 1024
 	}
 
-	disk, err := m.createDisk(storageDomainID, format, qcowSize, params)
+	diskParams, err := uploadDiskParams(params, size, imageInfo)
+	if err != nil {
+		return nil, err
+	}
+	disk, err := m.createDisk(storageDomainID, format, qcowSize, diskParams)
 	if err != nil {
 		return nil, err
 	}
@@ -545,12 +755,15 @@ func (m *mockClient) StartUploadToNewDisk(
 	disk.Unlock()
 
 	progress := &mockImageUploadProgress{
-		err:    nil,
-		disk:   disk,
-		client: m,
-		reader: reader,
-		size:   size,
-		done:   make(chan struct{}),
+		err:        nil,
+		lock:       &sync.Mutex{},
+		disk:       disk,
+		client:     m,
+		reader:     reader,
+		size:       size,
+		transferID: ImageTransferID(m.GenerateUUID()),
+		throttle:   newTransferThrottle(m.transferLimiter, uploadParams.BandwidthLimit()),
+		done:       make(chan struct{}),
 	}
 
 	// Lock the disk to simulate the upload being initialized.
@@ -583,13 +796,18 @@ func (m *mockClient) UploadToNewDisk(
 }
 
 type mockImageUploadProgress struct {
-	err           error
-	disk          *diskWithData
-	client        *mockClient
-	reader        io.ReadSeekCloser
-	size          uint64
-	uploadedBytes uint64
-	done          chan struct{}
+	err             error
+	lock            *sync.Mutex
+	disk            *diskWithData
+	client          *mockClient
+	reader          io.ReadSeekCloser
+	size            uint64
+	offset          uint64
+	transferID      ImageTransferID
+	uploadedBytes   uint64
+	confirmedOffset uint64
+	throttle        *transferThrottle
+	done            chan struct{}
 }
 
 func (m *mockImageUploadProgress) Disk() Disk {
@@ -601,14 +819,36 @@ func (m *mockImageUploadProgress) Disk() Disk {
 }
 
 func (m *mockImageUploadProgress) UploadedBytes() uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return m.uploadedBytes
 }
 
+func (m *mockImageUploadProgress) ConfirmedOffset() uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.confirmedOffset
+}
+
+func (m *mockImageUploadProgress) TransferID() ImageTransferID {
+	return m.transferID
+}
+
 func (m *mockImageUploadProgress) TotalBytes() uint64 {
 	return m.size
 }
 
+func (m *mockImageUploadProgress) BandwidthLimit() uint64 {
+	return m.throttle.Limit()
+}
+
+func (m *mockImageUploadProgress) SetBandwidthLimit(bytesPerSecond uint64) {
+	m.throttle.SetLimit(bytesPerSecond)
+}
+
 func (m *mockImageUploadProgress) Err() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return m.err
 }
 
@@ -622,14 +862,70 @@ func (m *mockImageUploadProgress) do() {
 		close(m.done)
 	}()
 
-	var err error
-	if _, err = m.reader.Seek(0, io.SeekStart); err != nil {
-		m.err = fmt.Errorf("failed to seek to start of image file (%w)", err)
-		return
-	}
-	m.disk.data, err = io.ReadAll(m.reader)
+	err := m.upload()
+	m.lock.Lock()
 	m.err = err
-	if err != nil {
+	if err == nil {
 		m.uploadedBytes = m.size
+		m.confirmedOffset = m.size
+	}
+	m.lock.Unlock()
+}
+
+// upload reads the image through the bandwidth limit, updating the progress as it goes, and stores it on the disk
+// once it has been read completely.
+func (m *mockImageUploadProgress) upload() error {
+	if _, err := m.reader.Seek(int64(m.offset), io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek to byte %d of image file (%w)", m.offset, err)
+	}
+	ctx := m.client.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	reader := m.throttle.reader(ctx, m.reader)
+	var remaining []byte
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		remaining = append(remaining, buf[:n]...)
+		m.lock.Lock()
+		m.uploadedBytes += uint64(n)
+		m.lock.Unlock()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
 	}
+	m.disk.replaceData(m.offset, remaining)
+	return nil
+}
+
+func (m *mockClient) UploadToNewDiskWithParams(
+	storageDomainID StorageDomainID,
+	format ImageFormat,
+	size uint64,
+	params CreateDiskOptionalParameters,
+	reader io.ReadSeekCloser,
+	uploadParams UploadParameters,
+	retries ...RetryStrategy,
+) (UploadImageResult, error) {
+	progress, err := m.StartUploadToNewDiskWithParams(
+		storageDomainID,
+		format,
+		size,
+		params,
+		reader,
+		uploadParams,
+		retries...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
 }
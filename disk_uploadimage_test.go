@@ -97,3 +97,64 @@ func TestImageUploadToExistingDisk(t *testing.T) {
 
 	assertCanUploadDiskImage(t, helper, disk)
 }
+
+func TestImageUploadToExistingDiskWithParams(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	imageName := fmt.Sprintf("client_test_%s", helper.GenerateRandomID(5))
+
+	disk, err := client.CreateDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatRaw,
+		uint64(1048576),
+		ovirtclient.CreateDiskParams().MustWithSparse(true).MustWithAlias(imageName),
+	)
+	if disk != nil {
+		defer func() {
+			_ = disk.Remove()
+		}()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fh, size := getTestImageFile(t)
+	progress, err := client.StartUploadToDiskWithParams(
+		disk.ID(),
+		size,
+		fh,
+		ovirtclient.UploadParams().MustWithChunkSize(1024*1024).MustWithParallelism(2),
+	)
+	if err != nil {
+		t.Fatalf("Failed to start disk image upload to disk %s. (%v)", disk.ID(), err)
+	}
+	<-progress.Done()
+	if err := progress.Err(); err != nil {
+		t.Fatalf("Failed to upload disk image to disk %s. (%v)", disk.ID(), err)
+	}
+	if progress.UploadedBytes() != size {
+		t.Fatalf("Incorrect number of uploaded bytes: %d instead of %d", progress.UploadedBytes(), size)
+	}
+	if progress.ConfirmedOffset() != size {
+		t.Fatalf("Incorrect confirmed offset: %d instead of %d", progress.ConfirmedOffset(), size)
+	}
+	if progress.TransferID() == "" {
+		t.Fatalf("No image transfer ID returned.")
+	}
+}
+
+func TestUploadParamsValidation(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ovirtclient.UploadParams().WithChunkSize(1024); err == nil {
+		t.Fatalf("Setting a chunk size below 1 MiB did not result in an error.")
+	}
+	if _, err := ovirtclient.UploadParams().WithParallelism(0); err == nil {
+		t.Fatalf("Setting a parallelism of 0 did not result in an error.")
+	}
+	if _, err := ovirtclient.UploadParams().WithResume("", 0); err == nil {
+		t.Fatalf("Resuming without an image transfer ID did not result in an error.")
+	}
+}
@@ -12,6 +12,7 @@ func (o *oVirtClient) WaitForDiskOK(diskID DiskID, retries ...RetryStrategy) (di
 	err = retry(
 		fmt.Sprintf("waiting for disk %s to become OK", diskID),
 		o.logger,
+		o.waitHooks("WaitForDiskOK"),
 		retries,
 		func() error {
 			disk, err = o.checkDiskOK(diskID)
@@ -0,0 +1,199 @@
+package ovirtclient
+
+import (
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// DiskProfileID is the identifier of a disk profile.
+type DiskProfileID string
+
+// DiskProfileClient contains the functions to manage disk profiles. Disk profiles belong to a storage domain and
+// apply the limits of a storage QoS entry to the disks using them. The engine creates a default disk profile without
+// limits for each storage domain.
+type DiskProfileClient interface {
+	// CreateDiskProfile creates a new disk profile on the specified storage domain.
+	CreateDiskProfile(
+		storageDomainID StorageDomainID,
+		name string,
+		params OptionalDiskProfileParameters,
+		retries ...RetryStrategy,
+	) (DiskProfile, error)
+	// GetDiskProfile returns a single disk profile by its ID.
+	GetDiskProfile(id DiskProfileID, retries ...RetryStrategy) (DiskProfile, error)
+	// ListDiskProfiles lists all disk profiles.
+	ListDiskProfiles(retries ...RetryStrategy) ([]DiskProfile, error)
+	// ListStorageDomainDiskProfiles lists the disk profiles of a storage domain.
+	ListStorageDomainDiskProfiles(storageDomainID StorageDomainID, retries ...RetryStrategy) ([]DiskProfile, error)
+	// RemoveDiskProfile removes a disk profile. Disk profiles used by disks cannot be removed.
+	RemoveDiskProfile(id DiskProfileID, retries ...RetryStrategy) error
+}
+
+// OptionalDiskProfileParameters contains the optional parameters for creating a disk profile.
+type OptionalDiskProfileParameters interface {
+	// Description returns the description of the disk profile.
+	Description() string
+	// QoSID returns the storage QoS entry to apply to the disks using the profile. If it returns nil, the disks are not
+	// limited.
+	QoSID() *StorageQoSID
+}
+
+// BuildableDiskProfileParameters is a buildable version of OptionalDiskProfileParameters.
+type BuildableDiskProfileParameters interface {
+	OptionalDiskProfileParameters
+
+	// WithDescription sets the description of the disk profile.
+	WithDescription(description string) (BuildableDiskProfileParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableDiskProfileParameters
+
+	// WithQoSID sets the storage QoS entry to apply to the disks using the profile. The QoS entry must belong to the
+	// datacenter of the storage domain.
+	WithQoSID(qosID StorageQoSID) (BuildableDiskProfileParameters, error)
+	// MustWithQoSID is identical to WithQoSID, but panics instead of returning an error.
+	MustWithQoSID(qosID StorageQoSID) BuildableDiskProfileParameters
+}
+
+// CreateDiskProfileParams creates a buildable set of optional parameters for disk profile creation.
+func CreateDiskProfileParams() BuildableDiskProfileParameters {
+	return &diskProfileParams{}
+}
+
+type diskProfileParams struct {
+	description string
+	qosID       *StorageQoSID
+}
+
+func (d *diskProfileParams) Description() string {
+	return d.description
+}
+
+func (d *diskProfileParams) WithDescription(description string) (BuildableDiskProfileParameters, error) {
+	d.description = description
+	return d, nil
+}
+
+func (d *diskProfileParams) MustWithDescription(description string) BuildableDiskProfileParameters {
+	builder, err := d.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *diskProfileParams) QoSID() *StorageQoSID {
+	return d.qosID
+}
+
+func (d *diskProfileParams) WithQoSID(qosID StorageQoSID) (BuildableDiskProfileParameters, error) {
+	if qosID == "" {
+		return nil, newError(EBadArgument, "the storage QoS ID cannot be empty")
+	}
+	d.qosID = &qosID
+	return d, nil
+}
+
+func (d *diskProfileParams) MustWithQoSID(qosID StorageQoSID) BuildableDiskProfileParameters {
+	builder, err := d.WithQoSID(qosID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// DiskProfileData is the core of DiskProfile, providing only data access functions.
+type DiskProfileData interface {
+	// ID returns the identifier of the disk profile.
+	ID() DiskProfileID
+	// Name returns the name of the disk profile.
+	Name() string
+	// Description returns the description of the disk profile.
+	Description() string
+	// StorageDomainID returns the ID of the storage domain the disk profile belongs to.
+	StorageDomainID() StorageDomainID
+	// QoSID returns the ID of the storage QoS entry applied to the disks using the profile, or nil if the disks are
+	// not limited.
+	QoSID() *StorageQoSID
+}
+
+// DiskProfile determines the storage QoS limits applied to disks on a storage domain.
+type DiskProfile interface {
+	DiskProfileData
+
+	// StorageDomain fetches the storage domain the disk profile belongs to. This is a network call and may be slow.
+	StorageDomain(retries ...RetryStrategy) (StorageDomain, error)
+	// Remove removes the disk profile.
+	Remove(retries ...RetryStrategy) error
+}
+
+func convertSDKDiskProfile(sdkObject *ovirtsdk.DiskProfile, client Client) (DiskProfile, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("disk profile", "ID")
+	}
+	name, ok := sdkObject.Name()
+	if !ok {
+		return nil, newFieldNotFound("disk profile", "name")
+	}
+	description, _ := sdkObject.Description()
+	sdkStorageDomain, ok := sdkObject.StorageDomain()
+	if !ok {
+		return nil, newFieldNotFound("disk profile", "storage domain")
+	}
+	storageDomainID, ok := sdkStorageDomain.Id()
+	if !ok {
+		return nil, newFieldNotFound("storage domain on disk profile", "ID")
+	}
+	var qosID *StorageQoSID
+	if sdkQoS, ok := sdkObject.Qos(); ok {
+		if id, ok := sdkQoS.Id(); ok {
+			storageQoSID := StorageQoSID(id)
+			qosID = &storageQoSID
+		}
+	}
+	return &diskProfile{
+		client:          client,
+		id:              DiskProfileID(id),
+		name:            name,
+		description:     description,
+		storageDomainID: StorageDomainID(storageDomainID),
+		qosID:           qosID,
+	}, nil
+}
+
+type diskProfile struct {
+	client Client
+
+	id              DiskProfileID
+	name            string
+	description     string
+	storageDomainID StorageDomainID
+	qosID           *StorageQoSID
+}
+
+func (d *diskProfile) ID() DiskProfileID {
+	return d.id
+}
+
+func (d *diskProfile) Name() string {
+	return d.name
+}
+
+func (d *diskProfile) Description() string {
+	return d.description
+}
+
+func (d *diskProfile) StorageDomainID() StorageDomainID {
+	return d.storageDomainID
+}
+
+func (d *diskProfile) QoSID() *StorageQoSID {
+	return d.qosID
+}
+
+func (d *diskProfile) StorageDomain(retries ...RetryStrategy) (StorageDomain, error) {
+	return d.client.GetStorageDomain(d.storageDomainID, retries...)
+}
+
+func (d *diskProfile) Remove(retries ...RetryStrategy) error {
+	return d.client.RemoveDiskProfile(d.id, retries...)
+}
@@ -0,0 +1,123 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateDiskProfile(
+	storageDomainID StorageDomainID,
+	name string,
+	params OptionalDiskProfileParameters,
+	retries ...RetryStrategy,
+) (result DiskProfile, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = CreateDiskProfileParams()
+	}
+	if err := validateDiskProfileCreationParameters(storageDomainID, name); err != nil {
+		return nil, err
+	}
+	builder := ovirtsdk.NewDiskProfileBuilder().
+		Name(name).
+		StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild())
+	if description := params.Description(); description != "" {
+		builder.Description(description)
+	}
+	if qosID := params.QoSID(); qosID != nil {
+		builder.Qos(ovirtsdk.NewQosBuilder().Id(string(*qosID)).MustBuild())
+	}
+	sdkProfile, err := builder.Build()
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build disk profile object")
+	}
+	err = retry(
+		fmt.Sprintf("creating disk profile %s on storage domain %s", name, storageDomainID),
+		o.logger,
+		o.requestHooks("CreateDiskProfile"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().DiskProfilesService().Add().Profile(sdkProfile).Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Profile()
+			if !ok {
+				return newFieldNotFound("response from disk profile creation", "profile")
+			}
+			result, e = convertSDKDiskProfile(sdkObject, o)
+			return e
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) CreateDiskProfile(
+	storageDomainID StorageDomainID,
+	name string,
+	params OptionalDiskProfileParameters,
+	_ ...RetryStrategy,
+) (DiskProfile, error) {
+	if params == nil {
+		params = CreateDiskProfileParams()
+	}
+	if err := validateDiskProfileCreationParameters(storageDomainID, name); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[storageDomainID]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if qosID := params.QoSID(); qosID != nil {
+		if _, ok := m.storageQoS[*qosID]; !ok {
+			return nil, newError(ENotFound, "storage QoS with ID %s not found", *qosID)
+		}
+	}
+	for _, profile := range m.diskProfiles {
+		if profile.storageDomainID == storageDomainID && profile.name == name {
+			return nil, newError(
+				EConflict,
+				"disk profile with the name %s already exists on storage domain %s",
+				name,
+				storageDomainID,
+			)
+		}
+	}
+	profile := &diskProfile{
+		client:          m,
+		id:              DiskProfileID(m.GenerateUUID()),
+		name:            name,
+		description:     params.Description(),
+		storageDomainID: storageDomainID,
+		qosID:           params.QoSID(),
+	}
+	m.diskProfiles[profile.id] = profile
+	return profile, nil
+}
+
+func validateDiskProfileCreationParameters(storageDomainID StorageDomainID, name string) error {
+	if name == "" {
+		return newError(EBadArgument, "name cannot be empty for disk profile creation")
+	}
+	if storageDomainID == "" {
+		return newError(EBadArgument, "storage domain ID cannot be empty for disk profile creation")
+	}
+	return nil
+}
+
+// addDefaultDiskProfile creates the default disk profile of a storage domain. The engine names it after the storage
+// domain. The caller must hold the mock lock.
+func (m *mockClient) addDefaultDiskProfile(sd *storageDomain) {
+	profile := &diskProfile{
+		client:          m,
+		id:              DiskProfileID(m.GenerateUUID()),
+		name:            sd.name,
+		storageDomainID: sd.id,
+	}
+	m.diskProfiles[profile.id] = profile
+	m.defaultDiskProfiles[sd.id] = profile.id
+}
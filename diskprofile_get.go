@@ -0,0 +1,41 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetDiskProfile(id DiskProfileID, retries ...RetryStrategy) (result DiskProfile, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting disk profile %s", id),
+		o.logger,
+		o.requestHooks("GetDiskProfile"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().DiskProfilesService().DiskProfileService(string(id)).Get().Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Profile()
+			if !ok {
+				return newError(ENotFound, "no disk profile returned when getting disk profile ID %s", id)
+			}
+			result, e = convertSDKDiskProfile(sdkObject, o)
+			if e != nil {
+				return wrap(e, EBug, "failed to convert disk profile %s", id)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) GetDiskProfile(id DiskProfileID, _ ...RetryStrategy) (DiskProfile, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if profile, ok := m.diskProfiles[id]; ok {
+		return profile, nil
+	}
+	return nil, newError(ENotFound, "disk profile with ID %s not found", id)
+}
@@ -0,0 +1,106 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ListDiskProfiles(retries ...RetryStrategy) (result []DiskProfile, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []DiskProfile{}
+	err = retry(
+		"listing disk profiles",
+		o.logger,
+		o.requestHooks("ListDiskProfiles"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().DiskProfilesService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Profile()
+			if !ok {
+				return nil
+			}
+			result, e = convertSDKDiskProfiles(sdkObjects, o)
+			return e
+		},
+	)
+	return result, err
+}
+
+func (o *oVirtClient) ListStorageDomainDiskProfiles(
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (result []DiskProfile, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []DiskProfile{}
+	err = retry(
+		fmt.Sprintf("listing disk profiles of storage domain %s", storageDomainID),
+		o.logger,
+		o.requestHooks("ListStorageDomainDiskProfiles"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(storageDomainID)).
+				DiskProfilesService().
+				List().
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Profiles()
+			if !ok {
+				return nil
+			}
+			result, e = convertSDKDiskProfiles(sdkObjects, o)
+			return e
+		},
+	)
+	return result, err
+}
+
+func convertSDKDiskProfiles(sdkObjects *ovirtsdk.DiskProfileSlice, client Client) ([]DiskProfile, error) {
+	result := make([]DiskProfile, len(sdkObjects.Slice()))
+	for i, sdkObject := range sdkObjects.Slice() {
+		profile, err := convertSDKDiskProfile(sdkObject, client)
+		if err != nil {
+			return nil, wrap(err, EBug, "failed to convert disk profile during listing item #%d", i)
+		}
+		result[i] = profile
+	}
+	return result, nil
+}
+
+func (m *mockClient) ListDiskProfiles(_ ...RetryStrategy) ([]DiskProfile, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	result := make([]DiskProfile, 0, len(m.diskProfiles))
+	for _, profile := range m.diskProfiles {
+		result = append(result, profile)
+	}
+	return result, nil
+}
+
+func (m *mockClient) ListStorageDomainDiskProfiles(
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) ([]DiskProfile, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[storageDomainID]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	result := []DiskProfile{}
+	for _, profile := range m.diskProfiles {
+		if profile.storageDomainID == storageDomainID {
+			result = append(result, profile)
+		}
+	}
+	return result, nil
+}
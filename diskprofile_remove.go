@@ -0,0 +1,39 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveDiskProfile(id DiskProfileID, retries ...RetryStrategy) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("removing disk profile %s", id),
+		o.logger,
+		o.requestHooks("RemoveDiskProfile"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().DiskProfilesService().DiskProfileService(string(id)).Remove().Send()
+			return err
+		},
+	)
+}
+
+func (m *mockClient) RemoveDiskProfile(id DiskProfileID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	profile, ok := m.diskProfiles[id]
+	if !ok {
+		return newError(ENotFound, "disk profile with ID %s not found", id)
+	}
+	for _, disk := range m.disks {
+		if disk.diskProfileID != nil && *disk.diskProfileID == id {
+			return newError(EConflict, "disk profile %s is used by disk %s", id, disk.id)
+		}
+	}
+	if m.defaultDiskProfiles[profile.storageDomainID] == id {
+		delete(m.defaultDiskProfiles, profile.storageDomainID)
+	}
+	delete(m.diskProfiles, id)
+	return nil
+}
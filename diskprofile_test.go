@@ -0,0 +1,111 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestNewDiskUsesDefaultDiskProfile(t *testing.T) {
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	profiles, err := client.ListStorageDomainDiskProfiles(helper.GetStorageDomainID())
+	if err != nil {
+		t.Fatalf("Failed to list disk profiles of storage domain %s (%v)", helper.GetStorageDomainID(), err)
+	}
+	if len(profiles) == 0 {
+		t.Fatalf("Storage domain %s has no disk profiles.", helper.GetStorageDomainID())
+	}
+
+	disk := assertCanCreateDisk(t, helper)
+	if disk.DiskProfileID() == nil {
+		t.Fatalf("New disk %s has no disk profile.", disk.ID())
+	}
+	found := false
+	for _, profile := range profiles {
+		if profile.ID() == *disk.DiskProfileID() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("The disk profile %s of disk %s is not on the storage domain.", *disk.DiskProfileID(), disk.ID())
+	}
+}
+
+func TestDiskProfileWithQoS(t *testing.T) {
+	helper := getHelper(t)
+	client := helper.GetClient()
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+
+	qos := assertCanCreateStorageQoS(t, helper, datacenterID, ovirtclient.StorageQoSParams().MustWithMaxIOPS(500))
+	profile := assertCanCreateDiskProfile(
+		t,
+		helper,
+		helper.GetStorageDomainID(),
+		ovirtclient.CreateDiskProfileParams().MustWithQoSID(qos.ID()),
+	)
+	if profile.QoSID() == nil || *profile.QoSID() != qos.ID() {
+		t.Fatalf("Incorrect QoS on new disk profile %s.", profile.ID())
+	}
+	// The profiles are created before the disk so the disk is removed before them during cleanup.
+	unlimitedProfile := assertCanCreateDiskProfile(t, helper, helper.GetStorageDomainID(), nil)
+
+	disk := assertCanCreateDiskWithParameters(
+		t,
+		helper,
+		ovirtclient.ImageFormatRaw,
+		ovirtclient.CreateDiskParams().MustWithDiskProfileID(profile.ID()),
+	)
+	if disk.DiskProfileID() == nil || *disk.DiskProfileID() != profile.ID() {
+		t.Fatalf("Incorrect disk profile on new disk %s.", disk.ID())
+	}
+
+	if err := profile.Remove(); err == nil {
+		t.Fatalf("Removing a disk profile in use by a disk did not fail.")
+	}
+	disk, err := client.UpdateDisk(
+		disk.ID(),
+		ovirtclient.UpdateDiskParams().MustWithDiskProfileID(unlimitedProfile.ID()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to change the disk profile of disk %s (%v)", disk.ID(), err)
+	}
+	if disk.DiskProfileID() == nil || *disk.DiskProfileID() != unlimitedProfile.ID() {
+		t.Fatalf("Incorrect disk profile on disk %s after update.", disk.ID())
+	}
+}
+
+func TestDiskProfileMustBelongToStorageDomain(t *testing.T) {
+	helper := getHelper(t)
+	secondaryStorageDomainID := helper.GetSecondaryStorageDomainID(t)
+
+	profile := assertCanCreateDiskProfile(t, helper, secondaryStorageDomainID, nil)
+	_, err := helper.GetClient().CreateDisk(
+		helper.GetStorageDomainID(),
+		ovirtclient.ImageFormatRaw,
+		1048576,
+		ovirtclient.CreateDiskParams().MustWithDiskProfileID(profile.ID()),
+	)
+	if err == nil {
+		t.Fatalf("Creating a disk with a disk profile of another storage domain did not fail.")
+	}
+}
+
+func assertCanCreateDiskProfile(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	storageDomainID ovirtclient.StorageDomainID,
+	params ovirtclient.OptionalDiskProfileParameters,
+) ovirtclient.DiskProfile {
+	profile, err := helper.GetClient().CreateDiskProfile(storageDomainID, helper.GenerateTestResourceName(t), params)
+	if err != nil {
+		t.Fatalf("Failed to create disk profile on storage domain %s (%v)", storageDomainID, err)
+	}
+	t.Cleanup(func() {
+		if err := profile.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove disk profile %s (%v)", profile.ID(), err)
+		}
+	})
+	return profile
+}
@@ -97,6 +97,22 @@ const EInvalidGrant ErrorCode = "invalid_grant"
 // ECannotRunVM indicates an error with the VM configuration which prevents it from being run.
 const ECannotRunVM ErrorCode = "cannot_run_vm"
 
+// EVMNotMigratable indicates that the VM cannot be migrated due to its placement policy or the chosen target host.
+const EVMNotMigratable ErrorCode = "vm_not_migratable"
+
+// EJobFailed indicates that an engine job failed or was aborted.
+const EJobFailed ErrorCode = "job_failed"
+
+// EChecksumMismatch indicates that a downloaded image does not match the checksum reported by the image server or
+// passed by the caller.
+const EChecksumMismatch ErrorCode = "checksum_mismatch"
+
+// ENoSpace indicates that a storage domain does not have enough free space for the requested operation.
+const ENoSpace ErrorCode = "no_space"
+
+// EClientClosed indicates that the client, or the client it was derived from, has been closed.
+const EClientClosed ErrorCode = "client_closed"
+
 // CanRecover returns true if there is a way to automatically recoverFailure from this error. For the actual recovery an
 // appropriate recovery strategy must be passed to the retry function.
 func (e ErrorCode) CanRecover() bool {
@@ -137,6 +153,16 @@ func (e ErrorCode) CanAutoRetry() bool {
 		return false
 	case ECannotRunVM:
 		return false
+	case EVMNotMigratable:
+		return false
+	case EJobFailed:
+		return false
+	case EChecksumMismatch:
+		return false
+	case ENoSpace:
+		return false
+	case EClientClosed:
+		return false
 	default:
 		return true
 	}
@@ -311,10 +337,14 @@ func realIdentify(err error) EngineError {
 		return wrap(err, ENotFound, "the requested resource was not found")
 	case strings.Contains(err.Error(), "Disk is locked"):
 		return wrap(err, EDiskLocked, "the disk is locked")
+	case strings.Contains(err.Error(), "VM is non migratable"):
+		return wrap(err, EVMNotMigratable, "the VM is not migratable")
 	case strings.Contains(err.Error(), "VM is locked"):
 		return wrap(err, EVMLocked, "the VM is locked")
 	case strings.Contains(err.Error(), "Failed to hot-plug disk"):
 		return wrap(err, EHotPlugFailed, "failed to hot-plug disk")
+	case strings.Contains(err.Error(), "Low disk space on Storage Domain"):
+		return wrap(err, ENoSpace, "not enough free space on the storage domain")
 	case strings.Contains(err.Error(), "Related operation is currently in progress."):
 		return wrap(err, ERelatedOperationInProgress, "a related operation is in progress")
 	case strings.Contains(err.Error(), "Disk configuration") && strings.Contains(err.Error(), " is incompatible with the storage domain type."):
@@ -0,0 +1,635 @@
+package ovirtclient
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// EventID is the identifier for engine events.
+type EventID string
+
+// EventClient describes the functions related to the engine event log (audit log).
+type EventClient interface {
+	// ListEvents lists the events recorded by the engine, ordered by their index in ascending order. The params can
+	// be used to only return events after a certain index and to filter the events. Pass nil to list all events.
+	ListEvents(params ListEventsParameters, retries ...RetryStrategy) ([]Event, error)
+	// WatchEvents polls the engine for new events in the background and sends them to the returned channel in order.
+	// If no starting index is passed in the params only events recorded after the call are returned. Failed polls are
+	// logged and retried on the next poll. The channel is closed when the context is cancelled.
+	WatchEvents(ctx context.Context, params WatchEventsParameters) (<-chan Event, error)
+}
+
+// EventData is the core of Event, providing only data access functions.
+type EventData interface {
+	// ID returns the identifier of the event.
+	ID() EventID
+	// Index returns the sequence number of the event. Events with higher indexes were recorded later.
+	Index() int64
+	// Code returns the engine audit log code identifying the type of the event.
+	Code() EventCode
+	// Severity returns the severity of the event.
+	Severity() EventSeverity
+	// Description returns the human-readable description of the event.
+	Description() string
+	// Time returns the time the event was recorded.
+	Time() time.Time
+	// CorrelationID returns the correlation ID of the action that caused this event. May be empty.
+	CorrelationID() string
+	// VMID returns the ID of the VM this event relates to, or nil if it does not relate to a VM.
+	VMID() *VMID
+	// HostID returns the ID of the host this event relates to, or nil if it does not relate to a host.
+	HostID() *HostID
+	// DiskID returns the ID of the disk this event relates to, or nil if it does not relate to a disk. The engine API
+	// does not link disks to events, so this is only filled by the mock client.
+	DiskID() *DiskID
+	// TemplateID returns the ID of the template this event relates to, or nil if it does not relate to a template.
+	TemplateID() *TemplateID
+	// StorageDomainID returns the ID of the storage domain this event relates to, or nil if it does not relate to a
+	// storage domain.
+	StorageDomainID() *StorageDomainID
+	// ClusterID returns the ID of the cluster this event relates to, or nil if it does not relate to a cluster.
+	ClusterID() *ClusterID
+}
+
+// Event is an entry in the engine audit log. Events are recorded for user actions, such as starting a VM, as well as
+// for state changes detected by the engine.
+type Event interface {
+	EventData
+}
+
+// EventCode is the engine audit log code identifying the type of event. The engine defines several hundred codes,
+// only the most common ones have constants in this library.
+type EventCode int64
+
+const (
+	// EventCodeVMStarted is recorded when a VM has been started on a host.
+	EventCodeVMStarted EventCode = 32
+	// EventCodeVMStopped is recorded when a user has powered off a VM.
+	EventCodeVMStopped EventCode = 33
+	// EventCodeVMDown is recorded when a VM has stopped running.
+	EventCodeVMDown EventCode = 61
+	// EventCodeVMShutdownInitiated is recorded when a user has initiated a graceful shutdown of a VM.
+	EventCodeVMShutdownInitiated EventCode = 73
+	// EventCodeDiskRemoved is recorded when a disk has been removed.
+	EventCodeDiskRemoved EventCode = 2014
+	// EventCodeDiskAdded is recorded when a disk has been created successfully.
+	EventCodeDiskAdded EventCode = 2021
+)
+
+// EventSeverity is the severity of an engine event.
+type EventSeverity string
+
+const (
+	// EventSeverityNormal indicates an informational event.
+	EventSeverityNormal EventSeverity = "normal"
+	// EventSeverityWarning indicates an event that may need attention.
+	EventSeverityWarning EventSeverity = "warning"
+	// EventSeverityError indicates a failure.
+	EventSeverityError EventSeverity = "error"
+	// EventSeverityAlert indicates a failure that needs immediate attention.
+	EventSeverityAlert EventSeverity = "alert"
+)
+
+// EventSeverityList is a list of EventSeverity.
+type EventSeverityList []EventSeverity
+
+// EventSeverityValues returns all possible EventSeverity values in ascending order of severity.
+func EventSeverityValues() EventSeverityList {
+	return []EventSeverity{
+		EventSeverityNormal,
+		EventSeverityWarning,
+		EventSeverityError,
+		EventSeverityAlert,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l EventSeverityList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// Validate returns an error if the severity is not one of the known values.
+func (s EventSeverity) Validate() error {
+	for _, severity := range EventSeverityValues() {
+		if severity == s {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid event severity: %s, must be one of: %v",
+		s,
+		EventSeverityValues().Strings(),
+	)
+}
+
+// level returns the position of the severity in EventSeverityValues. Unknown severities are treated as the lowest.
+func (s EventSeverity) level() int {
+	for i, severity := range EventSeverityValues() {
+		if severity == s {
+			return i
+		}
+	}
+	return 0
+}
+
+// EventFilter contains the filters that can be applied to events. Filters are applied on the client side and are
+// combined with a logical AND.
+type EventFilter interface {
+	// VMID returns the VM ID to filter for. May be nil.
+	VMID() *VMID
+	// HostID returns the host ID to filter for. May be nil.
+	HostID() *HostID
+	// DiskID returns the disk ID to filter for. May be nil.
+	DiskID() *DiskID
+	// MinSeverity returns the lowest severity to return. May be nil.
+	MinSeverity() *EventSeverity
+}
+
+// ListEventsParameters are the optional parameters for listing events.
+type ListEventsParameters interface {
+	EventFilter
+
+	// FromIndex returns the index after which events should be returned. May be nil.
+	FromIndex() *int64
+	// Max returns the maximum number of events the engine should return. The engine returns the most recent events
+	// first, so when both FromIndex and Max are set older events may be skipped. May be nil.
+	Max() *int64
+}
+
+// BuildableListEventsParameters is a buildable version of ListEventsParameters.
+type BuildableListEventsParameters interface {
+	ListEventsParameters
+
+	// WithFromIndex sets the index after which events should be returned.
+	WithFromIndex(index int64) (BuildableListEventsParameters, error)
+	// MustWithFromIndex is identical to WithFromIndex, but panics instead of returning an error.
+	MustWithFromIndex(index int64) BuildableListEventsParameters
+	// WithMax sets the maximum number of events to return.
+	WithMax(max int64) (BuildableListEventsParameters, error)
+	// MustWithMax is identical to WithMax, but panics instead of returning an error.
+	MustWithMax(max int64) BuildableListEventsParameters
+	// WithVMID only returns events related to the specified VM.
+	WithVMID(vmID VMID) (BuildableListEventsParameters, error)
+	// MustWithVMID is identical to WithVMID, but panics instead of returning an error.
+	MustWithVMID(vmID VMID) BuildableListEventsParameters
+	// WithHostID only returns events related to the specified host.
+	WithHostID(hostID HostID) (BuildableListEventsParameters, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableListEventsParameters
+	// WithDiskID only returns events related to the specified disk.
+	WithDiskID(diskID DiskID) (BuildableListEventsParameters, error)
+	// MustWithDiskID is identical to WithDiskID, but panics instead of returning an error.
+	MustWithDiskID(diskID DiskID) BuildableListEventsParameters
+	// WithMinSeverity only returns events with the specified or a higher severity.
+	WithMinSeverity(severity EventSeverity) (BuildableListEventsParameters, error)
+	// MustWithMinSeverity is identical to WithMinSeverity, but panics instead of returning an error.
+	MustWithMinSeverity(severity EventSeverity) BuildableListEventsParameters
+}
+
+// NewListEventsParams creates a buildable set of ListEventsParameters to pass to the ListEvents function.
+func NewListEventsParams() BuildableListEventsParameters {
+	return &listEventsParams{}
+}
+
+type eventFilterParams struct {
+	vmID        *VMID
+	hostID      *HostID
+	diskID      *DiskID
+	minSeverity *EventSeverity
+}
+
+func (e *eventFilterParams) VMID() *VMID {
+	return e.vmID
+}
+
+func (e *eventFilterParams) HostID() *HostID {
+	return e.hostID
+}
+
+func (e *eventFilterParams) DiskID() *DiskID {
+	return e.diskID
+}
+
+func (e *eventFilterParams) MinSeverity() *EventSeverity {
+	return e.minSeverity
+}
+
+type listEventsParams struct {
+	eventFilterParams
+
+	fromIndex *int64
+	max       *int64
+}
+
+func (l *listEventsParams) FromIndex() *int64 {
+	return l.fromIndex
+}
+
+func (l *listEventsParams) Max() *int64 {
+	return l.max
+}
+
+func (l *listEventsParams) WithFromIndex(index int64) (BuildableListEventsParameters, error) {
+	l.fromIndex = &index
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithFromIndex(index int64) BuildableListEventsParameters {
+	builder, err := l.WithFromIndex(index)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (l *listEventsParams) WithMax(max int64) (BuildableListEventsParameters, error) {
+	if max < 1 {
+		return nil, newError(EBadArgument, "the maximum number of events must be at least 1, %d given", max)
+	}
+	l.max = &max
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithMax(max int64) BuildableListEventsParameters {
+	builder, err := l.WithMax(max)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (l *listEventsParams) WithVMID(vmID VMID) (BuildableListEventsParameters, error) {
+	l.vmID = &vmID
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithVMID(vmID VMID) BuildableListEventsParameters {
+	builder, err := l.WithVMID(vmID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (l *listEventsParams) WithHostID(hostID HostID) (BuildableListEventsParameters, error) {
+	l.hostID = &hostID
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithHostID(hostID HostID) BuildableListEventsParameters {
+	builder, err := l.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (l *listEventsParams) WithDiskID(diskID DiskID) (BuildableListEventsParameters, error) {
+	l.diskID = &diskID
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithDiskID(diskID DiskID) BuildableListEventsParameters {
+	builder, err := l.WithDiskID(diskID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (l *listEventsParams) WithMinSeverity(severity EventSeverity) (BuildableListEventsParameters, error) {
+	if err := severity.Validate(); err != nil {
+		return nil, err
+	}
+	l.minSeverity = &severity
+	return l, nil
+}
+
+func (l *listEventsParams) MustWithMinSeverity(severity EventSeverity) BuildableListEventsParameters {
+	builder, err := l.WithMinSeverity(severity)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// WatchEventsParameters are the optional parameters for watching events.
+type WatchEventsParameters interface {
+	EventFilter
+
+	// FromIndex returns the index after which events should be returned. If nil, only events recorded after the
+	// watch started are returned.
+	FromIndex() *int64
+	// PollInterval returns the time to wait between two polls of the engine. If nil, a default of 5 seconds is used.
+	PollInterval() *time.Duration
+}
+
+// BuildableWatchEventsParameters is a buildable version of WatchEventsParameters.
+type BuildableWatchEventsParameters interface {
+	WatchEventsParameters
+
+	// WithFromIndex sets the index after which events should be returned.
+	WithFromIndex(index int64) (BuildableWatchEventsParameters, error)
+	// MustWithFromIndex is identical to WithFromIndex, but panics instead of returning an error.
+	MustWithFromIndex(index int64) BuildableWatchEventsParameters
+	// WithPollInterval sets the time to wait between two polls of the engine.
+	WithPollInterval(interval time.Duration) (BuildableWatchEventsParameters, error)
+	// MustWithPollInterval is identical to WithPollInterval, but panics instead of returning an error.
+	MustWithPollInterval(interval time.Duration) BuildableWatchEventsParameters
+	// WithVMID only returns events related to the specified VM.
+	WithVMID(vmID VMID) (BuildableWatchEventsParameters, error)
+	// MustWithVMID is identical to WithVMID, but panics instead of returning an error.
+	MustWithVMID(vmID VMID) BuildableWatchEventsParameters
+	// WithHostID only returns events related to the specified host.
+	WithHostID(hostID HostID) (BuildableWatchEventsParameters, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableWatchEventsParameters
+	// WithDiskID only returns events related to the specified disk.
+	WithDiskID(diskID DiskID) (BuildableWatchEventsParameters, error)
+	// MustWithDiskID is identical to WithDiskID, but panics instead of returning an error.
+	MustWithDiskID(diskID DiskID) BuildableWatchEventsParameters
+	// WithMinSeverity only returns events with the specified or a higher severity.
+	WithMinSeverity(severity EventSeverity) (BuildableWatchEventsParameters, error)
+	// MustWithMinSeverity is identical to WithMinSeverity, but panics instead of returning an error.
+	MustWithMinSeverity(severity EventSeverity) BuildableWatchEventsParameters
+}
+
+// NewWatchEventsParams creates a buildable set of WatchEventsParameters to pass to the WatchEvents function.
+func NewWatchEventsParams() BuildableWatchEventsParameters {
+	return &watchEventsParams{}
+}
+
+type watchEventsParams struct {
+	eventFilterParams
+
+	fromIndex    *int64
+	pollInterval *time.Duration
+}
+
+func (w *watchEventsParams) FromIndex() *int64 {
+	return w.fromIndex
+}
+
+func (w *watchEventsParams) PollInterval() *time.Duration {
+	return w.pollInterval
+}
+
+func (w *watchEventsParams) WithFromIndex(index int64) (BuildableWatchEventsParameters, error) {
+	w.fromIndex = &index
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithFromIndex(index int64) BuildableWatchEventsParameters {
+	builder, err := w.WithFromIndex(index)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (w *watchEventsParams) WithPollInterval(interval time.Duration) (BuildableWatchEventsParameters, error) {
+	if interval <= 0 {
+		return nil, newError(EBadArgument, "the poll interval must be positive, %s given", interval)
+	}
+	w.pollInterval = &interval
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithPollInterval(interval time.Duration) BuildableWatchEventsParameters {
+	builder, err := w.WithPollInterval(interval)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (w *watchEventsParams) WithVMID(vmID VMID) (BuildableWatchEventsParameters, error) {
+	w.vmID = &vmID
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithVMID(vmID VMID) BuildableWatchEventsParameters {
+	builder, err := w.WithVMID(vmID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (w *watchEventsParams) WithHostID(hostID HostID) (BuildableWatchEventsParameters, error) {
+	w.hostID = &hostID
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithHostID(hostID HostID) BuildableWatchEventsParameters {
+	builder, err := w.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (w *watchEventsParams) WithDiskID(diskID DiskID) (BuildableWatchEventsParameters, error) {
+	w.diskID = &diskID
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithDiskID(diskID DiskID) BuildableWatchEventsParameters {
+	builder, err := w.WithDiskID(diskID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (w *watchEventsParams) WithMinSeverity(severity EventSeverity) (BuildableWatchEventsParameters, error) {
+	if err := severity.Validate(); err != nil {
+		return nil, err
+	}
+	w.minSeverity = &severity
+	return w, nil
+}
+
+func (w *watchEventsParams) MustWithMinSeverity(severity EventSeverity) BuildableWatchEventsParameters {
+	builder, err := w.WithMinSeverity(severity)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// eventMatchesFilter returns true if the event passes all filters. A nil filter matches all events.
+func eventMatchesFilter(e Event, filter EventFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if vmID := filter.VMID(); vmID != nil && (e.VMID() == nil || *e.VMID() != *vmID) {
+		return false
+	}
+	if hostID := filter.HostID(); hostID != nil && (e.HostID() == nil || *e.HostID() != *hostID) {
+		return false
+	}
+	if diskID := filter.DiskID(); diskID != nil && (e.DiskID() == nil || *e.DiskID() != *diskID) {
+		return false
+	}
+	if minSeverity := filter.MinSeverity(); minSeverity != nil && e.Severity().level() < minSeverity.level() {
+		return false
+	}
+	return true
+}
+
+// filterAndSortEvents removes the events not matching the filter and sorts the rest by index in ascending order.
+func filterAndSortEvents(events []Event, filter EventFilter) []Event {
+	result := make([]Event, 0, len(events))
+	for _, e := range events {
+		if eventMatchesFilter(e, filter) {
+			result = append(result, e)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Index() < result[j].Index()
+	})
+	return result
+}
+
+func convertSDKEvent(sdkObject *ovirtsdk.Event) (Event, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("event", "id")
+	}
+	index, ok := sdkObject.Index()
+	if !ok {
+		return nil, newFieldNotFound("event", "index")
+	}
+	code, ok := sdkObject.Code()
+	if !ok {
+		return nil, newFieldNotFound("event", "code")
+	}
+	severity, ok := sdkObject.Severity()
+	if !ok {
+		return nil, newFieldNotFound("event", "severity")
+	}
+	eventTime, ok := sdkObject.Time()
+	if !ok {
+		return nil, newFieldNotFound("event", "time")
+	}
+	description, _ := sdkObject.Description()
+	correlationID, _ := sdkObject.CorrelationId()
+
+	result := &event{
+		id:            EventID(id),
+		index:         index,
+		code:          EventCode(code),
+		severity:      EventSeverity(severity),
+		description:   description,
+		time:          eventTime,
+		correlationID: correlationID,
+	}
+	if sdkVM, ok := sdkObject.Vm(); ok {
+		if vmID, ok := sdkVM.Id(); ok {
+			id := VMID(vmID)
+			result.vmID = &id
+		}
+	}
+	if sdkHost, ok := sdkObject.Host(); ok {
+		if hostID, ok := sdkHost.Id(); ok {
+			id := HostID(hostID)
+			result.hostID = &id
+		}
+	}
+	if sdkTemplate, ok := sdkObject.Template(); ok {
+		if templateID, ok := sdkTemplate.Id(); ok {
+			id := TemplateID(templateID)
+			result.templateID = &id
+		}
+	}
+	if sdkStorageDomain, ok := sdkObject.StorageDomain(); ok {
+		if storageDomainID, ok := sdkStorageDomain.Id(); ok {
+			id := StorageDomainID(storageDomainID)
+			result.storageDomainID = &id
+		}
+	}
+	if sdkCluster, ok := sdkObject.Cluster(); ok {
+		if clusterID, ok := sdkCluster.Id(); ok {
+			id := ClusterID(clusterID)
+			result.clusterID = &id
+		}
+	}
+	return result, nil
+}
+
+type event struct {
+	id              EventID
+	index           int64
+	code            EventCode
+	severity        EventSeverity
+	description     string
+	time            time.Time
+	correlationID   string
+	vmID            *VMID
+	hostID          *HostID
+	diskID          *DiskID
+	templateID      *TemplateID
+	storageDomainID *StorageDomainID
+	clusterID       *ClusterID
+}
+
+func (e event) ID() EventID {
+	return e.id
+}
+
+func (e event) Index() int64 {
+	return e.index
+}
+
+func (e event) Code() EventCode {
+	return e.code
+}
+
+func (e event) Severity() EventSeverity {
+	return e.severity
+}
+
+func (e event) Description() string {
+	return e.description
+}
+
+func (e event) Time() time.Time {
+	return e.time
+}
+
+func (e event) CorrelationID() string {
+	return e.correlationID
+}
+
+func (e event) VMID() *VMID {
+	return e.vmID
+}
+
+func (e event) HostID() *HostID {
+	return e.hostID
+}
+
+func (e event) DiskID() *DiskID {
+	return e.diskID
+}
+
+func (e event) TemplateID() *TemplateID {
+	return e.templateID
+}
+
+func (e event) StorageDomainID() *StorageDomainID {
+	return e.storageDomainID
+}
+
+func (e event) ClusterID() *ClusterID {
+	return e.clusterID
+}
@@ -0,0 +1,63 @@
+package ovirtclient
+
+func (o *oVirtClient) ListEvents(params ListEventsParameters, retries ...RetryStrategy) (result []Event, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Event{}
+	err = retry(
+		"listing events",
+		o.logger,
+		o.requestHooks("ListEvents"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().EventsService().List()
+			if params != nil {
+				if fromIndex := params.FromIndex(); fromIndex != nil {
+					request.From(*fromIndex)
+				}
+				if max := params.Max(); max != nil {
+					request.Max(*max)
+				}
+			}
+			response, e := request.Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Events()
+			if !ok {
+				return nil
+			}
+			events := make([]Event, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				events[i], e = convertSDKEvent(sdkObject)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert event during listing item #%d", i)
+				}
+			}
+			result = filterAndSortEvents(events, params)
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListEvents(params ListEventsParameters, _ ...RetryStrategy) ([]Event, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	events := make([]Event, 0, len(m.events))
+	for _, item := range m.events {
+		if params != nil {
+			if fromIndex := params.FromIndex(); fromIndex != nil && item.index <= *fromIndex {
+				continue
+			}
+		}
+		events = append(events, item)
+	}
+	if params != nil {
+		if max := params.Max(); max != nil && int64(len(events)) > *max {
+			// Mirror the engine, which returns the most recent events first.
+			events = filterAndSortEvents(events, nil)
+			events = events[int64(len(events))-*max:]
+		}
+	}
+	return filterAndSortEvents(events, params), nil
+}
@@ -0,0 +1,47 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+)
+
+// mockEventRefs contains the resources a mock event relates to.
+type mockEventRefs struct {
+	vmID   *VMID
+	hostID *HostID
+	diskID *DiskID
+}
+
+// emitEvent records a new event in the mock event log. The caller must hold the mock lock.
+func (m *mockClient) emitEvent(
+	code EventCode,
+	severity EventSeverity,
+	refs mockEventRefs,
+	format string,
+	args ...interface{},
+) {
+	e := &event{
+		id:          EventID(m.GenerateUUID()),
+		index:       int64(len(m.events)) + 1,
+		code:        code,
+		severity:    severity,
+		description: fmt.Sprintf(format, args...),
+		time:        time.Now(),
+		vmID:        refs.vmID,
+		hostID:      refs.hostID,
+		diskID:      refs.diskID,
+	}
+	m.events[e.id] = e
+}
+
+// emitVMEvent records a new event related to the specified VM and the host it runs on. The caller must hold the
+// mock lock.
+func (m *mockClient) emitVMEvent(code EventCode, item *vm, format string, args ...interface{}) {
+	vmID := item.id
+	refs := mockEventRefs{vmID: &vmID}
+	if item.hostID != nil {
+		hostID := *item.hostID
+		refs.hostID = &hostID
+	}
+	m.emitEvent(code, EventSeverityNormal, refs, format, args...)
+}
@@ -0,0 +1,90 @@
+package ovirtclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestListEventsAfterVMStart(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	lastIndex := assertCanGetLatestEventIndex(t, client)
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	assertVMWillStart(t, vm)
+
+	events, err := client.ListEvents(
+		ovirtclient.NewListEventsParams().MustWithFromIndex(lastIndex).MustWithVMID(vm.ID()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to list events (%v)", err)
+	}
+	for i, event := range events {
+		if event.Index() <= lastIndex {
+			t.Fatalf("Event %s has index %d, which is not after %d.", event.ID(), event.Index(), lastIndex)
+		}
+		if i > 0 && event.Index() < events[i-1].Index() {
+			t.Fatalf("Events are not sorted by index.")
+		}
+		if event.VMID() == nil || *event.VMID() != vm.ID() {
+			t.Fatalf("Event %s does not relate to VM %s.", event.ID(), vm.ID())
+		}
+	}
+	assertHasEventCode(t, events, ovirtclient.EventCodeVMStarted)
+}
+
+func TestWatchEventsReportsVMStart(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateBootableVM(t, helper)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	events, err := helper.GetClient().WatchEvents(
+		ctx,
+		ovirtclient.NewWatchEventsParams().MustWithVMID(vm.ID()).MustWithPollInterval(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Failed to watch events (%v)", err)
+	}
+
+	assertCanStartVM(t, helper, vm)
+	for event := range events {
+		if event.VMID() == nil || *event.VMID() != vm.ID() {
+			t.Fatalf("Received event %s that does not relate to VM %s.", event.ID(), vm.ID())
+		}
+		if event.Code() == ovirtclient.EventCodeVMStarted {
+			return
+		}
+	}
+	t.Fatalf("Timeout while waiting for the VM start event.")
+}
+
+func assertCanGetLatestEventIndex(t *testing.T, client ovirtclient.Client) int64 {
+	events, err := client.ListEvents(ovirtclient.NewListEventsParams().MustWithMax(1))
+	if err != nil {
+		t.Fatalf("Failed to list latest event (%v)", err)
+	}
+	var lastIndex int64
+	for _, event := range events {
+		if event.Index() > lastIndex {
+			lastIndex = event.Index()
+		}
+	}
+	return lastIndex
+}
+
+func assertHasEventCode(t *testing.T, events []ovirtclient.Event, code ovirtclient.EventCode) {
+	for _, event := range events {
+		if event.Code() == code {
+			return
+		}
+	}
+	t.Fatalf("No event with code %d found.", code)
+}
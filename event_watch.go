@@ -0,0 +1,81 @@
+package ovirtclient
+
+import (
+	"context"
+	"time"
+)
+
+const defaultEventPollInterval = 5 * time.Second
+
+func (o *oVirtClient) WatchEvents(ctx context.Context, params WatchEventsParameters) (<-chan Event, error) {
+	return watchEvents(ctx, o, o.logger, params)
+}
+
+func (m *mockClient) WatchEvents(ctx context.Context, params WatchEventsParameters) (<-chan Event, error) {
+	return watchEvents(ctx, m, m.logger, params)
+}
+
+// watchEvents implements WatchEvents on top of ListEvents for both the live and the mock client. The events are listed
+// using a client bound to ctx, so canceling the watch also aborts a pending list call and its retries.
+func watchEvents(
+	ctx context.Context,
+	client Client,
+	logger Logger,
+	params WatchEventsParameters,
+) (<-chan Event, error) {
+	client = client.WithContext(ctx)
+	if params == nil {
+		params = NewWatchEventsParams()
+	}
+	pollInterval := defaultEventPollInterval
+	if interval := params.PollInterval(); interval != nil {
+		pollInterval = *interval
+	}
+
+	var lastIndex int64
+	if fromIndex := params.FromIndex(); fromIndex != nil {
+		lastIndex = *fromIndex
+	} else {
+		latest, err := client.ListEvents(NewListEventsParams().MustWithMax(1))
+		if err != nil {
+			return nil, wrap(err, EUnidentified, "failed to determine the latest event index")
+		}
+		for _, e := range latest {
+			if e.Index() > lastIndex {
+				lastIndex = e.Index()
+			}
+		}
+	}
+
+	result := make(chan Event)
+	go func() {
+		defer close(result)
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			// The filters are applied here instead of in ListEvents so the index also advances past events that
+			// are filtered out.
+			events, err := client.ListEvents(NewListEventsParams().MustWithFromIndex(lastIndex))
+			if err != nil {
+				logger.Warningf("Failed to poll events after index %d, retrying in %s. (%v)", lastIndex, pollInterval, err)
+			}
+			for _, e := range events {
+				lastIndex = e.Index()
+				if !eventMatchesFilter(e, params) {
+					continue
+				}
+				select {
+				case result <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
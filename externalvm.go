@@ -0,0 +1,432 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExternalVMImportClient describes the functions for importing VMs from external providers, such as VMware vCenter,
+// KVM hosts managed by libvirt, and OVA files. The disks are converted by virt-v2v on a proxy host in the target
+// datacenter. The oVirt Engine API cannot list the VMs of an external provider, so they must be looked up using the
+// tools of the provider.
+//
+// The source is described using ExternalVMSourceParams:
+//
+//	source := ovirtclient.ExternalVMSourceParams().
+//	    MustWithVMware("vpx://vcenter.example.com/Datacenter/esxi01?no_verify=1", "admin@vsphere.local", "secret")
+//	vmImport, err := client.ImportExternalVM(source, "web01", clusterID, storageDomainID, nil)
+//	// ...
+//	vm, err := vmImport.Wait()
+type ExternalVMImportClient interface {
+	// ImportExternalVM imports the VM with the specified name from the external provider into the cluster, placing
+	// its disks on the storage domain. The import runs as an engine job, which can be waited for using the returned
+	// handle.
+	ImportExternalVM(
+		source ExternalVMSource,
+		vmName string,
+		clusterID ClusterID,
+		storageDomainID StorageDomainID,
+		params OptionalExternalVMImportParameters,
+		retries ...RetryStrategy,
+	) (VMImport, error)
+}
+
+// ExternalVMProvider is the type of system VMs are imported from.
+type ExternalVMProvider string
+
+const (
+	// ExternalVMProviderVMware imports VMs from VMware vCenter or ESXi.
+	ExternalVMProviderVMware ExternalVMProvider = "vmware"
+	// ExternalVMProviderKVM imports VMs from a KVM host managed by libvirt.
+	ExternalVMProviderKVM ExternalVMProvider = "kvm"
+	// ExternalVMProviderOVA imports VMs from an OVA file on a host.
+	ExternalVMProviderOVA ExternalVMProvider = "ova"
+)
+
+// ExternalVMProviderList is a list of ExternalVMProvider.
+type ExternalVMProviderList []ExternalVMProvider
+
+// ExternalVMProviderValues returns all possible ExternalVMProvider values.
+func ExternalVMProviderValues() ExternalVMProviderList {
+	return []ExternalVMProvider{
+		ExternalVMProviderVMware,
+		ExternalVMProviderKVM,
+		ExternalVMProviderOVA,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l ExternalVMProviderList) Strings() []string {
+	result := make([]string, len(l))
+	for i, provider := range l {
+		result[i] = string(provider)
+	}
+	return result
+}
+
+// Validate returns an error if the external VM provider is not valid.
+func (p ExternalVMProvider) Validate() error {
+	for _, provider := range ExternalVMProviderValues() {
+		if provider == p {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid external VM provider: %s, must be one of: %s",
+		p,
+		strings.Join(ExternalVMProviderValues().Strings(), ", "),
+	)
+}
+
+// ExternalVMSource describes the external provider VMs are imported from.
+type ExternalVMSource interface {
+	// Provider returns the type of the external provider.
+	Provider() ExternalVMProvider
+	// URL returns the URL of the external provider, for example vpx://vcenter/Datacenter/host for VMware,
+	// qemu+ssh://root@kvm01/system for KVM or ova:///path/to/file.ova for OVA files.
+	URL() string
+	// Username returns the user to log in to the external provider with. Empty if not required.
+	Username() string
+	// Password returns the password to log in to the external provider with. Empty if not required.
+	Password() string
+	// HostID returns the proxy host that connects to the external provider and converts the disks. For OVA files
+	// this is the host the file is on. If nil, the oVirt Engine picks a host in the target cluster.
+	HostID() *HostID
+}
+
+// BuildableExternalVMSource is a buildable version of ExternalVMSource.
+type BuildableExternalVMSource interface {
+	ExternalVMSource
+
+	// WithVMware sets a VMware vCenter or ESXi URL starting with vpx:// or esx:// as the source.
+	WithVMware(url string, username string, password string) (BuildableExternalVMSource, error)
+	// MustWithVMware is identical to WithVMware, but panics instead of returning an error.
+	MustWithVMware(url string, username string, password string) BuildableExternalVMSource
+	// WithKVM sets a libvirt URL starting with qemu as the source. The username and password may be empty, for
+	// example when connecting using SSH keys.
+	WithKVM(url string, username string, password string) (BuildableExternalVMSource, error)
+	// MustWithKVM is identical to WithKVM, but panics instead of returning an error.
+	MustWithKVM(url string, username string, password string) BuildableExternalVMSource
+	// WithOVA sets the OVA file at the absolute path on the host as the source.
+	WithOVA(hostID HostID, path string) (BuildableExternalVMSource, error)
+	// MustWithOVA is identical to WithOVA, but panics instead of returning an error.
+	MustWithOVA(hostID HostID, path string) BuildableExternalVMSource
+	// WithHostID sets the proxy host for VMware and KVM sources.
+	WithHostID(hostID HostID) (BuildableExternalVMSource, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableExternalVMSource
+}
+
+// ExternalVMSourceParams creates a builder for the description of an external provider. One of WithVMware, WithKVM
+// and WithOVA must be called.
+func ExternalVMSourceParams() BuildableExternalVMSource {
+	return &externalVMSource{}
+}
+
+type externalVMSource struct {
+	provider ExternalVMProvider
+	url      string
+	username string
+	password string
+	hostID   *HostID
+}
+
+func (e *externalVMSource) Provider() ExternalVMProvider {
+	return e.provider
+}
+
+func (e *externalVMSource) URL() string {
+	return e.url
+}
+
+func (e *externalVMSource) Username() string {
+	return e.username
+}
+
+func (e *externalVMSource) Password() string {
+	return e.password
+}
+
+func (e *externalVMSource) HostID() *HostID {
+	return e.hostID
+}
+
+func (e *externalVMSource) WithVMware(url string, username string, password string) (BuildableExternalVMSource, error) {
+	if !strings.HasPrefix(url, "vpx://") && !strings.HasPrefix(url, "esx://") {
+		return nil, newError(EBadArgument, "VMware URLs must start with vpx:// or esx:// (%s given)", url)
+	}
+	if username == "" || password == "" {
+		return nil, newError(EBadArgument, "a username and password are required for VMware")
+	}
+	e.provider = ExternalVMProviderVMware
+	e.url = url
+	e.username = username
+	e.password = password
+	return e, nil
+}
+
+func (e *externalVMSource) MustWithVMware(url string, username string, password string) BuildableExternalVMSource {
+	builder, err := e.WithVMware(url, username, password)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (e *externalVMSource) WithKVM(url string, username string, password string) (BuildableExternalVMSource, error) {
+	if !strings.HasPrefix(url, "qemu") {
+		return nil, newError(EBadArgument, "libvirt URLs must start with qemu, for example qemu+ssh:// (%s given)", url)
+	}
+	e.provider = ExternalVMProviderKVM
+	e.url = url
+	e.username = username
+	e.password = password
+	return e, nil
+}
+
+func (e *externalVMSource) MustWithKVM(url string, username string, password string) BuildableExternalVMSource {
+	builder, err := e.WithKVM(url, username, password)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (e *externalVMSource) WithOVA(hostID HostID, path string) (BuildableExternalVMSource, error) {
+	if hostID == "" {
+		return nil, newError(EBadArgument, "the host of the OVA file must be set")
+	}
+	if err := validateOVAPath(path); err != nil {
+		return nil, err
+	}
+	e.provider = ExternalVMProviderOVA
+	e.url = fmt.Sprintf("ova://%s", path)
+	e.username = ""
+	e.password = ""
+	e.hostID = &hostID
+	return e, nil
+}
+
+func (e *externalVMSource) MustWithOVA(hostID HostID, path string) BuildableExternalVMSource {
+	builder, err := e.WithOVA(hostID, path)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (e *externalVMSource) WithHostID(hostID HostID) (BuildableExternalVMSource, error) {
+	if hostID == "" {
+		return nil, newError(EBadArgument, "the host ID must not be empty")
+	}
+	e.hostID = &hostID
+	return e, nil
+}
+
+func (e *externalVMSource) MustWithHostID(hostID HostID) BuildableExternalVMSource {
+	builder, err := e.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// validateExternalVMSource checks that a provider was set on the source.
+func validateExternalVMSource(source ExternalVMSource) error {
+	if source == nil {
+		return newError(EBadArgument, "the external VM source must not be nil")
+	}
+	if err := source.Provider().Validate(); err != nil {
+		return wrap(err, EBadArgument, "call WithVMware, WithKVM or WithOVA on the external VM source")
+	}
+	if source.Provider() == ExternalVMProviderOVA && source.HostID() == nil {
+		return newError(EBadArgument, "the host of the OVA file must be set")
+	}
+	return nil
+}
+
+// OptionalExternalVMImportParameters are the optional parameters for importing VMs from external providers.
+type OptionalExternalVMImportParameters interface {
+	// Name returns the name of the imported VM in oVirt. If nil, the name on the external provider is used.
+	Name() *string
+	// Sparse returns true if the disks should be thin provisioned, or false if they should be preallocated. If nil,
+	// the disks are thin provisioned unless Format is ImageFormatRaw.
+	Sparse() *bool
+	// Format returns the format of the imported disks. The oVirt Engine imports thin provisioned disks as
+	// ImageFormatCow and preallocated disks as ImageFormatRaw, so the format must match Sparse. If nil, the format
+	// follows Sparse.
+	Format() *ImageFormat
+}
+
+// BuildableExternalVMImportParameters is a buildable version of OptionalExternalVMImportParameters.
+type BuildableExternalVMImportParameters interface {
+	OptionalExternalVMImportParameters
+
+	// WithName sets the name of the imported VM in oVirt.
+	WithName(name string) (BuildableExternalVMImportParameters, error)
+	// MustWithName is identical to WithName, but panics instead of returning an error.
+	MustWithName(name string) BuildableExternalVMImportParameters
+	// WithSparse sets whether the disks should be thin provisioned.
+	WithSparse(sparse bool) (BuildableExternalVMImportParameters, error)
+	// MustWithSparse is identical to WithSparse, but panics instead of returning an error.
+	MustWithSparse(sparse bool) BuildableExternalVMImportParameters
+	// WithFormat sets the format of the imported disks.
+	WithFormat(format ImageFormat) (BuildableExternalVMImportParameters, error)
+	// MustWithFormat is identical to WithFormat, but panics instead of returning an error.
+	MustWithFormat(format ImageFormat) BuildableExternalVMImportParameters
+}
+
+// ExternalVMImportParams creates a builder for the parameters of external VM imports.
+func ExternalVMImportParams() BuildableExternalVMImportParameters {
+	return &externalVMImportParams{}
+}
+
+type externalVMImportParams struct {
+	name   *string
+	sparse *bool
+	format *ImageFormat
+}
+
+func (e *externalVMImportParams) Name() *string {
+	return e.name
+}
+
+func (e *externalVMImportParams) Sparse() *bool {
+	return e.sparse
+}
+
+func (e *externalVMImportParams) Format() *ImageFormat {
+	return e.format
+}
+
+func (e *externalVMImportParams) WithName(name string) (BuildableExternalVMImportParameters, error) {
+	if name == "" {
+		return nil, newError(EBadArgument, "the name of the imported VM must not be empty")
+	}
+	e.name = &name
+	return e, nil
+}
+
+func (e *externalVMImportParams) MustWithName(name string) BuildableExternalVMImportParameters {
+	builder, err := e.WithName(name)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (e *externalVMImportParams) WithSparse(sparse bool) (BuildableExternalVMImportParameters, error) {
+	e.sparse = &sparse
+	return e, nil
+}
+
+func (e *externalVMImportParams) MustWithSparse(sparse bool) BuildableExternalVMImportParameters {
+	builder, err := e.WithSparse(sparse)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (e *externalVMImportParams) WithFormat(format ImageFormat) (BuildableExternalVMImportParameters, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	e.format = &format
+	return e, nil
+}
+
+func (e *externalVMImportParams) MustWithFormat(format ImageFormat) BuildableExternalVMImportParameters {
+	builder, err := e.WithFormat(format)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// externalVMDiskAllocation returns the format and allocation of the imported disks, rejecting combinations the oVirt
+// Engine does not support.
+func externalVMDiskAllocation(params OptionalExternalVMImportParameters) (ImageFormat, bool, error) {
+	sparse := true
+	if format := params.Format(); format != nil {
+		sparse = *format == ImageFormatCow
+	}
+	if paramSparse := params.Sparse(); paramSparse != nil {
+		if params.Format() != nil && *paramSparse != sparse {
+			return "", false, newError(
+				EBadArgument,
+				"the oVirt Engine imports sparse disks as %s and preallocated disks as %s, %s disks cannot be sparse=%t",
+				ImageFormatCow,
+				ImageFormatRaw,
+				*params.Format(),
+				*paramSparse,
+			)
+		}
+		sparse = *paramSparse
+	}
+	if sparse {
+		return ImageFormatCow, true, nil
+	}
+	return ImageFormatRaw, false, nil
+}
+
+// ExternalVM is a VM in the fake external provider inventory of the mock client. See MockClient.AddExternalVM.
+type ExternalVM interface {
+	// Name returns the name of the VM on the external provider.
+	Name() string
+	// Memory returns the memory of the VM in bytes.
+	Memory() int64
+	// CPUs returns the number of virtual CPUs of the VM.
+	CPUs() uint
+	// Disks returns the disks of the VM.
+	Disks() []ExternalVMDisk
+}
+
+// ExternalVMDisk is a disk of a VM on an external provider.
+type ExternalVMDisk interface {
+	// Name returns the name of the disk on the external provider.
+	Name() string
+	// ProvisionedSize returns the size of the disk in bytes.
+	ProvisionedSize() uint64
+}
+
+type externalVM struct {
+	name   string
+	memory int64
+	cpus   uint
+	disks  []*externalVMDisk
+}
+
+func (e *externalVM) Name() string {
+	return e.name
+}
+
+func (e *externalVM) Memory() int64 {
+	return e.memory
+}
+
+func (e *externalVM) CPUs() uint {
+	return e.cpus
+}
+
+func (e *externalVM) Disks() []ExternalVMDisk {
+	result := make([]ExternalVMDisk, len(e.disks))
+	for i, disk := range e.disks {
+		result[i] = disk
+	}
+	return result
+}
+
+type externalVMDisk struct {
+	name            string
+	provisionedSize uint64
+}
+
+func (e *externalVMDisk) Name() string {
+	return e.name
+}
+
+func (e *externalVMDisk) ProvisionedSize() uint64 {
+	return e.provisionedSize
+}
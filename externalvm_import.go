@@ -0,0 +1,147 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ImportExternalVM(
+	source ExternalVMSource,
+	vmName string,
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	params OptionalExternalVMImportParameters,
+	retries ...RetryStrategy,
+) (VMImport, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = &externalVMImportParams{}
+	}
+	if err := validateExternalVMImport(source, vmName); err != nil {
+		return nil, err
+	}
+	_, sparse, err := externalVMDiskAllocation(params)
+	if err != nil {
+		return nil, err
+	}
+	correlationID, err := correlationIDFor(o.ctx, "vm_import_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	name := vmName
+	if params.Name() != nil {
+		name = *params.Name()
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("importing VM %s from %s provider %s", vmName, source.Provider(), source.URL()),
+		o.logger,
+		o.requestHooks("ImportExternalVM"),
+		retries,
+		func() error {
+			builder := ovirtsdk.NewExternalVmImportBuilder().
+				Name(vmName).
+				Provider(ovirtsdk.ExternalVmProviderType(source.Provider())).
+				Url(source.URL()).
+				Cluster(ovirtsdk.NewClusterBuilder().Id(string(clusterID)).MustBuild()).
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Sparse(sparse).
+				Vm(ovirtsdk.NewVmBuilder().Name(name).MustBuild())
+			if source.Username() != "" {
+				builder.Username(source.Username())
+			}
+			if source.Password() != "" {
+				builder.Password(source.Password())
+			}
+			if hostID := source.HostID(); hostID != nil {
+				builder.Host(ovirtsdk.NewHostBuilder().Id(string(*hostID)).MustBuild())
+			}
+			sdkImport, err := builder.Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build external import of VM %s", vmName)
+			}
+			_, err = o.conn.SystemService().
+				ExternalVmImportsService().
+				Add().
+				Import(sdkImport).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	job, err := o.findCorrelatedJob(correlationID, sentAt, retries)
+	if err != nil {
+		return nil, err
+	}
+	return &vmImport{client: o, job: job, name: name}, nil
+}
+
+func (m *mockClient) ImportExternalVM(
+	source ExternalVMSource,
+	vmName string,
+	clusterID ClusterID,
+	storageDomainID StorageDomainID,
+	params OptionalExternalVMImportParameters,
+	_ ...RetryStrategy,
+) (VMImport, error) {
+	if params == nil {
+		params = &externalVMImportParams{}
+	}
+	if err := validateExternalVMImport(source, vmName); err != nil {
+		return nil, err
+	}
+	format, sparse, err := externalVMDiskAllocation(params)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "vm_import_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	inventory, err := m.externalVMInventory(source)
+	if err != nil {
+		return nil, err
+	}
+	var item *externalVM
+	for _, candidate := range inventory {
+		if candidate.name == vmName {
+			item = candidate
+			break
+		}
+	}
+	if item == nil {
+		return nil, newError(ENotFound, "VM %s not found on %s provider %s", vmName, source.Provider(), source.URL())
+	}
+	name := vmName
+	if params.Name() != nil {
+		name = *params.Name()
+	}
+	// The import always creates new IDs, so the VM is passed on as a clone with the target name.
+	return m.importVM(
+		m.externalVMOVF(item, format, sparse),
+		clusterID,
+		&ovfImportParams{name: &name, storageDomainID: &storageDomainID},
+		nil,
+		correlationID,
+	)
+}
+
+// validateExternalVMImport checks the source and name of an external VM import.
+func validateExternalVMImport(source ExternalVMSource, vmName string) error {
+	if err := validateExternalVMSource(source); err != nil {
+		return err
+	}
+	if vmName == "" {
+		return newError(EBadArgument, "the name of the VM on the external provider must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// mockExternalVMKey returns the key of an external provider in the externalVMs map of the mock.
+func mockExternalVMKey(source ExternalVMSource) string {
+	return fmt.Sprintf("%s:%s", source.Provider(), source.URL())
+}
+
+// mockDefaultExternalVMs returns the inventory of an external provider the mock has not seen before.
+func mockDefaultExternalVMs() []*externalVM {
+	return []*externalVM{
+		{
+			name:   "external-vm-1",
+			memory: 1073741824,
+			cpus:   1,
+			disks:  []*externalVMDisk{{name: "external-vm-1-disk-1", provisionedSize: 1073741824}},
+		},
+		{
+			name:   "external-vm-2",
+			memory: 2147483648,
+			cpus:   2,
+			disks: []*externalVMDisk{
+				{name: "external-vm-2-disk-1", provisionedSize: 1073741824},
+				{name: "external-vm-2-disk-2", provisionedSize: 2147483648},
+			},
+		},
+	}
+}
+
+// externalVMInventory returns the VMs on the external provider. VMware and KVM providers the mock has not seen yet
+// are filled with a default inventory, OVA files contain the VM exported into them. The caller must hold the lock.
+func (m *mockClient) externalVMInventory(source ExternalVMSource) ([]*externalVM, error) {
+	if hostID := source.HostID(); hostID != nil {
+		if err := m.checkStorageDomainHost(*hostID); err != nil {
+			return nil, err
+		}
+	}
+	if source.Provider() == ExternalVMProviderOVA {
+		path := strings.TrimPrefix(source.URL(), "ova://")
+		ovf, err := m.ovaFile(*source.HostID(), path)
+		if err != nil {
+			return nil, err
+		}
+		if ovf.vm == nil {
+			return nil, newError(EBadArgument, "the OVA file %s contains a template, not a VM", path)
+		}
+		return []*externalVM{ovfExternalVM(ovf)}, nil
+	}
+	key := mockExternalVMKey(source)
+	if _, ok := m.externalVMs[key]; !ok {
+		m.externalVMs[key] = mockDefaultExternalVMs()
+	}
+	return m.externalVMs[key], nil
+}
+
+func (m *mockClient) ListExternalVMs(source ExternalVMSource) ([]ExternalVM, error) {
+	if err := validateExternalVMSource(source); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	inventory, err := m.externalVMInventory(source)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ExternalVM, len(inventory))
+	for i, item := range inventory {
+		result[i] = item
+	}
+	return result, nil
+}
+
+func (m *mockClient) AddExternalVM(source ExternalVMSource, name string, diskSizes ...uint64) (ExternalVM, error) {
+	if err := validateExternalVMImport(source, name); err != nil {
+		return nil, err
+	}
+	if source.Provider() == ExternalVMProviderOVA {
+		return nil, newError(EBadArgument, "VMs cannot be added to OVA files, use ExportVMAsOVA instead")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	inventory, err := m.externalVMInventory(source)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range inventory {
+		if existing.name == name {
+			return nil, newError(EConflict, "VM %s already exists on %s provider %s", name, source.Provider(), source.URL())
+		}
+	}
+	item := &externalVM{
+		name:   name,
+		memory: 1073741824,
+		cpus:   1,
+		disks:  make([]*externalVMDisk, len(diskSizes)),
+	}
+	for i, size := range diskSizes {
+		item.disks[i] = &externalVMDisk{
+			name:            fmt.Sprintf("%s-disk-%d", name, i+1),
+			provisionedSize: size,
+		}
+	}
+	key := mockExternalVMKey(source)
+	m.externalVMs[key] = append(m.externalVMs[key], item)
+	return item, nil
+}
+
+// ovfExternalVM returns the VM in the OVF as seen on an external provider.
+func ovfExternalVM(ovf *mockOVF) *externalVM {
+	cpus := uint(1)
+	if ovf.vm.cpu != nil && ovf.vm.cpu.topo != nil {
+		topo := ovf.vm.cpu.topo
+		cpus = topo.cores * topo.threads * topo.sockets
+	}
+	result := &externalVM{
+		name:   ovf.vm.name,
+		memory: ovf.vm.memory,
+		cpus:   cpus,
+		disks:  make([]*externalVMDisk, len(ovf.disks)),
+	}
+	for i, disk := range ovf.disks {
+		result.disks[i] = &externalVMDisk{
+			name:            disk.alias,
+			provisionedSize: disk.provisionedSize,
+		}
+	}
+	return result
+}
+
+// externalVMOVF returns the external VM as converted by virt-v2v, with its disks in the requested format and with
+// VirtIO attachments, the first one bootable.
+func (m *mockClient) externalVMOVF(item *externalVM, format ImageFormat, sparse bool) *mockOVF {
+	vmID := VMID(m.GenerateUUID())
+	result := &mockOVF{
+		vm: &vm{
+			client:     m,
+			id:         vmID,
+			name:       item.name,
+			templateID: DefaultBlankTemplateID,
+			status:     VMStatusDown,
+			cpu: &vmCPU{
+				topo: &vmCPUTopo{
+					cores:   1,
+					threads: 1,
+					sockets: item.cpus,
+				},
+			},
+			memory:           item.memory,
+			initialization:   &initialization{},
+			memoryPolicy:     &memoryPolicy{ballooning: true},
+			vmType:           VMTypeServer,
+			os:               &vmOS{t: "other"},
+			soundcardEnabled: true,
+		},
+	}
+	for i, sourceDisk := range item.disks {
+		imported := &diskWithData{
+			disk: disk{
+				client:          m,
+				id:              DiskID(m.GenerateUUID()),
+				alias:           sourceDisk.name,
+				format:          format,
+				provisionedSize: sourceDisk.provisionedSize,
+				totalSize:       sourceDisk.provisionedSize,
+				status:          DiskStatusOK,
+				sparse:          sparse,
+				contentType:     DiskContentTypeData,
+				storageType:     DiskStorageTypeImage,
+			},
+			lock:         &sync.Mutex{},
+			dirtyBitmaps: map[CheckpointID][]bool{},
+		}
+		result.disks = append(result.disks, imported)
+		result.vmAttachments = append(result.vmAttachments, &diskAttachment{
+			client:        m,
+			id:            DiskAttachmentID(m.GenerateUUID()),
+			vmid:          vmID,
+			diskID:        imported.id,
+			diskInterface: DiskInterfaceVirtIO,
+			active:        true,
+			bootable:      i == 0,
+		})
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+// This file contains tests for importing VMs from external providers in the mock client, as the live tests have no
+// VMware or KVM provider to import from. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestMockExternalVMListAndAdd(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	source := ExternalVMSourceParams().MustWithKVM("qemu+ssh://root@kvm01.example.com/system", "", "")
+
+	vms, err := client.ListExternalVMs(source)
+	if err != nil {
+		t.Fatalf("Failed to list external VMs (%v)", err)
+	}
+	if len(vms) != 2 || vms[0].Name() != "external-vm-1" || len(vms[0].Disks()) != 1 {
+		t.Fatalf("Incorrect default inventory of the external provider: %d VMs", len(vms))
+	}
+
+	added, err := client.AddExternalVM(source, "added-vm", 1024*1024, 2*1024*1024)
+	if err != nil {
+		t.Fatalf("Failed to add external VM (%v)", err)
+	}
+	if added.CPUs() != 1 || len(added.Disks()) != 2 || added.Disks()[1].ProvisionedSize() != 2*1024*1024 {
+		t.Fatalf("Incorrect added external VM: %d CPUs, %d disks", added.CPUs(), len(added.Disks()))
+	}
+	if _, err := client.AddExternalVM(source, "added-vm"); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Adding a duplicate external VM did not return an EConflict error (%v)", err)
+	}
+	if vms, err = client.ListExternalVMs(source); err != nil || len(vms) != 3 {
+		t.Fatalf("The added VM was not listed (%v)", err)
+	}
+
+	if _, err := client.ListExternalVMs(ExternalVMSourceParams()); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Listing without a provider did not return an EBadArgument error (%v)", err)
+	}
+	if _, err := ExternalVMSourceParams().WithVMware("https://vcenter.example.com", "admin", "secret"); err == nil {
+		t.Fatalf("Setting a VMware source with an invalid URL did not return an error")
+	}
+}
+
+func TestMockExternalVMImport(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	clusterID, sdID := mockOVFTestIDs(t, client)
+	source := ExternalVMSourceParams().
+		MustWithVMware("vpx://vcenter.example.com/Datacenter/esxi01?no_verify=1", "admin@vsphere.local", "secret")
+	if _, err := client.AddExternalVM(source, "web01", 1024*1024); err != nil {
+		t.Fatalf("Failed to add external VM (%v)", err)
+	}
+
+	invalidParams := ExternalVMImportParams().MustWithSparse(true).MustWithFormat(ImageFormatRaw)
+	_, err := client.ImportExternalVM(source, "web01", clusterID, sdID, invalidParams)
+	if !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Importing sparse raw disks did not return an EBadArgument error (%v)", err)
+	}
+	if _, err := client.ImportExternalVM(source, "missing", clusterID, sdID, nil); !HasErrorCode(err, ENotFound) {
+		t.Fatalf("Importing a missing VM did not return an ENotFound error (%v)", err)
+	}
+
+	vmImport, err := client.ImportExternalVM(
+		source,
+		"web01",
+		clusterID,
+		sdID,
+		ExternalVMImportParams().MustWithName("web01-imported").MustWithFormat(ImageFormatRaw),
+	)
+	if err != nil {
+		t.Fatalf("Failed to import external VM (%v)", err)
+	}
+	imported, err := vmImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the import (%v)", err)
+	}
+	if imported.Name() != "web01-imported" || imported.Status() != VMStatusDown {
+		t.Fatalf("Incorrect imported VM: %s %s", imported.Name(), imported.Status())
+	}
+	attachments, err := imported.ListDiskAttachments()
+	if err != nil || len(attachments) != 1 || !attachments[0].Bootable() {
+		t.Fatalf("Incorrect disk attachments on the imported VM (%v)", err)
+	}
+	disk, err := client.GetDisk(attachments[0].DiskID())
+	if err != nil {
+		t.Fatalf("Failed to get imported disk (%v)", err)
+	}
+	if disk.Format() != ImageFormatRaw || disk.Sparse() || disk.Status() != DiskStatusOK {
+		t.Fatalf("Incorrect imported disk: %s sparse=%t %s", disk.Format(), disk.Sparse(), disk.Status())
+	}
+	if ids := disk.StorageDomainIDs(); len(ids) != 1 || ids[0] != sdID {
+		t.Fatalf("The imported disk is on the incorrect storage domains: %v", ids)
+	}
+
+	if _, err := client.ImportExternalVM(source, "web01", clusterID, sdID, nil); err != nil {
+		t.Fatalf("Failed to import the external VM under its own name (%v)", err)
+	}
+	if _, err := client.ImportExternalVM(source, "web01", clusterID, sdID, nil); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Importing a VM with an existing name did not return an EConflict error (%v)", err)
+	}
+}
+
+func TestMockExternalVMImportOVA(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, _ := mockStorageDomainTestIDs(t, client)
+	clusterID, sdID := mockOVFTestIDs(t, client)
+	vm, disk := mockOVFTestVM(t, client, clusterID, sdID, "external-ova-vm")
+
+	job, err := client.ExportVMAsOVA(vm.ID(), hostID, "/var/tmp/export", "")
+	if err != nil {
+		t.Fatalf("Failed to export VM as OVA (%v)", err)
+	}
+	if _, err := job.Wait(); err != nil {
+		t.Fatalf("Failed to wait for the OVA export (%v)", err)
+	}
+
+	source := ExternalVMSourceParams().MustWithOVA(hostID, "/var/tmp/export/external-ova-vm.ova")
+	vms, err := client.ListExternalVMs(source)
+	if err != nil || len(vms) != 1 || vms[0].Name() != "external-ova-vm" {
+		t.Fatalf("Failed to list the VM in the OVA file (%v)", err)
+	}
+	if _, err := client.AddExternalVM(source, "other"); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Adding a VM to an OVA file did not return an EBadArgument error (%v)", err)
+	}
+	vmImport, err := client.ImportExternalVM(
+		source,
+		"external-ova-vm",
+		clusterID,
+		sdID,
+		ExternalVMImportParams().MustWithName("external-ova-vm-copy"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to import VM from OVA (%v)", err)
+	}
+	imported, err := vmImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the OVA import (%v)", err)
+	}
+	if imported.ID() == vm.ID() || imported.Name() != "external-ova-vm-copy" {
+		t.Fatalf("Incorrect imported VM: %s %s", imported.ID(), imported.Name())
+	}
+	mockOVFCheckImportedDisk(t, client, imported, disk, sdID)
+}
@@ -47,6 +47,7 @@ func (o *oVirtClient) SupportsFeature(feature Feature, retries ...RetryStrategy)
 	err = retry(
 		"fetching engine version",
 		o.logger,
+		o.requestHooks("SupportsFeature"),
 		retries,
 		func() error {
 			systemGetResponse, err := o.conn.SystemService().Get().Send()
@@ -13,6 +13,18 @@ type HostID string
 type HostClient interface {
 	ListHosts(retries ...RetryStrategy) ([]Host, error)
 	GetHost(id HostID, retries ...RetryStrategy) (Host, error)
+	// DeactivateHost puts the host into maintenance mode. Running virtual machines are migrated to other hosts
+	// first, during which the host is in the HostStatusPreparingForMaintenance status. Use WaitForHostStatus to wait
+	// for the host to reach HostStatusMaintenance.
+	DeactivateHost(id HostID, params DeactivateHostParameters, retries ...RetryStrategy) error
+	// ActivateHost activates a host that is in maintenance mode. Use WaitForHostStatus to wait for the host to reach
+	// HostStatusUp.
+	ActivateHost(id HostID, retries ...RetryStrategy) error
+	// RestartHost restarts the host using its configured fencing agent. Virtual machines running on the host are
+	// stopped. Use WaitForHostStatus to wait for the host to come back.
+	RestartHost(id HostID, params RestartHostParameters, retries ...RetryStrategy) error
+	// WaitForHostStatus waits for the host to reach the desired status.
+	WaitForHostStatus(id HostID, status HostStatus, retries ...RetryStrategy) (Host, error)
 }
 
 // HostData is the core of Host, providing only data access functions.
@@ -36,6 +48,131 @@ type HostData interface {
 // See https://www.ovirt.org/documentation/administration_guide/#chap-Hosts for details.
 type Host interface {
 	HostData
+
+	// Deactivate puts the host into maintenance mode. See HostClient.DeactivateHost for details.
+	Deactivate(params DeactivateHostParameters, retries ...RetryStrategy) error
+	// Activate activates the host from maintenance mode. See HostClient.ActivateHost for details.
+	Activate(retries ...RetryStrategy) error
+	// Restart restarts the host using its fencing agent. See HostClient.RestartHost for details.
+	Restart(params RestartHostParameters, retries ...RetryStrategy) error
+	// WaitForStatus waits for the host to reach the desired status and returns the updated host.
+	WaitForStatus(status HostStatus, retries ...RetryStrategy) (Host, error)
+}
+
+// DeactivateHostParameters contains the optional parameters for putting a host into maintenance mode.
+type DeactivateHostParameters interface {
+	// Reason returns the reason for the maintenance that is recorded in the engine. May be nil.
+	Reason() *string
+	// StopGlusterService returns true if the Gluster service should be stopped on the host. May be nil.
+	StopGlusterService() *bool
+}
+
+// BuildableDeactivateHostParameters is a buildable version of DeactivateHostParameters.
+type BuildableDeactivateHostParameters interface {
+	DeactivateHostParameters
+
+	// WithReason sets the reason for the maintenance.
+	WithReason(reason string) (BuildableDeactivateHostParameters, error)
+	// MustWithReason is identical to WithReason, but panics instead of returning an error.
+	MustWithReason(reason string) BuildableDeactivateHostParameters
+	// WithStopGlusterService sets if the Gluster service should be stopped on the host.
+	WithStopGlusterService(stopGlusterService bool) (BuildableDeactivateHostParameters, error)
+	// MustWithStopGlusterService is identical to WithStopGlusterService, but panics instead of returning an error.
+	MustWithStopGlusterService(stopGlusterService bool) BuildableDeactivateHostParameters
+}
+
+// NewDeactivateHostParams creates a buildable set of DeactivateHostParameters to pass to the DeactivateHost function.
+func NewDeactivateHostParams() BuildableDeactivateHostParameters {
+	return &deactivateHostParams{}
+}
+
+type deactivateHostParams struct {
+	reason             *string
+	stopGlusterService *bool
+}
+
+func (d *deactivateHostParams) Reason() *string {
+	return d.reason
+}
+
+func (d *deactivateHostParams) StopGlusterService() *bool {
+	return d.stopGlusterService
+}
+
+func (d *deactivateHostParams) WithReason(reason string) (BuildableDeactivateHostParameters, error) {
+	d.reason = &reason
+	return d, nil
+}
+
+func (d *deactivateHostParams) MustWithReason(reason string) BuildableDeactivateHostParameters {
+	builder, err := d.WithReason(reason)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (d *deactivateHostParams) WithStopGlusterService(stopGlusterService bool) (
+	BuildableDeactivateHostParameters,
+	error,
+) {
+	d.stopGlusterService = &stopGlusterService
+	return d, nil
+}
+
+func (d *deactivateHostParams) MustWithStopGlusterService(stopGlusterService bool) BuildableDeactivateHostParameters {
+	builder, err := d.WithStopGlusterService(stopGlusterService)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// RestartHostParameters contains the optional parameters for restarting a host.
+type RestartHostParameters interface {
+	// MaintenanceAfterRestart returns true if the host should be put into maintenance mode after the restart instead
+	// of being activated. May be nil.
+	MaintenanceAfterRestart() *bool
+}
+
+// BuildableRestartHostParameters is a buildable version of RestartHostParameters.
+type BuildableRestartHostParameters interface {
+	RestartHostParameters
+
+	// WithMaintenanceAfterRestart sets if the host should be put into maintenance mode after the restart.
+	WithMaintenanceAfterRestart(maintenanceAfterRestart bool) (BuildableRestartHostParameters, error)
+	// MustWithMaintenanceAfterRestart is identical to WithMaintenanceAfterRestart, but panics instead of returning an
+	// error.
+	MustWithMaintenanceAfterRestart(maintenanceAfterRestart bool) BuildableRestartHostParameters
+}
+
+// NewRestartHostParams creates a buildable set of RestartHostParameters to pass to the RestartHost function.
+func NewRestartHostParams() BuildableRestartHostParameters {
+	return &restartHostParams{}
+}
+
+type restartHostParams struct {
+	maintenanceAfterRestart *bool
+}
+
+func (r *restartHostParams) MaintenanceAfterRestart() *bool {
+	return r.maintenanceAfterRestart
+}
+
+func (r *restartHostParams) WithMaintenanceAfterRestart(maintenanceAfterRestart bool) (
+	BuildableRestartHostParameters,
+	error,
+) {
+	r.maintenanceAfterRestart = &maintenanceAfterRestart
+	return r, nil
+}
+
+func (r *restartHostParams) MustWithMaintenanceAfterRestart(maintenanceAfterRestart bool) BuildableRestartHostParameters {
+	builder, err := r.WithMaintenanceAfterRestart(maintenanceAfterRestart)
+	if err != nil {
+		panic(err)
+	}
+	return builder
 }
 
 // HostStatus represents the complex states an oVirt host can be in.
@@ -197,3 +334,24 @@ func (h host) Comment() string {
 func (h host) HostNICs() ([]HostNIC, error) {
 	return h.client.ListHostNICs(h.id)
 }
+
+func (h host) withStatus(status HostStatus) *host {
+	h.status = status
+	return &h
+}
+
+func (h host) Deactivate(params DeactivateHostParameters, retries ...RetryStrategy) error {
+	return h.client.DeactivateHost(h.id, params, retries...)
+}
+
+func (h host) Activate(retries ...RetryStrategy) error {
+	return h.client.ActivateHost(h.id, retries...)
+}
+
+func (h host) Restart(params RestartHostParameters, retries ...RetryStrategy) error {
+	return h.client.RestartHost(h.id, params, retries...)
+}
+
+func (h host) WaitForStatus(status HostStatus, retries ...RetryStrategy) (Host, error) {
+	return h.client.WaitForHostStatus(h.id, status, retries...)
+}
@@ -0,0 +1,47 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+)
+
+func (o *oVirtClient) ActivateHost(id HostID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("activating host %s", id),
+		o.logger,
+		o.requestHooks("ActivateHost"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().HostsService().HostService(string(id)).Activate().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) ActivateHost(id HostID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, ok := m.hosts[id]
+	if !ok {
+		return newError(ENotFound, "host with ID %s not found", id)
+	}
+	switch item.status {
+	case HostStatusUp:
+		return nil
+	case HostStatusMaintenance, HostStatusNonOperational:
+	default:
+		return newError(EConflict, "host %s is in status %s and cannot be activated", id, item.status)
+	}
+	m.hosts[id] = item.withStatus(HostStatusInitializing)
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if item, ok := m.hosts[id]; ok && item.status == HostStatusInitializing {
+			m.hosts[id] = item.withStatus(HostStatusUp)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,88 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+)
+
+func (o *oVirtClient) DeactivateHost(id HostID, params DeactivateHostParameters, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewDeactivateHostParams()
+	}
+	err = retry(
+		fmt.Sprintf("deactivating host %s", id),
+		o.logger,
+		o.requestHooks("DeactivateHost"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().HostsService().HostService(string(id)).Deactivate()
+			if reason := params.Reason(); reason != nil {
+				request.Reason(*reason)
+			}
+			if stopGlusterService := params.StopGlusterService(); stopGlusterService != nil {
+				request.StopGlusterService(*stopGlusterService)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) DeactivateHost(id HostID, _ DeactivateHostParameters, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, ok := m.hosts[id]
+	if !ok {
+		return newError(ENotFound, "host with ID %s not found", id)
+	}
+	switch item.status {
+	case HostStatusMaintenance, HostStatusPreparingForMaintenance:
+		return newError(EConflict, "host %s is already in status %s", id, item.status)
+	case HostStatusReboot, HostStatusInstalling, HostStatusInstallingOS:
+		return newError(EConflict, "host %s is in status %s and cannot be deactivated", id, item.status)
+	}
+
+	// Find a target for each VM before changing anything so a failed evacuation leaves the mock untouched.
+	targets := map[VMID]HostID{}
+	for _, vm := range m.vms {
+		if vm.hostID == nil || *vm.hostID != id || vm.status == VMStatusDown {
+			continue
+		}
+		if err := m.checkVMNotLocked(vm.id); err != nil {
+			return err
+		}
+		if err := checkMockVMMigratable(vm, false); err != nil {
+			return wrap(err, EVMNotMigratable, "cannot evacuate host %s", id)
+		}
+		targetHostID, err := m.findMigrationTargetHost(vm, nil)
+		if err != nil {
+			return wrap(err, EConflict, "cannot evacuate host %s", id)
+		}
+		targets[vm.id] = targetHostID
+	}
+
+	for vmID := range targets {
+		m.vms[vmID].status = VMStatusMigrating
+	}
+	m.hosts[id] = item.withStatus(HostStatusPreparingForMaintenance)
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		for vmID, targetHostID := range targets {
+			vm, ok := m.vms[vmID]
+			if !ok || vm.status != VMStatusMigrating {
+				continue
+			}
+			hostID := targetHostID
+			vm.hostID = &hostID
+			vm.status = VMStatusUp
+		}
+		if item, ok := m.hosts[id]; ok && item.status == HostStatusPreparingForMaintenance {
+			m.hosts[id] = item.withStatus(HostStatusMaintenance)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,58 @@
+// This file contains tests for evacuating hosts in the mock client, as the live tests cannot control the number of
+// hosts and VMs placed on them. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestMockHostEvacuationHonorsAffinityGroups(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	clusters, err := client.ListClusters()
+	if err != nil || len(clusters) == 0 {
+		t.Fatalf("Failed to list clusters (%v)", err)
+	}
+	clusterID := clusters[0].ID()
+	if _, err := client.AddHost(clusterID); err != nil {
+		t.Fatalf("Failed to add host (%v)", err)
+	}
+	ag, err := client.CreateAffinityGroup(
+		clusterID,
+		"evacuation",
+		CreateAffinityGroupParams().MustWithVMsRuleParameters(true, AffinityNegative, true),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create affinity group (%v)", err)
+	}
+
+	var vms []VM
+	for _, name := range []string{"evacuation-1", "evacuation-2"} {
+		vm, err := client.CreateVM(clusterID, DefaultBlankTemplateID, name, nil)
+		if err != nil {
+			t.Fatalf("Failed to create VM (%v)", err)
+		}
+		if err := ag.AddVM(vm.ID()); err != nil {
+			t.Fatalf("Failed to add VM to affinity group (%v)", err)
+		}
+		if err := vm.Start(); err != nil {
+			t.Fatalf("Failed to start VM (%v)", err)
+		}
+		if vm, err = vm.WaitForStatus(VMStatusUp); err != nil {
+			t.Fatalf("Failed to wait for VM to start (%v)", err)
+		}
+		vms = append(vms, vm)
+	}
+	if *vms[0].HostID() == *vms[1].HostID() {
+		t.Fatalf("Both VMs were started on the same host despite the affinity group.")
+	}
+
+	if err := client.MigrateVM(vms[0].ID(), nil); err == nil || !HasErrorCode(err, EConflict) {
+		t.Fatalf("Migrating against the affinity group did not return an EConflict error (%v)", err)
+	}
+	err = client.DeactivateHost(*vms[0].HostID(), nil)
+	if err == nil || !HasErrorCode(err, EConflict) {
+		t.Fatalf("Evacuating against the affinity group did not return an EConflict error (%v)", err)
+	}
+}
@@ -11,6 +11,7 @@ func (o *oVirtClient) GetHost(id HostID, retries ...RetryStrategy) (result Host,
 	err = retry(
 		fmt.Sprintf("getting host %s", id),
 		o.logger,
+		o.requestHooks("GetHost"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().HostsService().HostService(string(id)).Get().Send()
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListHosts(retries ...RetryStrategy) (result []Host, err er
 	err = retry(
 		"listing hosts",
 		o.logger,
+		o.requestHooks("ListHosts"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().HostsService().List().Send()
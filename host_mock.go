@@ -0,0 +1,22 @@
+package ovirtclient
+
+import (
+	"github.com/google/uuid"
+)
+
+func (m *mockClient) AddHost(clusterID ClusterID) (Host, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.clusters[clusterID]; !ok {
+		return nil, newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	h := &host{
+		client:    m,
+		id:        HostID(uuid.NewString()),
+		clusterID: clusterID,
+		status:    HostStatusUp,
+	}
+	m.hosts[h.id] = h
+	return h, nil
+}
@@ -11,6 +11,7 @@ func (o *oVirtClient) ListHostNICs(
 	err = retry(
 		"listing host nics",
 		o.logger,
+		o.requestHooks("ListHostNICs"),
 		retries,
 		func() error {
 			response, e := o.conn.
@@ -0,0 +1,69 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) RestartHost(id HostID, params RestartHostParameters, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewRestartHostParams()
+	}
+	err = retry(
+		fmt.Sprintf("restarting host %s", id),
+		o.logger,
+		o.requestHooks("RestartHost"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().HostsService().HostService(string(id)).Fence().
+				FenceType(string(ovirtsdk.FENCETYPE_RESTART))
+			if maintenanceAfterRestart := params.MaintenanceAfterRestart(); maintenanceAfterRestart != nil {
+				request.MaintenanceAfterRestart(*maintenanceAfterRestart)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RestartHost(id HostID, params RestartHostParameters, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewRestartHostParams()
+	}
+	item, ok := m.hosts[id]
+	if !ok {
+		return newError(ENotFound, "host with ID %s not found", id)
+	}
+	if item.status == HostStatusReboot {
+		return newError(EConflict, "host %s is already restarting", id)
+	}
+	finalStatus := HostStatusUp
+	if maintenanceAfterRestart := params.MaintenanceAfterRestart(); maintenanceAfterRestart != nil &&
+		*maintenanceAfterRestart {
+		finalStatus = HostStatusMaintenance
+	}
+
+	// Fencing the host kills all virtual machines running on it.
+	for _, vm := range m.vms {
+		if vm.hostID != nil && *vm.hostID == id {
+			vm.status = VMStatusDown
+			vm.hostID = nil
+		}
+	}
+	m.hosts[id] = item.withStatus(HostStatusReboot)
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if item, ok := m.hosts[id]; ok && item.status == HostStatusReboot {
+			m.hosts[id] = item.withStatus(finalStatus)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,93 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestHostMaintenanceEvacuatesVMs(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+	sourceHostID := *vm.HostID()
+
+	host, err := helper.GetClient().GetHost(sourceHostID)
+	if err != nil {
+		t.Fatalf("Failed to get host %s (%v)", sourceHostID, err)
+	}
+	assertCanDeactivateHost(t, host)
+
+	vm, err = vm.WaitForStatus(ovirtclient.VMStatusUp)
+	if err != nil {
+		t.Fatalf("Failed to wait for VM %s to come up after evacuation (%v)", vm.ID(), err)
+	}
+	if vm.HostID() == nil || *vm.HostID() == sourceHostID {
+		t.Fatalf("VM %s was not evacuated from host %s.", vm.ID(), sourceHostID)
+	}
+}
+
+func TestHostMaintenanceFailsWithPinnedVM(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	hosts := assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().WithPlacementPolicy(
+			ovirtclient.
+				NewVMPlacementPolicyParameters().
+				MustWithAffinity(ovirtclient.VMAffinityPinned).
+				MustWithHostIDs([]ovirtclient.HostID{hosts[0].ID()}),
+		),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	assertCanAttachDiskWithParams(
+		t,
+		vm,
+		disk,
+		ovirtclient.CreateDiskAttachmentParams().MustWithBootable(true).MustWithActive(true),
+	)
+	assertCanStartVM(t, helper, vm)
+	assertVMWillStart(t, vm)
+
+	err := hosts[0].Deactivate(nil)
+	if err == nil {
+		t.Cleanup(func() {
+			_ = hosts[0].Activate()
+		})
+		t.Fatalf("Deactivating a host with a pinned VM did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EVMNotMigratable) {
+		t.Fatalf("Deactivating a host with a pinned VM did not return an EVMNotMigratable error (%v).", err)
+	}
+}
+
+// assertCanDeactivateHost puts the host into maintenance, waits for it to reach the maintenance status and registers a
+// cleanup function to activate the host again.
+func assertCanDeactivateHost(t *testing.T, host ovirtclient.Host) ovirtclient.Host {
+	reason := "go-ovirt-client test " + t.Name()
+	if err := host.Deactivate(ovirtclient.NewDeactivateHostParams().MustWithReason(reason)); err != nil {
+		t.Fatalf("Failed to deactivate host %s (%v)", host.ID(), err)
+	}
+	t.Cleanup(func() {
+		if err := host.Activate(); err != nil {
+			t.Fatalf("Failed to activate host %s (%v)", host.ID(), err)
+		}
+		if _, err := host.WaitForStatus(ovirtclient.HostStatusUp); err != nil {
+			t.Fatalf("Failed to wait for host %s to come up (%v)", host.ID(), err)
+		}
+	})
+	result, err := host.WaitForStatus(ovirtclient.HostStatusMaintenance)
+	if err != nil {
+		t.Fatalf("Failed to wait for host %s to enter maintenance (%v)", host.ID(), err)
+	}
+	return result
+}
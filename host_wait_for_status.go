@@ -0,0 +1,53 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForHostStatus(
+	id HostID,
+	status HostStatus,
+	retries ...RetryStrategy,
+) (result Host, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for host %s to enter status \"%s\"", id, status),
+		o.logger,
+		o.waitHooks("WaitForHostStatus"),
+		retries,
+		func() error {
+			result, err = o.GetHost(id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "Host %s status is \"%s\", not \"%s\".", id, result.Status(), status)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) WaitForHostStatus(
+	id HostID,
+	status HostStatus,
+	retries ...RetryStrategy,
+) (result Host, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for host %s to enter status \"%s\"", id, status),
+		nil,
+		nil,
+		retries,
+		func() error {
+			result, err = m.GetHost(id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(EPending, "Host %s status is \"%s\", not \"%s\".", id, result.Status(), status)
+			}
+			return nil
+		})
+	return
+}
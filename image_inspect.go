@@ -0,0 +1,469 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ImageFileFormat is the format of an image file as detected by InspectImage. Unlike ImageFormat, which only contains
+// the formats disks can be stored in, it also contains the formats that have to be converted before uploading.
+type ImageFileFormat string
+
+const (
+	// ImageFileFormatRaw is an image file without a recognized header, containing the raw bytes of the disk.
+	ImageFileFormatRaw ImageFileFormat = "raw"
+	// ImageFileFormatQCOW2 is an image file in the QCOW2 format.
+	ImageFileFormatQCOW2 ImageFileFormat = "qcow2"
+	// ImageFileFormatISO is an ISO 9660 CD-ROM image. It is uploaded as a raw image.
+	ImageFileFormatISO ImageFileFormat = "iso"
+	// ImageFileFormatVMDK is a VMware disk image. It must be converted before uploading.
+	ImageFileFormatVMDK ImageFileFormat = "vmdk"
+	// ImageFileFormatVHD is a Virtual PC or Hyper-V disk image. It must be converted before uploading.
+	ImageFileFormatVHD ImageFileFormat = "vhd"
+	// ImageFileFormatVHDX is a Hyper-V disk image. It must be converted before uploading.
+	ImageFileFormatVHDX ImageFileFormat = "vhdx"
+)
+
+// ImageFileFormatList is a list of ImageFileFormat values.
+type ImageFileFormatList []ImageFileFormat
+
+// ImageFileFormatValues returns all possible ImageFileFormat values.
+func ImageFileFormatValues() ImageFileFormatList {
+	return []ImageFileFormat{
+		ImageFileFormatRaw,
+		ImageFileFormatQCOW2,
+		ImageFileFormatISO,
+		ImageFileFormatVMDK,
+		ImageFileFormatVHD,
+		ImageFileFormatVHDX,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l ImageFileFormatList) Strings() []string {
+	result := make([]string, len(l))
+	for i, format := range l {
+		result[i] = string(format)
+	}
+	return result
+}
+
+// ImageInfo describes an image file inspected by InspectImage.
+type ImageInfo interface {
+	// FileFormat returns the detected format of the image file.
+	FileFormat() ImageFileFormat
+	// Format returns the format the image has to be uploaded as.
+	Format() ImageFormat
+	// FileSize returns the size of the image file in bytes.
+	FileSize() uint64
+	// VirtualSize returns the size of the disk contained in the image in bytes. For raw images this is the file size.
+	VirtualSize() uint64
+	// QCOWVersion returns the version of the QCOW2 header (2 or 3), or 0 for other formats.
+	QCOWVersion() uint32
+	// QCOWCompat returns the compatibility level of the QCOW2 image as used by qemu-img ("0.10" for version 2, "1.1"
+	// for version 3), or an empty string for other formats.
+	QCOWCompat() string
+	// ClusterSize returns the cluster size of the QCOW2 image in bytes, or 0 for other formats.
+	ClusterSize() uint64
+	// LazyRefcounts returns true if the QCOW2 image has lazy refcounts enabled.
+	LazyRefcounts() bool
+	// Snapshots returns the number of internal snapshots in the QCOW2 image.
+	Snapshots() uint32
+}
+
+// InspectImage reads the headers of the image in reader and returns its format and size. It returns an error with
+// the EBadArgument code if the image cannot be uploaded to oVirt, for example because it is a VMDK or VHD image, or
+// because it is a QCOW2 image with a backing file, encryption, or a damaged header. The reader is positioned at the
+// start of the image when the function returns successfully.
+//
+// The upload functions of the DiskClient run the same inspection before starting the upload.
+func InspectImage(reader io.ReadSeeker) (ImageInfo, error) {
+	size, err := reader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, wrap(err, ELocalIO, "failed to determine the size of the image")
+	}
+	return inspectImage(reader, uint64(size))
+}
+
+// extractImageParameters inspects an image before uploading it and returns the disk format and the virtual size.
+func extractImageParameters(fileSize uint64, reader io.ReadSeeker) (ImageFormat, uint64, error) {
+	info, err := inspectImage(reader, fileSize)
+	if err != nil {
+		return "", 0, err
+	}
+	return info.Format(), info.VirtualSize(), nil
+}
+
+const (
+	qcowMagic = "QFI\xfb"
+	// qcowV2HeaderSize is the size of the version 2 header. Version 3 headers are at least qcowV3HeaderSize bytes.
+	qcowV2HeaderSize = 72
+	qcowV3HeaderSize = 104
+	// qcowMinClusterBits and qcowMaxClusterBits are the cluster sizes qemu accepts (512 bytes to 2 MiB).
+	qcowMinClusterBits = 9
+	qcowMaxClusterBits = 21
+	// qcowMaxBackingFileSize is the longest backing file name qemu accepts.
+	qcowMaxBackingFileSize = 1023
+	qcowMaxRefcountOrder   = 6
+
+	qcowIncompatibleDirty        = 1 << 0
+	qcowIncompatibleCorrupt      = 1 << 1
+	qcowIncompatibleExternalData = 1 << 2
+	qcowIncompatibleCompression  = 1 << 3
+	qcowIncompatibleExtendedL2   = 1 << 4
+	qcowCompatibleLazyRefcounts  = 1 << 0
+
+	vmdkSparseMagic     = "KDMV"
+	vmdkDescriptorMagic = "# Disk DescriptorFile"
+	vhdMagic            = "conectix"
+	vhdFooterSize       = 512
+	vhdxMagic           = "vhdxfile"
+	isoMagic            = "CD001"
+	// isoMagicOffset is the offset of the identifier in the first volume descriptor, which follows the 32 KiB system
+	// area.
+	isoMagicOffset = 32*1024 + 1
+)
+
+// qcowHeader is the QCOW2 header as described in https://github.com/qemu/qemu/blob/master/docs/interop/qcow2.txt.
+// The fields after SnapshotsOffset are only present in version 3.
+type qcowHeader struct {
+	Magic                 [4]byte
+	Version               uint32
+	BackingFileOffset     uint64
+	BackingFileSize       uint32
+	ClusterBits           uint32
+	Size                  uint64
+	CryptMethod           uint32
+	L1Size                uint32
+	L1TableOffset         uint64
+	RefcountTableOffset   uint64
+	RefcountTableClusters uint32
+	NBSnapshots           uint32
+	SnapshotsOffset       uint64
+	IncompatibleFeatures  uint64
+	CompatibleFeatures    uint64
+	AutoclearFeatures     uint64
+	RefcountOrder         uint32
+	HeaderLength          uint32
+}
+
+func inspectImage(reader io.ReadSeeker, fileSize uint64) (*imageInfo, error) {
+	if fileSize == 0 {
+		return nil, newError(EBadArgument, "expected positive image size, got 0 instead")
+	}
+	header, err := readImageAt(reader, 0, qcowV3HeaderSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *imageInfo
+	switch {
+	case bytes.HasPrefix(header, []byte(qcowMagic)):
+		info, err = inspectQCOW(reader, header, fileSize)
+	case bytes.HasPrefix(header, []byte(vmdkSparseMagic)) || bytes.HasPrefix(header, []byte(vmdkDescriptorMagic)):
+		err = newUnsupportedImageFormatError(ImageFileFormatVMDK)
+	case bytes.HasPrefix(header, []byte(vhdxMagic)):
+		err = newUnsupportedImageFormatError(ImageFileFormatVHDX)
+	case bytes.HasPrefix(header, []byte(vhdMagic)):
+		// Dynamic VHD images start with a copy of the footer.
+		err = newUnsupportedImageFormatError(ImageFileFormatVHD)
+	default:
+		info, err = inspectRawImage(reader, fileSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to seek to the start of the image")
+	}
+	return info, nil
+}
+
+// inspectRawImage checks the signatures that are not at the start of the image and returns the raw image info if the
+// image can be uploaded.
+func inspectRawImage(reader io.ReadSeeker, fileSize uint64) (*imageInfo, error) {
+	// Fixed VHD images only have a footer.
+	if fileSize >= vhdFooterSize {
+		footer, err := readImageAt(reader, int64(fileSize-vhdFooterSize), len(vhdMagic))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(footer, []byte(vhdMagic)) {
+			return nil, newUnsupportedImageFormatError(ImageFileFormatVHD)
+		}
+	}
+	fileFormat := ImageFileFormatRaw
+	if fileSize >= isoMagicOffset+uint64(len(isoMagic)) {
+		magic, err := readImageAt(reader, isoMagicOffset, len(isoMagic))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(magic, []byte(isoMagic)) {
+			fileFormat = ImageFileFormatISO
+		}
+	}
+	return &imageInfo{
+		fileFormat:  fileFormat,
+		format:      ImageFormatRaw,
+		fileSize:    fileSize,
+		virtualSize: fileSize,
+	}, nil
+}
+
+// inspectQCOW parses and validates the QCOW2 header. Images oVirt cannot use are rejected with an EBadArgument error.
+func inspectQCOW(reader io.ReadSeeker, rawHeader []byte, fileSize uint64) (*imageInfo, error) {
+	if len(rawHeader) < qcowV2HeaderSize {
+		return nil, newError(EBadArgument, "the QCOW2 header is truncated (%d bytes)", len(rawHeader))
+	}
+	header := &qcowHeader{}
+	if len(rawHeader) < qcowV3HeaderSize {
+		rawHeader = append(rawHeader, make([]byte, qcowV3HeaderSize-len(rawHeader))...)
+	}
+	if err := binary.Read(bytes.NewReader(rawHeader), binary.BigEndian, header); err != nil {
+		return nil, wrap(err, EBug, "failed to decode QCOW2 header")
+	}
+
+	info := &imageInfo{
+		fileFormat:  ImageFileFormatQCOW2,
+		format:      ImageFormatCow,
+		fileSize:    fileSize,
+		virtualSize: header.Size,
+		qcowVersion: header.Version,
+		snapshots:   header.NBSnapshots,
+	}
+	switch header.Version {
+	case 2:
+		info.qcowCompat = "0.10"
+	case 3:
+		info.qcowCompat = "1.1"
+		if err := checkQCOWV3Header(header, fileSize); err != nil {
+			return nil, err
+		}
+		info.lazyRefcounts = header.CompatibleFeatures&qcowCompatibleLazyRefcounts != 0
+	default:
+		return nil, newError(EBadArgument, "unsupported QCOW version %d, only versions 2 and 3 are supported", header.Version)
+	}
+
+	if header.ClusterBits < qcowMinClusterBits || header.ClusterBits > qcowMaxClusterBits {
+		return nil, newError(
+			EBadArgument,
+			"invalid QCOW2 cluster size: 2^%d bytes, expected between 2^%d and 2^%d bytes",
+			header.ClusterBits,
+			qcowMinClusterBits,
+			qcowMaxClusterBits,
+		)
+	}
+	info.clusterSize = uint64(1) << header.ClusterBits
+	if header.Size == 0 {
+		return nil, newError(EBadArgument, "expected positive image size, got 0 instead")
+	}
+	if header.BackingFileOffset != 0 {
+		return nil, newError(
+			EBadArgument,
+			"the QCOW2 image has a backing file (%s), which oVirt does not accept; merge the backing chain first, for"+
+				" example using qemu-img convert -O qcow2",
+			readQCOWBackingFile(reader, header, fileSize),
+		)
+	}
+	if header.CryptMethod != 0 {
+		return nil, newError(
+			EBadArgument,
+			"the QCOW2 image is encrypted (method %d), which oVirt does not accept",
+			header.CryptMethod,
+		)
+	}
+	if err := checkQCOWTables(header, info.clusterSize, fileSize); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// checkQCOWV3Header validates the fields only present in version 3 headers.
+func checkQCOWV3Header(header *qcowHeader, fileSize uint64) error {
+	if header.HeaderLength < qcowV3HeaderSize || uint64(header.HeaderLength) > fileSize {
+		return newError(EBadArgument, "invalid QCOW2 header length: %d bytes", header.HeaderLength)
+	}
+	incompatible := header.IncompatibleFeatures
+	if incompatible&qcowIncompatibleDirty != 0 {
+		return newError(
+			EBadArgument,
+			"the QCOW2 image was not closed cleanly and its refcounts may be inconsistent; repair it first, for example"+
+				" using qemu-img check -r all",
+		)
+	}
+	if incompatible&qcowIncompatibleCorrupt != 0 {
+		return newError(
+			EBadArgument,
+			"the QCOW2 image is marked as corrupt; repair it first, for example using qemu-img check -r all",
+		)
+	}
+	if incompatible&qcowIncompatibleExternalData != 0 {
+		return newError(EBadArgument, "the QCOW2 image uses an external data file, which oVirt does not accept")
+	}
+	known := uint64(qcowIncompatibleDirty | qcowIncompatibleCorrupt | qcowIncompatibleExternalData |
+		qcowIncompatibleCompression | qcowIncompatibleExtendedL2)
+	if unknown := incompatible &^ known; unknown != 0 {
+		return newError(EBadArgument, "the QCOW2 image uses unknown incompatible features (0x%x)", unknown)
+	}
+	if header.RefcountOrder > qcowMaxRefcountOrder {
+		return newError(EBadArgument, "invalid QCOW2 refcount order: %d", header.RefcountOrder)
+	}
+	return nil
+}
+
+// checkQCOWTables checks that the refcount table, the L1 table and the snapshot table are cluster-aligned and inside
+// the file, and that the L1 table is large enough for the virtual size. A failed check indicates a damaged or
+// truncated image.
+func checkQCOWTables(header *qcowHeader, clusterSize uint64, fileSize uint64) error {
+	if header.RefcountTableOffset == 0 || header.RefcountTableClusters == 0 {
+		return newError(EBadArgument, "the QCOW2 image has no refcount table")
+	}
+	if err := checkQCOWTable(
+		"refcount table",
+		header.RefcountTableOffset,
+		uint64(header.RefcountTableClusters)*clusterSize,
+		clusterSize,
+		fileSize,
+	); err != nil {
+		return err
+	}
+
+	l2Coverage := clusterSize * (clusterSize / 8)
+	requiredL1Size := (header.Size + l2Coverage - 1) / l2Coverage
+	if uint64(header.L1Size) < requiredL1Size {
+		return newError(
+			EBadArgument,
+			"the QCOW2 L1 table has %d entries, but %d are required for the virtual size of %d bytes",
+			header.L1Size,
+			requiredL1Size,
+			header.Size,
+		)
+	}
+	if err := checkQCOWTable(
+		"L1 table",
+		header.L1TableOffset,
+		uint64(header.L1Size)*8,
+		clusterSize,
+		fileSize,
+	); err != nil {
+		return err
+	}
+
+	if header.NBSnapshots > 0 {
+		// Each snapshot table entry is at least 40 bytes long.
+		if err := checkQCOWTable(
+			"snapshot table",
+			header.SnapshotsOffset,
+			uint64(header.NBSnapshots)*40,
+			clusterSize,
+			fileSize,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkQCOWTable(name string, offset uint64, length uint64, clusterSize uint64, fileSize uint64) error {
+	if offset%clusterSize != 0 {
+		return newError(EBadArgument, "the QCOW2 %s at offset %d is not aligned to the cluster size", name, offset)
+	}
+	if offset > fileSize || length > fileSize-offset {
+		return newError(
+			EBadArgument,
+			"the QCOW2 %s (offset %d, %d bytes) extends beyond the end of the file (%d bytes), the image may be"+
+				" truncated",
+			name,
+			offset,
+			length,
+			fileSize,
+		)
+	}
+	return nil
+}
+
+// readQCOWBackingFile returns the backing file name for error messages, or a placeholder if it cannot be read.
+func readQCOWBackingFile(reader io.ReadSeeker, header *qcowHeader, fileSize uint64) string {
+	if header.BackingFileSize == 0 || header.BackingFileSize > qcowMaxBackingFileSize ||
+		header.BackingFileOffset+uint64(header.BackingFileSize) > fileSize {
+		return "unreadable name"
+	}
+	name, err := readImageAt(reader, int64(header.BackingFileOffset), int(header.BackingFileSize))
+	if err != nil {
+		return "unreadable name"
+	}
+	return strings.ToValidUTF8(string(name), "?")
+}
+
+// readImageAt reads up to length bytes from the specified offset. It returns fewer bytes if the image ends before.
+func readImageAt(reader io.ReadSeeker, offset int64, length int) ([]byte, error) {
+	if _, err := reader.Seek(offset, io.SeekStart); err != nil {
+		return nil, wrap(err, ELocalIO, "failed to seek to byte %d of the image", offset)
+	}
+	data := make([]byte, length)
+	n, err := io.ReadFull(reader, data)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, wrap(err, ELocalIO, "failed to read %d bytes at offset %d of the image", length, offset)
+	}
+	return data[:n], nil
+}
+
+func newUnsupportedImageFormatError(format ImageFileFormat) error {
+	return newError(
+		EBadArgument,
+		"the image is in the %s format, which oVirt does not accept; convert it to raw or qcow2 first, for example"+
+			" using qemu-img convert -O qcow2",
+		format,
+	)
+}
+
+type imageInfo struct {
+	fileFormat    ImageFileFormat
+	format        ImageFormat
+	fileSize      uint64
+	virtualSize   uint64
+	qcowVersion   uint32
+	qcowCompat    string
+	clusterSize   uint64
+	lazyRefcounts bool
+	snapshots     uint32
+}
+
+func (i *imageInfo) FileFormat() ImageFileFormat {
+	return i.fileFormat
+}
+
+func (i *imageInfo) Format() ImageFormat {
+	return i.format
+}
+
+func (i *imageInfo) FileSize() uint64 {
+	return i.fileSize
+}
+
+func (i *imageInfo) VirtualSize() uint64 {
+	return i.virtualSize
+}
+
+func (i *imageInfo) QCOWVersion() uint32 {
+	return i.qcowVersion
+}
+
+func (i *imageInfo) QCOWCompat() string {
+	return i.qcowCompat
+}
+
+func (i *imageInfo) ClusterSize() uint64 {
+	return i.clusterSize
+}
+
+func (i *imageInfo) LazyRefcounts() bool {
+	return i.lazyRefcounts
+}
+
+func (i *imageInfo) Snapshots() uint32 {
+	return i.snapshots
+}
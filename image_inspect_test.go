@@ -0,0 +1,190 @@
+package ovirtclient_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestInspectQCOW2Image(t *testing.T) {
+	t.Parallel()
+
+	info, err := ovirtclient.InspectImage(bytes.NewReader(newInspectTestQCOW(func(h *inspectTestQCOWHeader) {
+		h.CompatibleFeatures = 1
+	})))
+	if err != nil {
+		t.Fatalf("Failed to inspect valid QCOW2 image (%v)", err)
+	}
+	if info.FileFormat() != ovirtclient.ImageFileFormatQCOW2 {
+		t.Fatalf("Incorrect file format: %s", info.FileFormat())
+	}
+	if info.Format() != ovirtclient.ImageFormatCow {
+		t.Fatalf("Incorrect upload format: %s", info.Format())
+	}
+	if info.VirtualSize() != 1024*1024 {
+		t.Fatalf("Incorrect virtual size: %d", info.VirtualSize())
+	}
+	if info.ClusterSize() != 64*1024 {
+		t.Fatalf("Incorrect cluster size: %d", info.ClusterSize())
+	}
+	if info.QCOWVersion() != 3 || info.QCOWCompat() != "1.1" {
+		t.Fatalf("Incorrect QCOW version: %d (compat %s)", info.QCOWVersion(), info.QCOWCompat())
+	}
+	if !info.LazyRefcounts() {
+		t.Fatalf("Lazy refcounts not detected.")
+	}
+}
+
+func TestInspectRejectedQCOW2Images(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]func(h *inspectTestQCOWHeader){
+		"backing file": func(h *inspectTestQCOWHeader) {
+			h.BackingFileOffset = 512
+			h.BackingFileSize = 8
+		},
+		"encryption": func(h *inspectTestQCOWHeader) {
+			h.CryptMethod = 2
+		},
+		"dirty": func(h *inspectTestQCOWHeader) {
+			h.IncompatibleFeatures = 1
+		},
+		"external data file": func(h *inspectTestQCOWHeader) {
+			h.IncompatibleFeatures = 4
+		},
+		"unsupported version": func(h *inspectTestQCOWHeader) {
+			h.Version = 1
+		},
+		"invalid cluster size": func(h *inspectTestQCOWHeader) {
+			h.ClusterBits = 30
+		},
+		"refcount table beyond file": func(h *inspectTestQCOWHeader) {
+			h.RefcountTableClusters = 100
+		},
+		"unaligned L1 table": func(h *inspectTestQCOWHeader) {
+			h.L1TableOffset = 3*64*1024 + 8
+		},
+		"L1 table too small": func(h *inspectTestQCOWHeader) {
+			h.Size = 1024 * 1024 * 1024
+		},
+	}
+	for name, modify := range testCases {
+		name, modify := name, modify
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ovirtclient.InspectImage(bytes.NewReader(newInspectTestQCOW(modify)))
+			if err == nil {
+				t.Fatalf("Inspecting a QCOW2 image with %s did not fail.", name)
+			}
+			if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+				t.Fatalf("Inspecting a QCOW2 image with %s did not return an EBadArgument error (%v).", name, err)
+			}
+		})
+	}
+}
+
+func TestInspectOtherImageFormats(t *testing.T) {
+	t.Parallel()
+
+	iso := make([]byte, 64*1024)
+	copy(iso[32*1024+1:], "CD001")
+	vhd := make([]byte, 64*1024)
+	copy(vhd[len(vhd)-512:], "conectix")
+	testImage, _ := getTestImageData(t)
+
+	testCases := map[string]struct {
+		image      []byte
+		fileFormat ovirtclient.ImageFileFormat
+		rejected   bool
+	}{
+		"raw":             {testImage, ovirtclient.ImageFileFormatRaw, false},
+		"iso":             {iso, ovirtclient.ImageFileFormatISO, false},
+		"vmdk":            {append([]byte("KDMV"), make([]byte, 1024)...), ovirtclient.ImageFileFormatVMDK, true},
+		"vmdk descriptor": {[]byte("# Disk DescriptorFile\nversion=1\n"), ovirtclient.ImageFileFormatVMDK, true},
+		"vhd":             {vhd, ovirtclient.ImageFileFormatVHD, true},
+		"vhdx":            {append([]byte("vhdxfile"), make([]byte, 1024)...), ovirtclient.ImageFileFormatVHDX, true},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			info, err := ovirtclient.InspectImage(bytes.NewReader(testCase.image))
+			if testCase.rejected {
+				if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+					t.Fatalf("Inspecting a %s image did not return an EBadArgument error (%v).", testCase.fileFormat, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to inspect %s image (%v)", testCase.fileFormat, err)
+			}
+			if info.FileFormat() != testCase.fileFormat {
+				t.Fatalf("Incorrect file format: %s instead of %s", info.FileFormat(), testCase.fileFormat)
+			}
+			if info.Format() != ovirtclient.ImageFormatRaw {
+				t.Fatalf("Incorrect upload format: %s", info.Format())
+			}
+			if info.VirtualSize() != uint64(len(testCase.image)) {
+				t.Fatalf("Incorrect virtual size: %d", info.VirtualSize())
+			}
+		})
+	}
+}
+
+func TestUploadRejectsUnsupportedImage(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	image := append([]byte("KDMV"), make([]byte, 1024*1024)...)
+	_, err := helper.GetClient().UploadToNewDisk(
+		helper.GetStorageDomainID(),
+		"",
+		uint64(len(image)),
+		ovirtclient.CreateDiskParams().MustWithAlias(helper.GenerateTestResourceName(t)),
+		&nopReadCloser{bytes.NewReader(image)},
+		ovirtclient.MaxTries(1),
+	)
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Uploading a VMDK image did not return an EBadArgument error (%v).", err)
+	}
+}
+
+// inspectTestQCOWHeader is a version 3 QCOW2 header for building test images.
+type inspectTestQCOWHeader struct {
+	qcowHeader
+	IncompatibleFeatures uint64
+	CompatibleFeatures   uint64
+	AutoclearFeatures    uint64
+	RefcountOrder        uint32
+	HeaderLength         uint32
+}
+
+// newInspectTestQCOW creates a QCOW2 image with a virtual size of 1 MiB and 64 KiB clusters. The refcount table is in
+// the second cluster and the L1 table in the fourth. The modify function can change the header before it is written.
+func newInspectTestQCOW(modify func(h *inspectTestQCOWHeader)) []byte {
+	header := &inspectTestQCOWHeader{
+		qcowHeader: qcowHeader{
+			Magic:                 [4]byte{'Q', 'F', 'I', 0xfb},
+			Version:               3,
+			ClusterBits:           16,
+			Size:                  1024 * 1024,
+			L1Size:                1,
+			L1TableOffset:         3 * 64 * 1024,
+			RefcountTableOffset:   64 * 1024,
+			RefcountTableClusters: 1,
+		},
+		RefcountOrder: 4,
+		HeaderLength:  104,
+	}
+	if modify != nil {
+		modify(header)
+	}
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.BigEndian, header)
+	image := make([]byte, 4*64*1024)
+	copy(image, buf.Bytes())
+	copy(image[512:], "base.img")
+	return image
+}
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetInstanceType(id InstanceTypeID, retries ...RetryStrateg
 	err = retry(
 		fmt.Sprintf("getting instance type %s", id),
 		o.logger,
+		o.requestHooks("GetInstanceType"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().InstanceTypesService().InstanceTypeService(string(id)).Get().Send()
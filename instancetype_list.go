@@ -6,6 +6,7 @@ func (o *oVirtClient) ListInstanceTypes(retries ...RetryStrategy) (result []Inst
 	err = retry(
 		"listing instance types",
 		o.logger,
+		o.requestHooks("ListInstanceTypes"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().InstanceTypesService().List().Send()
@@ -0,0 +1,503 @@
+package ovirtclient
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// JobID is the identifier for engine jobs.
+type JobID string
+
+// JobStepID is the identifier for the steps of an engine job.
+type JobStepID string
+
+// JobClient describes the functions related to the asynchronous jobs the engine runs, for example when creating a
+// VM or copying a disk.
+//
+// Jobs can be traced back to the call that started them using a correlation ID. Pass your own correlation ID to a
+// call by setting it on the context of the client. Each correlation ID can only be used for a single call:
+//
+//	ctx := ovirtclient.WithCorrelationID(context.Background(), "my-vm-create")
+//	vm, err := client.WithContext(ctx).CreateVM(clusterID, templateID, name, nil)
+//	// ...
+//	jobs, err := client.ListJobs(ovirtclient.NewListJobsParams().MustWithCorrelationID("my-vm-create"))
+type JobClient interface {
+	// ListJobs lists the jobs known to the engine. The params can be used to filter the jobs. Pass nil to list all
+	// jobs.
+	ListJobs(params ListJobsParameters, retries ...RetryStrategy) ([]Job, error)
+	// GetJob returns a single job.
+	GetJob(id JobID, retries ...RetryStrategy) (Job, error)
+	// ListJobSteps lists the steps of the specified job.
+	ListJobSteps(id JobID, retries ...RetryStrategy) ([]JobStep, error)
+	// WaitForJob waits for the job to leave the JobStatusStarted status. If the job failed or was aborted an
+	// EJobFailed error is returned together with the job, containing the descriptions of the failed steps.
+	WaitForJob(id JobID, retries ...RetryStrategy) (Job, error)
+}
+
+// JobData is the core of Job, providing only data access functions.
+type JobData interface {
+	// ID returns the identifier of the job.
+	ID() JobID
+	// Description returns the human-readable description of the job.
+	Description() string
+	// Status returns the current status of the job.
+	Status() JobStatus
+	// StartTime returns the time the job was started.
+	StartTime() time.Time
+	// EndTime returns the time the job ended, or nil if the job has not ended yet.
+	EndTime() *time.Time
+	// LastUpdated returns the time the job was last updated.
+	LastUpdated() time.Time
+	// External returns true if the job was created by an external system through the API.
+	External() bool
+	// AutoCleared returns true if the engine removes the job automatically after it finishes.
+	AutoCleared() bool
+}
+
+// Job is an asynchronous operation running on the engine, consisting of one or more steps.
+type Job interface {
+	JobData
+
+	// Steps lists the steps of the job.
+	Steps(retries ...RetryStrategy) ([]JobStep, error)
+	// Wait waits for the job to finish. See JobClient.WaitForJob for details.
+	Wait(retries ...RetryStrategy) (Job, error)
+}
+
+// JobStep is a single step of a Job.
+type JobStep interface {
+	// ID returns the identifier of the step.
+	ID() JobStepID
+	// JobID returns the ID of the job this step belongs to.
+	JobID() JobID
+	// ParentStepID returns the ID of the parent step, or nil if this is a top-level step.
+	ParentStepID() *JobStepID
+	// Type returns the type of the step.
+	Type() JobStepType
+	// Description returns the human-readable description of the step.
+	Description() string
+	// Number returns the order of the step within the job.
+	Number() int64
+	// Status returns the status of the step.
+	Status() JobStatus
+	// Progress returns the progress of the step in percent, if reported by the engine.
+	Progress() *int64
+	// StartTime returns the time the step was started.
+	StartTime() time.Time
+	// EndTime returns the time the step ended, or nil if the step has not ended yet.
+	EndTime() *time.Time
+	// ExecutionHostID returns the ID of the host the step is executed on, if any.
+	ExecutionHostID() *HostID
+}
+
+// JobStatus is the status of a job or a job step.
+type JobStatus string
+
+const (
+	// JobStatusStarted indicates that the job is still running.
+	JobStatusStarted JobStatus = "started"
+	// JobStatusFinished indicates that the job finished successfully.
+	JobStatusFinished JobStatus = "finished"
+	// JobStatusFailed indicates that the job failed.
+	JobStatusFailed JobStatus = "failed"
+	// JobStatusAborted indicates that the job was aborted, for example because the engine was restarted.
+	JobStatusAborted JobStatus = "aborted"
+	// JobStatusUnknown indicates that the engine does not know the status of the job.
+	JobStatusUnknown JobStatus = "unknown"
+)
+
+// JobStatusList is a list of JobStatus.
+type JobStatusList []JobStatus
+
+// JobStatusValues returns all possible JobStatus values.
+func JobStatusValues() JobStatusList {
+	return []JobStatus{
+		JobStatusStarted,
+		JobStatusFinished,
+		JobStatusFailed,
+		JobStatusAborted,
+		JobStatusUnknown,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l JobStatusList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// Failed returns true if the job ended without finishing successfully.
+func (s JobStatus) Failed() bool {
+	return s == JobStatusFailed || s == JobStatusAborted
+}
+
+// JobStepType is the type of a job step.
+type JobStepType string
+
+const (
+	// JobStepTypeValidating is the step validating the input of the job.
+	JobStepTypeValidating JobStepType = "validating"
+	// JobStepTypeExecuting is the step executing the job.
+	JobStepTypeExecuting JobStepType = "executing"
+	// JobStepTypeFinalizing is the step cleaning up after the job.
+	JobStepTypeFinalizing JobStepType = "finalizing"
+	// JobStepTypeRebalancingVolume is a Gluster volume rebalancing step.
+	JobStepTypeRebalancingVolume JobStepType = "rebalancing_volume"
+	// JobStepTypeRemovingBricks is a Gluster brick removal step.
+	JobStepTypeRemovingBricks JobStepType = "removing_bricks"
+	// JobStepTypeUnknown is a step the engine does not know the type of.
+	JobStepTypeUnknown JobStepType = "unknown"
+)
+
+// JobStepTypeList is a list of JobStepType.
+type JobStepTypeList []JobStepType
+
+// JobStepTypeValues returns all possible JobStepType values.
+func JobStepTypeValues() JobStepTypeList {
+	return []JobStepType{
+		JobStepTypeValidating,
+		JobStepTypeExecuting,
+		JobStepTypeFinalizing,
+		JobStepTypeRebalancingVolume,
+		JobStepTypeRemovingBricks,
+		JobStepTypeUnknown,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l JobStepTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, stepType := range l {
+		result[i] = string(stepType)
+	}
+	return result
+}
+
+// ListJobsParameters are the optional parameters for listing jobs.
+type ListJobsParameters interface {
+	// CorrelationID returns the correlation ID the jobs should be filtered for. May be nil.
+	CorrelationID() *string
+}
+
+// BuildableListJobsParameters is a buildable version of ListJobsParameters.
+type BuildableListJobsParameters interface {
+	ListJobsParameters
+
+	// WithCorrelationID only returns jobs started with the specified correlation ID.
+	WithCorrelationID(correlationID string) (BuildableListJobsParameters, error)
+	// MustWithCorrelationID is identical to WithCorrelationID, but panics instead of returning an error.
+	MustWithCorrelationID(correlationID string) BuildableListJobsParameters
+}
+
+// NewListJobsParams creates a buildable set of ListJobsParameters to pass to the ListJobs function.
+func NewListJobsParams() BuildableListJobsParameters {
+	return &listJobsParams{}
+}
+
+type listJobsParams struct {
+	correlationID *string
+}
+
+func (l *listJobsParams) CorrelationID() *string {
+	return l.correlationID
+}
+
+func (l *listJobsParams) WithCorrelationID(correlationID string) (BuildableListJobsParameters, error) {
+	if err := validateCorrelationID(correlationID); err != nil {
+		return nil, err
+	}
+	l.correlationID = &correlationID
+	return l, nil
+}
+
+func (l *listJobsParams) MustWithCorrelationID(correlationID string) BuildableListJobsParameters {
+	builder, err := l.WithCorrelationID(correlationID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+type correlationIDContextKey struct{}
+
+// correlationIDClaim is the correlation ID stored in a context. It records if a call has used the correlation ID, so
+// the jobs of two calls cannot be mixed up.
+type correlationIDClaim struct {
+	correlationID string
+	used          uint32
+}
+
+// WithCorrelationID returns a copy of the context carrying the specified correlation ID. Pass the context to
+// Client.WithContext to have the engine tag the jobs started by the next call of the resulting client with the
+// correlation ID. Calls that support correlation IDs include CreateVM, CreateTemplate, CreateDisk and
+// CopyTemplateDiskToStorageDomain. The correlation ID should be unique and may only contain letters, numbers,
+// dashes and underscores.
+//
+// The correlation ID can only be used for a single call. Further calls supporting correlation IDs made with the
+// context, or a context derived from it, fail with an EBadArgument error. Set a new correlation ID for each call.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, &correlationIDClaim{correlationID: correlationID})
+}
+
+// CorrelationIDFromContext returns the correlation ID set using WithCorrelationID, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	claim := correlationIDClaimFromContext(ctx)
+	if claim == nil {
+		return "", false
+	}
+	return claim.correlationID, true
+}
+
+func correlationIDClaimFromContext(ctx context.Context) *correlationIDClaim {
+	if ctx == nil {
+		return nil
+	}
+	claim, _ := ctx.Value(correlationIDContextKey{}).(*correlationIDClaim)
+	return claim
+}
+
+// validateCorrelationID checks that the correlation ID can be safely used in a search query.
+func validateCorrelationID(correlationID string) error {
+	if correlationID == "" {
+		return newError(EBadArgument, "correlation ID must not be empty")
+	}
+	for _, c := range correlationID {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return newError(
+				EBadArgument,
+				"correlation ID %s contains invalid character %q, only letters, numbers, dashes and underscores are allowed",
+				correlationID,
+				c,
+			)
+		}
+	}
+	return nil
+}
+
+// correlationIDFor returns the correlation ID set on the context, or generates a new one with the specified prefix.
+// A correlation ID set on the context can only be used once.
+func correlationIDFor(ctx context.Context, prefix string, r *rand.Rand) (string, error) {
+	if claim := correlationIDClaimFromContext(ctx); claim != nil {
+		if err := validateCorrelationID(claim.correlationID); err != nil {
+			return "", err
+		}
+		if !atomic.CompareAndSwapUint32(&claim.used, 0, 1) {
+			return "", newError(
+				EBadArgument,
+				"correlation ID %s has already been used by another call, set a new correlation ID for each call",
+				claim.correlationID,
+			)
+		}
+		return claim.correlationID, nil
+	}
+	return fmt.Sprintf("%s%s", prefix, generateRandomID(5, r)), nil
+}
+
+func convertSDKJob(sdkObject *ovirtsdk.Job, client Client) (Job, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("job", "id")
+	}
+	status, ok := sdkObject.Status()
+	if !ok {
+		return nil, newFieldNotFound("job", "status")
+	}
+	startTime, ok := sdkObject.StartTime()
+	if !ok {
+		return nil, newFieldNotFound("job", "start time")
+	}
+	description, _ := sdkObject.Description()
+	lastUpdated, _ := sdkObject.LastUpdated()
+	external, _ := sdkObject.External()
+	autoCleared, _ := sdkObject.AutoCleared()
+	result := &job{
+		client:      client,
+		id:          JobID(id),
+		description: description,
+		status:      JobStatus(status),
+		startTime:   startTime,
+		lastUpdated: lastUpdated,
+		external:    external,
+		autoCleared: autoCleared,
+	}
+	if endTime, ok := sdkObject.EndTime(); ok {
+		result.endTime = &endTime
+	}
+	return result, nil
+}
+
+func convertSDKJobStep(sdkObject *ovirtsdk.Step, jobID JobID) (JobStep, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("step", "id")
+	}
+	status, ok := sdkObject.Status()
+	if !ok {
+		return nil, newFieldNotFound("step", "status")
+	}
+	stepType, ok := sdkObject.Type()
+	if !ok {
+		return nil, newFieldNotFound("step", "type")
+	}
+	description, _ := sdkObject.Description()
+	number, _ := sdkObject.Number()
+	startTime, _ := sdkObject.StartTime()
+	result := &jobStep{
+		id:          JobStepID(id),
+		jobID:       jobID,
+		stepType:    JobStepType(stepType),
+		description: description,
+		number:      number,
+		status:      JobStatus(status),
+		startTime:   startTime,
+	}
+	if parent, ok := sdkObject.ParentStep(); ok {
+		if parentID, ok := parent.Id(); ok {
+			stepID := JobStepID(parentID)
+			result.parentStepID = &stepID
+		}
+	}
+	if progress, ok := sdkObject.Progress(); ok {
+		result.progress = &progress
+	}
+	if endTime, ok := sdkObject.EndTime(); ok {
+		result.endTime = &endTime
+	}
+	if host, ok := sdkObject.ExecutionHost(); ok {
+		if hostID, ok := host.Id(); ok {
+			id := HostID(hostID)
+			result.executionHostID = &id
+		}
+	}
+	return result, nil
+}
+
+// jobFailedError creates an EJobFailed error from the job and the descriptions of its failed steps.
+func jobFailedError(j Job, steps []JobStep) error {
+	failures := ""
+	for _, step := range steps {
+		if step.Status().Failed() {
+			failures += fmt.Sprintf("; step %d %s: %s", step.Number(), step.Status(), step.Description())
+		}
+	}
+	return newError(EJobFailed, "job %s (%s) %s%s", j.ID(), j.Description(), j.Status(), failures)
+}
+
+type job struct {
+	client Client
+
+	id          JobID
+	description string
+	status      JobStatus
+	startTime   time.Time
+	endTime     *time.Time
+	lastUpdated time.Time
+	external    bool
+	autoCleared bool
+}
+
+func (j job) ID() JobID {
+	return j.id
+}
+
+func (j job) Description() string {
+	return j.description
+}
+
+func (j job) Status() JobStatus {
+	return j.status
+}
+
+func (j job) StartTime() time.Time {
+	return j.startTime
+}
+
+func (j job) EndTime() *time.Time {
+	return j.endTime
+}
+
+func (j job) LastUpdated() time.Time {
+	return j.lastUpdated
+}
+
+func (j job) External() bool {
+	return j.external
+}
+
+func (j job) AutoCleared() bool {
+	return j.autoCleared
+}
+
+func (j job) Steps(retries ...RetryStrategy) ([]JobStep, error) {
+	return j.client.ListJobSteps(j.id, retries...)
+}
+
+func (j job) Wait(retries ...RetryStrategy) (Job, error) {
+	return j.client.WaitForJob(j.id, retries...)
+}
+
+type jobStep struct {
+	id              JobStepID
+	jobID           JobID
+	parentStepID    *JobStepID
+	stepType        JobStepType
+	description     string
+	number          int64
+	status          JobStatus
+	progress        *int64
+	startTime       time.Time
+	endTime         *time.Time
+	executionHostID *HostID
+}
+
+func (j jobStep) ID() JobStepID {
+	return j.id
+}
+
+func (j jobStep) JobID() JobID {
+	return j.jobID
+}
+
+func (j jobStep) ParentStepID() *JobStepID {
+	return j.parentStepID
+}
+
+func (j jobStep) Type() JobStepType {
+	return j.stepType
+}
+
+func (j jobStep) Description() string {
+	return j.description
+}
+
+func (j jobStep) Number() int64 {
+	return j.number
+}
+
+func (j jobStep) Status() JobStatus {
+	return j.status
+}
+
+func (j jobStep) Progress() *int64 {
+	return j.progress
+}
+
+func (j jobStep) StartTime() time.Time {
+	return j.startTime
+}
+
+func (j jobStep) EndTime() *time.Time {
+	return j.endTime
+}
+
+func (j jobStep) ExecutionHostID() *HostID {
+	return j.executionHostID
+}
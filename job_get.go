@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetJob(id JobID, retries ...RetryStrategy) (result Job, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting job %s", id),
+		o.logger,
+		o.requestHooks("GetJob"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().JobsService().JobService(string(id)).Get().Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Job()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no job returned when getting job ID %s",
+					id,
+				)
+			}
+			result, err = convertSDKJob(sdkObject, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert job %s",
+					id,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) GetJob(id JobID, _ ...RetryStrategy) (Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if item, ok := m.jobs[id]; ok {
+		return item.copyJob(), nil
+	}
+	return nil, newError(ENotFound, "job with ID %s not found", id)
+}
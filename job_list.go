@@ -0,0 +1,56 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListJobs(params ListJobsParameters, retries ...RetryStrategy) (result []Job, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Job{}
+	err = retry(
+		"listing jobs",
+		o.logger,
+		o.requestHooks("ListJobs"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().JobsService().List()
+			if params != nil {
+				if correlationID := params.CorrelationID(); correlationID != nil {
+					request.Search(fmt.Sprintf("correlation_id=%s", *correlationID))
+				}
+			}
+			response, e := request.Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Jobs()
+			if !ok {
+				return nil
+			}
+			result = make([]Job, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKJob(sdkObject, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert job during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListJobs(params ListJobsParameters, _ ...RetryStrategy) ([]Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	result := make([]Job, 0, len(m.jobs))
+	for _, item := range m.jobs {
+		if params != nil {
+			if correlationID := params.CorrelationID(); correlationID != nil && item.correlationID != *correlationID {
+				continue
+			}
+		}
+		result = append(result, item.copyJob())
+	}
+	return result, nil
+}
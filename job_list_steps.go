@@ -0,0 +1,49 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListJobSteps(id JobID, retries ...RetryStrategy) (result []JobStep, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []JobStep{}
+	err = retry(
+		fmt.Sprintf("listing steps of job %s", id),
+		o.logger,
+		o.requestHooks("ListJobSteps"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().JobsService().JobService(string(id)).StepsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Steps()
+			if !ok {
+				return nil
+			}
+			result = make([]JobStep, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKJobStep(sdkObject, id)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert step during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListJobSteps(id JobID, _ ...RetryStrategy) ([]JobStep, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	item, ok := m.jobs[id]
+	if !ok {
+		return nil, newError(ENotFound, "job with ID %s not found", id)
+	}
+	result := make([]JobStep, len(item.steps))
+	for i, step := range item.steps {
+		stepCopy := *step
+		result[i] = &stepCopy
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package ovirtclient
+
+import (
+	"time"
+)
+
+// mockJob is the mock representation of an engine job. It records the correlation ID, which the engine only exposes
+// as a search criteria.
+type mockJob struct {
+	job
+
+	correlationID string
+	steps         []*jobStep
+}
+
+func (j *mockJob) copyJob() *job {
+	result := j.job
+	return &result
+}
+
+// startJob records a new running job with a single executing step. The caller must hold the mock lock.
+func (m *mockClient) startJob(description string, correlationID string) *mockJob {
+	now := time.Now()
+	item := &mockJob{
+		job: job{
+			client:      m,
+			id:          JobID(m.GenerateUUID()),
+			description: description,
+			status:      JobStatusStarted,
+			startTime:   now,
+			lastUpdated: now,
+			autoCleared: true,
+		},
+		correlationID: correlationID,
+	}
+	item.steps = []*jobStep{
+		{
+			id:          JobStepID(m.GenerateUUID()),
+			jobID:       item.id,
+			stepType:    JobStepTypeExecuting,
+			description: "Executing",
+			number:      0,
+			status:      JobStatusStarted,
+			startTime:   now,
+		},
+	}
+	m.jobs[item.id] = item
+	return item
+}
+
+// endJob marks the job and its running steps with the specified status. The caller must hold the mock lock.
+func (m *mockClient) endJob(item *mockJob, status JobStatus) {
+	now := time.Now()
+	item.status = status
+	item.endTime = &now
+	item.lastUpdated = now
+	for _, step := range item.steps {
+		if step.status == JobStatusStarted {
+			step.status = status
+			step.endTime = &now
+		}
+	}
+}
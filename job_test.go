@@ -0,0 +1,108 @@
+package ovirtclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestCreateVMWithCorrelationID(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	correlationID := fmt.Sprintf("test_%s", helper.GenerateRandomID(10))
+	client := helper.GetClient().WithContext(ovirtclient.WithCorrelationID(context.Background(), correlationID))
+	vm, err := client.CreateVM(
+		helper.GetClusterID(),
+		helper.GetBlankTemplateID(),
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test VM (%v)", err)
+	}
+	t.Cleanup(func() {
+		if err := vm.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove test VM %s (%v)", vm.ID(), err)
+		}
+	})
+
+	jobs, err := helper.GetClient().ListJobs(ovirtclient.NewListJobsParams().MustWithCorrelationID(correlationID))
+	if err != nil {
+		t.Fatalf("Failed to list jobs with correlation ID %s (%v)", correlationID, err)
+	}
+	if len(jobs) == 0 {
+		t.Fatalf("No jobs found with correlation ID %s.", correlationID)
+	}
+	for _, startedJob := range jobs {
+		job, err := startedJob.Wait()
+		if err != nil {
+			t.Fatalf("Failed to wait for job %s (%v)", startedJob.ID(), err)
+		}
+		if job.Status() != ovirtclient.JobStatusFinished {
+			t.Fatalf("Job %s is in status %s, not %s.", job.ID(), job.Status(), ovirtclient.JobStatusFinished)
+		}
+		if job.EndTime() == nil {
+			t.Fatalf("Finished job %s has no end time.", job.ID())
+		}
+		steps, err := job.Steps()
+		if err != nil {
+			t.Fatalf("Failed to list steps of job %s (%v)", job.ID(), err)
+		}
+		for _, step := range steps {
+			if step.JobID() != job.ID() {
+				t.Fatalf("Step %s has job ID %s instead of %s.", step.ID(), step.JobID(), job.ID())
+			}
+		}
+	}
+}
+
+func TestCorrelationIDIsOnlyUsedOnce(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	correlationID := fmt.Sprintf("test_%s", helper.GenerateRandomID(10))
+	client := helper.GetClient().WithContext(ovirtclient.WithCorrelationID(context.Background(), correlationID))
+	vm, err := client.CreateVM(
+		helper.GetClusterID(),
+		helper.GetBlankTemplateID(),
+		helper.GenerateTestResourceName(t),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test VM (%v)", err)
+	}
+	t.Cleanup(func() {
+		if err := vm.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove test VM %s (%v)", vm.ID(), err)
+		}
+	})
+
+	secondVM, err := client.CreateVM(
+		helper.GetClusterID(),
+		helper.GetBlankTemplateID(),
+		helper.GenerateTestResourceName(t)+"-2",
+		nil,
+	)
+	if err == nil {
+		_ = secondVM.Remove()
+		t.Fatalf("A second call reused the correlation ID %s.", correlationID)
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Reusing a correlation ID did not return an EBadArgument error (%v).", err)
+	}
+}
+
+func TestInvalidCorrelationIDIsRejected(t *testing.T) {
+	t.Parallel()
+
+	_, err := ovirtclient.NewListJobsParams().WithCorrelationID("invalid id")
+	if err == nil {
+		t.Fatalf("Setting an invalid correlation ID did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Setting an invalid correlation ID did not return an EBadArgument error (%v).", err)
+	}
+}
@@ -0,0 +1,56 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForJob(id JobID, retries ...RetryStrategy) (result Job, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for job %s to finish", id),
+		o.logger,
+		o.waitHooks("WaitForJob"),
+		retries,
+		func() error {
+			result, err = o.GetJob(id, retries...)
+			if err != nil {
+				return err
+			}
+			return checkJobFinished(o, result, retries)
+		})
+	return
+}
+
+func (m *mockClient) WaitForJob(id JobID, retries ...RetryStrategy) (result Job, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for job %s to finish", id),
+		nil,
+		nil,
+		retries,
+		func() error {
+			result, err = m.GetJob(id, retries...)
+			if err != nil {
+				return err
+			}
+			return checkJobFinished(m, result, retries)
+		})
+	return
+}
+
+// checkJobFinished returns an EPending error while the job is running and an EJobFailed error describing the failed
+// steps if the job did not finish successfully.
+func checkJobFinished(client JobClient, j Job, retries []RetryStrategy) error {
+	switch {
+	case j.Status() == JobStatusStarted:
+		return newError(EPending, "job %s is still %s", j.ID(), j.Status())
+	case j.Status().Failed():
+		steps, err := client.ListJobSteps(j.ID(), retries...)
+		if err != nil {
+			return err
+		}
+		return jobFailedError(j, steps)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package ovirtclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// requestLimiter limits the rate and the number of concurrent requests sent to the oVirt Engine. A single limiter is
+// shared between a client and all clients derived from it using WithContext.
+type requestLimiter struct {
+	// inFlight is a semaphore limiting the number of concurrent requests. Nil if there is no limit.
+	inFlight chan struct{}
+
+	lock       *sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newRequestLimiter creates a limiter allowing requestsPerSecond requests on average with bursts of up to burst
+// requests, and at most maxInFlight concurrent requests. A rate of 0 or less disables rate limiting, a maxInFlight of
+// 0 disables the concurrency limit. Returns nil if neither limit is set.
+func newRequestLimiter(requestsPerSecond float64, burst uint, maxInFlight uint) *requestLimiter {
+	if requestsPerSecond <= 0 && maxInFlight == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = 1
+	}
+	l := &requestLimiter{
+		lock:       &sync.Mutex{},
+		rate:       requestsPerSecond,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// acquire waits until a request may be sent and returns a function that must be called once the request is
+// complete. It returns an error if ctx is canceled while waiting. It is safe to call on a nil receiver.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+	if err := l.waitForToken(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// waitForToken takes a token from the bucket, waiting for one to become available if needed.
+func (l *requestLimiter) waitForToken(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	for {
+		ok, wait := l.takeToken()
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// takeToken refills the bucket and takes a token if one is available. Otherwise, it returns the time until the next
+// token becomes available.
+func (l *requestLimiter) takeToken() (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastRefill = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1-l.tokens)/l.rate*float64(time.Second)) + 1
+}
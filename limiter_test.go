@@ -0,0 +1,203 @@
+// This file contains tests for the internal request limiter. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func TestRequestLimiterRate(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(20, 1, 0)
+	startTime := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to acquire request limiter (%v)", err)
+		}
+		release()
+	}
+	// The first request uses the burst, the remaining 4 have to wait 50ms each.
+	if elapsed := time.Since(startTime); elapsed < 190*time.Millisecond {
+		t.Fatalf("Rate limit was not applied (5 requests took %s)", elapsed)
+	}
+}
+
+func TestRequestLimiterMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	const maxInFlight = 2
+	limiter := newRequestLimiter(0, 0, maxInFlight)
+
+	lock := &sync.Mutex{}
+	inFlight := 0
+	maxSeen := 0
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.acquire(context.Background())
+			if err != nil {
+				t.Errorf("Failed to acquire request limiter (%v)", err)
+				return
+			}
+			defer release()
+			lock.Lock()
+			inFlight++
+			if inFlight > maxSeen {
+				maxSeen = inFlight
+			}
+			lock.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			lock.Lock()
+			inFlight--
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	if maxSeen > maxInFlight {
+		t.Fatalf("Too many requests in flight (expected at most %d, got %d)", maxInFlight, maxSeen)
+	}
+}
+
+func TestRetryHonorsContextWhileQueued(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 0, 1)
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire request limiter (%v)", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	called := false
+	err = retry(
+		"test",
+		nil,
+		&requestHooks{ctx: ctx, limiter: limiter},
+		[]RetryStrategy{
+			ExponentialBackoff(1),
+			ContextStrategy(ctx),
+		},
+		func() error {
+			called = true
+			return nil
+		},
+	)
+	if err == nil {
+		t.Fatalf("Queued call did not fail after the context was canceled.")
+	}
+	if !HasErrorCode(err, ETimeout) {
+		t.Fatalf("Queued call did not return an ETimeout error (%v)", err)
+	}
+	if called {
+		t.Fatalf("Queued call was executed despite the limit.")
+	}
+}
+
+func TestImageTransfersBypassConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRequestLimiter(0, 0, 1)
+	client := &oVirtClient{
+		limiter:  limiter,
+		observer: RequestObserverFunc(func(RequestAttempt) {}),
+	}
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to acquire request limiter (%v)", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	called := false
+	err = retry(
+		"downloading image",
+		nil,
+		client.transferHooks("DownloadImage"),
+		[]RetryStrategy{
+			ExponentialBackoff(1),
+			ContextStrategy(ctx),
+		},
+		func() error {
+			called = true
+			return nil
+		},
+	)
+	if err != nil || !called {
+		t.Fatalf("Image transfer request waited for the engine concurrency limit (%v)", err)
+	}
+}
+
+func TestWaitForDiskOKDoesNotDeadlockWithConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(
+			`<disk id="disk-1"><alias>test</alias><provisioned_size>1048576</provisioned_size>` +
+				`<total_size>1048576</total_size><format>raw</format><status>ok</status><sparse>false</sparse>` +
+				`<storage_domains><storage_domain id="sd-1"/></storage_domains></disk>`,
+		))
+	}))
+	defer server.Close()
+
+	client := newTestEngineClient(t, server)
+	client.limiter = newRequestLimiter(0, 0, 1)
+	client.observer = RequestObserverFunc(func(RequestAttempt) {})
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.WaitForDiskOK("disk-1", ExponentialBackoff(1), MaxTries(3))
+		result <- err
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("Failed to wait for disk (%v)", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Waiting for the disk deadlocked on the concurrency limit.")
+	}
+}
+
+// newTestEngineClient creates a live client sending its requests to a test server in place of the oVirt Engine. It
+// authenticates using a bearer token, so the server does not have to handle SSO requests.
+func newTestEngineClient(t *testing.T, server *httptest.Server) *oVirtClient {
+	auth := BearerTokenAuth("test-token")
+	conn, err := auth.configureConnection(ovirtsdk4.NewConnectionBuilder().URL(server.URL + "/ovirt-engine/api")).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build connection (%v)", err)
+	}
+	tokens := &tokenManager{
+		lock:       &sync.Mutex{},
+		usage:      &sync.RWMutex{},
+		store:      NewMemoryTokenStore(),
+		url:        server.URL + "/ovirt-engine/api",
+		auth:       auth,
+		httpClient: *server.Client(),
+		logger:     &noopLogger{},
+	}
+	if err := tokens.connect(conn, conn, false); err != nil {
+		t.Fatalf("Failed to connect (%v)", err)
+	}
+	return &oVirtClient{
+		conn:   conn,
+		ctx:    context.Background(),
+		logger: &noopLogger{},
+		tokens: tokens,
+	}
+}
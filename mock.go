@@ -16,6 +16,15 @@ type MockClient interface {
 
 	// GenerateUUID generates a UUID for testing purposes.
 	GenerateUUID() string
+	// AddHost adds a host in status up to the cluster. The default mock only has a single host, so tests that need to
+	// move VMs between hosts can use this function to add more.
+	AddHost(clusterID ClusterID) (Host, error)
+	// ListExternalVMs lists the VMs in the fake inventory of the external provider. The live client has no equivalent,
+	// as the oVirt Engine API cannot list the VMs of an external provider.
+	ListExternalVMs(source ExternalVMSource) ([]ExternalVM, error)
+	// AddExternalVM adds a VM with disks of the specified sizes to the fake inventory of the external provider, so it
+	// can be imported using ImportExternalVM. The VM has 1 GiB of memory and 1 CPU.
+	AddExternalVM(source ExternalVMSource, name string, diskSizes ...uint64) (ExternalVM, error)
 }
 
 type mockClient struct {
@@ -35,7 +44,7 @@ type mockClient struct {
 	networks                          map[NetworkID]*network
 	dataCenters                       map[DatacenterID]*datacenterWithClusters
 	vmDiskAttachmentsByVM             map[VMID]map[DiskAttachmentID]*diskAttachment
-	vmDiskAttachmentsByDisk           map[DiskID]*diskAttachment
+	vmDiskAttachmentsByDisk           map[DiskID]map[DiskAttachmentID]*diskAttachment
 	templateDiskAttachmentsByTemplate map[TemplateID][]*templateDiskAttachment
 	templateDiskAttachmentsByDisk     map[DiskID]*templateDiskAttachment
 	tags                              map[TagID]*tag
@@ -44,6 +53,24 @@ type mockClient struct {
 	instanceTypes                     map[InstanceTypeID]*instanceType
 	graphicsConsolesByVM              map[VMID][]*vmGraphicsConsole
 	networkAttachment                 map[NetworkAttachmentID]*networkAttachment
+	snapshots                         map[VMID][]*snapshotWithData
+	events                            map[EventID]*event
+	jobs                              map[JobID]*mockJob
+	backups                           map[VMID][]*backupWithData
+	checkpoints                       map[VMID][]*checkpoint
+	cdromsByVM                        map[VMID][]*mockCdrom
+	diskProfiles                      map[DiskProfileID]*diskProfile
+	defaultDiskProfiles               map[StorageDomainID]DiskProfileID
+	storageQoS                        map[StorageQoSID]*storageQoS
+	transferLimiter                   *bandwidthLimiter
+	storageDomainLocations            map[StorageDomainID]string
+	orphanedStorageDomains            map[string]*storageDomain
+	unregisteredDisks                 map[StorageDomainID]map[DiskID]*diskWithData
+	unregisteredVMs                   map[StorageDomainID]map[VMID]*mockUnregisteredVM
+	ovaFiles                          map[string]*mockOVF
+	exportedVMs                       map[StorageDomainID]map[VMID]*mockOVF
+	exportedTemplates                 map[StorageDomainID]map[TemplateID]*mockOVF
+	externalVMs                       map[string][]*externalVM
 }
 
 func (m *mockClient) WithContext(ctx context.Context) Client {
@@ -73,6 +100,24 @@ func (m *mockClient) WithContext(ctx context.Context) Client {
 		m.instanceTypes,
 		m.graphicsConsolesByVM,
 		m.networkAttachment,
+		m.snapshots,
+		m.events,
+		m.jobs,
+		m.backups,
+		m.checkpoints,
+		m.cdromsByVM,
+		m.diskProfiles,
+		m.defaultDiskProfiles,
+		m.storageQoS,
+		m.transferLimiter,
+		m.storageDomainLocations,
+		m.orphanedStorageDomains,
+		m.unregisteredDisks,
+		m.unregisteredVMs,
+		m.ovaFiles,
+		m.exportedVMs,
+		m.exportedTemplates,
+		m.externalVMs,
 	}
 }
 
@@ -84,6 +129,10 @@ func (m *mockClient) Reconnect() (err error) {
 	return nil
 }
 
+func (m *mockClient) Close() error {
+	return nil
+}
+
 func (m *mockClient) GetURL() string {
 	return m.url
 }
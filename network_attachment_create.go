@@ -17,6 +17,7 @@ func (o *oVirtClient) AttachNetworkToHost(
 	err = retry(
 		fmt.Sprintf("attaching network %s to host %s on nic %s", networkID, hostID, hostNicID),
 		o.logger,
+		o.requestHooks("AttachNetworkToHost"),
 		retries,
 		func() error {
 			hostService := o.conn.SystemService().HostsService().HostService(string(hostID))
@@ -14,6 +14,7 @@ func (o *oVirtClient) GetNetworkAttachment(
 	err = retry(
 		fmt.Sprintf("getting network attachment %s from host %s on nic %s", id, hostID, hostNicID),
 		o.logger,
+		o.requestHooks("GetNetworkAttachment"),
 		retries,
 		func() error {
 			hostService := o.conn.SystemService().HostsService().HostService(string(hostID))
@@ -12,6 +12,7 @@ func (o *oVirtClient) NetworkAttachmentList(
 	err = retry(
 		fmt.Sprintf("getting network attachments from host %s", hostID),
 		o.logger,
+		o.requestHooks("NetworkAttachmentList"),
 		retries,
 		func() error {
 			hostService := o.conn.SystemService().HostsService().HostService(string(hostID))
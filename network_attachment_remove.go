@@ -14,6 +14,7 @@ func (o *oVirtClient) DetachNetworkFromHost(
 	err = retry(
 		fmt.Sprintf("detaching network attachment %s from host %s on nic %s", id, hostID, hostNicID),
 		o.logger,
+		o.requestHooks("DetachNetworkFromHost"),
 		retries,
 		func() error {
 			hostService := o.conn.SystemService().HostsService().HostService(string(hostID))
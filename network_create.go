@@ -22,6 +22,7 @@ func (o *oVirtClient) CreateNetwork(
 	err = retry(
 		fmt.Sprintf("creating network %s", name),
 		o.logger,
+		o.requestHooks("CreateNetwork"),
 		retries,
 		func() error {
 			networkBuilder := ovirtsdk.NewNetworkBuilder()
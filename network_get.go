@@ -11,6 +11,7 @@ func (o *oVirtClient) GetNetwork(id NetworkID, retries ...RetryStrategy) (result
 	err = retry(
 		fmt.Sprintf("getting network %s", id),
 		o.logger,
+		o.requestHooks("GetNetwork"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().NetworksService().NetworkService(string(id)).Get().Send()
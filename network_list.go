@@ -8,6 +8,7 @@ func (o *oVirtClient) ListNetworks(retries ...RetryStrategy) (result []Network,
 	err = retry(
 		"listing networks",
 		o.logger,
+		o.requestHooks("ListNetworks"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().NetworksService().List().Send()
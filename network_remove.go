@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveNetwork(id NetworkID, retries ...RetryStrategy) (err
 	err = retry(
 		fmt.Sprintf("removing network %s", id),
 		o.logger,
+		o.requestHooks("RemoveNetwork"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().NetworksService().NetworkService(string(id)).Remove().Send()
@@ -23,6 +23,7 @@ func (o *oVirtClient) UpdateNetwork(
 	err = retry(
 		fmt.Sprintf("updating network %s", name),
 		o.logger,
+		o.requestHooks("UpdateNetwork"),
 		retries,
 		func() error {
 			networkBuilder := ovirtsdk.NewNetworkBuilder()
@@ -25,9 +25,51 @@ type ExtraSettings interface {
 	Proxy() *string
 }
 
+// ExtraSettingsV2 extends ExtraSettings with request instrumentation.
+type ExtraSettingsV2 interface {
+	ExtraSettings
+
+	// RequestObserver returns the observer that is notified after every attempt at an API call. May be nil.
+	RequestObserver() RequestObserver
+}
+
+// ExtraSettingsV3 extends ExtraSettingsV2 with client-side request limits. The limits apply to every attempt made by
+// the client, including retries, and are shared between the client and all clients derived from it using
+// WithContext.
+type ExtraSettingsV3 interface {
+	ExtraSettingsV2
+
+	// RateLimit returns the average number of requests per second the client may send to the engine. Zero or less
+	// means no limit.
+	RateLimit() float64
+	// RateLimitBurst returns the number of requests that may be sent at once before the rate limit applies.
+	RateLimitBurst() uint
+	// MaxConcurrentRequests returns the maximum number of requests that may be in flight at the same time. Zero means
+	// no limit.
+	MaxConcurrentRequests() uint
+}
+
+// ExtraSettingsV4 extends ExtraSettingsV3 with SSO token persistence.
+type ExtraSettingsV4 interface {
+	ExtraSettingsV3
+
+	// TokenStore returns the store used to persist and reuse SSO tokens. May be nil, in which case every client opens
+	// a new SSO session.
+	TokenStore() TokenStore
+}
+
+// ExtraSettingsV5 extends ExtraSettingsV4 with a bandwidth limit for image transfers.
+type ExtraSettingsV5 interface {
+	ExtraSettingsV4
+
+	// TransferBandwidthLimit returns the combined speed limit of all image uploads and downloads of the client in
+	// bytes per second. Zero means no limit.
+	TransferBandwidthLimit() uint64
+}
+
 // ExtraSettingsBuilder is a buildable version of ExtraSettings.
 type ExtraSettingsBuilder interface {
-	ExtraSettings
+	ExtraSettingsV5
 
 	// WithExtraHeaders adds extra headers to send along with each request.
 	WithExtraHeaders(map[string]string) ExtraSettingsBuilder
@@ -35,6 +77,22 @@ type ExtraSettingsBuilder interface {
 	WithCompression() ExtraSettingsBuilder
 	// WithProxy explicitly sets a proxy server to use for requests.
 	WithProxy(string) ExtraSettingsBuilder
+	// WithRequestObserver sets an observer that is notified after every attempt at an API call, including retries.
+	// Use NewMultiRequestObserver to combine multiple observers.
+	WithRequestObserver(RequestObserver) ExtraSettingsBuilder
+	// WithRateLimit limits the client to requestsPerSecond requests on average, allowing bursts of up to burst
+	// requests. Calls waiting for the rate limit are aborted when the context of the client is canceled.
+	WithRateLimit(requestsPerSecond float64, burst uint) ExtraSettingsBuilder
+	// WithMaxConcurrentRequests limits the number of requests that may be in flight at the same time. Calls waiting
+	// for a free slot are aborted when the context of the client is canceled. The image data of uploads and downloads
+	// is transferred outside of this limit, only the engine calls setting up the transfer count against it.
+	WithMaxConcurrentRequests(maxRequests uint) ExtraSettingsBuilder
+	// WithTokenStore sets a store to persist SSO tokens in. Stored tokens are reused instead of opening a new SSO
+	// session, and are refreshed shortly before they expire.
+	WithTokenStore(TokenStore) ExtraSettingsBuilder
+	// WithTransferBandwidthLimit limits the combined speed of all image uploads and downloads of the client to
+	// bytesPerSecond. The limit can be changed later using Client.SetTransferBandwidthLimit.
+	WithTransferBandwidthLimit(bytesPerSecond uint64) ExtraSettingsBuilder
 }
 
 // NewExtraSettings creates a builder for ExtraSettings.
@@ -46,6 +104,12 @@ type extraSettings struct {
 	headers     map[string]string
 	compression bool
 	proxy       *string
+	observer    RequestObserver
+	rateLimit   float64
+	burst       uint
+	maxInFlight uint
+	tokenStore  TokenStore
+	bandwidth   uint64
 }
 
 func (e *extraSettings) ExtraHeaders() map[string]string {
@@ -60,6 +124,30 @@ func (e *extraSettings) Proxy() *string {
 	return e.proxy
 }
 
+func (e *extraSettings) RequestObserver() RequestObserver {
+	return e.observer
+}
+
+func (e *extraSettings) RateLimit() float64 {
+	return e.rateLimit
+}
+
+func (e *extraSettings) RateLimitBurst() uint {
+	return e.burst
+}
+
+func (e *extraSettings) MaxConcurrentRequests() uint {
+	return e.maxInFlight
+}
+
+func (e *extraSettings) TokenStore() TokenStore {
+	return e.tokenStore
+}
+
+func (e *extraSettings) TransferBandwidthLimit() uint64 {
+	return e.bandwidth
+}
+
 func (e *extraSettings) WithExtraHeaders(m map[string]string) ExtraSettingsBuilder {
 	e.headers = m
 	return e
@@ -75,6 +163,32 @@ func (e *extraSettings) WithProxy(addr string) ExtraSettingsBuilder {
 	return e
 }
 
+func (e *extraSettings) WithRequestObserver(observer RequestObserver) ExtraSettingsBuilder {
+	e.observer = observer
+	return e
+}
+
+func (e *extraSettings) WithRateLimit(requestsPerSecond float64, burst uint) ExtraSettingsBuilder {
+	e.rateLimit = requestsPerSecond
+	e.burst = burst
+	return e
+}
+
+func (e *extraSettings) WithMaxConcurrentRequests(maxRequests uint) ExtraSettingsBuilder {
+	e.maxInFlight = maxRequests
+	return e
+}
+
+func (e *extraSettings) WithTokenStore(store TokenStore) ExtraSettingsBuilder {
+	e.tokenStore = store
+	return e
+}
+
+func (e *extraSettings) WithTransferBandwidthLimit(bytesPerSecond uint64) ExtraSettingsBuilder {
+	e.bandwidth = bytesPerSecond
+	return e
+}
+
 // New creates a new copy of the enhanced oVirt client. It accepts the following options:
 //
 //	url
@@ -101,7 +215,9 @@ func (e *extraSettings) WithProxy(addr string) ExtraSettingsBuilder {
 //	extraSettings
 //
 // This is an implementation of the ExtraSettings interface, allowing for customization of headers and turning on
-// compression.
+// compression. Passing an implementation of ExtraSettingsV2 additionally allows for instrumenting API calls, while
+// ExtraSettingsV3 adds client-side rate and concurrency limits. ExtraSettingsV4 allows for reusing SSO sessions across
+// clients and processes using a TokenStore, and ExtraSettingsV5 limits the bandwidth of image transfers.
 //
 // # TLS
 //
@@ -159,17 +275,62 @@ func NewWithVerify(
 	logger Logger,
 	extraSettings ExtraSettings,
 	verify func(connection Client) error,
+) (ClientWithLegacySupport, error) {
+	if err := validateUsername(username); err != nil {
+		return nil, wrap(err, EBadArgument, "invalid username: %s", username)
+	}
+	return NewWithAuthAndVerify(u, PasswordAuth(username, password), tls, logger, extraSettings, verify)
+}
+
+// NewWithAuth is equivalent to New, but authenticates using the specified AuthProvider instead of a username and
+// password. The following providers are available:
+//
+//	// Authenticate with a username and password, identical to New.
+//	auth := ovirtclient.PasswordAuth("admin@internal", "password")
+//
+//	// Authenticate with an SSO token obtained elsewhere, for example from a Kerberos-backed SSO login.
+//	auth := ovirtclient.BearerTokenAuth(token)
+//
+//	// Authenticate with a TLS client certificate.
+//	auth, err := ovirtclient.ClientCertificateAuthFromFiles("/path/to/cert.pem", "/path/to/key.pem")
+//
+//	client, err := ovirtclient.NewWithAuth(url, auth, tls, logger, extraSettings)
+func NewWithAuth(
+	url string,
+	auth AuthProvider,
+	tls TLSProvider,
+	logger Logger,
+	extraSettings ExtraSettings,
+) (ClientWithLegacySupport, error) {
+	return NewWithAuthAndVerify(url, auth, tls, logger, extraSettings, testConnection)
+}
+
+// NewWithAuthAndVerify is equivalent to NewWithAuth, but allows customizing the verification function for the
+// connection. Alternatively, a nil can be passed to disable connection verification.
+func NewWithAuthAndVerify(
+	u string,
+	auth AuthProvider,
+	tls TLSProvider,
+	logger Logger,
+	extraSettings ExtraSettings,
+	verify func(connection Client) error,
 ) (ClientWithLegacySupport, error) {
 	if err := validateURL(u); err != nil {
 		return nil, wrap(err, EBadArgument, "invalid URL: %s", u)
 	}
-	if err := validateUsername(username); err != nil {
-		return nil, wrap(err, EBadArgument, "invalid username: %s", username)
+	if auth == nil {
+		return nil, newError(EBadArgument, "no authentication provider passed")
+	}
+	if err := auth.validate(); err != nil {
+		return nil, err
 	}
 	tlsConfig, err := tls.CreateTLSConfig()
 	if err != nil {
 		return nil, wrap(err, ETLSError, "failed to create TLS configuration")
 	}
+	if err := auth.configureTLS(tlsConfig); err != nil {
+		return nil, wrap(err, ETLSError, "failed to add client credentials to the TLS configuration")
+	}
 
 	proxyFunc, err := getProxyFunc(extraSettings)
 	if err != nil {
@@ -189,21 +350,71 @@ func NewWithVerify(
 		httpClient,
 		logger,
 		u,
-		username,
-		password,
+		auth,
 		tlsConfig,
 		extraSettings,
 		rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
 		verify,
+		getRequestObserver(extraSettings),
+		getRequestLimiter(extraSettings),
+		nil,
+		getTransferLimiter(extraSettings),
 	}
+	client.tokens = getTokenManager(extraSettings, client)
 
-	if err := client.Reconnect(); err != nil {
+	if err := client.connect(false); err != nil {
 		return nil, err
 	}
 
 	return client, nil
 }
 
+func getRequestObserver(extraSettings ExtraSettings) RequestObserver {
+	if extraSettingsV2, ok := extraSettings.(ExtraSettingsV2); ok {
+		return extraSettingsV2.RequestObserver()
+	}
+	return nil
+}
+
+func getRequestLimiter(extraSettings ExtraSettings) *requestLimiter {
+	if extraSettingsV3, ok := extraSettings.(ExtraSettingsV3); ok {
+		return newRequestLimiter(
+			extraSettingsV3.RateLimit(),
+			extraSettingsV3.RateLimitBurst(),
+			extraSettingsV3.MaxConcurrentRequests(),
+		)
+	}
+	return nil
+}
+
+func getTransferLimiter(extraSettings ExtraSettings) *bandwidthLimiter {
+	if extraSettingsV5, ok := extraSettings.(ExtraSettingsV5); ok {
+		return newBandwidthLimiter(extraSettingsV5.TransferBandwidthLimit())
+	}
+	return newBandwidthLimiter(0)
+}
+
+// getTokenManager returns the token manager of the client. Password clients without a TokenStore leave
+// authentication to the SDK. Other clients without a TokenStore keep their token in a private memory store, as the
+// SDK cannot obtain their tokens, so it is not shared with other clients.
+func getTokenManager(extraSettings ExtraSettings, client *oVirtClient) *tokenManager {
+	var store TokenStore
+	if extraSettingsV4, ok := extraSettings.(ExtraSettingsV4); ok && extraSettingsV4.TokenStore() != nil {
+		store = extraSettingsV4.TokenStore()
+	} else if _, ok := client.auth.(*passwordAuth); !ok {
+		store = NewMemoryTokenStore()
+	}
+	return &tokenManager{
+		lock:       &sync.Mutex{},
+		usage:      &sync.RWMutex{},
+		store:      store,
+		url:        client.url,
+		auth:       client.auth,
+		httpClient: client.httpClient,
+		logger:     client.logger,
+	}
+}
+
 func getProxyFunc(extraSettings ExtraSettings) (func(req *http.Request) (*url.URL, error), error) {
 	proxyFunc := http.ProxyFromEnvironment
 	if extraSettings == nil {
@@ -21,9 +21,10 @@ func NewMock() MockClient {
 func NewMockWithLogger(logger Logger) MockClient {
 	testCluster := generateTestCluster()
 	testHost := generateTestHost(testCluster)
-	testStorageDomain := generateTestStorageDomain()
-	secondaryStorageDomain := generateTestStorageDomain()
 	testDatacenter := generateTestDatacenter(testCluster)
+	testStorageDomain := generateTestStorageDomain(testDatacenter)
+	secondaryStorageDomain := generateTestStorageDomain(testDatacenter)
+	testStorageDomain.master = true
 	testNetwork := generateTestNetwork(testDatacenter)
 	testVNICProfile := generateTestVNICProfile(testNetwork)
 	blankTemplate := &template{
@@ -110,7 +111,7 @@ func getClient(
 			testDatacenter.ID(): testDatacenter,
 		},
 		vmDiskAttachmentsByVM:   map[VMID]map[DiskAttachmentID]*diskAttachment{},
-		vmDiskAttachmentsByDisk: map[DiskID]*diskAttachment{},
+		vmDiskAttachmentsByDisk: map[DiskID]map[DiskAttachmentID]*diskAttachment{},
 		templateDiskAttachmentsByTemplate: map[TemplateID][]*templateDiskAttachment{
 			blankTemplate.ID(): {},
 		},
@@ -118,11 +119,31 @@ func getClient(
 		affinityGroups: map[ClusterID]map[AffinityGroupID]*affinityGroup{
 			testCluster.ID(): {},
 		},
-		vmIPs:                map[VMID]map[string][]net.IP{},
-		instanceTypes:        nil,
-		graphicsConsolesByVM: map[VMID][]*vmGraphicsConsole{},
+		vmIPs:                  map[VMID]map[string][]net.IP{},
+		instanceTypes:          nil,
+		graphicsConsolesByVM:   map[VMID][]*vmGraphicsConsole{},
+		snapshots:              map[VMID][]*snapshotWithData{},
+		events:                 map[EventID]*event{},
+		jobs:                   map[JobID]*mockJob{},
+		backups:                map[VMID][]*backupWithData{},
+		checkpoints:            map[VMID][]*checkpoint{},
+		cdromsByVM:             map[VMID][]*mockCdrom{},
+		diskProfiles:           map[DiskProfileID]*diskProfile{},
+		defaultDiskProfiles:    map[StorageDomainID]DiskProfileID{},
+		storageQoS:             map[StorageQoSID]*storageQoS{},
+		transferLimiter:        newBandwidthLimiter(0),
+		storageDomainLocations: map[StorageDomainID]string{},
+		orphanedStorageDomains: map[string]*storageDomain{},
+		unregisteredDisks:      map[StorageDomainID]map[DiskID]*diskWithData{},
+		unregisteredVMs:        map[StorageDomainID]map[VMID]*mockUnregisteredVM{},
+		ovaFiles:               map[string]*mockOVF{},
+		exportedVMs:            map[StorageDomainID]map[VMID]*mockOVF{},
+		exportedTemplates:      map[StorageDomainID]map[TemplateID]*mockOVF{},
+		externalVMs:            map[string][]*externalVM{},
 	}
 	client.instanceTypes = getInstanceTypes(client)
+	client.addDefaultDiskProfile(testStorageDomain)
+	client.addDefaultDiskProfile(secondaryStorageDomain)
 	return client
 }
 
@@ -174,7 +195,7 @@ func generateTestNetwork(testDatacenter *datacenterWithClusters) *network {
 }
 
 func generateTestDatacenter(testCluster *cluster) *datacenterWithClusters {
-	return &datacenterWithClusters{
+	dc := &datacenterWithClusters{
 		datacenter: datacenter{
 			id:   DatacenterID(uuid.NewString()),
 			name: "test",
@@ -183,9 +204,11 @@ func generateTestDatacenter(testCluster *cluster) *datacenterWithClusters {
 			testCluster.ID(),
 		},
 	}
+	testCluster.datacenterID = dc.id
+	return dc
 }
 
-func generateTestStorageDomain() *storageDomain {
+func generateTestStorageDomain(dc *datacenterWithClusters) *storageDomain {
 	return &storageDomain{
 		id:             StorageDomainID(uuid.NewString()),
 		name:           "Test storage domain",
@@ -193,6 +216,12 @@ func generateTestStorageDomain() *storageDomain {
 		status:         StorageDomainStatusActive,
 		externalStatus: StorageDomainExternalStatusNA,
 		storageType:    StorageDomainTypeNFS,
+		function:       StorageDomainFunctionData,
+		datacenterIDs:  []DatacenterID{dc.ID()},
+
+		warningLowSpaceIndicator: mockWarningLowSpaceIndicator,
+		// The test storage domains are small, so they use a lower threshold than the oVirt Engine default.
+		criticalSpaceActionBlocker: 1024 * 1024 * 1024,
 	}
 }
 
@@ -31,6 +31,7 @@ func (o *oVirtClient) CreateNIC(
 	err = retry(
 		fmt.Sprintf("creating NIC for VM %s", vmid),
 		o.logger,
+		o.requestHooks("CreateNIC"),
 		retries,
 		func() error {
 			nicBuilder := ovirtsdk.NewNicBuilder()
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetNIC(vmid VMID, id NICID, retries ...RetryStrategy) (res
 	err = retry(
 		fmt.Sprintf("getting NIC %s for VM %s", id, vmid),
 		o.logger,
+		o.requestHooks("GetNIC"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VmsService().VmService(string(vmid)).NicsService().NicService(string(id)).Get().Send()
@@ -9,6 +9,7 @@ func (o *oVirtClient) ListNICs(vmid VMID, retries ...RetryStrategy) (result []NI
 	err = retry(
 		fmt.Sprintf("listing NICs for VM %s", vmid),
 		o.logger,
+		o.requestHooks("ListNICs"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().VmsService().VmService(string(vmid)).NicsService().List().Send()
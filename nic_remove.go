@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveNIC(vmid VMID, id NICID, retries ...RetryStrategy) (
 	err = retry(
 		fmt.Sprintf("removing NIC %s from VM %s", id, vmid),
 		o.logger,
+		o.requestHooks("RemoveNIC"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(vmid)).NicsService().NicService(string(id)).Remove().Send()
@@ -35,6 +35,7 @@ func (o *oVirtClient) UpdateNIC(
 	err = retry(
 		fmt.Sprintf("updating NIC %s for VM %s", nicID, vmid),
 		o.logger,
+		o.requestHooks("UpdateNIC"),
 		retries,
 		func() error {
 			update, err := req.Send()
@@ -0,0 +1,158 @@
+package ovirtclient
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// RequestObserver is notified after every attempt the client makes to perform an action against the oVirt Engine,
+// including attempts that are retried. It can be used to collect metrics or create tracing spans. Ready-made
+// observers for Prometheus and OpenTelemetry are available in the ovirtclientprometheus and ovirtclientotel
+// packages.
+//
+// ObserveAttempt is called synchronously from the calling goroutine and must therefore return quickly. It may be
+// called concurrently from multiple goroutines.
+type RequestObserver interface {
+	ObserveAttempt(attempt RequestAttempt)
+}
+
+// RequestObserverFunc is a function that implements RequestObserver.
+type RequestObserverFunc func(attempt RequestAttempt)
+
+// ObserveAttempt calls the function itself.
+func (f RequestObserverFunc) ObserveAttempt(attempt RequestAttempt) {
+	f(attempt)
+}
+
+// NewMultiRequestObserver creates a RequestObserver that passes every attempt to all specified observers in order.
+func NewMultiRequestObserver(observers ...RequestObserver) RequestObserver {
+	return RequestObserverFunc(func(attempt RequestAttempt) {
+		for _, observer := range observers {
+			observer.ObserveAttempt(attempt)
+		}
+	})
+}
+
+// RequestAttempt describes a single attempt at performing an action.
+type RequestAttempt interface {
+	// Context returns the context of the client that performed the attempt. May be nil if the client has no context,
+	// see Client.WithContext.
+	Context() context.Context
+	// Action returns the description of the action being performed, for example "getting vm <ID>". The action
+	// contains resource identifiers and names and is therefore not suitable as a metric label. See Operation.
+	Action() string
+	// Operation returns the fixed name of the operation, for example "GetVM". The number of operations is limited
+	// and they contain no identifiers, so they are suitable as metric labels.
+	Operation() string
+	// Attempt returns the number of the attempt, starting at 1. Values higher than 1 indicate a retry after a failed
+	// attempt. Pending attempts do not count as failed, so polls in wait loops are all reported as attempt 1 until one
+	// of them fails.
+	Attempt() uint
+	// Pending returns true if the attempt succeeded, but the condition a wait loop is polling for, such as a VM
+	// being up, is not met yet. Err returns nil for pending attempts.
+	Pending() bool
+	// StartTime returns the time the attempt was started.
+	StartTime() time.Time
+	// Duration returns how long the attempt took.
+	Duration() time.Duration
+	// Err returns the error the attempt failed with, or nil if it succeeded.
+	Err() error
+	// ErrorCode returns the error code of the failed attempt, or an empty string if it succeeded.
+	ErrorCode() ErrorCode
+	// HTTPStatus returns the HTTP status code the engine responded with on failed attempts if it could be
+	// determined, 0 otherwise.
+	HTTPStatus() int
+}
+
+// errorCodeOf returns the error code of an error, identifying it if it is not an EngineError.
+func errorCodeOf(err error) ErrorCode {
+	var e EngineError
+	if errors.As(err, &e) {
+		return e.Code()
+	}
+	if e := realIdentify(err); e != nil {
+		return e.Code()
+	}
+	return EUnidentified
+}
+
+var httpStatusRe = regexp.MustCompile(`HTTP response code is "(\d{3})"`)
+
+// httpStatusOf extracts the HTTP status code from an error returned by the SDK, or returns 0.
+func httpStatusOf(err error) int {
+	var authErr *ovirtsdk.AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Code
+	}
+	var notFoundErr *ovirtsdk.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return notFoundErr.Code
+	}
+	var parseErr *ovirtsdk.ResponseParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Code
+	}
+	if match := httpStatusRe.FindStringSubmatch(err.Error()); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		return status
+	}
+	return 0
+}
+
+type requestAttempt struct {
+	ctx        context.Context
+	action     string
+	operation  string
+	attempt    uint
+	pending    bool
+	startTime  time.Time
+	duration   time.Duration
+	err        error
+	errorCode  ErrorCode
+	httpStatus int
+}
+
+func (r *requestAttempt) Context() context.Context {
+	return r.ctx
+}
+
+func (r *requestAttempt) Action() string {
+	return r.action
+}
+
+func (r *requestAttempt) Operation() string {
+	return r.operation
+}
+
+func (r *requestAttempt) Attempt() uint {
+	return r.attempt
+}
+
+func (r *requestAttempt) Pending() bool {
+	return r.pending
+}
+
+func (r *requestAttempt) StartTime() time.Time {
+	return r.startTime
+}
+
+func (r *requestAttempt) Duration() time.Duration {
+	return r.duration
+}
+
+func (r *requestAttempt) Err() error {
+	return r.err
+}
+
+func (r *requestAttempt) ErrorCode() ErrorCode {
+	return r.errorCode
+}
+
+func (r *requestAttempt) HTTPStatus() int {
+	return r.httpStatus
+}
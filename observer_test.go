@@ -0,0 +1,140 @@
+// This file contains tests for the internal request observer functionality. It is therefore excluded from the
+// testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryReportsAttemptsToObserver(t *testing.T) {
+	t.Parallel()
+
+	lock := &sync.Mutex{}
+	var attempts []RequestAttempt
+	observer := &requestHooks{
+		ctx:       context.Background(),
+		operation: "GetVM",
+		observer: RequestObserverFunc(func(attempt RequestAttempt) {
+			lock.Lock()
+			defer lock.Unlock()
+			attempts = append(attempts, attempt)
+		}),
+	}
+
+	calls := 0
+	err := retry(
+		"getting vm 6b4f3b0d-8c4c-4d2c-9b2a-0a6f3f6bb3a1",
+		nil,
+		observer,
+		[]RetryStrategy{
+			FixedDelayStrategy(10 * time.Millisecond),
+			MaxTries(3),
+		},
+		func() error {
+			calls++
+			if calls == 1 {
+				return newError(ETimeout, "test failure")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Retry failed (%v)", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(attempts) != 2 {
+		t.Fatalf("Incorrect number of attempts reported (expected: %d, got: %d)", 2, len(attempts))
+	}
+	if attempts[0].Attempt() != 1 || attempts[1].Attempt() != 2 {
+		t.Fatalf("Incorrect attempt numbers reported (%d, %d)", attempts[0].Attempt(), attempts[1].Attempt())
+	}
+	if attempts[0].ErrorCode() != ETimeout {
+		t.Fatalf("Incorrect error code on first attempt (expected: %s, got: %s)", ETimeout, attempts[0].ErrorCode())
+	}
+	if attempts[1].Err() != nil || attempts[1].ErrorCode() != "" {
+		t.Fatalf("Successful attempt reported an error (%v)", attempts[1].Err())
+	}
+	if operation := attempts[0].Operation(); operation != "GetVM" {
+		t.Fatalf("Incorrect operation reported (%s)", operation)
+	}
+	if attempts[0].Context() != observer.ctx {
+		t.Fatalf("Attempt was not reported with the client context.")
+	}
+}
+
+func TestRetryReportsPendingAttemptsAsSuccessful(t *testing.T) {
+	t.Parallel()
+
+	var attempts []RequestAttempt
+	observer := &requestHooks{
+		ctx:       context.Background(),
+		operation: "WaitForVMStatus",
+		observer: RequestObserverFunc(func(attempt RequestAttempt) {
+			attempts = append(attempts, attempt)
+		}),
+	}
+
+	calls := 0
+	err := retry(
+		"waiting for vm 6b4f3b0d-8c4c-4d2c-9b2a-0a6f3f6bb3a1",
+		nil,
+		observer,
+		[]RetryStrategy{
+			FixedDelayStrategy(10 * time.Millisecond),
+			MaxTries(5),
+		},
+		func() error {
+			calls++
+			switch calls {
+			case 1, 3:
+				return newError(EPending, "VM is not up yet")
+			case 2:
+				return newError(ETimeout, "test failure")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("Retry failed (%v)", err)
+	}
+
+	if len(attempts) != 4 {
+		t.Fatalf("Incorrect number of attempts reported (expected: %d, got: %d)", 4, len(attempts))
+	}
+	if !attempts[0].Pending() || attempts[0].Err() != nil || attempts[0].ErrorCode() != "" {
+		t.Fatalf("Pending attempt was not reported as pending (%v)", attempts[0].Err())
+	}
+	if attempts[1].Attempt() != 1 || attempts[1].ErrorCode() != ETimeout {
+		t.Fatalf("A pending attempt was counted as failed (attempt: %d)", attempts[1].Attempt())
+	}
+	if attempts[2].Attempt() != 2 || !attempts[2].Pending() || attempts[3].Attempt() != 2 {
+		t.Fatalf(
+			"Incorrect attempt numbers after a failure (%d, %d)",
+			attempts[2].Attempt(),
+			attempts[3].Attempt(),
+		)
+	}
+}
+
+func TestHTTPStatusOf(t *testing.T) {
+	t.Parallel()
+
+	err := wrap(
+		fmt.Errorf("failed to parse response, HTTP response code is \"503\" and body is \"\""),
+		EUnidentified,
+		"failed to get VM",
+	)
+	if status := httpStatusOf(err); status != 503 {
+		t.Fatalf("Incorrect HTTP status extracted (expected: %d, got: %d)", 503, status)
+	}
+	if status := httpStatusOf(fmt.Errorf("connection refused")); status != 0 {
+		t.Fatalf("HTTP status extracted from an error without status (%d)", status)
+	}
+}
@@ -0,0 +1,332 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OVFClient describes the functions for moving VMs and templates between oVirt Engines. VMs and templates are
+// stored in the OVF format, either in OVA files on a host or on an export storage domain. They can be imported from
+// OVA files, export storage domains and data storage domains, which is how VMs and templates are moved using a data
+// storage domain detached from another oVirt Engine.
+//
+// Exports and imports are long-running operations. They return as soon as the engine has started the job, which can
+// be waited for using the returned handle.
+type OVFClient interface {
+	// ExportVMAsOVA exports the VM into an OVA file in the directory on the host. If filename is empty, the engine
+	// names the file after the VM. The VM must be down.
+	ExportVMAsOVA(vmID VMID, hostID HostID, directory string, filename string, retries ...RetryStrategy) (Job, error)
+	// ExportTemplateAsOVA exports the template into an OVA file in the directory on the host. If filename is empty,
+	// the engine names the file after the template.
+	ExportTemplateAsOVA(
+		templateID TemplateID,
+		hostID HostID,
+		directory string,
+		filename string,
+		retries ...RetryStrategy,
+	) (Job, error)
+	// ExportVMToStorageDomain exports the VM to an export storage domain attached to the datacenter of the VM. The VM
+	// must be down.
+	ExportVMToStorageDomain(vmID VMID, storageDomainID StorageDomainID, retries ...RetryStrategy) (Job, error)
+	// ExportTemplateToStorageDomain exports the template to an export storage domain attached to the datacenter of
+	// the template.
+	ExportTemplateToStorageDomain(
+		templateID TemplateID,
+		storageDomainID StorageDomainID,
+		retries ...RetryStrategy,
+	) (Job, error)
+
+	// ImportVMFromStorageDomain imports a VM from an export storage domain, or an unregistered VM from a data storage
+	// domain, into the cluster. The disks of VMs on export storage domains are copied to the storage domains set in
+	// params, which are required. The disks of VMs on data storage domains remain on the data storage domain.
+	ImportVMFromStorageDomain(
+		storageDomainID StorageDomainID,
+		vmID VMID,
+		clusterID ClusterID,
+		params OptionalOVFImportParameters,
+		retries ...RetryStrategy,
+	) (VMImport, error)
+	// ImportTemplateFromStorageDomain imports a template from an export storage domain, or an unregistered template
+	// from a data storage domain, into the cluster. The storage domains are handled as in ImportVMFromStorageDomain.
+	ImportTemplateFromStorageDomain(
+		storageDomainID StorageDomainID,
+		templateID TemplateID,
+		clusterID ClusterID,
+		params OptionalOVFImportParameters,
+		retries ...RetryStrategy,
+	) (TemplateImport, error)
+	// ImportVMFromOVA imports a VM from the OVA file at the path on the host into the cluster. The name and the
+	// target storage domain must be set in params.
+	ImportVMFromOVA(
+		hostID HostID,
+		path string,
+		clusterID ClusterID,
+		params OptionalOVFImportParameters,
+		retries ...RetryStrategy,
+	) (VMImport, error)
+	// ImportTemplateFromOVA imports a template from the OVA file at the path on the host into the cluster. The name
+	// and the target storage domain must be set in params.
+	ImportTemplateFromOVA(
+		hostID HostID,
+		path string,
+		clusterID ClusterID,
+		params OptionalOVFImportParameters,
+		retries ...RetryStrategy,
+	) (TemplateImport, error)
+}
+
+// VMImport is a handle for a running VM import.
+type VMImport interface {
+	// Job returns the engine job importing the VM.
+	Job() Job
+	// Wait waits for the import to finish and returns the imported VM. If the import failed an EJobFailed error is
+	// returned.
+	Wait(retries ...RetryStrategy) (VM, error)
+}
+
+// TemplateImport is a handle for a running template import.
+type TemplateImport interface {
+	// Job returns the engine job importing the template.
+	Job() Job
+	// Wait waits for the import to finish and returns the imported template. If the import failed an EJobFailed
+	// error is returned.
+	Wait(retries ...RetryStrategy) (Template, error)
+}
+
+// OptionalOVFImportParameters are the optional parameters for importing VMs and templates.
+type OptionalOVFImportParameters interface {
+	// Name returns the new name of the imported VM or template. If set, the VM or template is imported as a copy with
+	// new IDs, so it can be imported next to the original. Returns nil if the original name should be kept. It is
+	// required for imports from OVA files, which always receive new IDs.
+	Name() *string
+	// StorageDomainID returns the storage domain the disks are copied to. It is required for imports from OVA files
+	// and export storage domains, and must not be set for imports from data storage domains.
+	StorageDomainID() *StorageDomainID
+	// DiskStorageDomainIDs returns the storage domains individual disks are copied to instead of StorageDomainID,
+	// keyed by the ID of the disk on the export storage domain. Only supported for imports from export storage
+	// domains.
+	DiskStorageDomainIDs() map[DiskID]StorageDomainID
+}
+
+// BuildableOVFImportParameters is a buildable version of OptionalOVFImportParameters.
+type BuildableOVFImportParameters interface {
+	OptionalOVFImportParameters
+
+	// WithName sets the new name of the imported VM or template.
+	WithName(name string) (BuildableOVFImportParameters, error)
+	// MustWithName is identical to WithName, but panics instead of returning an error.
+	MustWithName(name string) BuildableOVFImportParameters
+	// WithStorageDomainID sets the storage domain the disks are copied to.
+	WithStorageDomainID(storageDomainID StorageDomainID) (BuildableOVFImportParameters, error)
+	// MustWithStorageDomainID is identical to WithStorageDomainID, but panics instead of returning an error.
+	MustWithStorageDomainID(storageDomainID StorageDomainID) BuildableOVFImportParameters
+	// WithDiskStorageDomainID copies the disk to the specified storage domain instead of the one set using
+	// WithStorageDomainID.
+	WithDiskStorageDomainID(diskID DiskID, storageDomainID StorageDomainID) (BuildableOVFImportParameters, error)
+	// MustWithDiskStorageDomainID is identical to WithDiskStorageDomainID, but panics instead of returning an error.
+	MustWithDiskStorageDomainID(diskID DiskID, storageDomainID StorageDomainID) BuildableOVFImportParameters
+}
+
+// OVFImportParams creates a builder for the parameters of VM and template imports.
+func OVFImportParams() BuildableOVFImportParameters {
+	return &ovfImportParams{}
+}
+
+type ovfImportParams struct {
+	name                 *string
+	storageDomainID      *StorageDomainID
+	diskStorageDomainIDs map[DiskID]StorageDomainID
+}
+
+func (p *ovfImportParams) Name() *string {
+	return p.name
+}
+
+func (p *ovfImportParams) StorageDomainID() *StorageDomainID {
+	return p.storageDomainID
+}
+
+func (p *ovfImportParams) DiskStorageDomainIDs() map[DiskID]StorageDomainID {
+	return p.diskStorageDomainIDs
+}
+
+func (p *ovfImportParams) WithName(name string) (BuildableOVFImportParameters, error) {
+	if name == "" {
+		return nil, newError(EBadArgument, "the name of the imported object must not be empty")
+	}
+	p.name = &name
+	return p, nil
+}
+
+func (p *ovfImportParams) MustWithName(name string) BuildableOVFImportParameters {
+	builder, err := p.WithName(name)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (p *ovfImportParams) WithStorageDomainID(storageDomainID StorageDomainID) (BuildableOVFImportParameters, error) {
+	if storageDomainID == "" {
+		return nil, newError(EBadArgument, "the storage domain ID must not be empty")
+	}
+	p.storageDomainID = &storageDomainID
+	return p, nil
+}
+
+func (p *ovfImportParams) MustWithStorageDomainID(storageDomainID StorageDomainID) BuildableOVFImportParameters {
+	builder, err := p.WithStorageDomainID(storageDomainID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (p *ovfImportParams) WithDiskStorageDomainID(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+) (BuildableOVFImportParameters, error) {
+	if diskID == "" || storageDomainID == "" {
+		return nil, newError(EBadArgument, "the disk ID and the storage domain ID must not be empty")
+	}
+	if p.diskStorageDomainIDs == nil {
+		p.diskStorageDomainIDs = map[DiskID]StorageDomainID{}
+	}
+	p.diskStorageDomainIDs[diskID] = storageDomainID
+	return p, nil
+}
+
+func (p *ovfImportParams) MustWithDiskStorageDomainID(
+	diskID DiskID,
+	storageDomainID StorageDomainID,
+) BuildableOVFImportParameters {
+	builder, err := p.WithDiskStorageDomainID(diskID, storageDomainID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// validateOVFImportParameters checks the import parameters against the source of the import, which is either an OVA
+// file, an export storage domain or a data storage domain.
+func validateOVFImportParameters(params OptionalOVFImportParameters, source StorageDomainFunction, ova bool) error {
+	switch {
+	case ova && params.Name() == nil:
+		return newError(EBadArgument, "a name is required when importing from an OVA file")
+	case ova && params.StorageDomainID() == nil:
+		return newError(EBadArgument, "a target storage domain is required when importing from an OVA file")
+	case ova && len(params.DiskStorageDomainIDs()) > 0:
+		return newError(EBadArgument, "disk storage domains cannot be set when importing from an OVA file")
+	case ova:
+		return nil
+	case source == StorageDomainFunctionExport && params.StorageDomainID() == nil:
+		return newError(EBadArgument, "a target storage domain is required when importing from an export storage domain")
+	case source == StorageDomainFunctionData &&
+		(params.StorageDomainID() != nil || len(params.DiskStorageDomainIDs()) > 0):
+		return newError(
+			EBadArgument,
+			"target storage domains cannot be set when importing from a data storage domain, the disks remain on it",
+		)
+	case source != StorageDomainFunctionExport && source != StorageDomainFunctionData:
+		return newError(EBadArgument, "cannot import from a %s storage domain", source)
+	}
+	return nil
+}
+
+// ovaFilePath returns the path of an exported OVA file, which the engine names after the exported object by default.
+func ovaFilePath(directory string, filename string, objectName string) string {
+	if filename == "" {
+		filename = fmt.Sprintf("%s.ova", objectName)
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(directory, "/"), filename)
+}
+
+// validateOVAPath checks that the OVA file is passed as an absolute path on the host.
+func validateOVAPath(path string) error {
+	if !strings.HasPrefix(path, "/") {
+		return newError(EBadArgument, "the OVA file must be passed as an absolute path (%s given)", path)
+	}
+	return nil
+}
+
+// jobClockSkew is the difference between the clocks of the client and the engine tolerated when matching jobs by their
+// start time.
+const jobClockSkew = 5 * time.Minute
+
+// findCorrelatedJob returns the job the engine started for a request with the specified correlation ID, sent at
+// sentAt. The job may appear with a delay, so the lookup is retried until it does.
+func (o *oVirtClient) findCorrelatedJob(
+	correlationID string,
+	sentAt time.Time,
+	retries []RetryStrategy,
+) (result Job, err error) {
+	params := NewListJobsParams().MustWithCorrelationID(correlationID)
+	err = retry(
+		fmt.Sprintf("waiting for the job with correlation ID %s to start", correlationID),
+		o.logger,
+		o.waitHooks("FindCorrelatedJob"),
+		retries,
+		func() error {
+			jobs, err := o.ListJobs(params, retries...)
+			if err != nil {
+				return err
+			}
+			result = selectCorrelatedJob(jobs, sentAt)
+			if result == nil {
+				return newError(EPending, "no job with correlation ID %s started yet", correlationID)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+// selectCorrelatedJob returns the most recently started of the jobs that started after the request was sent at sentAt,
+// or nil if there is none. Jobs started before were started by an earlier request using the same correlation ID.
+func selectCorrelatedJob(jobs []Job, sentAt time.Time) Job {
+	var result Job
+	for _, job := range jobs {
+		if job.StartTime().Before(sentAt.Add(-jobClockSkew)) {
+			continue
+		}
+		if result == nil || job.StartTime().After(result.StartTime()) {
+			result = job
+		}
+	}
+	return result
+}
+
+type vmImport struct {
+	client Client
+	job    Job
+	name   string
+}
+
+func (v *vmImport) Job() Job {
+	return v.job
+}
+
+func (v *vmImport) Wait(retries ...RetryStrategy) (VM, error) {
+	if _, err := v.job.Wait(retries...); err != nil {
+		return nil, err
+	}
+	return v.client.GetVMByName(v.name, retries...)
+}
+
+type templateImport struct {
+	client Client
+	job    Job
+	name   string
+}
+
+func (t *templateImport) Job() Job {
+	return t.job
+}
+
+func (t *templateImport) Wait(retries ...RetryStrategy) (Template, error) {
+	if _, err := t.job.Wait(retries...); err != nil {
+		return nil, err
+	}
+	return t.client.GetTemplateByName(t.name, retries...)
+}
@@ -0,0 +1,345 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ExportVMAsOVA(
+	vmID VMID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) (Job, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID, err := correlationIDFor(o.ctx, "vm_export_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("exporting VM %s as OVA to %s on host %s", vmID, directory, hostID),
+		o.logger,
+		o.requestHooks("ExportVMAsOVA"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				ExportToPathOnHost().
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Directory(directory)
+			if filename != "" {
+				request.Filename(filename)
+			}
+			_, err := request.Query("correlation_id", correlationID).Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.findCorrelatedJob(correlationID, sentAt, retries)
+}
+
+func (o *oVirtClient) ExportTemplateAsOVA(
+	templateID TemplateID,
+	hostID HostID,
+	directory string,
+	filename string,
+	retries ...RetryStrategy,
+) (Job, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID, err := correlationIDFor(o.ctx, "template_export_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("exporting template %s as OVA to %s on host %s", templateID, directory, hostID),
+		o.logger,
+		o.requestHooks("ExportTemplateAsOVA"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().
+				TemplatesService().
+				TemplateService(string(templateID)).
+				ExportToPathOnHost().
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Directory(directory)
+			if filename != "" {
+				request.Filename(filename)
+			}
+			_, err := request.Query("correlation_id", correlationID).Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.findCorrelatedJob(correlationID, sentAt, retries)
+}
+
+func (o *oVirtClient) ExportVMToStorageDomain(
+	vmID VMID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Job, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID, err := correlationIDFor(o.ctx, "vm_export_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("exporting VM %s to storage domain %s", vmID, storageDomainID),
+		o.logger,
+		o.requestHooks("ExportVMToStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				Export().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.findCorrelatedJob(correlationID, sentAt, retries)
+}
+
+func (o *oVirtClient) ExportTemplateToStorageDomain(
+	templateID TemplateID,
+	storageDomainID StorageDomainID,
+	retries ...RetryStrategy,
+) (Job, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	correlationID, err := correlationIDFor(o.ctx, "template_export_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("exporting template %s to storage domain %s", templateID, storageDomainID),
+		o.logger,
+		o.requestHooks("ExportTemplateToStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				TemplatesService().
+				TemplateService(string(templateID)).
+				Export().
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.findCorrelatedJob(correlationID, sentAt, retries)
+}
+
+func (m *mockClient) ExportVMAsOVA(
+	vmID VMID,
+	hostID HostID,
+	directory string,
+	filename string,
+	_ ...RetryStrategy,
+) (Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "vm_export_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	ovf, err := m.vmOVF(item)
+	if err != nil {
+		return nil, err
+	}
+	return m.exportOVA(ovf, item.name, hostID, directory, filename, correlationID)
+}
+
+func (m *mockClient) ExportTemplateAsOVA(
+	templateID TemplateID,
+	hostID HostID,
+	directory string,
+	filename string,
+	_ ...RetryStrategy,
+) (Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "template_export_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	tpl, ok := m.templates[templateID]
+	if !ok {
+		return nil, newError(ENotFound, "template with ID %s not found", templateID)
+	}
+	ovf, err := m.templateOVF(tpl)
+	if err != nil {
+		return nil, err
+	}
+	return m.exportOVA(ovf, tpl.name, hostID, directory, filename, correlationID)
+}
+
+// exportOVA writes the OVF into an OVA file on the host once the export job finishes. The caller must hold the lock.
+func (m *mockClient) exportOVA(
+	ovf *mockOVF,
+	name string,
+	hostID HostID,
+	directory string,
+	filename string,
+	correlationID string,
+) (Job, error) {
+	if err := m.checkStorageDomainHost(hostID); err != nil {
+		return nil, err
+	}
+	if err := validateOVAPath(directory); err != nil {
+		return nil, err
+	}
+	path := ovaFilePath(directory, filename, name)
+	key := mockOVAKey(hostID, path)
+	if _, ok := m.ovaFiles[key]; ok {
+		return nil, newError(EConflict, "file %s already exists on host %s", path, hostID)
+	}
+	job := m.startJob(fmt.Sprintf("Exporting %s as OVA to %s on Host %s", name, path, hostID), correlationID)
+	m.finishOVFJob(job, func() {
+		m.ovaFiles[key] = ovf
+	})
+	return job.copyJob(), nil
+}
+
+func (m *mockClient) ExportVMToStorageDomain(
+	vmID VMID,
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) (Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "vm_export_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	var datacenterID *DatacenterID
+	if cluster, ok := m.clusters[item.clusterID]; ok {
+		datacenterID = &cluster.datacenterID
+	}
+	if err := m.checkExportStorageDomain(storageDomainID, datacenterID); err != nil {
+		return nil, err
+	}
+	if _, ok := m.exportedVMs[storageDomainID][vmID]; ok {
+		return nil, newError(EConflict, "VM %s already exists on storage domain %s", vmID, storageDomainID)
+	}
+	ovf, err := m.vmOVF(item)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkStorageDomainSpace(storageDomainID, ovf.ovfSize()); err != nil {
+		return nil, err
+	}
+	job := m.startJob(fmt.Sprintf("Exporting VM %s To Export Domain %s", item.name, storageDomainID), correlationID)
+	m.finishOVFJob(job, func() {
+		if _, ok := m.storageDomains[storageDomainID]; !ok {
+			return
+		}
+		if _, ok := m.exportedVMs[storageDomainID]; !ok {
+			m.exportedVMs[storageDomainID] = map[VMID]*mockOVF{}
+		}
+		m.exportedVMs[storageDomainID][vmID] = ovf
+	})
+	return job.copyJob(), nil
+}
+
+func (m *mockClient) ExportTemplateToStorageDomain(
+	templateID TemplateID,
+	storageDomainID StorageDomainID,
+	_ ...RetryStrategy,
+) (Job, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "template_export_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	tpl, ok := m.templates[templateID]
+	if !ok {
+		return nil, newError(ENotFound, "template with ID %s not found", templateID)
+	}
+	// Templates are not bound to a cluster in the mock, so the datacenter is not checked.
+	if err := m.checkExportStorageDomain(storageDomainID, nil); err != nil {
+		return nil, err
+	}
+	if _, ok := m.exportedTemplates[storageDomainID][templateID]; ok {
+		return nil, newError(EConflict, "template %s already exists on storage domain %s", templateID, storageDomainID)
+	}
+	ovf, err := m.templateOVF(tpl)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkStorageDomainSpace(storageDomainID, ovf.ovfSize()); err != nil {
+		return nil, err
+	}
+	job := m.startJob(
+		fmt.Sprintf("Exporting Template %s To Export Domain %s", tpl.name, storageDomainID),
+		correlationID,
+	)
+	m.finishOVFJob(job, func() {
+		if _, ok := m.storageDomains[storageDomainID]; !ok {
+			return
+		}
+		if _, ok := m.exportedTemplates[storageDomainID]; !ok {
+			m.exportedTemplates[storageDomainID] = map[TemplateID]*mockOVF{}
+		}
+		m.exportedTemplates[storageDomainID][templateID] = ovf
+	})
+	return job.copyJob(), nil
+}
+
+// checkExportStorageDomain checks that VMs and templates can be exported to the storage domain. If datacenterID is
+// set, the storage domain must be attached to that datacenter. The caller must hold the lock.
+func (m *mockClient) checkExportStorageDomain(storageDomainID StorageDomainID, datacenterID *DatacenterID) error {
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if sd.function != StorageDomainFunctionExport {
+		return newError(
+			EBadArgument,
+			"storage domain %s is a %s storage domain, only export storage domains can be exported to",
+			storageDomainID,
+			sd.function,
+		)
+	}
+	if datacenterID != nil && !sd.attachedTo(*datacenterID) {
+		return newError(
+			EConflict,
+			"storage domain %s is not attached to datacenter %s",
+			storageDomainID,
+			*datacenterID,
+		)
+	}
+	return m.checkStorageDomainActive(sd)
+}
@@ -0,0 +1,592 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ImportVMFromStorageDomain(
+	storageDomainID StorageDomainID,
+	vmID VMID,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	retries ...RetryStrategy,
+) (VMImport, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	correlationID, err := correlationIDFor(o.ctx, "vm_import_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := o.GetStorageDomain(storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, sd.Function(), false); err != nil {
+		return nil, err
+	}
+	registration := sd.Function() == StorageDomainFunctionData
+	name, err := o.storageDomainVMName(storageDomainID, vmID, registration, params, retries)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("importing VM %s from storage domain %s into cluster %s", vmID, storageDomainID, clusterID),
+		o.logger,
+		o.requestHooks("ImportVMFromStorageDomain"),
+		retries,
+		func() error {
+			cluster := ovirtsdk.NewClusterBuilder().Id(string(clusterID)).MustBuild()
+			clone := params.Name() != nil
+			vmService := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(storageDomainID)).
+				VmsService().
+				VmService(string(vmID))
+			if registration {
+				request := vmService.Register().Cluster(cluster).Clone(clone)
+				if clone {
+					request.Vm(buildSDKOVFImportVM(params))
+				}
+				_, err := request.Query("correlation_id", correlationID).Send()
+				return err
+			}
+			_, err := vmService.Import().
+				Cluster(cluster).
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(*params.StorageDomainID())).MustBuild()).
+				Clone(clone).
+				Vm(buildSDKOVFImportVM(params)).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	job, err := o.findCorrelatedJob(correlationID, sentAt, retries)
+	if err != nil {
+		return nil, err
+	}
+	return &vmImport{client: o, job: job, name: name}, nil
+}
+
+func (o *oVirtClient) ImportTemplateFromStorageDomain(
+	storageDomainID StorageDomainID,
+	templateID TemplateID,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	retries ...RetryStrategy,
+) (TemplateImport, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	correlationID, err := correlationIDFor(o.ctx, "template_import_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := o.GetStorageDomain(storageDomainID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, sd.Function(), false); err != nil {
+		return nil, err
+	}
+	registration := sd.Function() == StorageDomainFunctionData
+	name, err := o.storageDomainTemplateName(storageDomainID, templateID, registration, params, retries)
+	if err != nil {
+		return nil, err
+	}
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf(
+			"importing template %s from storage domain %s into cluster %s",
+			templateID,
+			storageDomainID,
+			clusterID,
+		),
+		o.logger,
+		o.requestHooks("ImportTemplateFromStorageDomain"),
+		retries,
+		func() error {
+			cluster := ovirtsdk.NewClusterBuilder().Id(string(clusterID)).MustBuild()
+			clone := params.Name() != nil
+			templateService := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(storageDomainID)).
+				TemplatesService().
+				TemplateService(string(templateID))
+			if registration {
+				request := templateService.Register().Cluster(cluster).Clone(clone)
+				if clone {
+					request.Template(buildSDKOVFImportTemplate(params))
+				}
+				_, err := request.Query("correlation_id", correlationID).Send()
+				return err
+			}
+			_, err := templateService.Import().
+				Cluster(cluster).
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(*params.StorageDomainID())).MustBuild()).
+				Clone(clone).
+				Template(buildSDKOVFImportTemplate(params)).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	job, err := o.findCorrelatedJob(correlationID, sentAt, retries)
+	if err != nil {
+		return nil, err
+	}
+	return &templateImport{client: o, job: job, name: name}, nil
+}
+
+func (o *oVirtClient) ImportVMFromOVA(
+	hostID HostID,
+	path string,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	retries ...RetryStrategy,
+) (VMImport, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	if err := validateOVAPath(path); err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, "", true); err != nil {
+		return nil, err
+	}
+	correlationID, err := correlationIDFor(o.ctx, "vm_import_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	name := *params.Name()
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("importing VM %s from OVA %s on host %s", name, path, hostID),
+		o.logger,
+		o.requestHooks("ImportVMFromOVA"),
+		retries,
+		func() error {
+			sdkImport, err := ovirtsdk.NewExternalVmImportBuilder().
+				Name(name).
+				Provider(ovirtsdk.ExternalVmProviderType("ova")).
+				Url(fmt.Sprintf("ova://%s", path)).
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Cluster(ovirtsdk.NewClusterBuilder().Id(string(clusterID)).MustBuild()).
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(*params.StorageDomainID())).MustBuild()).
+				Vm(ovirtsdk.NewVmBuilder().Name(name).MustBuild()).
+				Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build OVA import of VM %s", name)
+			}
+			_, err = o.conn.SystemService().
+				ExternalVmImportsService().
+				Add().
+				Import(sdkImport).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	job, err := o.findCorrelatedJob(correlationID, sentAt, retries)
+	if err != nil {
+		return nil, err
+	}
+	return &vmImport{client: o, job: job, name: name}, nil
+}
+
+func (o *oVirtClient) ImportTemplateFromOVA(
+	hostID HostID,
+	path string,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	retries ...RetryStrategy,
+) (TemplateImport, error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	if err := validateOVAPath(path); err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, "", true); err != nil {
+		return nil, err
+	}
+	correlationID, err := correlationIDFor(o.ctx, "template_import_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	name := *params.Name()
+	sentAt := time.Now()
+	err = retry(
+		fmt.Sprintf("importing template %s from OVA %s on host %s", name, path, hostID),
+		o.logger,
+		o.requestHooks("ImportTemplateFromOVA"),
+		retries,
+		func() error {
+			sdkImport, err := ovirtsdk.NewExternalTemplateImportBuilder().
+				Url(fmt.Sprintf("ova://%s", path)).
+				Host(ovirtsdk.NewHostBuilder().Id(string(hostID)).MustBuild()).
+				Cluster(ovirtsdk.NewClusterBuilder().Id(string(clusterID)).MustBuild()).
+				StorageDomain(ovirtsdk.NewStorageDomainBuilder().Id(string(*params.StorageDomainID())).MustBuild()).
+				Template(ovirtsdk.NewTemplateBuilder().Name(name).MustBuild()).
+				Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build OVA import of template %s", name)
+			}
+			_, err = o.conn.SystemService().
+				ExternalTemplateImportsService().
+				Add().
+				Import(sdkImport).
+				Query("correlation_id", correlationID).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	job, err := o.findCorrelatedJob(correlationID, sentAt, retries)
+	if err != nil {
+		return nil, err
+	}
+	return &templateImport{client: o, job: job, name: name}, nil
+}
+
+// storageDomainVMName returns the name the VM will have after the import, which is needed to find it once the import
+// finished as cloned VMs receive a new ID.
+func (o *oVirtClient) storageDomainVMName(
+	storageDomainID StorageDomainID,
+	vmID VMID,
+	unregistered bool,
+	params OptionalOVFImportParameters,
+	retries []RetryStrategy,
+) (string, error) {
+	if name := params.Name(); name != nil {
+		return *name, nil
+	}
+	vms, err := o.listStorageDomainVMs(storageDomainID, unregistered, retries)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range vms {
+		if item.ID() == vmID {
+			return item.Name(), nil
+		}
+	}
+	return "", newError(ENotFound, "VM %s not found on storage domain %s", vmID, storageDomainID)
+}
+
+// storageDomainTemplateName returns the name the template will have after the import.
+func (o *oVirtClient) storageDomainTemplateName(
+	storageDomainID StorageDomainID,
+	templateID TemplateID,
+	unregistered bool,
+	params OptionalOVFImportParameters,
+	retries []RetryStrategy,
+) (string, error) {
+	if name := params.Name(); name != nil {
+		return *name, nil
+	}
+	templates, err := o.listStorageDomainTemplates(storageDomainID, unregistered, retries)
+	if err != nil {
+		return "", err
+	}
+	for _, tpl := range templates {
+		if tpl.ID() == templateID {
+			return tpl.Name(), nil
+		}
+	}
+	return "", newError(ENotFound, "template %s not found on storage domain %s", templateID, storageDomainID)
+}
+
+// buildSDKOVFImportVM builds the VM sent along with an import, carrying the new name and the storage domains of the
+// individual disks.
+func buildSDKOVFImportVM(params OptionalOVFImportParameters) *ovirtsdk.Vm {
+	builder := ovirtsdk.NewVmBuilder()
+	if name := params.Name(); name != nil {
+		builder.Name(*name)
+	}
+	builder.DiskAttachmentsOfAny(buildSDKOVFImportDiskAttachments(params)...)
+	return builder.MustBuild()
+}
+
+// buildSDKOVFImportTemplate builds the template sent along with an import, as buildSDKOVFImportVM does for VMs.
+func buildSDKOVFImportTemplate(params OptionalOVFImportParameters) *ovirtsdk.Template {
+	builder := ovirtsdk.NewTemplateBuilder()
+	if name := params.Name(); name != nil {
+		builder.Name(*name)
+	}
+	builder.DiskAttachmentsOfAny(buildSDKOVFImportDiskAttachments(params)...)
+	return builder.MustBuild()
+}
+
+func buildSDKOVFImportDiskAttachments(params OptionalOVFImportParameters) []*ovirtsdk.DiskAttachment {
+	var result []*ovirtsdk.DiskAttachment
+	for diskID, storageDomainID := range params.DiskStorageDomainIDs() {
+		disk := ovirtsdk.NewDiskBuilder().
+			Id(string(diskID)).
+			StorageDomainsOfAny(ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID)).MustBuild()).
+			MustBuild()
+		result = append(result, ovirtsdk.NewDiskAttachmentBuilder().Disk(disk).MustBuild())
+	}
+	return result
+}
+
+func (m *mockClient) ImportVMFromStorageDomain(
+	storageDomainID StorageDomainID,
+	vmID VMID,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	_ ...RetryStrategy,
+) (VMImport, error) {
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "vm_import_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if err := validateOVFImportParameters(params, sd.function, false); err != nil {
+		return nil, err
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	var source *mockOVF
+	var dataStorageDomainID *StorageDomainID
+	if sd.function == StorageDomainFunctionData {
+		if unregistered, ok := m.unregisteredVMs[storageDomainID][vmID]; ok {
+			source = &mockOVF{
+				vm:            unregistered.vm,
+				vmAttachments: unregistered.attachments,
+				disks:         unregistered.disks,
+				nics:          unregistered.nics,
+			}
+		}
+		dataStorageDomainID = &storageDomainID
+	} else {
+		source = m.exportedVMs[storageDomainID][vmID]
+	}
+	if source == nil {
+		return nil, newError(ENotFound, "VM %s not found on storage domain %s", vmID, storageDomainID)
+	}
+	result, err := m.importVM(source, clusterID, params, dataStorageDomainID, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	if dataStorageDomainID != nil {
+		delete(m.unregisteredVMs[storageDomainID], vmID)
+	}
+	return result, nil
+}
+
+func (m *mockClient) ImportTemplateFromStorageDomain(
+	storageDomainID StorageDomainID,
+	templateID TemplateID,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	_ ...RetryStrategy,
+) (TemplateImport, error) {
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "template_import_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := m.storageDomains[storageDomainID]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+	}
+	if err := validateOVFImportParameters(params, sd.function, false); err != nil {
+		return nil, err
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	// The mock does not detach data storage domains holding template disks, so it never has unregistered templates.
+	source := m.exportedTemplates[storageDomainID][templateID]
+	if source == nil {
+		return nil, newError(ENotFound, "template %s not found on storage domain %s", templateID, storageDomainID)
+	}
+	return m.importTemplate(source, clusterID, params, correlationID)
+}
+
+func (m *mockClient) ImportVMFromOVA(
+	hostID HostID,
+	path string,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	_ ...RetryStrategy,
+) (VMImport, error) {
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	if err := validateOVAPath(path); err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, "", true); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "vm_import_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	source, err := m.ovaFile(hostID, path)
+	if err != nil {
+		return nil, err
+	}
+	if source.vm == nil {
+		return nil, newError(EBadArgument, "the OVA file %s on host %s contains a template, not a VM", path, hostID)
+	}
+	return m.importVM(source, clusterID, params, nil, correlationID)
+}
+
+func (m *mockClient) ImportTemplateFromOVA(
+	hostID HostID,
+	path string,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	_ ...RetryStrategy,
+) (TemplateImport, error) {
+	if params == nil {
+		params = &ovfImportParams{}
+	}
+	if err := validateOVAPath(path); err != nil {
+		return nil, err
+	}
+	if err := validateOVFImportParameters(params, "", true); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	correlationID, err := correlationIDFor(m.ctx, "template_import_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
+	source, err := m.ovaFile(hostID, path)
+	if err != nil {
+		return nil, err
+	}
+	if source.template == nil {
+		return nil, newError(EBadArgument, "the OVA file %s on host %s contains a VM, not a template", path, hostID)
+	}
+	return m.importTemplate(source, clusterID, params, correlationID)
+}
+
+// ovaFile returns the contents of the OVA file on the host. The caller must hold the lock.
+func (m *mockClient) ovaFile(hostID HostID, path string) (*mockOVF, error) {
+	if err := m.checkStorageDomainHost(hostID); err != nil {
+		return nil, err
+	}
+	source, ok := m.ovaFiles[mockOVAKey(hostID, path)]
+	if !ok {
+		return nil, newError(ENotFound, "OVA file %s not found on host %s", path, hostID)
+	}
+	return source, nil
+}
+
+// importVM starts the import of the VM in the OVF. If dataStorageDomainID is set, the VM is registered from that data
+// storage domain. The caller must hold the lock.
+func (m *mockClient) importVM(
+	source *mockOVF,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	dataStorageDomainID *StorageDomainID,
+	correlationID string,
+) (VMImport, error) {
+	cluster, ok := m.clusters[clusterID]
+	if !ok {
+		return nil, newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	name := source.vm.name
+	// Imports from OVA files always carry a new name, so they always receive new IDs.
+	clone := params.Name() != nil
+	if clone {
+		name = *params.Name()
+	}
+	targets, err := m.ovfDiskTargets(source, cluster.datacenterID, params, dataStorageDomainID, clone)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkOVFImportName(source, name, clone); err != nil {
+		return nil, err
+	}
+	imported, diskIDs := m.importVMOVF(source, clusterID, name, targets, clone)
+	job := m.startJob(fmt.Sprintf("Importing VM %s to Cluster %s", name, cluster.name), correlationID)
+	m.finishOVFJob(job, func() {
+		m.unlockImportedDisks(diskIDs)
+		if item, ok := m.vms[imported.id]; ok && item.status == VMStatusImageLocked {
+			item.status = VMStatusDown
+		}
+	})
+	return &vmImport{client: m, job: job.copyJob(), name: name}, nil
+}
+
+// importTemplate starts the import of the template in the OVF. The caller must hold the lock.
+func (m *mockClient) importTemplate(
+	source *mockOVF,
+	clusterID ClusterID,
+	params OptionalOVFImportParameters,
+	correlationID string,
+) (TemplateImport, error) {
+	cluster, ok := m.clusters[clusterID]
+	if !ok {
+		return nil, newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	name := source.template.name
+	clone := params.Name() != nil
+	if clone {
+		name = *params.Name()
+	}
+	targets, err := m.ovfDiskTargets(source, cluster.datacenterID, params, nil, clone)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkOVFImportName(source, name, clone); err != nil {
+		return nil, err
+	}
+	imported, diskIDs := m.importTemplateOVF(source, name, targets, clone)
+	job := m.startJob(fmt.Sprintf("Importing Template %s to Cluster %s", name, cluster.name), correlationID)
+	m.finishOVFJob(job, func() {
+		m.unlockImportedDisks(diskIDs)
+		if tpl, ok := m.templates[imported.id]; ok && tpl.status == TemplateStatusLocked {
+			tpl.status = TemplateStatusOK
+		}
+	})
+	return &templateImport{client: m, job: job.copyJob(), name: name}, nil
+}
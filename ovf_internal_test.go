@@ -0,0 +1,45 @@
+// This file contains tests for the internal job matching of exports and imports. It is therefore excluded from the
+// testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectCorrelatedJobIgnoresJobsOfEarlierRequests(t *testing.T) {
+	t.Parallel()
+
+	sentAt := time.Now()
+	earlier := &testJob{id: "earlier", startTime: sentAt.Add(-time.Hour)}
+	first := &testJob{id: "first", startTime: sentAt.Add(time.Second)}
+	latest := &testJob{id: "latest", startTime: sentAt.Add(2 * time.Second)}
+
+	if job := selectCorrelatedJob([]Job{earlier}, sentAt); job != nil {
+		t.Fatalf("Job %s of an earlier request was selected.", job.ID())
+	}
+	if job := selectCorrelatedJob([]Job{earlier, latest, first}, sentAt); job == nil || job.ID() != "latest" {
+		t.Fatalf("The most recently started job was not selected (%v).", job)
+	}
+	skewed := &testJob{id: "skewed", startTime: sentAt.Add(-time.Minute)}
+	if job := selectCorrelatedJob([]Job{earlier, skewed}, sentAt); job == nil || job.ID() != "skewed" {
+		t.Fatalf("A job started within the tolerated clock skew was not selected (%v).", job)
+	}
+}
+
+// testJob is a Job that only provides its ID and start time.
+type testJob struct {
+	Job
+
+	id        JobID
+	startTime time.Time
+}
+
+func (t *testJob) ID() JobID {
+	return t.id
+}
+
+func (t *testJob) StartTime() time.Time {
+	return t.startTime
+}
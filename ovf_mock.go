@@ -0,0 +1,334 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// mockOVFJobTime is the time the mock takes to finish an export or import job.
+const mockOVFJobTime = time.Second
+
+// mockOVF is a VM or template stored in the OVF format outside of the engine, either in an OVA file or on an export
+// storage domain. Exactly one of vm and template is set.
+type mockOVF struct {
+	vm                  *vm
+	template            *template
+	vmAttachments       []*diskAttachment
+	templateAttachments []*templateDiskAttachment
+	disks               []*diskWithData
+	nics                []*nic
+}
+
+// mockOVAKey returns the key of an OVA file in the ovaFiles map of the mock.
+func mockOVAKey(hostID HostID, path string) string {
+	return fmt.Sprintf("%s:%s", hostID, path)
+}
+
+// vmOVF returns a copy of the VM with its disks and NICs as stored in an OVF. The caller must hold the lock.
+func (m *mockClient) vmOVF(source *vm) (*mockOVF, error) {
+	if source.status != VMStatusDown {
+		return nil, newError(EConflict, "VM %s is in status %s, it must be down to be exported", source.id, source.status)
+	}
+	if err := m.checkVMNotLocked(source.id); err != nil {
+		return nil, err
+	}
+	exported := *source
+	result := &mockOVF{
+		vm: &exported,
+	}
+	for _, attachment := range m.vmDiskAttachmentsByVM[source.id] {
+		disk, err := m.diskOVF(attachment.diskID)
+		if err != nil {
+			return nil, err
+		}
+		exportedAttachment := *attachment
+		result.vmAttachments = append(result.vmAttachments, &exportedAttachment)
+		result.disks = append(result.disks, disk)
+	}
+	for _, item := range m.nics {
+		if item.vmid == source.id {
+			exportedNIC := *item
+			result.nics = append(result.nics, &exportedNIC)
+		}
+	}
+	return result, nil
+}
+
+// templateOVF returns a copy of the template with its disks as stored in an OVF. The caller must hold the lock.
+func (m *mockClient) templateOVF(source *template) (*mockOVF, error) {
+	if source.status != TemplateStatusOK {
+		return nil, newError(
+			EConflict,
+			"template %s is in status %s, it must be ok to be exported",
+			source.id,
+			source.status,
+		)
+	}
+	exported := *source
+	result := &mockOVF{
+		template: &exported,
+	}
+	for _, attachment := range m.templateDiskAttachmentsByTemplate[source.id] {
+		disk, err := m.diskOVF(attachment.diskID)
+		if err != nil {
+			return nil, err
+		}
+		exportedAttachment := *attachment
+		result.templateAttachments = append(result.templateAttachments, &exportedAttachment)
+		result.disks = append(result.disks, disk)
+	}
+	return result, nil
+}
+
+// diskOVF returns a copy of the disk with the same ID, as stored in an OVF. The caller must hold the lock.
+func (m *mockClient) diskOVF(diskID DiskID) (*diskWithData, error) {
+	disk := m.disks[diskID]
+	if disk.storageType != DiskStorageTypeImage {
+		return nil, newError(EBadArgument, "disk %s is a %s disk, only image disks can be exported", diskID, disk.storageType)
+	}
+	if disk.status != DiskStatusOK {
+		return nil, newError(EDiskLocked, "disk %s is %s", diskID, disk.status)
+	}
+	exported := disk.clone(nil)
+	exported.id = diskID
+	return exported, nil
+}
+
+// ovfSize returns the space the disks of the OVF take up.
+func (o *mockOVF) ovfSize() uint64 {
+	var size uint64
+	for _, disk := range o.disks {
+		size += disk.totalSize
+	}
+	return size
+}
+
+// checkOVFImportName checks that the name of an imported VM or template is not taken, and that the VM or template
+// does not exist yet if it is imported without new IDs. The caller must hold the lock.
+func (m *mockClient) checkOVFImportName(source *mockOVF, name string, clone bool) error {
+	if source.vm != nil {
+		for _, existing := range m.vms {
+			if existing.name == name {
+				return newError(EConflict, "A VM with the name \"%s\" already exists.", name)
+			}
+		}
+		if _, ok := m.vms[source.vm.id]; ok && !clone {
+			return newError(EConflict, "VM %s already exists, import it with a new name", source.vm.id)
+		}
+		return nil
+	}
+	for _, existing := range m.templates {
+		if existing.name == name {
+			return newError(EConflict, "A template with the name \"%s\" already exists.", name)
+		}
+	}
+	if _, ok := m.templates[source.template.id]; ok && !clone {
+		return newError(EConflict, "template %s already exists, import it with a new name", source.template.id)
+	}
+	return nil
+}
+
+// ovfDiskTargets returns the storage domains the disks of the OVF are imported to, keyed by the disk ID in the OVF.
+// If dataStorageDomainID is set, the disks are imported from that data storage domain and stay on it. The caller must
+// hold the lock.
+func (m *mockClient) ovfDiskTargets(
+	source *mockOVF,
+	datacenterID DatacenterID,
+	params OptionalOVFImportParameters,
+	dataStorageDomainID *StorageDomainID,
+	clone bool,
+) (map[DiskID]StorageDomainID, error) {
+	sourceDisks := map[DiskID]bool{}
+	for _, disk := range source.disks {
+		sourceDisks[disk.id] = true
+	}
+	for diskID := range params.DiskStorageDomainIDs() {
+		if !sourceDisks[diskID] {
+			return nil, newError(EBadArgument, "disk %s is not part of the imported VM or template", diskID)
+		}
+	}
+	targets := map[DiskID]StorageDomainID{}
+	sizes := map[StorageDomainID]uint64{}
+	for _, disk := range source.disks {
+		if _, ok := m.disks[disk.id]; ok && !clone {
+			return nil, newError(EConflict, "a disk with the ID %s already exists, import it with a new name", disk.id)
+		}
+		if dataStorageDomainID != nil {
+			targets[disk.id] = *dataStorageDomainID
+			sizes[*dataStorageDomainID] = 0
+			continue
+		}
+		target := *params.StorageDomainID()
+		if storageDomainID, ok := params.DiskStorageDomainIDs()[disk.id]; ok {
+			target = storageDomainID
+		}
+		targets[disk.id] = target
+		sizes[target] += disk.totalSize
+	}
+	for storageDomainID, size := range sizes {
+		sd, ok := m.storageDomains[storageDomainID]
+		if !ok {
+			return nil, newError(ENotFound, "storage domain with ID %s not found", storageDomainID)
+		}
+		if sd.function != StorageDomainFunctionData {
+			return nil, newError(
+				EBadArgument,
+				"storage domain %s is a %s storage domain, disks can only be imported to data storage domains",
+				storageDomainID,
+				sd.function,
+			)
+		}
+		if !sd.attachedTo(datacenterID) {
+			return nil, newError(
+				EConflict,
+				"storage domain %s is not attached to datacenter %s of the cluster",
+				storageDomainID,
+				datacenterID,
+			)
+		}
+		if err := m.checkStorageDomainActive(sd); err != nil {
+			return nil, err
+		}
+		// Disks imported from a data storage domain already take up space on it.
+		if dataStorageDomainID == nil {
+			if err := m.checkStorageDomainSpace(storageDomainID, size); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return targets, nil
+}
+
+// importOVFDisks adds locked copies of the disks of the OVF to the mock on their target storage domains. It returns
+// the IDs of the imported disks, keyed by the disk ID in the OVF. The caller must hold the lock.
+func (m *mockClient) importOVFDisks(source *mockOVF, targets map[DiskID]StorageDomainID, clone bool) map[DiskID]DiskID {
+	diskIDs := make(map[DiskID]DiskID, len(source.disks))
+	for _, disk := range source.disks {
+		imported := disk.clone(nil)
+		if !clone {
+			imported.id = disk.id
+		}
+		imported.client = m
+		imported.storageDomainIDs = []StorageDomainID{targets[disk.id]}
+		imported.diskProfileID = nil
+		if diskProfileID, ok := m.defaultDiskProfiles[targets[disk.id]]; ok {
+			imported.diskProfileID = &diskProfileID
+		}
+		imported.status = DiskStatusLocked
+		m.disks[imported.id] = imported
+		diskIDs[disk.id] = imported.id
+	}
+	return diskIDs
+}
+
+// importVMOVF adds the VM of the OVF to the cluster under the specified name. The VM and its disks are locked until
+// the import job finishes. The caller must hold the lock and must have checked the name and disk targets.
+func (m *mockClient) importVMOVF(
+	source *mockOVF,
+	clusterID ClusterID,
+	name string,
+	targets map[DiskID]StorageDomainID,
+	clone bool,
+) (*vm, map[DiskID]DiskID) {
+	imported := *source.vm
+	imported.client = m
+	if clone {
+		imported.id = VMID(m.GenerateUUID())
+	}
+	imported.name = name
+	imported.clusterID = clusterID
+	imported.status = VMStatusImageLocked
+	imported.hostID = nil
+	// Tags are not part of the OVF.
+	imported.tagIDs = nil
+	if _, ok := m.templates[imported.templateID]; !ok {
+		imported.templateID = DefaultBlankTemplateID
+	}
+	m.vms[imported.id] = &imported
+
+	diskIDs := m.importOVFDisks(source, targets, clone)
+	m.vmDiskAttachmentsByVM[imported.id] = make(map[DiskAttachmentID]*diskAttachment, len(source.vmAttachments))
+	for _, attachment := range source.vmAttachments {
+		importedAttachment := *attachment
+		importedAttachment.client = m
+		if clone {
+			importedAttachment.id = DiskAttachmentID(m.GenerateUUID())
+		}
+		importedAttachment.vmid = imported.id
+		importedAttachment.diskID = diskIDs[attachment.diskID]
+		m.vmDiskAttachmentsByVM[imported.id][importedAttachment.id] = &importedAttachment
+		m.addDiskAttachmentByDisk(importedAttachment.diskID, &importedAttachment)
+	}
+	for _, item := range source.nics {
+		importedNIC := *item
+		importedNIC.client = m
+		if clone {
+			importedNIC.id = NICID(m.GenerateUUID())
+		}
+		importedNIC.vmid = imported.id
+		m.nics[importedNIC.id] = &importedNIC
+	}
+	m.vmIPs[imported.id] = map[string][]net.IP{}
+	m.addGraphicsConsoles(&imported)
+	m.addCdroms(&imported)
+	return &imported, diskIDs
+}
+
+// importTemplateOVF adds the template of the OVF under the specified name. The template and its disks are locked
+// until the import job finishes. The caller must hold the lock and must have checked the name and disk targets.
+func (m *mockClient) importTemplateOVF(
+	source *mockOVF,
+	name string,
+	targets map[DiskID]StorageDomainID,
+	clone bool,
+) (*template, map[DiskID]DiskID) {
+	imported := *source.template
+	imported.client = m
+	if clone {
+		imported.id = TemplateID(m.GenerateUUID())
+	}
+	imported.name = name
+	imported.status = TemplateStatusLocked
+	m.templates[imported.id] = &imported
+
+	diskIDs := m.importOVFDisks(source, targets, clone)
+	m.templateDiskAttachmentsByTemplate[imported.id] = make(
+		[]*templateDiskAttachment,
+		len(source.templateAttachments),
+	)
+	for i, attachment := range source.templateAttachments {
+		importedAttachment := *attachment
+		importedAttachment.client = m
+		if clone {
+			importedAttachment.id = TemplateDiskAttachmentID(m.GenerateUUID())
+		}
+		importedAttachment.templateID = imported.id
+		importedAttachment.diskID = diskIDs[attachment.diskID]
+		m.templateDiskAttachmentsByTemplate[imported.id][i] = &importedAttachment
+		m.templateDiskAttachmentsByDisk[importedAttachment.diskID] = &importedAttachment
+	}
+	return &imported, diskIDs
+}
+
+// finishOVFJob ends the export or import job after a delay. The apply function is called with the lock held before
+// the job is marked as finished.
+func (m *mockClient) finishOVFJob(job *mockJob, apply func()) {
+	go func() {
+		time.Sleep(mockOVFJobTime)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		apply()
+		m.endJob(job, JobStatusFinished)
+	}()
+}
+
+// unlockImportedDisks unlocks the disks of a finished import that have not been removed in the meantime. The caller
+// must hold the lock.
+func (m *mockClient) unlockImportedDisks(diskIDs map[DiskID]DiskID) {
+	for _, diskID := range diskIDs {
+		if disk, ok := m.disks[diskID]; ok {
+			disk.Unlock()
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// This file contains tests for exporting and importing VMs and templates in the mock client, as the live tests cannot
+// provision export storage domains or OVA files on hosts. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestMockOVAExportImport(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, _ := mockStorageDomainTestIDs(t, client)
+	clusterID, sdID := mockOVFTestIDs(t, client)
+	vm, disk := mockOVFTestVM(t, client, clusterID, sdID, "ova-vm")
+
+	job, err := client.ExportVMAsOVA(vm.ID(), hostID, "/var/tmp/export", "")
+	if err != nil {
+		t.Fatalf("Failed to export VM as OVA (%v)", err)
+	}
+	if job, err = job.Wait(); err != nil || job.Status() != JobStatusFinished {
+		t.Fatalf("Failed to wait for the OVA export (%v)", err)
+	}
+	if _, err := client.ExportVMAsOVA(vm.ID(), hostID, "/var/tmp/export", "ova-vm.ova"); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Exporting to an existing OVA file did not return an EConflict error (%v)", err)
+	}
+
+	path := "/var/tmp/export/ova-vm.ova"
+	params := OVFImportParams().MustWithStorageDomainID(sdID)
+	if _, err := client.ImportVMFromOVA(hostID, path, clusterID, params); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Importing from an OVA file without a name did not return an EBadArgument error (%v)", err)
+	}
+	vmImport, err := client.ImportVMFromOVA(hostID, path, clusterID, params.MustWithName("ova-vm-copy"))
+	if err != nil {
+		t.Fatalf("Failed to import VM from OVA (%v)", err)
+	}
+	imported, err := vmImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the OVA import (%v)", err)
+	}
+	if imported.ID() == vm.ID() || imported.Name() != "ova-vm-copy" || imported.Status() != VMStatusDown {
+		t.Fatalf("Incorrect imported VM: %s %s %s", imported.ID(), imported.Name(), imported.Status())
+	}
+	mockOVFCheckImportedDisk(t, client, imported, disk, sdID)
+
+	tpl, err := client.CreateTemplate(vm.ID(), "ova-template", nil)
+	if err != nil {
+		t.Fatalf("Failed to create template (%v)", err)
+	}
+	if _, err := tpl.WaitForStatus(TemplateStatusOK); err != nil {
+		t.Fatalf("Failed to wait for template (%v)", err)
+	}
+	job, err = client.ExportTemplateAsOVA(tpl.ID(), hostID, "/var/tmp/export", "template.ova")
+	if err != nil {
+		t.Fatalf("Failed to export template as OVA (%v)", err)
+	}
+	if _, err := job.Wait(); err != nil {
+		t.Fatalf("Failed to wait for the OVA export (%v)", err)
+	}
+	templatePath := "/var/tmp/export/template.ova"
+	if _, err := client.ImportVMFromOVA(
+		hostID,
+		templatePath,
+		clusterID,
+		OVFImportParams().MustWithStorageDomainID(sdID).MustWithName("not-a-vm"),
+	); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Importing a VM from a template OVA did not return an EBadArgument error (%v)", err)
+	}
+	templateImport, err := client.ImportTemplateFromOVA(
+		hostID,
+		templatePath,
+		clusterID,
+		OVFImportParams().MustWithStorageDomainID(sdID).MustWithName("ova-template-copy"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to import template from OVA (%v)", err)
+	}
+	importedTemplate, err := templateImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the template import (%v)", err)
+	}
+	if importedTemplate.ID() == tpl.ID() || importedTemplate.Status() != TemplateStatusOK {
+		t.Fatalf("Incorrect imported template: %s %s", importedTemplate.ID(), importedTemplate.Status())
+	}
+}
+
+func TestMockOVFExportStorageDomain(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, datacenterID := mockStorageDomainTestIDs(t, client)
+	clusterID, sdID := mockOVFTestIDs(t, client)
+	vm, disk := mockOVFTestVM(t, client, clusterID, sdID, "export-vm")
+
+	exportDomain, err := client.CreateStorageDomain(
+		hostID,
+		"export",
+		StorageDomainTypeNFS,
+		CreateStorageDomainParams().
+			MustWithNFS("192.0.2.1", "/exports/export").
+			MustWithFunction(StorageDomainFunctionExport),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create export storage domain (%v)", err)
+	}
+	if _, err := client.ExportVMToStorageDomain(vm.ID(), exportDomain.ID()); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Exporting to an unattached storage domain did not return an EConflict error (%v)", err)
+	}
+	mockStorageDomainAttach(t, exportDomain, datacenterID)
+	if _, err := client.ExportVMToStorageDomain(vm.ID(), sdID); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Exporting to a data storage domain did not return an EBadArgument error (%v)", err)
+	}
+	job, err := client.ExportVMToStorageDomain(vm.ID(), exportDomain.ID())
+	if err != nil {
+		t.Fatalf("Failed to export VM (%v)", err)
+	}
+	if _, err := job.Wait(); err != nil {
+		t.Fatalf("Failed to wait for the export (%v)", err)
+	}
+	vms, err := client.ListStorageDomainVMs(exportDomain.ID())
+	if err != nil {
+		t.Fatalf("Failed to list VMs on the export storage domain (%v)", err)
+	}
+	if len(vms) != 1 || vms[0].ID() != vm.ID() {
+		t.Fatalf("The exported VM is not listed on the export storage domain.")
+	}
+
+	if _, err := client.ImportVMFromStorageDomain(
+		exportDomain.ID(),
+		vm.ID(),
+		clusterID,
+		nil,
+	); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Importing without a target storage domain did not return an EBadArgument error (%v)", err)
+	}
+	params := OVFImportParams().MustWithStorageDomainID(sdID)
+	if _, err := client.ImportVMFromStorageDomain(exportDomain.ID(), vm.ID(), clusterID, params); !HasErrorCode(
+		err,
+		EConflict,
+	) {
+		t.Fatalf("Importing an existing VM without a new name did not return an EConflict error (%v)", err)
+	}
+	if _, err := client.ImportVMFromStorageDomain(
+		exportDomain.ID(),
+		vm.ID(),
+		clusterID,
+		OVFImportParams().MustWithStorageDomainID(sdID).MustWithDiskStorageDomainID("unknown", sdID),
+	); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Mapping an unknown disk did not return an EBadArgument error (%v)", err)
+	}
+
+	if err := client.RemoveVM(vm.ID()); err != nil {
+		t.Fatalf("Failed to remove VM (%v)", err)
+	}
+	vmImport, err := client.ImportVMFromStorageDomain(exportDomain.ID(), vm.ID(), clusterID, params)
+	if err != nil {
+		t.Fatalf("Failed to import VM (%v)", err)
+	}
+	if vmImport.Job().Status() != JobStatusStarted {
+		t.Fatalf("Incorrect status of the import job: %s", vmImport.Job().Status())
+	}
+	imported, err := vmImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the import (%v)", err)
+	}
+	if imported.ID() != vm.ID() || imported.Name() != vm.Name() {
+		t.Fatalf("The VM imported without a new name did not keep its ID and name.")
+	}
+	mockOVFCheckImportedDisk(t, client, imported, disk, sdID)
+}
+
+func TestMockOVFImportDataStorageDomain(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, datacenterID := mockStorageDomainTestIDs(t, client)
+	clusterID, _ := mockOVFTestIDs(t, client)
+
+	sd, err := client.CreateStorageDomain(
+		hostID,
+		"transfer",
+		StorageDomainTypeNFS,
+		CreateStorageDomainParams().MustWithNFS("192.0.2.1", "/exports/transfer"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain (%v)", err)
+	}
+	mockStorageDomainAttach(t, sd, datacenterID)
+	vm, disk := mockOVFTestVM(t, client, clusterID, sd.ID(), "transfer-vm")
+	mockStorageDomainDetach(t, sd, datacenterID)
+	mockStorageDomainAttach(t, sd, datacenterID)
+
+	if _, err := client.ImportVMFromStorageDomain(
+		sd.ID(),
+		vm.ID(),
+		clusterID,
+		OVFImportParams().MustWithStorageDomainID(sd.ID()),
+	); !HasErrorCode(err, EBadArgument) {
+		t.Fatalf("Setting a target for a data storage domain import did not return an EBadArgument error (%v)", err)
+	}
+	vmImport, err := client.ImportVMFromStorageDomain(
+		sd.ID(),
+		vm.ID(),
+		clusterID,
+		OVFImportParams().MustWithName("transferred-vm"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to import VM from data storage domain (%v)", err)
+	}
+	imported, err := vmImport.Wait()
+	if err != nil {
+		t.Fatalf("Failed to wait for the import (%v)", err)
+	}
+	if imported.Name() != "transferred-vm" || imported.ID() == vm.ID() {
+		t.Fatalf("The VM was not imported with a new name and ID.")
+	}
+	mockOVFCheckImportedDisk(t, client, imported, disk, sd.ID())
+	unregistered, err := client.ListUnregisteredStorageDomainVMs(sd.ID())
+	if err != nil {
+		t.Fatalf("Failed to list unregistered VMs (%v)", err)
+	}
+	if len(unregistered) != 0 {
+		t.Fatalf("The imported VM is still unregistered.")
+	}
+}
+
+// mockOVFTestIDs returns the ID of a cluster and of a data storage domain attached to its datacenter.
+func mockOVFTestIDs(t *testing.T, client MockClient) (ClusterID, StorageDomainID) {
+	clusters, err := client.ListClusters()
+	if err != nil || len(clusters) == 0 {
+		t.Fatalf("Failed to list clusters (%v)", err)
+	}
+	return clusters[0].ID(), testStorageDomainID(client)
+}
+
+// mockOVFTestVM creates a VM with a disk on the storage domain.
+func mockOVFTestVM(
+	t *testing.T,
+	client MockClient,
+	clusterID ClusterID,
+	sdID StorageDomainID,
+	name string,
+) (VM, Disk) {
+	disk, err := client.CreateDisk(sdID, ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	vm, err := client.CreateVM(clusterID, DefaultBlankTemplateID, name, nil)
+	if err != nil {
+		t.Fatalf("Failed to create VM (%v)", err)
+	}
+	if _, err := vm.AttachDisk(disk.ID(), DiskInterfaceVirtIO, nil); err != nil {
+		t.Fatalf("Failed to attach disk (%v)", err)
+	}
+	return vm, disk
+}
+
+// mockOVFCheckImportedDisk checks that the imported VM has a copy of the disk on the storage domain.
+func mockOVFCheckImportedDisk(t *testing.T, client MockClient, vm VM, source Disk, sdID StorageDomainID) {
+	attachments, err := vm.ListDiskAttachments()
+	if err != nil {
+		t.Fatalf("Failed to list disk attachments (%v)", err)
+	}
+	if len(attachments) != 1 {
+		t.Fatalf("Incorrect number of disk attachments on the imported VM: %d", len(attachments))
+	}
+	disk, err := client.GetDisk(attachments[0].DiskID())
+	if err != nil {
+		t.Fatalf("Failed to get imported disk (%v)", err)
+	}
+	if disk.Status() != DiskStatusOK {
+		t.Fatalf("Incorrect status of the imported disk: %s", disk.Status())
+	}
+	if ids := disk.StorageDomainIDs(); len(ids) != 1 || ids[0] != sdID {
+		t.Fatalf("The imported disk is on the incorrect storage domains: %v", ids)
+	}
+	if disk.ProvisionedSize() != source.ProvisionedSize() {
+		t.Fatalf("Incorrect size of the imported disk: %d", disk.ProvisionedSize())
+	}
+}
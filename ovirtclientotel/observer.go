@@ -0,0 +1,73 @@
+// Package ovirtclientotel provides an OpenTelemetry adapter that creates a span for every attempt the oVirt client
+// makes to perform an API call.
+//
+// Pass the observer to the client using ovirtclient.ExtraSettingsBuilder.WithRequestObserver. Spans are created as
+// children of the span in the context passed to ovirtclient.Client.WithContext:
+//
+//	client, err := ovirtclient.New(
+//	    url, username, password, tls, logger,
+//	    ovirtclient.NewExtraSettings().WithRequestObserver(
+//	        ovirtclientotel.NewTracingObserver(otel.Tracer("ovirtclient")),
+//	    ),
+//	)
+//	vm, err := client.WithContext(ctx).GetVM(id)
+package ovirtclientotel
+
+import (
+	"context"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys set on the spans created by the observer.
+const (
+	AttributeAction     attribute.Key = "ovirt.action"
+	AttributeAttempt    attribute.Key = "ovirt.attempt"
+	AttributePending    attribute.Key = "ovirt.pending"
+	AttributeErrorCode  attribute.Key = "ovirt.error_code"
+	AttributeHTTPStatus attribute.Key = "http.status_code"
+)
+
+// NewTracingObserver creates a RequestObserver that records every attempt as a span using the specified tracer. The
+// span is named after the operation (see ovirtclient.RequestAttempt.Operation) and covers the duration of the
+// attempt.
+func NewTracingObserver(tracer trace.Tracer) ovirtclient.RequestObserver {
+	return &tracingObserver{
+		tracer: tracer,
+	}
+}
+
+type tracingObserver struct {
+	tracer trace.Tracer
+}
+
+func (t *tracingObserver) ObserveAttempt(attempt ovirtclient.RequestAttempt) {
+	ctx := attempt.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	startTime := attempt.StartTime()
+	_, span := t.tracer.Start(
+		ctx,
+		attempt.Operation(),
+		trace.WithTimestamp(startTime),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttributeAction.String(attempt.Action()),
+			AttributeAttempt.Int64(int64(attempt.Attempt())),
+			AttributePending.Bool(attempt.Pending()),
+		),
+	)
+	if err := attempt.Err(); err != nil {
+		span.SetAttributes(AttributeErrorCode.String(string(attempt.ErrorCode())))
+		if status := attempt.HTTPStatus(); status != 0 {
+			span.SetAttributes(AttributeHTTPStatus.Int(status))
+		}
+		span.RecordError(err, trace.WithTimestamp(startTime.Add(attempt.Duration())))
+		span.SetStatus(codes.Error, string(attempt.ErrorCode()))
+	}
+	span.End(trace.WithTimestamp(startTime.Add(attempt.Duration())))
+}
@@ -0,0 +1,180 @@
+package ovirtclientotel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+	"github.com/yosefsatrioaji/go-ovirt-client/v3/ovirtclientotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingObserverRecordsAttempts(t *testing.T) {
+	t.Parallel()
+
+	tracer := &testTracer{}
+	observer := ovirtclientotel.NewTracingObserver(tracer)
+
+	startTime := time.Now().Add(-time.Second)
+	observer.ObserveAttempt(&testAttempt{
+		operation:  "GetVM",
+		action:     "getting VM 123",
+		attempt:    1,
+		startTime:  startTime,
+		duration:   100 * time.Millisecond,
+		errorCode:  ovirtclient.EConnection,
+		httpStatus: 503,
+	})
+	observer.ObserveAttempt(&testAttempt{
+		operation: "WaitForVMStatus",
+		action:    "waiting for VM 123 status",
+		attempt:   2,
+		startTime: startTime,
+		duration:  50 * time.Millisecond,
+		pending:   true,
+	})
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Incorrect number of spans (expected: %d, got: %d)", 2, len(tracer.spans))
+	}
+
+	failed := tracer.spans[0]
+	if failed.name != "GetVM" {
+		t.Fatalf("Incorrect span name (expected: %s, got: %s)", "GetVM", failed.name)
+	}
+	assertSpanAttributes(t, failed, map[attribute.Key]attribute.Value{
+		ovirtclientotel.AttributeAction:     attribute.StringValue("getting VM 123"),
+		ovirtclientotel.AttributeAttempt:    attribute.Int64Value(1),
+		ovirtclientotel.AttributePending:    attribute.BoolValue(false),
+		ovirtclientotel.AttributeErrorCode:  attribute.StringValue(string(ovirtclient.EConnection)),
+		ovirtclientotel.AttributeHTTPStatus: attribute.IntValue(503),
+	})
+	if failed.statusCode != codes.Error || failed.statusDescription != string(ovirtclient.EConnection) {
+		t.Fatalf("Incorrect span status (%v: %s)", failed.statusCode, failed.statusDescription)
+	}
+	if len(failed.errors) != 1 {
+		t.Fatalf("The error was not recorded on the span.")
+	}
+	if !failed.start.Equal(startTime) || !failed.end.Equal(startTime.Add(100*time.Millisecond)) {
+		t.Fatalf("Incorrect span timestamps (start: %s, end: %s)", failed.start, failed.end)
+	}
+
+	pending := tracer.spans[1]
+	if pending.name != "WaitForVMStatus" {
+		t.Fatalf("Incorrect span name (expected: %s, got: %s)", "WaitForVMStatus", pending.name)
+	}
+	assertSpanAttributes(t, pending, map[attribute.Key]attribute.Value{
+		ovirtclientotel.AttributeAction:  attribute.StringValue("waiting for VM 123 status"),
+		ovirtclientotel.AttributeAttempt: attribute.Int64Value(2),
+		ovirtclientotel.AttributePending: attribute.BoolValue(true),
+	})
+	if pending.statusCode != codes.Unset || len(pending.errors) != 0 {
+		t.Fatalf("Pending attempt was recorded as failed (%v: %s)", pending.statusCode, pending.statusDescription)
+	}
+}
+
+func assertSpanAttributes(t *testing.T, span *testSpan, expected map[attribute.Key]attribute.Value) {
+	t.Helper()
+	if len(span.attributes) != len(expected) {
+		t.Fatalf("Incorrect span attributes (expected: %v, got: %v)", expected, span.attributes)
+	}
+	for key, value := range expected {
+		if actual, ok := span.attributes[key]; !ok || actual != value {
+			t.Fatalf("Incorrect span attribute %s (expected: %s, got: %s)", key, value.Emit(), actual.Emit())
+		}
+	}
+}
+
+// testTracer records the spans it starts, so the test does not depend on the OpenTelemetry SDK.
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(
+	ctx context.Context,
+	spanName string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &testSpan{
+		Span:       trace.SpanFromContext(ctx),
+		name:       spanName,
+		start:      config.Timestamp(),
+		attributes: map[attribute.Key]attribute.Value{},
+	}
+	span.SetAttributes(config.Attributes()...)
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type testSpan struct {
+	trace.Span
+
+	name              string
+	start             time.Time
+	end               time.Time
+	attributes        map[attribute.Key]attribute.Value
+	errors            []error
+	statusCode        codes.Code
+	statusDescription string
+}
+
+func (s *testSpan) End(options ...trace.SpanEndOption) {
+	config := trace.NewSpanEndConfig(options...)
+	s.end = config.Timestamp()
+}
+
+func (s *testSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *testSpan) SetStatus(code codes.Code, description string) {
+	s.statusCode = code
+	s.statusDescription = description
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attributes[attr.Key] = attr.Value
+	}
+}
+
+type testAttempt struct {
+	operation  string
+	action     string
+	attempt    uint
+	startTime  time.Time
+	duration   time.Duration
+	pending    bool
+	errorCode  ovirtclient.ErrorCode
+	httpStatus int
+}
+
+func (t *testAttempt) Context() context.Context { return context.Background() }
+
+func (t *testAttempt) Action() string { return t.action }
+
+func (t *testAttempt) Operation() string { return t.operation }
+
+func (t *testAttempt) Attempt() uint { return t.attempt }
+
+func (t *testAttempt) Pending() bool { return t.pending }
+
+func (t *testAttempt) StartTime() time.Time { return t.startTime }
+
+func (t *testAttempt) Duration() time.Duration { return t.duration }
+
+func (t *testAttempt) Err() error {
+	if t.errorCode == "" {
+		return nil
+	}
+	return fmt.Errorf("test failure")
+}
+
+func (t *testAttempt) ErrorCode() ovirtclient.ErrorCode { return t.errorCode }
+
+func (t *testAttempt) HTTPStatus() int { return t.httpStatus }
@@ -0,0 +1,133 @@
+// Package ovirtclientprometheus provides a Prometheus collector that records metrics about the API calls made by
+// the oVirt client.
+//
+// The collector is both an ovirtclient.RequestObserver and a prometheus.Collector. Pass it to the client using
+// ovirtclient.ExtraSettingsBuilder.WithRequestObserver and register it with your Prometheus registry:
+//
+//	collector := ovirtclientprometheus.NewCollector()
+//	prometheus.MustRegister(collector)
+//	client, err := ovirtclient.New(
+//	    url, username, password, tls, logger,
+//	    ovirtclient.NewExtraSettings().WithRequestObserver(collector),
+//	)
+package ovirtclientprometheus
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+// Collector records the number and duration of attempts made by the oVirt client. All metrics are labeled with the
+// operation (see ovirtclient.RequestAttempt.Operation), the error code and the HTTP status code. The error code and
+// HTTP status labels are empty for successful attempts, including polls of wait loops that are still pending.
+type Collector interface {
+	prometheus.Collector
+	ovirtclient.RequestObserver
+}
+
+// Option customizes the collector created by NewCollector.
+type Option func(c *collectorOptions)
+
+// WithNamespace sets the namespace prefix of the metrics. Defaults to "ovirt_client".
+func WithNamespace(namespace string) Option {
+	return func(c *collectorOptions) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the attempt duration histogram in seconds. Defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(c *collectorOptions) {
+		c.buckets = buckets
+	}
+}
+
+// WithConstLabels adds labels with fixed values to all metrics, for example to distinguish multiple engines.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *collectorOptions) {
+		c.constLabels = labels
+	}
+}
+
+// NewCollector creates a new collector with the specified options.
+func NewCollector(options ...Option) Collector {
+	opts := &collectorOptions{
+		namespace: "ovirt_client",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	labels := []string{"operation", "error_code", "http_status"}
+	return &collector{
+		attempts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   opts.namespace,
+				Name:        "request_attempts_total",
+				Help:        "Number of attempts made to perform an operation against the oVirt Engine.",
+				ConstLabels: opts.constLabels,
+			},
+			labels,
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   opts.namespace,
+				Name:        "request_retries_total",
+				Help:        "Number of attempts that were retries of a previously failed attempt.",
+				ConstLabels: opts.constLabels,
+			},
+			[]string{"operation"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   opts.namespace,
+				Name:        "request_attempt_duration_seconds",
+				Help:        "Duration of attempts made to perform an operation against the oVirt Engine.",
+				ConstLabels: opts.constLabels,
+				Buckets:     opts.buckets,
+			},
+			labels,
+		),
+	}
+}
+
+type collectorOptions struct {
+	namespace   string
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+type collector struct {
+	attempts *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func (c *collector) ObserveAttempt(attempt ovirtclient.RequestAttempt) {
+	operation := attempt.Operation()
+	httpStatus := ""
+	if status := attempt.HTTPStatus(); status != 0 {
+		httpStatus = strconv.Itoa(status)
+	}
+	errorCode := string(attempt.ErrorCode())
+
+	c.attempts.WithLabelValues(operation, errorCode, httpStatus).Inc()
+	c.duration.WithLabelValues(operation, errorCode, httpStatus).Observe(attempt.Duration().Seconds())
+	if attempt.Attempt() > 1 {
+		c.retries.WithLabelValues(operation).Inc()
+	}
+}
+
+func (c *collector) Describe(descs chan<- *prometheus.Desc) {
+	c.attempts.Describe(descs)
+	c.retries.Describe(descs)
+	c.duration.Describe(descs)
+}
+
+func (c *collector) Collect(metrics chan<- prometheus.Metric) {
+	c.attempts.Collect(metrics)
+	c.retries.Collect(metrics)
+	c.duration.Collect(metrics)
+}
@@ -0,0 +1,91 @@
+package ovirtclientprometheus_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+	"github.com/yosefsatrioaji/go-ovirt-client/v3/ovirtclientprometheus"
+)
+
+func TestCollectorRecordsAttempts(t *testing.T) {
+	t.Parallel()
+
+	collector := ovirtclientprometheus.NewCollector(ovirtclientprometheus.WithNamespace("test"))
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Failed to register collector (%v)", err)
+	}
+
+	collector.ObserveAttempt(&testAttempt{
+		operation:  "GetVM",
+		attempt:    1,
+		duration:   100 * time.Millisecond,
+		errorCode:  ovirtclient.EConnection,
+		httpStatus: 503,
+	})
+	collector.ObserveAttempt(&testAttempt{
+		operation: "GetVM",
+		attempt:   2,
+		duration:  50 * time.Millisecond,
+	})
+
+	expected := `
+# HELP test_request_attempts_total Number of attempts made to perform an operation against the oVirt Engine.
+# TYPE test_request_attempts_total counter
+test_request_attempts_total{error_code="",http_status="",operation="GetVM"} 1
+test_request_attempts_total{error_code="connection",http_status="503",operation="GetVM"} 1
+# HELP test_request_retries_total Number of attempts that were retries of a previously failed attempt.
+# TYPE test_request_retries_total counter
+test_request_retries_total{operation="GetVM"} 1
+`
+	if err := testutil.GatherAndCompare(
+		registry,
+		strings.NewReader(expected),
+		"test_request_attempts_total",
+		"test_request_retries_total",
+	); err != nil {
+		t.Fatalf("Unexpected metrics (%v)", err)
+	}
+	if count := testutil.CollectAndCount(collector, "test_request_attempt_duration_seconds"); count != 2 {
+		t.Fatalf("Incorrect number of duration series (expected: %d, got: %d)", 2, count)
+	}
+}
+
+type testAttempt struct {
+	operation  string
+	attempt    uint
+	duration   time.Duration
+	errorCode  ovirtclient.ErrorCode
+	httpStatus int
+}
+
+func (t *testAttempt) Context() context.Context { return context.Background() }
+
+func (t *testAttempt) Action() string { return t.operation }
+
+func (t *testAttempt) Operation() string { return t.operation }
+
+func (t *testAttempt) Attempt() uint { return t.attempt }
+
+func (t *testAttempt) Pending() bool { return false }
+
+func (t *testAttempt) StartTime() time.Time { return time.Now().Add(-t.duration) }
+
+func (t *testAttempt) Duration() time.Duration { return t.duration }
+
+func (t *testAttempt) Err() error {
+	if t.errorCode == "" {
+		return nil
+	}
+	return fmt.Errorf("test failure")
+}
+
+func (t *testAttempt) ErrorCode() ovirtclient.ErrorCode { return t.errorCode }
+
+func (t *testAttempt) HTTPStatus() int { return t.httpStatus }
@@ -0,0 +1,81 @@
+package ovirtclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// requestHooks carries the per-client hooks that retry invokes around every attempt: the rate and concurrency limiter,
+// the SSO token manager and the request observer. They are bound to the context of the client performing the
+// request. A nil *requestHooks disables all hooks, which is what the mock client uses.
+type requestHooks struct {
+	ctx       context.Context
+	operation string
+	observer  RequestObserver
+	limiter   *requestLimiter
+	tokens    *tokenManager
+}
+
+// acquire waits for the limiter to allow the next attempt and returns a function to call once the attempt is
+// complete. It is safe to call on a nil receiver.
+func (h *requestHooks) acquire() (func(), error) {
+	if h == nil {
+		return func() {}, nil
+	}
+	return h.limiter.acquire(h.ctx)
+}
+
+// useToken requests a new SSO token if the current one is about to expire and returns a function to call once the
+// attempt is complete. It is safe to call on a nil receiver.
+func (h *requestHooks) useToken() (func(), error) {
+	if h == nil || h.tokens == nil {
+		return func() {}, nil
+	}
+	return h.tokens.use()
+}
+
+// classify turns authentication failures caused by a reused token into EInvalidGrant errors, so the
+// ReconnectStrategy requests a new token. Tokens loaded from a TokenStore may have been revoked or may have expired
+// on the engine side without the client knowing. Failures of tokens the SDK obtained itself are left as they are. It
+// is safe to call on a nil receiver.
+func (h *requestHooks) classify(err error) error {
+	if err == nil || h == nil || h.tokens == nil || !h.tokens.managed() ||
+		httpStatusOf(err) != http.StatusUnauthorized {
+		return err
+	}
+	return wrap(err, EInvalidGrant, "the SSO token has expired or was revoked")
+}
+
+// observe reports an attempt to the observer. Attempts that returned an EPending error are reported as pending
+// instead of failed. It is safe to call on a nil receiver.
+func (h *requestHooks) observe(action string, attempt uint, startTime time.Time, err error) {
+	if h == nil || h.observer == nil {
+		return
+	}
+	result := &requestAttempt{
+		ctx:       h.ctx,
+		action:    action,
+		operation: h.operation,
+		attempt:   attempt,
+		startTime: startTime,
+		duration:  time.Since(startTime),
+	}
+	switch {
+	case err == nil:
+	case isPending(err):
+		result.pending = true
+	default:
+		result.err = err
+		result.errorCode = errorCodeOf(err)
+		result.httpStatus = httpStatusOf(err)
+	}
+	h.observer.ObserveAttempt(result)
+}
+
+// isPending returns true if the error signals that the condition a wait loop is polling for is not met yet.
+func isPending(err error) bool {
+	var e EngineError
+	return errors.As(err, &e) && e.Code() == EPending
+}
@@ -18,10 +18,12 @@ import (
 // - action is the action that is being performed in the "ing" form, for example "creating disk".
 // - what is the function that should be called repeatedly.
 // - logger is an optional logger that can be passed to log retry actions.
+// - hooks are optional hooks that limit the request rate and are notified after every attempt.
 // - howLong is the retry configuration that should be used.
 func retry(
 	action string,
 	logger ovirtclientlog.Logger,
+	hooks *requestHooks,
 	howLong []RetryStrategy,
 	what func() error,
 ) error {
@@ -34,12 +36,32 @@ func retry(
 		logger = &noopLogger{}
 	}
 	logger.Infof("%s%s...", strings.ToUpper(action[:1]), action[1:])
+	var attempt uint
 	for {
-		err := what()
+		attempt++
+		release, err := hooks.acquire()
+		if err != nil {
+			err = wrap(err, ETimeout, "timeout while waiting for request limit for %s", action)
+			logger.Infof("Giving up %s (%v)", action, err)
+			return err
+		}
+		startTime := time.Now()
+		done, err := hooks.useToken()
+		if err == nil {
+			err = what()
+			done()
+		}
+		release()
+		err = hooks.classify(err)
+		hooks.observe(action, attempt, startTime, err)
 		if err == nil {
 			logger.Infof("Completed %s.", action)
 			return nil
 		}
+		if isPending(err) {
+			// Polls that are still waiting for a condition are not failed attempts, so the next one is not a retry.
+			attempt--
+		}
 		for _, r := range retries {
 			if err := r.Continue(err, action); err != nil {
 				logger.Infof("Giving up %s (%v)", action, err)
@@ -442,17 +464,24 @@ func CallTimeout(timeout time.Duration) RetryStrategy {
 type timeoutStrategy struct {
 	duration  time.Duration
 	startTime time.Time
+	// extension optionally returns additional time added to the duration, for example the time an image transfer
+	// spent waiting for a bandwidth limit.
+	extension func() time.Duration
 }
 
 func (t *timeoutStrategy) Recover(err error) error { return err }
 
 func (t *timeoutStrategy) Continue(err error, action string) error {
-	if elapsedTime := time.Since(t.startTime); elapsedTime > t.duration {
+	duration := t.duration
+	if t.extension != nil {
+		duration += t.extension()
+	}
+	if elapsedTime := time.Since(t.startTime); elapsedTime > duration {
 		return wrap(
 			err,
 			ETimeout,
 			"timeout of %d seconds while %s, giving up",
-			t.duration/time.Second,
+			duration/time.Second,
 			action,
 		)
 	}
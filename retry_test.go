@@ -25,6 +25,7 @@ func TestTimeoutStrategy(t *testing.T) {
 	err := retry(
 		"test",
 		nil,
+		nil,
 		[]RetryStrategy{
 			ExponentialBackoff(1),
 			Timeout(3 * time.Second),
@@ -63,6 +64,7 @@ func TestContextStrategy(t *testing.T) {
 		err = retry(
 			"test",
 			nil,
+			nil,
 			[]RetryStrategy{
 				ExponentialBackoff(1),
 				ContextStrategy(ctx),
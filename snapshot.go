@@ -0,0 +1,396 @@
+package ovirtclient
+
+import (
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// SnapshotID is the identifier for VM snapshots.
+type SnapshotID string
+
+// SnapshotClient describes the functions related to VM snapshots.
+type SnapshotClient interface {
+	// CreateVMSnapshot creates a snapshot of the specified VM. The snapshot will be in the SnapshotStatusLocked
+	// status while it is being created and the VM is locked during this time. Use WaitForSnapshotStatus to wait for
+	// the snapshot to become SnapshotStatusOK.
+	CreateVMSnapshot(
+		vmID VMID,
+		description string,
+		params CreateSnapshotParameters,
+		retries ...RetryStrategy,
+	) (Snapshot, error)
+	// ListVMSnapshots lists all snapshots of the specified VM, including the snapshot representing the active VM.
+	ListVMSnapshots(vmID VMID, retries ...RetryStrategy) ([]Snapshot, error)
+	// GetVMSnapshot returns a single snapshot of the specified VM.
+	GetVMSnapshot(vmID VMID, snapshotID SnapshotID, retries ...RetryStrategy) (Snapshot, error)
+	// PreviewSnapshot boots the VM into the state recorded in the specified snapshot without discarding the
+	// current state. The VM must be down. The preview can be made permanent with CommitSnapshot or discarded with
+	// UndoSnapshot.
+	PreviewSnapshot(
+		vmID VMID,
+		snapshotID SnapshotID,
+		params RestoreSnapshotParameters,
+		retries ...RetryStrategy,
+	) error
+	// CommitSnapshot makes the currently previewed snapshot permanent. Snapshots taken after the previewed snapshot
+	// are removed.
+	CommitSnapshot(vmID VMID, retries ...RetryStrategy) error
+	// UndoSnapshot discards the currently previewed snapshot and returns the VM to its state before the preview.
+	UndoSnapshot(vmID VMID, retries ...RetryStrategy) error
+	// RestoreVMSnapshot restores the VM to the state recorded in the specified snapshot. This is equivalent to
+	// previewing and committing the snapshot in one step. The VM must be down.
+	RestoreVMSnapshot(
+		vmID VMID,
+		snapshotID SnapshotID,
+		params RestoreSnapshotParameters,
+		retries ...RetryStrategy,
+	) error
+	// RemoveVMSnapshot removes the specified snapshot of the VM.
+	RemoveVMSnapshot(vmID VMID, snapshotID SnapshotID, retries ...RetryStrategy) error
+	// WaitForSnapshotStatus waits for the snapshot to reach the desired status.
+	WaitForSnapshotStatus(
+		vmID VMID,
+		snapshotID SnapshotID,
+		status SnapshotStatus,
+		retries ...RetryStrategy,
+	) (Snapshot, error)
+}
+
+// SnapshotData is the core of Snapshot, providing only data access functions.
+type SnapshotData interface {
+	// ID returns the identifier of the snapshot.
+	ID() SnapshotID
+	// VMID returns the ID of the VM this snapshot belongs to.
+	VMID() VMID
+	// Description returns the user-provided description of the snapshot.
+	Description() string
+	// Status returns the current status of the snapshot.
+	Status() SnapshotStatus
+	// Type returns the type of the snapshot.
+	Type() SnapshotType
+	// Date returns the time the snapshot was taken.
+	Date() time.Time
+	// PersistMemoryState returns true if the memory state of the VM was saved with the snapshot.
+	PersistMemoryState() bool
+}
+
+// Snapshot is a point-in-time copy of the disks, and optionally the memory state, of a VM.
+type Snapshot interface {
+	SnapshotData
+
+	// Preview boots the VM into the state of this snapshot. See SnapshotClient.PreviewSnapshot for details.
+	Preview(params RestoreSnapshotParameters, retries ...RetryStrategy) error
+	// Restore restores the VM to the state of this snapshot. See SnapshotClient.RestoreVMSnapshot for details.
+	Restore(params RestoreSnapshotParameters, retries ...RetryStrategy) error
+	// Remove removes this snapshot.
+	Remove(retries ...RetryStrategy) error
+	// WaitForStatus waits for this snapshot to reach the desired status and returns the updated snapshot.
+	WaitForStatus(status SnapshotStatus, retries ...RetryStrategy) (Snapshot, error)
+}
+
+// SnapshotStatus is the status of a snapshot.
+type SnapshotStatus string
+
+const (
+	// SnapshotStatusInPreview indicates that the VM is currently running in the state of this snapshot.
+	SnapshotStatusInPreview SnapshotStatus = "in_preview"
+	// SnapshotStatusLocked indicates that the snapshot is currently being created or manipulated.
+	SnapshotStatusLocked SnapshotStatus = "locked"
+	// SnapshotStatusOK indicates that the snapshot is ready for use.
+	SnapshotStatusOK SnapshotStatus = "ok"
+)
+
+// SnapshotStatusList is a list of SnapshotStatus.
+type SnapshotStatusList []SnapshotStatus
+
+// SnapshotStatusValues returns all possible SnapshotStatus values.
+func SnapshotStatusValues() SnapshotStatusList {
+	return []SnapshotStatus{
+		SnapshotStatusInPreview,
+		SnapshotStatusLocked,
+		SnapshotStatusOK,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l SnapshotStatusList) Strings() []string {
+	result := make([]string, len(l))
+	for i, status := range l {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// SnapshotType is the type of snapshot.
+type SnapshotType string
+
+const (
+	// SnapshotTypeActive is the snapshot representing the current state of the VM.
+	SnapshotTypeActive SnapshotType = "active"
+	// SnapshotTypePreview is the snapshot representing the state of the VM before a preview was started.
+	SnapshotTypePreview SnapshotType = "preview"
+	// SnapshotTypeRegular is a snapshot taken by a user.
+	SnapshotTypeRegular SnapshotType = "regular"
+	// SnapshotTypeStateless is a snapshot taken for a stateless VM run.
+	SnapshotTypeStateless SnapshotType = "stateless"
+)
+
+// SnapshotTypeList is a list of SnapshotType.
+type SnapshotTypeList []SnapshotType
+
+// SnapshotTypeValues returns all possible SnapshotType values.
+func SnapshotTypeValues() SnapshotTypeList {
+	return []SnapshotType{
+		SnapshotTypeActive,
+		SnapshotTypePreview,
+		SnapshotTypeRegular,
+		SnapshotTypeStateless,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l SnapshotTypeList) Strings() []string {
+	result := make([]string, len(l))
+	for i, t := range l {
+		result[i] = string(t)
+	}
+	return result
+}
+
+// CreateSnapshotParameters contains the optional parameters for snapshot creation.
+type CreateSnapshotParameters interface {
+	// PersistMemoryState indicates if the memory state of a running VM should be saved with the snapshot.
+	PersistMemoryState() *bool
+	// DiskIDs returns the list of disks to include in the snapshot. If empty, all disks are included.
+	DiskIDs() []DiskID
+}
+
+// BuildableCreateSnapshotParameters is a buildable version of CreateSnapshotParameters.
+type BuildableCreateSnapshotParameters interface {
+	CreateSnapshotParameters
+
+	// WithPersistMemoryState sets if the memory state should be saved with the snapshot.
+	WithPersistMemoryState(persistMemoryState bool) (BuildableCreateSnapshotParameters, error)
+	// MustWithPersistMemoryState is identical to WithPersistMemoryState, but panics instead of returning an error.
+	MustWithPersistMemoryState(persistMemoryState bool) BuildableCreateSnapshotParameters
+	// WithDiskIDs sets the subset of disks to include in the snapshot.
+	WithDiskIDs(diskIDs []DiskID) (BuildableCreateSnapshotParameters, error)
+	// MustWithDiskIDs is identical to WithDiskIDs, but panics instead of returning an error.
+	MustWithDiskIDs(diskIDs []DiskID) BuildableCreateSnapshotParameters
+}
+
+// NewCreateSnapshotParams creates a buildable set of CreateSnapshotParameters to pass to the CreateVMSnapshot
+// function.
+func NewCreateSnapshotParams() BuildableCreateSnapshotParameters {
+	return &createSnapshotParams{}
+}
+
+type createSnapshotParams struct {
+	persistMemoryState *bool
+	diskIDs            []DiskID
+}
+
+func (c *createSnapshotParams) PersistMemoryState() *bool {
+	return c.persistMemoryState
+}
+
+func (c *createSnapshotParams) DiskIDs() []DiskID {
+	return c.diskIDs
+}
+
+func (c *createSnapshotParams) WithPersistMemoryState(persistMemoryState bool) (
+	BuildableCreateSnapshotParameters,
+	error,
+) {
+	c.persistMemoryState = &persistMemoryState
+	return c, nil
+}
+
+func (c *createSnapshotParams) MustWithPersistMemoryState(persistMemoryState bool) BuildableCreateSnapshotParameters {
+	builder, err := c.WithPersistMemoryState(persistMemoryState)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createSnapshotParams) WithDiskIDs(diskIDs []DiskID) (BuildableCreateSnapshotParameters, error) {
+	for _, diskID := range diskIDs {
+		if diskID == "" {
+			return nil, newError(EBadArgument, "disk IDs for snapshots must not be empty")
+		}
+	}
+	c.diskIDs = diskIDs
+	return c, nil
+}
+
+func (c *createSnapshotParams) MustWithDiskIDs(diskIDs []DiskID) BuildableCreateSnapshotParameters {
+	builder, err := c.WithDiskIDs(diskIDs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// RestoreSnapshotParameters contains the optional parameters for previewing or restoring a snapshot.
+type RestoreSnapshotParameters interface {
+	// RestoreMemory indicates if the saved memory state should be restored, if the snapshot has one.
+	RestoreMemory() *bool
+	// DiskIDs returns the list of disks to restore from the snapshot. If empty, all disks are restored.
+	DiskIDs() []DiskID
+}
+
+// BuildableRestoreSnapshotParameters is a buildable version of RestoreSnapshotParameters.
+type BuildableRestoreSnapshotParameters interface {
+	RestoreSnapshotParameters
+
+	// WithRestoreMemory sets if the saved memory state should be restored.
+	WithRestoreMemory(restoreMemory bool) (BuildableRestoreSnapshotParameters, error)
+	// MustWithRestoreMemory is identical to WithRestoreMemory, but panics instead of returning an error.
+	MustWithRestoreMemory(restoreMemory bool) BuildableRestoreSnapshotParameters
+	// WithDiskIDs sets the subset of disks to restore.
+	WithDiskIDs(diskIDs []DiskID) (BuildableRestoreSnapshotParameters, error)
+	// MustWithDiskIDs is identical to WithDiskIDs, but panics instead of returning an error.
+	MustWithDiskIDs(diskIDs []DiskID) BuildableRestoreSnapshotParameters
+}
+
+// NewRestoreSnapshotParams creates a buildable set of RestoreSnapshotParameters to pass to the PreviewSnapshot and
+// RestoreVMSnapshot functions.
+func NewRestoreSnapshotParams() BuildableRestoreSnapshotParameters {
+	return &restoreSnapshotParams{}
+}
+
+type restoreSnapshotParams struct {
+	restoreMemory *bool
+	diskIDs       []DiskID
+}
+
+func (r *restoreSnapshotParams) RestoreMemory() *bool {
+	return r.restoreMemory
+}
+
+func (r *restoreSnapshotParams) DiskIDs() []DiskID {
+	return r.diskIDs
+}
+
+func (r *restoreSnapshotParams) WithRestoreMemory(restoreMemory bool) (BuildableRestoreSnapshotParameters, error) {
+	r.restoreMemory = &restoreMemory
+	return r, nil
+}
+
+func (r *restoreSnapshotParams) MustWithRestoreMemory(restoreMemory bool) BuildableRestoreSnapshotParameters {
+	builder, err := r.WithRestoreMemory(restoreMemory)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (r *restoreSnapshotParams) WithDiskIDs(diskIDs []DiskID) (BuildableRestoreSnapshotParameters, error) {
+	for _, diskID := range diskIDs {
+		if diskID == "" {
+			return nil, newError(EBadArgument, "disk IDs for snapshot restores must not be empty")
+		}
+	}
+	r.diskIDs = diskIDs
+	return r, nil
+}
+
+func (r *restoreSnapshotParams) MustWithDiskIDs(diskIDs []DiskID) BuildableRestoreSnapshotParameters {
+	builder, err := r.WithDiskIDs(diskIDs)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func convertSDKSnapshot(sdkObject *ovirtsdk.Snapshot, vmID VMID, client Client) (Snapshot, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "id")
+	}
+	status, ok := sdkObject.SnapshotStatus()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "snapshot status")
+	}
+	snapshotType, ok := sdkObject.SnapshotType()
+	if !ok {
+		return nil, newFieldNotFound("snapshot", "snapshot type")
+	}
+	// The description and date are not set on every snapshot type (e.g. the active VM snapshot).
+	description, _ := sdkObject.Description()
+	date, _ := sdkObject.Date()
+	persistMemoryState, _ := sdkObject.PersistMemorystate()
+	if sdkVM, ok := sdkObject.Vm(); ok {
+		if sdkVMID, ok := sdkVM.Id(); ok {
+			vmID = VMID(sdkVMID)
+		}
+	}
+	return &snapshot{
+		client:             client,
+		id:                 SnapshotID(id),
+		vmID:               vmID,
+		description:        description,
+		status:             SnapshotStatus(status),
+		snapshotType:       SnapshotType(snapshotType),
+		date:               date,
+		persistMemoryState: persistMemoryState,
+	}, nil
+}
+
+type snapshot struct {
+	client Client
+
+	id                 SnapshotID
+	vmID               VMID
+	description        string
+	status             SnapshotStatus
+	snapshotType       SnapshotType
+	date               time.Time
+	persistMemoryState bool
+}
+
+func (s *snapshot) ID() SnapshotID {
+	return s.id
+}
+
+func (s *snapshot) VMID() VMID {
+	return s.vmID
+}
+
+func (s *snapshot) Description() string {
+	return s.description
+}
+
+func (s *snapshot) Status() SnapshotStatus {
+	return s.status
+}
+
+func (s *snapshot) Type() SnapshotType {
+	return s.snapshotType
+}
+
+func (s *snapshot) Date() time.Time {
+	return s.date
+}
+
+func (s *snapshot) PersistMemoryState() bool {
+	return s.persistMemoryState
+}
+
+func (s *snapshot) Preview(params RestoreSnapshotParameters, retries ...RetryStrategy) error {
+	return s.client.PreviewSnapshot(s.vmID, s.id, params, retries...)
+}
+
+func (s *snapshot) Restore(params RestoreSnapshotParameters, retries ...RetryStrategy) error {
+	return s.client.RestoreVMSnapshot(s.vmID, s.id, params, retries...)
+}
+
+func (s *snapshot) Remove(retries ...RetryStrategy) error {
+	return s.client.RemoveVMSnapshot(s.vmID, s.id, retries...)
+}
+
+func (s *snapshot) WaitForStatus(status SnapshotStatus, retries ...RetryStrategy) (Snapshot, error) {
+	return s.client.WaitForSnapshotStatus(s.vmID, s.id, status, retries...)
+}
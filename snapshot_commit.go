@@ -0,0 +1,40 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) CommitSnapshot(vmID VMID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("committing previewed snapshot of VM %s", vmID),
+		o.logger,
+		o.requestHooks("CommitSnapshot"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(vmID)).CommitSnapshot().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) CommitSnapshot(vmID VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	snap := m.findSnapshotInPreview(vmID)
+	if snap == nil {
+		return newError(EConflict, "VM %s has no snapshot in preview", vmID)
+	}
+	_, i, err := m.findSnapshot(vmID, snap.id)
+	if err != nil {
+		return err
+	}
+	snap.previewBackup = nil
+	snap.setStatus(SnapshotStatusOK)
+	m.removeSnapshotsAfter(vmID, i)
+	return nil
+}
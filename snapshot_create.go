@@ -0,0 +1,136 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateVMSnapshot(
+	vmID VMID,
+	description string,
+	params CreateSnapshotParameters,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewCreateSnapshotParams()
+	}
+	err = retry(
+		fmt.Sprintf("creating snapshot for VM %s", vmID),
+		o.logger,
+		o.requestHooks("CreateVMSnapshot"),
+		retries,
+		func() error {
+			snapshotBuilder := ovirtsdk.NewSnapshotBuilder().Description(description)
+			if persistMemoryState := params.PersistMemoryState(); persistMemoryState != nil {
+				snapshotBuilder.PersistMemorystate(*persistMemoryState)
+			}
+			if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+				diskAttachments := make([]*ovirtsdk.DiskAttachment, len(diskIDs))
+				for i, diskID := range diskIDs {
+					diskAttachments[i] = ovirtsdk.NewDiskAttachmentBuilder().
+						Disk(ovirtsdk.NewDiskBuilder().Id(string(diskID)).MustBuild()).
+						MustBuild()
+				}
+				snapshotBuilder.DiskAttachmentsOfAny(diskAttachments...)
+			}
+			response, e := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				Add().
+				Snapshot(snapshotBuilder.MustBuild()).
+				Send()
+			if e != nil {
+				return e
+			}
+
+			sdkSnapshot, ok := response.Snapshot()
+			if !ok {
+				return newError(EFieldMissing, "missing snapshot in response")
+			}
+
+			result, err = convertSDKSnapshot(sdkSnapshot, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert snapshot",
+				)
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) CreateVMSnapshot(
+	vmID VMID,
+	description string,
+	params CreateSnapshotParameters,
+	_ ...RetryStrategy,
+) (Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewCreateSnapshotParams()
+	}
+
+	vm, ok := m.vms[vmID]
+	if !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	if err := m.checkVMNotLocked(vmID); err != nil {
+		return nil, err
+	}
+	if m.findSnapshotInPreview(vmID) != nil {
+		return nil, newError(EConflict, "cannot create a snapshot of VM %s while a snapshot is in preview", vmID)
+	}
+
+	diskIDs := params.DiskIDs()
+	if len(diskIDs) == 0 {
+		for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+			diskIDs = append(diskIDs, attachment.DiskID())
+		}
+	}
+	diskData := make(map[DiskID][]byte, len(diskIDs))
+	for _, diskID := range diskIDs {
+		if !m.isDiskAttachedToVM(diskID, vmID) {
+			return nil, newError(EBadArgument, "disk %s is not attached to VM %s", diskID, vmID)
+		}
+		diskData[diskID] = copyMockDiskData(m.disks[diskID].data)
+	}
+
+	persistMemoryState := false
+	if p := params.PersistMemoryState(); p != nil && vm.status == VMStatusUp {
+		persistMemoryState = *p
+	}
+
+	snap := &snapshotWithData{
+		snapshot: snapshot{
+			client:             m,
+			id:                 SnapshotID(m.GenerateUUID()),
+			vmID:               vmID,
+			description:        description,
+			status:             SnapshotStatusLocked,
+			snapshotType:       SnapshotTypeRegular,
+			date:               time.Now(),
+			persistMemoryState: persistMemoryState,
+		},
+		lock:     &sync.Mutex{},
+		diskData: diskData,
+	}
+	m.snapshots[vmID] = append(m.snapshots[vmID], snap)
+
+	go func() {
+		// Sleep to give tests a chance to observe the locked VM.
+		time.Sleep(2 * time.Second)
+		snap.Unlock()
+	}()
+
+	return snap, nil
+}
@@ -0,0 +1,58 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) GetVMSnapshot(vmID VMID, snapshotID SnapshotID, retries ...RetryStrategy) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting snapshot %s of VM %s", snapshotID, vmID),
+		o.logger,
+		o.requestHooks("GetVMSnapshot"),
+		retries,
+		func() error {
+			response, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(snapshotID)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Snapshot()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no snapshot returned when getting snapshot ID %s of VM %s",
+					snapshotID,
+					vmID,
+				)
+			}
+			result, err = convertSDKSnapshot(sdkObject, vmID, o)
+			if err != nil {
+				return wrap(
+					err,
+					EBug,
+					"failed to convert snapshot %s",
+					snapshotID,
+				)
+			}
+			return nil
+		})
+	return result, err
+}
+
+func (m *mockClient) GetVMSnapshot(vmID VMID, snapshotID SnapshotID, _ ...RetryStrategy) (Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	snap, _, err := m.findSnapshot(vmID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
@@ -0,0 +1,48 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListVMSnapshots(vmID VMID, retries ...RetryStrategy) (result []Snapshot, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []Snapshot{}
+	err = retry(
+		fmt.Sprintf("listing snapshots of VM %s", vmID),
+		o.logger,
+		o.requestHooks("ListVMSnapshots"),
+		retries,
+		func() error {
+			response, e := o.conn.SystemService().VmsService().VmService(string(vmID)).SnapshotsService().List().Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Snapshots()
+			if !ok {
+				return nil
+			}
+			result = make([]Snapshot, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], e = convertSDKSnapshot(sdkObject, vmID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert snapshot during listing item #%d", i)
+				}
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) ListVMSnapshots(vmID VMID, _ ...RetryStrategy) ([]Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	result := make([]Snapshot, len(m.snapshots[vmID]))
+	for i, snap := range m.snapshots[vmID] {
+		result[i] = snap
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package ovirtclient
+
+import (
+	"sync"
+)
+
+// snapshotWithData adds the ability to store the disk contents in the snapshot for mocking purposes.
+type snapshotWithData struct {
+	snapshot
+	lock *sync.Mutex
+	// diskData holds the contents of the disks at the time the snapshot was taken.
+	diskData map[DiskID][]byte
+	// previewBackup holds the contents of the disks before a preview was started so UndoSnapshot can return to it.
+	previewBackup map[DiskID][]byte
+}
+
+func (s *snapshotWithData) Status() SnapshotStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.status
+}
+
+func (s *snapshotWithData) setStatus(status SnapshotStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.status = status
+}
+
+// Unlock changes the snapshot status from locked to OK. It has no effect on snapshots in other states.
+func (s *snapshotWithData) Unlock() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.status == SnapshotStatusLocked {
+		s.status = SnapshotStatusOK
+	}
+}
+
+// checkVMNotLocked returns an EVMLocked error if an operation is in progress on the VM that prevents it from being
+// modified. The caller must hold the mock lock.
+func (m *mockClient) checkVMNotLocked(vmID VMID) error {
+	for _, snap := range m.snapshots[vmID] {
+		if snap.Status() == SnapshotStatusLocked {
+			return newError(EVMLocked, "VM %s is locked while snapshot %s is in progress", vmID, snap.id)
+		}
+	}
+	return nil
+}
+
+// findSnapshot returns the snapshot with the specified ID on the VM along with its index. The caller must hold the
+// mock lock.
+func (m *mockClient) findSnapshot(vmID VMID, snapshotID SnapshotID) (*snapshotWithData, int, error) {
+	if _, ok := m.vms[vmID]; !ok {
+		return nil, -1, newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	for i, snap := range m.snapshots[vmID] {
+		if snap.id == snapshotID {
+			return snap, i, nil
+		}
+	}
+	return nil, -1, newError(ENotFound, "snapshot with ID %s not found on VM %s", snapshotID, vmID)
+}
+
+// findSnapshotInPreview returns the snapshot currently in preview on the VM, or nil if there is none. The caller
+// must hold the mock lock.
+func (m *mockClient) findSnapshotInPreview(vmID VMID) *snapshotWithData {
+	for _, snap := range m.snapshots[vmID] {
+		if snap.Status() == SnapshotStatusInPreview {
+			return snap
+		}
+	}
+	return nil
+}
+
+// checkSnapshotRestorable validates that the VM and snapshot are in a state that allows previewing or restoring.
+// The caller must hold the mock lock.
+func (m *mockClient) checkSnapshotRestorable(vmID VMID, snapshotID SnapshotID) (*snapshotWithData, int, error) {
+	snap, i, err := m.findSnapshot(vmID, snapshotID)
+	if err != nil {
+		return nil, -1, err
+	}
+	if err := m.checkVMNotLocked(vmID); err != nil {
+		return nil, -1, err
+	}
+	if status := m.vms[vmID].status; status != VMStatusDown {
+		return nil, -1, newError(EConflict, "VM %s must be down to restore a snapshot, current status is %s", vmID, status)
+	}
+	if m.findSnapshotInPreview(vmID) != nil {
+		return nil, -1, newError(EConflict, "VM %s already has a snapshot in preview", vmID)
+	}
+	if status := snap.Status(); status != SnapshotStatusOK {
+		return nil, -1, newError(EConflict, "snapshot %s is in status %s, not %s", snapshotID, status, SnapshotStatusOK)
+	}
+	return snap, i, nil
+}
+
+// applySnapshotData writes the disk contents from the snapshot to the disks selected by the parameters and returns
+// the previous disk contents. The caller must hold the mock lock.
+func (m *mockClient) applySnapshotData(
+	snap *snapshotWithData,
+	params RestoreSnapshotParameters,
+) (map[DiskID][]byte, error) {
+	diskIDs := params.DiskIDs()
+	if len(diskIDs) == 0 {
+		for diskID := range snap.diskData {
+			diskIDs = append(diskIDs, diskID)
+		}
+	}
+	for _, diskID := range diskIDs {
+		if _, ok := snap.diskData[diskID]; !ok {
+			return nil, newError(EBadArgument, "disk %s is not part of snapshot %s", diskID, snap.id)
+		}
+		if _, ok := m.disks[diskID]; !ok {
+			return nil, newError(ENotFound, "disk %s from snapshot %s no longer exists", diskID, snap.id)
+		}
+	}
+	previous := make(map[DiskID][]byte, len(diskIDs))
+	for _, diskID := range diskIDs {
+		disk := m.disks[diskID]
+		previous[diskID] = disk.data
+		disk.replaceData(0, snap.diskData[diskID])
+	}
+	return previous, nil
+}
+
+// removeSnapshotsAfter removes all snapshots taken after the snapshot at the specified index, as the engine does
+// when a snapshot is committed or restored. The caller must hold the mock lock.
+func (m *mockClient) removeSnapshotsAfter(vmID VMID, index int) {
+	m.snapshots[vmID] = m.snapshots[vmID][:index+1]
+}
+
+func copyMockDiskData(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	result := make([]byte, len(data))
+	copy(result, data)
+	return result
+}
@@ -0,0 +1,74 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) PreviewSnapshot(
+	vmID VMID,
+	snapshotID SnapshotID,
+	params RestoreSnapshotParameters,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewRestoreSnapshotParams()
+	}
+	err = retry(
+		fmt.Sprintf("previewing snapshot %s of VM %s", snapshotID, vmID),
+		o.logger,
+		o.requestHooks("PreviewSnapshot"),
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				PreviewSnapshot().
+				Snapshot(ovirtsdk.NewSnapshotBuilder().Id(string(snapshotID)).MustBuild())
+			if restoreMemory := params.RestoreMemory(); restoreMemory != nil {
+				request.RestoreMemory(*restoreMemory)
+			}
+			if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+				request.DisksOfAny(convertDiskIDsToSDKDisks(diskIDs)...)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) PreviewSnapshot(
+	vmID VMID,
+	snapshotID SnapshotID,
+	params RestoreSnapshotParameters,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewRestoreSnapshotParams()
+	}
+	snap, _, err := m.checkSnapshotRestorable(vmID, snapshotID)
+	if err != nil {
+		return err
+	}
+	previous, err := m.applySnapshotData(snap, params)
+	if err != nil {
+		return err
+	}
+	snap.previewBackup = previous
+	snap.setStatus(SnapshotStatusInPreview)
+	return nil
+}
+
+func convertDiskIDsToSDKDisks(diskIDs []DiskID) []*ovirtsdk.Disk {
+	disks := make([]*ovirtsdk.Disk, len(diskIDs))
+	for i, diskID := range diskIDs {
+		disks[i] = ovirtsdk.NewDiskBuilder().Id(string(diskID)).MustBuild()
+	}
+	return disks
+}
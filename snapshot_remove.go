@@ -0,0 +1,46 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveVMSnapshot(vmID VMID, snapshotID SnapshotID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("removing snapshot %s of VM %s", snapshotID, vmID),
+		o.logger,
+		o.requestHooks("RemoveVMSnapshot"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(snapshotID)).
+				Remove().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RemoveVMSnapshot(vmID VMID, snapshotID SnapshotID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	snap, i, err := m.findSnapshot(vmID, snapshotID)
+	if err != nil {
+		return err
+	}
+	if err := m.checkVMNotLocked(vmID); err != nil {
+		return err
+	}
+	if snap.Status() == SnapshotStatusInPreview {
+		return newError(EConflict, "snapshot %s is in preview and cannot be removed", snapshotID)
+	}
+
+	snapshots := m.snapshots[vmID]
+	m.snapshots[vmID] = append(snapshots[:i:i], snapshots[i+1:]...)
+	return nil
+}
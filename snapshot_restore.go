@@ -0,0 +1,63 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RestoreVMSnapshot(
+	vmID VMID,
+	snapshotID SnapshotID,
+	params RestoreSnapshotParameters,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewRestoreSnapshotParams()
+	}
+	err = retry(
+		fmt.Sprintf("restoring snapshot %s of VM %s", snapshotID, vmID),
+		o.logger,
+		o.requestHooks("RestoreVMSnapshot"),
+		retries,
+		func() error {
+			request := o.conn.
+				SystemService().
+				VmsService().
+				VmService(string(vmID)).
+				SnapshotsService().
+				SnapshotService(string(snapshotID)).
+				Restore()
+			if restoreMemory := params.RestoreMemory(); restoreMemory != nil {
+				request.RestoreMemory(*restoreMemory)
+			}
+			if diskIDs := params.DiskIDs(); len(diskIDs) > 0 {
+				request.DisksOfAny(convertDiskIDsToSDKDisks(diskIDs)...)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RestoreVMSnapshot(
+	vmID VMID,
+	snapshotID SnapshotID,
+	params RestoreSnapshotParameters,
+	_ ...RetryStrategy,
+) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewRestoreSnapshotParams()
+	}
+	snap, i, err := m.checkSnapshotRestorable(vmID, snapshotID)
+	if err != nil {
+		return err
+	}
+	if _, err := m.applySnapshotData(snap, params); err != nil {
+		return err
+	}
+	m.removeSnapshotsAfter(vmID, i)
+	return nil
+}
@@ -0,0 +1,145 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestVMSnapshotLifecycle(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	snapshot := assertCanCreateVMSnapshot(t, helper, vm, "test snapshot")
+	if snapshot.Description() != "test snapshot" {
+		t.Fatalf("Snapshot description mismatch (expected: %s, got: %s).", "test snapshot", snapshot.Description())
+	}
+
+	fetchedSnapshot, err := client.GetVMSnapshot(vm.ID(), snapshot.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch snapshot %s (%v)", snapshot.ID(), err)
+	}
+	if fetchedSnapshot.ID() != snapshot.ID() {
+		t.Fatalf("Snapshot ID mismatch after fetch (%s != %s)", fetchedSnapshot.ID(), snapshot.ID())
+	}
+	assertVMHasSnapshot(t, helper, vm, snapshot.ID(), true)
+
+	if err := snapshot.Remove(); err != nil {
+		t.Fatalf("Failed to remove snapshot %s (%v)", snapshot.ID(), err)
+	}
+	assertVMHasSnapshot(t, helper, vm, snapshot.ID(), false)
+}
+
+func TestVMSnapshotLocksVM(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	snapshot, err := client.CreateVMSnapshot(vm.ID(), "test snapshot", nil)
+	if err != nil {
+		t.Fatalf("Failed to create snapshot of VM %s (%v)", vm.ID(), err)
+	}
+	if snapshot.Status() == ovirtclient.SnapshotStatusLocked {
+		_, err = client.UpdateVM(
+			vm.ID(),
+			ovirtclient.UpdateVMParams().MustWithComment("locked"),
+			ovirtclient.MaxTries(1),
+		)
+		if err == nil {
+			t.Fatalf("Updating a VM with a snapshot in progress did not fail.")
+		}
+		if !ovirtclient.HasErrorCode(err, ovirtclient.EVMLocked) {
+			t.Fatalf("Updating a VM with a snapshot in progress did not return an EVMLocked error (%v).", err)
+		}
+	}
+	if _, err := snapshot.WaitForStatus(ovirtclient.SnapshotStatusOK); err != nil {
+		t.Fatalf("Failed to wait for snapshot %s to become OK (%v)", snapshot.ID(), err)
+	}
+	if _, err := client.UpdateVM(vm.ID(), ovirtclient.UpdateVMParams().MustWithComment("unlocked")); err != nil {
+		t.Fatalf("Failed to update VM after snapshot creation (%v)", err)
+	}
+}
+
+func TestVMSnapshotPreviewAndUndo(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	snapshot := assertCanCreateVMSnapshot(t, helper, vm, "test snapshot")
+
+	if err := snapshot.Preview(nil); err != nil {
+		t.Fatalf("Failed to preview snapshot %s (%v)", snapshot.ID(), err)
+	}
+	if _, err := client.WaitForSnapshotStatus(vm.ID(), snapshot.ID(), ovirtclient.SnapshotStatusInPreview); err != nil {
+		t.Fatalf("Snapshot %s did not enter preview (%v)", snapshot.ID(), err)
+	}
+	if err := client.UndoSnapshot(vm.ID()); err != nil {
+		t.Fatalf("Failed to undo snapshot preview of VM %s (%v)", vm.ID(), err)
+	}
+	if _, err := snapshot.WaitForStatus(ovirtclient.SnapshotStatusOK); err != nil {
+		t.Fatalf("Snapshot %s did not return to OK after undo (%v)", snapshot.ID(), err)
+	}
+	assertVMHasSnapshot(t, helper, vm, snapshot.ID(), true)
+}
+
+func TestVMSnapshotRestoreRemovesLaterSnapshots(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	firstSnapshot := assertCanCreateVMSnapshot(t, helper, vm, "first snapshot")
+	secondSnapshot := assertCanCreateVMSnapshot(t, helper, vm, "second snapshot")
+
+	if err := firstSnapshot.Restore(nil); err != nil {
+		t.Fatalf("Failed to restore snapshot %s (%v)", firstSnapshot.ID(), err)
+	}
+	if _, err := firstSnapshot.WaitForStatus(ovirtclient.SnapshotStatusOK); err != nil {
+		t.Fatalf("Snapshot %s did not return to OK after restore (%v)", firstSnapshot.ID(), err)
+	}
+	assertVMHasSnapshot(t, helper, vm, firstSnapshot.ID(), true)
+	assertVMHasSnapshot(t, helper, vm, secondSnapshot.ID(), false)
+}
+
+func assertCanCreateVMSnapshot(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	vm ovirtclient.VM,
+	description string,
+) ovirtclient.Snapshot {
+	client := helper.GetClient()
+	snapshot, err := client.CreateVMSnapshot(vm.ID(), description, nil)
+	if err != nil {
+		t.Fatalf("Failed to create snapshot of VM %s (%v)", vm.ID(), err)
+	}
+	snapshot, err = snapshot.WaitForStatus(ovirtclient.SnapshotStatusOK)
+	if err != nil {
+		t.Fatalf("Failed to wait for snapshot %s to become OK (%v)", snapshot.ID(), err)
+	}
+	return snapshot
+}
+
+func assertVMHasSnapshot(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	vm ovirtclient.VM,
+	snapshotID ovirtclient.SnapshotID,
+	shouldExist bool,
+) {
+	snapshots, err := helper.GetClient().ListVMSnapshots(vm.ID())
+	if err != nil {
+		t.Fatalf("Failed to list snapshots of VM %s (%v)", vm.ID(), err)
+	}
+	found := false
+	for _, snapshot := range snapshots {
+		if snapshot.ID() == snapshotID {
+			found = true
+		}
+	}
+	if found != shouldExist {
+		t.Fatalf("Snapshot %s presence on VM %s mismatch (expected: %t, got: %t).", snapshotID, vm.ID(), shouldExist, found)
+	}
+}
@@ -0,0 +1,40 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) UndoSnapshot(vmID VMID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("undoing previewed snapshot of VM %s", vmID),
+		o.logger,
+		o.requestHooks("UndoSnapshot"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().VmsService().VmService(string(vmID)).UndoSnapshot().Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) UndoSnapshot(vmID VMID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.vms[vmID]; !ok {
+		return newError(ENotFound, "VM with ID %s not found", vmID)
+	}
+	snap := m.findSnapshotInPreview(vmID)
+	if snap == nil {
+		return newError(EConflict, "VM %s has no snapshot in preview", vmID)
+	}
+	for diskID, data := range snap.previewBackup {
+		if disk, ok := m.disks[diskID]; ok {
+			disk.replaceData(0, data)
+		}
+	}
+	snap.previewBackup = nil
+	snap.setStatus(SnapshotStatusOK)
+	return nil
+}
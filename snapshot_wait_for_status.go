@@ -0,0 +1,67 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForSnapshotStatus(
+	vmID VMID,
+	snapshotID SnapshotID,
+	status SnapshotStatus,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for snapshot %s of VM %s to enter status \"%s\"", snapshotID, vmID, status),
+		o.logger,
+		o.waitHooks("WaitForSnapshotStatus"),
+		retries,
+		func() error {
+			result, err = o.GetVMSnapshot(vmID, snapshotID, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(
+					EPending,
+					"Snapshot %s status is \"%s\", not \"%s\".",
+					snapshotID,
+					result.Status(),
+					status,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) WaitForSnapshotStatus(
+	vmID VMID,
+	snapshotID SnapshotID,
+	status SnapshotStatus,
+	retries ...RetryStrategy,
+) (result Snapshot, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for snapshot %s of VM %s to enter status \"%s\"", snapshotID, vmID, status),
+		m.logger,
+		nil,
+		retries,
+		func() error {
+			result, err = m.GetVMSnapshot(vmID, snapshotID, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(
+					EPending,
+					"Snapshot %s status is \"%s\", not \"%s\".",
+					snapshotID,
+					result.Status(),
+					status,
+				)
+			}
+			return nil
+		})
+	return
+}
@@ -0,0 +1,322 @@
+package ovirtclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+// tokenRefreshMargin is the time before the expiry of a token when a new token is requested.
+const tokenRefreshMargin = time.Minute
+
+// tokenManager obtains SSO tokens from the engine, persists them in a TokenStore and injects them into the SDK
+// connection. A single tokenManager is shared between a client and all clients derived from it using WithContext.
+//
+// Clients using password authentication without a TokenStore leave authentication to the SDK, which requests a token
+// on the first request. Their tokenManager has no store and only serializes connection replacement and Close with the
+// requests in flight.
+type tokenManager struct {
+	lock *sync.Mutex
+	// usage is held for reading while a request is sent using the connection, and for writing while the token in the
+	// connection or the connection itself is replaced. The SDK reads the token without synchronization, so it must
+	// not change while a request is in flight. It is only locked for writing while lock is held.
+	usage *sync.RWMutex
+	// store persists the tokens. If it is nil, the SDK obtains the tokens itself.
+	store      TokenStore
+	url        string
+	auth       AuthProvider
+	httpClient http.Client
+	logger     Logger
+	conn       *ovirtsdk4.Connection
+	current    *Token
+	closed     bool
+}
+
+// connect injects a token into the newly built conn and copies it into target, so all clients sharing target use the
+// new connection. If reauthenticate is false a stored token is reused if it is still valid. Otherwise, the stored
+// token is discarded and a new one is requested.
+func (t *tokenManager) connect(target *ovirtsdk4.Connection, conn *ovirtsdk4.Connection, reauthenticate bool) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return newClientClosedError()
+	}
+	if !t.managed() {
+		t.usage.Lock()
+		defer t.usage.Unlock()
+		// The new connection has no token yet, so the SDK authenticates again on the next request.
+		if target != conn {
+			*target = *conn
+		}
+		t.conn = target
+		return nil
+	}
+	if reauthenticate {
+		t.current = nil
+		if err := t.store.Delete(t.url, t.auth.Identity()); err != nil {
+			return err
+		}
+	} else if t.current == nil {
+		stored, err := t.store.Load(t.url, t.auth.Identity())
+		if err != nil {
+			return err
+		}
+		if stored != nil && !stored.ExpiresWithin(tokenRefreshMargin) {
+			t.logger.Debugf("Reusing stored SSO token for %s.", t.auth.Identity())
+			t.current = stored
+		}
+	}
+	if err := t.ensureToken(); err != nil {
+		return err
+	}
+
+	t.usage.Lock()
+	defer t.usage.Unlock()
+	if err := setSSOToken(conn, t.current.AccessToken); err != nil {
+		return err
+	}
+	if target != conn {
+		*target = *conn
+	}
+	t.conn = target
+	return nil
+}
+
+// use requests a new token if the current one is about to expire and returns a function to call once the request
+// using the connection is complete. The token in the connection is not replaced until then.
+func (t *tokenManager) use() (func(), error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return nil, newClientClosedError()
+	}
+	if t.managed() && (t.current == nil || t.current.ExpiresWithin(tokenRefreshMargin)) {
+		t.logger.Debugf("SSO token for %s is about to expire, requesting a new token...", t.auth.Identity())
+		t.current = nil
+		if err := t.ensureToken(); err != nil {
+			return nil, err
+		}
+		if err := t.install(); err != nil {
+			return nil, err
+		}
+	}
+	// No writer can be waiting for the usage lock while lock is held, so this does not block.
+	t.usage.RLock()
+	return t.usage.RUnlock, nil
+}
+
+// managed returns true if the token manager obtains the tokens instead of the SDK.
+func (t *tokenManager) managed() bool {
+	return t.store != nil
+}
+
+// ensureToken requests a token if there is none and persists it in the store. Must be called with the lock held.
+func (t *tokenManager) ensureToken() error {
+	if t.current != nil {
+		return nil
+	}
+	token, err := t.auth.requestToken(t.httpClient, t.url)
+	if err != nil {
+		return err
+	}
+	if err := t.store.Store(t.url, t.auth.Identity(), token); err != nil {
+		return err
+	}
+	t.current = &token
+	return nil
+}
+
+// install injects the current token into the connection once no request is in flight. Must be called with the lock
+// held.
+func (t *tokenManager) install() error {
+	t.usage.Lock()
+	defer t.usage.Unlock()
+	return setSSOToken(t.conn, t.current.AccessToken)
+}
+
+// revoke closes the token manager, ends the SSO session of the current token and removes it from the store. The
+// revoked token is left in the connection, so direct use of the SDK connection fails instead of opening a new
+// session. If revoking the token fails, calling revoke again retries it.
+func (t *tokenManager) revoke() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed && t.current == nil {
+		return nil
+	}
+	t.closed = true
+	if !t.managed() && t.current == nil {
+		// The SDK requested the token itself, if it sent any request at all.
+		t.usage.Lock()
+		token, err := getSSOToken(t.conn)
+		t.usage.Unlock()
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			t.current = &Token{AccessToken: token}
+		}
+	}
+	if t.current == nil {
+		return nil
+	}
+	if err := revokeSSOToken(t.httpClient, t.url, t.current.AccessToken); err != nil {
+		return err
+	}
+	t.current = nil
+	if !t.managed() {
+		return nil
+	}
+	return t.store.Delete(t.url, t.auth.Identity())
+}
+
+func newClientClosedError() error {
+	return newError(EClientClosed, "the client has been closed")
+}
+
+type ssoTokenResponse struct {
+	AccessToken string          `json:"access_token"`
+	ExpiresIn   json.Number     `json:"expires_in"`
+	Exp         json.RawMessage `json:"exp"`
+	Error       string          `json:"error"`
+	ErrorCode   string          `json:"error_code"`
+}
+
+// expiresAt returns the expiry of the token. The engine reports the expiry in the non-standard "exp" field as
+// milliseconds since the epoch, while other SSO providers use the standard "expires_in" field in seconds.
+func (s ssoTokenResponse) expiresAt(now time.Time) time.Time {
+	if seconds, err := s.ExpiresIn.Int64(); err == nil && seconds > 0 {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if len(s.Exp) == 0 {
+		return time.Time{}
+	}
+	exp := strings.Trim(string(s.Exp), `"`)
+	millis, err := strconv.ParseInt(exp, 10, 64)
+	// Sessions that never expire are reported with the maximum value, which time.Time cannot represent.
+	if err != nil || millis <= 0 || millis > now.Add(100*365*24*time.Hour).UnixNano()/int64(time.Millisecond) {
+		return time.Time{}
+	}
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+func requestSSOToken(httpClient http.Client, engineURL string, path string, params url.Values) (Token, error) {
+	now := time.Now()
+	response, err := sendSSORequest(httpClient, engineURL, path, params)
+	if err != nil {
+		return Token{}, err
+	}
+	if response.AccessToken == "" {
+		return Token{}, newError(ENotAnOVirtEngine, "the SSO response did not contain an access token")
+	}
+	return Token{
+		AccessToken: response.AccessToken,
+		ExpiresAt:   response.expiresAt(now),
+	}, nil
+}
+
+func revokeSSOToken(httpClient http.Client, engineURL string, token string) error {
+	_, err := sendSSORequest(
+		httpClient,
+		engineURL,
+		"/ovirt-engine/services/sso-logout",
+		url.Values{
+			"scope": {""},
+			"token": {token},
+		},
+	)
+	return err
+}
+
+func sendSSORequest(httpClient http.Client, engineURL string, path string, params url.Values) (
+	*ssoTokenResponse,
+	error,
+) {
+	ssoURL, err := url.Parse(engineURL)
+	if err != nil {
+		return nil, wrap(err, EBadArgument, "failed to parse engine URL %s", engineURL)
+	}
+	ssoURL.Path = path
+	ssoURL.RawQuery = ""
+	req, err := http.NewRequest(http.MethodPost, ssoURL.String(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to create SSO request")
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, wrap(err, EUnidentified, "failed to send SSO request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, wrap(err, EConnection, "failed to read SSO response")
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, newError(EAccessDenied, "authentication failed (response was: %s)", body)
+	}
+	response := &ssoTokenResponse{}
+	if len(body) == 0 {
+		// The logout endpoint responds with an empty body.
+		return response, nil
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, wrap(
+			fmt.Errorf("failed to parse non-array sso with response %s (%w)", body, err),
+			EUnidentified,
+			"failed to parse SSO response",
+		)
+	}
+	if response.Error != "" || response.ErrorCode != "" {
+		// Use the same message format as the SDK so the error is identified the same way.
+		return nil, wrap(
+			fmt.Errorf("Error during SSO authentication %s : %s", response.ErrorCode, response.Error), //nolint:stylecheck,revive
+			EUnidentified,
+			"SSO request failed",
+		)
+	}
+	return response, nil
+}
+
+// ssoTokenField returns the unexported field of the SDK connection that holds the SSO token. The SDK has no API to set
+// the token or to replace its HTTP transport, so we have to resort to reflection. TestSDKConnectionUsesInjectedToken
+// fails if an SDK update renames the field or stops using it. Writes must hold tokenManager.usage.
+func ssoTokenField(conn *ovirtsdk4.Connection) (reflect.Value, error) {
+	field := reflect.ValueOf(conn).Elem().FieldByName("ssoToken")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return reflect.Value{}, newError(EUnsupported, "the oVirt SDK in use does not support reusing SSO tokens")
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), nil //nolint:gosec
+}
+
+func setSSOToken(conn *ovirtsdk4.Connection, token string) error {
+	field, err := ssoTokenField(conn)
+	if err != nil {
+		return err
+	}
+	field.SetString(token)
+	return nil
+}
+
+func getSSOToken(conn *ovirtsdk4.Connection) (string, error) {
+	field, err := ssoTokenField(conn)
+	if err != nil {
+		return "", err
+	}
+	return field.String(), nil
+}
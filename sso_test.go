@@ -0,0 +1,237 @@
+// This file contains tests for the internal SSO token handling. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func TestTokenManagerReusesAndRevokesStoredToken(t *testing.T) {
+	t.Parallel()
+
+	lock := &sync.Mutex{}
+	issued := 0
+	revoked := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/ovirt-engine/sso/oauth/token":
+			issued++
+			exp := time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","exp":"%d","token_type":"bearer"}`, issued, exp)
+		case "/ovirt-engine/services/sso-logout":
+			revoked[r.Form.Get("token")] = true
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store := NewMemoryTokenStore()
+	newManager := func() (*tokenManager, *ovirtsdk4.Connection) {
+		conn, err := ovirtsdk4.NewConnectionBuilder().
+			URL(server.URL + "/ovirt-engine/api").
+			Username("admin@internal").
+			Password("password").
+			Build()
+		if err != nil {
+			t.Fatalf("Failed to build connection (%v)", err)
+		}
+		return &tokenManager{
+			lock:       &sync.Mutex{},
+			usage:      &sync.RWMutex{},
+			store:      store,
+			url:        server.URL + "/ovirt-engine/api",
+			auth:       PasswordAuth("admin@internal", "password"),
+			httpClient: *server.Client(),
+			logger:     &noopLogger{},
+		}, conn
+	}
+
+	first, firstConn := newManager()
+	if err := first.connect(firstConn, firstConn, false); err != nil {
+		t.Fatalf("Failed to connect (%v)", err)
+	}
+	second, secondConn := newManager()
+	if err := second.connect(secondConn, secondConn, false); err != nil {
+		t.Fatalf("Failed to connect (%v)", err)
+	}
+	token, err := getSSOToken(secondConn)
+	if err != nil {
+		t.Fatalf("Failed to read SSO token (%v)", err)
+	}
+	if token != "token-1" || issued != 1 {
+		t.Fatalf("Stored token was not reused (token: %s, issued: %d)", token, issued)
+	}
+
+	if err := second.revoke(); err != nil {
+		t.Fatalf("Failed to revoke token (%v)", err)
+	}
+	if !revoked["token-1"] {
+		t.Fatalf("Token was not revoked on the engine.")
+	}
+	if stored, _ := store.Load(second.url, second.auth.Identity()); stored != nil {
+		t.Fatalf("Revoked token is still in the store.")
+	}
+	if _, err := second.use(); err == nil || !HasErrorCode(err, EClientClosed) {
+		t.Fatalf("Closed token manager did not return an EClientClosed error (%v)", err)
+	}
+	if err := second.connect(secondConn, secondConn, true); err == nil || !HasErrorCode(err, EClientClosed) {
+		t.Fatalf("Closed token manager reconnected (%v)", err)
+	}
+	if issued != 1 {
+		t.Fatalf("Closed token manager requested a new token (issued: %d)", issued)
+	}
+}
+
+func TestTokenManagerWithoutStoreLeavesAuthenticationToSDK(t *testing.T) {
+	t.Parallel()
+
+	lock := &sync.Mutex{}
+	issued := 0
+	revoked := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/ovirt-engine/sso/oauth/token":
+			issued++
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer"}`, issued)
+		case "/ovirt-engine/services/sso-logout":
+			revoked[r.Form.Get("token")] = true
+		case "/ovirt-engine/api":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<api></api>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	conn, err := ovirtsdk4.NewConnectionBuilder().
+		URL(server.URL + "/ovirt-engine/api").
+		Username("admin@internal").
+		Password("password").
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build connection (%v)", err)
+	}
+	manager := &tokenManager{
+		lock:       &sync.Mutex{},
+		usage:      &sync.RWMutex{},
+		url:        server.URL + "/ovirt-engine/api",
+		auth:       PasswordAuth("admin@internal", "password"),
+		httpClient: *server.Client(),
+		logger:     &noopLogger{},
+	}
+	if err := manager.connect(conn, conn, false); err != nil {
+		t.Fatalf("Failed to connect (%v)", err)
+	}
+	if issued != 0 {
+		t.Fatalf("Token manager without a store requested a token on connect (issued: %d)", issued)
+	}
+	unauthorized := newError(EAccessDenied, `HTTP response code is "401"`)
+	if err := (&requestHooks{tokens: manager}).classify(unauthorized); !HasErrorCode(err, EAccessDenied) ||
+		HasErrorCode(err, EInvalidGrant) {
+		t.Fatalf("Authentication failure of a token obtained by the SDK was reclassified (%v)", err)
+	}
+
+	done, err := manager.use()
+	if err != nil {
+		t.Fatalf("Failed to use connection (%v)", err)
+	}
+	_, err = conn.SystemService().Get().Send()
+	done()
+	if err != nil {
+		t.Fatalf("Failed to send request (%v)", err)
+	}
+
+	if err := manager.revoke(); err != nil {
+		t.Fatalf("Failed to revoke token (%v)", err)
+	}
+	if issued != 1 || !revoked["token-1"] {
+		t.Fatalf("The token obtained by the SDK was not revoked (issued: %d, revoked: %v)", issued, revoked)
+	}
+	if _, err := manager.use(); err == nil || !HasErrorCode(err, EClientClosed) {
+		t.Fatalf("Closed token manager did not return an EClientClosed error (%v)", err)
+	}
+}
+
+// TestSDKConnectionUsesInjectedToken guards the reflection in ssoTokenField against SDK updates: the SDK must send the
+// injected token and must not request a token itself.
+func TestSDKConnectionUsesInjectedToken(t *testing.T) {
+	t.Parallel()
+
+	lock := &sync.Mutex{}
+	var authorization []string
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		paths = append(paths, r.URL.Path)
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<api></api>`))
+	}))
+	defer server.Close()
+
+	conn, err := ovirtsdk4.NewConnectionBuilder().
+		URL(server.URL + "/ovirt-engine/api").
+		Username("admin@internal").
+		Password("password").
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build connection (%v)", err)
+	}
+	if err := setSSOToken(conn, "injected-token"); err != nil {
+		t.Fatalf("The SDK connection no longer has an SSO token field (%v)", err)
+	}
+	if token, err := getSSOToken(conn); err != nil || token != "injected-token" {
+		t.Fatalf("Failed to read back the injected token (%s, %v)", token, err)
+	}
+	if _, err := conn.SystemService().Get().Send(); err != nil {
+		t.Fatalf("Failed to send request (%v)", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(paths) != 1 || paths[0] != "/ovirt-engine/api" {
+		t.Fatalf("The SDK sent unexpected requests (%v)", paths)
+	}
+	if authorization[0] != "Bearer injected-token" {
+		t.Fatalf("The SDK did not send the injected token (%s)", authorization[0])
+	}
+}
+
+func TestSSOTokenResponseExpiry(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+
+	if exp := (ssoTokenResponse{Exp: []byte(`"9223372036854775807"`)}).expiresAt(now); !exp.IsZero() {
+		t.Fatalf("Non-expiring session reported an expiry (%s)", exp)
+	}
+	if exp := (ssoTokenResponse{ExpiresIn: "3600"}).expiresAt(now); !exp.Equal(now.Add(time.Hour)) {
+		t.Fatalf("Incorrect expiry from expires_in (%s)", exp)
+	}
+	millis := now.Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	exp := (ssoTokenResponse{Exp: []byte(fmt.Sprintf(`"%d"`, millis))}).expiresAt(now)
+	if exp.Sub(now.Add(time.Hour)) > time.Millisecond || now.Add(time.Hour).Sub(exp) > time.Millisecond {
+		t.Fatalf("Incorrect expiry from exp (%s)", exp)
+	}
+}
@@ -21,6 +21,91 @@ type StorageDomainClient interface {
 	// RemoveDiskFromStorageDomain removes a disk from a specific storage domain, but leaves the disk on other storage
 	// domains if any. If the disk is not present on any more storage domains, the entire disk will be removed.
 	RemoveDiskFromStorageDomain(id StorageDomainID, diskID DiskID, retries ...RetryStrategy) error
+	// ListStorageDomainDisks lists all disks that have an image on the storage domain.
+	ListStorageDomainDisks(id StorageDomainID, retries ...RetryStrategy) ([]Disk, error)
+	// ListStorageDomainTemplates lists all templates that have at least one disk on the storage domain.
+	ListStorageDomainTemplates(id StorageDomainID, retries ...RetryStrategy) ([]Template, error)
+	// ListStorageDomainVMs lists all VMs that have at least one disk on the storage domain.
+	ListStorageDomainVMs(id StorageDomainID, retries ...RetryStrategy) ([]VM, error)
+	// ListUnregisteredStorageDomainDisks lists the disks stored on the storage domain that are not known to the oVirt
+	// Engine, for example after the storage domain was imported. Disks belonging to unregistered VMs are registered
+	// together with the VM. The storage domain must be active.
+	ListUnregisteredStorageDomainDisks(id StorageDomainID, retries ...RetryStrategy) ([]Disk, error)
+	// ListUnregisteredStorageDomainVMs lists the VMs stored on the storage domain that are not known to the oVirt
+	// Engine. The storage domain must be active.
+	ListUnregisteredStorageDomainVMs(id StorageDomainID, retries ...RetryStrategy) ([]VM, error)
+	// RegisterStorageDomainDisk adds an unregistered disk on the storage domain to the oVirt Engine. The disk keeps
+	// its ID.
+	RegisterStorageDomainDisk(id StorageDomainID, diskID DiskID, retries ...RetryStrategy) (Disk, error)
+	// RegisterStorageDomainVM adds an unregistered VM on the storage domain to the oVirt Engine in the specified
+	// cluster, together with its disks. The VM keeps its ID.
+	RegisterStorageDomainVM(
+		id StorageDomainID,
+		vmID VMID,
+		clusterID ClusterID,
+		retries ...RetryStrategy,
+	) (VM, error)
+
+	// CreateStorageDomain creates a new storage domain of the specified storage type, using the host to prepare the
+	// storage. The storage location is passed in params: an address and a path for NFS, a path for local storage and
+	// LUN IDs for iSCSI and FCP. The new storage domain is in the StorageDomainStatusUnattached status and must be
+	// attached to a datacenter using AttachStorageDomain before it can be used.
+	CreateStorageDomain(
+		hostID HostID,
+		name string,
+		storageType StorageDomainType,
+		params CreateStorageDomainParameters,
+		retries ...RetryStrategy,
+	) (StorageDomain, error)
+	// ImportStorageDomain adds a storage domain that already exists on the storage, for example one that was
+	// removed from another oVirt Engine without formatting it. The parameters are the same as for
+	// CreateStorageDomain.
+	ImportStorageDomain(
+		hostID HostID,
+		name string,
+		storageType StorageDomainType,
+		params CreateStorageDomainParameters,
+		retries ...RetryStrategy,
+	) (StorageDomain, error)
+	// AttachStorageDomain attaches an unattached storage domain to a datacenter. The oVirt Engine activates the
+	// storage domain once it is attached. Use WaitForStorageDomainStatus to wait for StorageDomainStatusActive.
+	AttachStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// ActivateStorageDomain activates a storage domain in maintenance in the specified datacenter. Use
+	// WaitForStorageDomainStatus to wait for StorageDomainStatusActive.
+	ActivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// DeactivateStorageDomain puts a storage domain in the specified datacenter into maintenance. Disks on the
+	// storage domain must not be used by running VMs. Use WaitForStorageDomainStatus to wait for
+	// StorageDomainStatusMaintenance.
+	DeactivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// DetachStorageDomain detaches a storage domain in maintenance from the specified datacenter. Use
+	// WaitForStorageDomainStatus to wait for StorageDomainStatusUnattached. The VMs and disks on a data storage domain
+	// are removed from the oVirt Engine, but remain on the storage. Once the storage domain is attached again, they
+	// can be registered using RegisterStorageDomainVM and RegisterStorageDomainDisk.
+	DetachStorageDomain(id StorageDomainID, datacenterID DatacenterID, retries ...RetryStrategy) error
+	// RemoveStorageDomain removes an unattached storage domain from the oVirt Engine using the specified host. If
+	// format is true, the data on the storage is erased. Otherwise, the storage domain can be imported again later
+	// using ImportStorageDomain.
+	RemoveStorageDomain(id StorageDomainID, hostID HostID, format bool, retries ...RetryStrategy) error
+	// DestroyStorageDomain removes a storage domain from the oVirt Engine without accessing the storage. This is
+	// useful if the storage is no longer reachable. The data on the storage is left as it is.
+	DestroyStorageDomain(id StorageDomainID, retries ...RetryStrategy) error
+	// WaitForStorageDomainStatus waits for the storage domain to reach the desired status. For storage domains
+	// attached to a datacenter, the status in the datacenter is used.
+	WaitForStorageDomainStatus(
+		id StorageDomainID,
+		status StorageDomainStatus,
+		retries ...RetryStrategy,
+	) (StorageDomain, error)
+	// ListDatacenterStorageDomains lists the storage domains attached to a datacenter. Unlike ListStorageDomains, the
+	// returned storage domains have the status they have in the datacenter.
+	ListDatacenterStorageDomains(datacenterID DatacenterID, retries ...RetryStrategy) (StorageDomainList, error)
+	// FindStorageDomainForDisk returns the storage domain in the datacenter best suited for a new disk of the
+	// specified size in bytes. See StorageDomainList.BestForDisk for details.
+	FindStorageDomainForDisk(
+		datacenterID DatacenterID,
+		size uint64,
+		retries ...RetryStrategy,
+	) (StorageDomain, error)
 }
 
 // StorageDomainData is the core of StorageDomain, providing only data access functions.
@@ -38,11 +123,48 @@ type StorageDomainData interface {
 	Status() StorageDomainStatus
 	// ExternalStatus returns the external status of a storage domain.
 	ExternalStatus() StorageDomainExternalStatus
+	// Function returns what the storage domain is used for, for example for VM disks or ISO images.
+	Function() StorageDomainFunction
+	// DatacenterIDs returns the IDs of the datacenters the storage domain is attached to. It is empty for
+	// unattached storage domains.
+	DatacenterIDs() []DatacenterID
+	// Used returns the number of bytes used on the storage domain.
+	Used() uint64
+	// Committed returns the number of bytes promised to the disks on the storage domain. This may be larger than the
+	// size of the storage domain if thin provisioned disks are used.
+	Committed() uint64
+	// WarningLowSpaceIndicator returns the percentage of free space below which the oVirt Engine warns that the
+	// storage domain is running out of space.
+	WarningLowSpaceIndicator() uint
+	// CriticalSpaceActionBlocker returns the number of free bytes below which the oVirt Engine blocks operations that
+	// take up space on the storage domain, such as creating disks.
+	CriticalSpaceActionBlocker() uint64
+	// Backup returns true if the storage domain is used for backups. Disks on backup storage domains cannot be used
+	// by running VMs.
+	Backup() bool
+	// Master returns true if the storage domain is the master storage domain of its datacenter, which holds the
+	// metadata of the datacenter.
+	Master() bool
 }
 
 // StorageDomain represents a storage domain returned from the oVirt Engine API.
 type StorageDomain interface {
 	StorageDomainData
+
+	// Attach attaches the storage domain to a datacenter. See StorageDomainClient.AttachStorageDomain for details.
+	Attach(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Activate activates the storage domain in a datacenter. See StorageDomainClient.ActivateStorageDomain for
+	// details.
+	Activate(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Deactivate puts the storage domain into maintenance. See StorageDomainClient.DeactivateStorageDomain for
+	// details.
+	Deactivate(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Detach detaches the storage domain from a datacenter. See StorageDomainClient.DetachStorageDomain for details.
+	Detach(datacenterID DatacenterID, retries ...RetryStrategy) error
+	// Remove removes the storage domain. See StorageDomainClient.RemoveStorageDomain for details.
+	Remove(hostID HostID, format bool, retries ...RetryStrategy) error
+	// WaitForStatus waits for the storage domain to reach the desired status and returns the updated storage domain.
+	WaitForStatus(status StorageDomainStatus, retries ...RetryStrategy) (StorageDomain, error)
 }
 
 // StorageDomainList represents a list of storage domains.
@@ -59,6 +181,48 @@ func (list StorageDomainList) Filter(apply func(StorageDomain) bool) StorageDoma
 	return filtered
 }
 
+// BestForDisk returns the storage domain best suited for a new disk of the specified size in bytes. Only active
+// data storage domains that are not backup storage domains are considered. Storage domains that would have less free
+// space than their CriticalSpaceActionBlocker after adding the disk are skipped. Storage domains that stay above
+// their WarningLowSpaceIndicator are preferred, and among them the one with the most available space is chosen. If
+// no storage domain can hold the disk, an error with the ENoSpace code is returned.
+func (list StorageDomainList) BestForDisk(size uint64) (StorageDomain, error) {
+	var best StorageDomain
+	bestAboveWarning := false
+	for _, sd := range list {
+		if sd.Status() != StorageDomainStatusActive || sd.Function() != StorageDomainFunctionData || sd.Backup() {
+			continue
+		}
+		if sd.Available() < size || sd.Available()-size < sd.CriticalSpaceActionBlocker() {
+			continue
+		}
+		aboveWarning := !storageDomainBelowWarning(sd, size)
+		switch {
+		case best == nil:
+		case aboveWarning && !bestAboveWarning:
+		case aboveWarning == bestAboveWarning && sd.Available() > best.Available():
+		default:
+			continue
+		}
+		best = sd
+		bestAboveWarning = aboveWarning
+	}
+	if best == nil {
+		return nil, newError(ENoSpace, "no active data storage domain has room for a disk of %d bytes", size)
+	}
+	return best, nil
+}
+
+// storageDomainBelowWarning returns true if the free space of the storage domain would fall below its
+// WarningLowSpaceIndicator after adding size bytes.
+func storageDomainBelowWarning(sd StorageDomainData, size uint64) bool {
+	total := sd.Used() + sd.Available()
+	if total == 0 || sd.Available() < size {
+		return true
+	}
+	return (sd.Available()-size)*100 < uint64(sd.WarningLowSpaceIndicator())*total
+}
+
 // StorageDomainType represents the type of the storage domain.
 type StorageDomainType string
 
@@ -114,6 +278,64 @@ func StorageDomainTypeValues() StorageDomainTypeList {
 	}
 }
 
+// StorageDomainFunction describes what a storage domain is used for.
+type StorageDomainFunction string
+
+const (
+	// StorageDomainFunctionData is a storage domain for VM and template disks.
+	StorageDomainFunctionData StorageDomainFunction = "data"
+	// StorageDomainFunctionISO is a legacy storage domain for ISO images. ISO images can also be uploaded to data
+	// storage domains.
+	StorageDomainFunctionISO StorageDomainFunction = "iso"
+	// StorageDomainFunctionExport is a legacy storage domain for moving VMs and templates between datacenters.
+	StorageDomainFunctionExport StorageDomainFunction = "export"
+	// StorageDomainFunctionImage is a storage domain provided by an external image provider, such as Glance.
+	StorageDomainFunctionImage StorageDomainFunction = "image"
+	// StorageDomainFunctionVolume is a storage domain provided by an external volume provider, such as Cinder.
+	StorageDomainFunctionVolume StorageDomainFunction = "volume"
+	// StorageDomainFunctionManagedBlockStorage is a storage domain for managed block storage.
+	StorageDomainFunctionManagedBlockStorage StorageDomainFunction = "managed_block_storage"
+)
+
+// StorageDomainFunctionList is a list of StorageDomainFunction values.
+type StorageDomainFunctionList []StorageDomainFunction
+
+// StorageDomainFunctionValues returns all possible StorageDomainFunction values.
+func StorageDomainFunctionValues() StorageDomainFunctionList {
+	return []StorageDomainFunction{
+		StorageDomainFunctionData,
+		StorageDomainFunctionISO,
+		StorageDomainFunctionExport,
+		StorageDomainFunctionImage,
+		StorageDomainFunctionVolume,
+		StorageDomainFunctionManagedBlockStorage,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l StorageDomainFunctionList) Strings() []string {
+	result := make([]string, len(l))
+	for i, function := range l {
+		result[i] = string(function)
+	}
+	return result
+}
+
+// Validate returns an error if the storage domain function doesn't have a valid value.
+func (f StorageDomainFunction) Validate() error {
+	for _, function := range StorageDomainFunctionValues() {
+		if function == f {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid storage domain function: %s must be one of: %s",
+		f,
+		strings.Join(StorageDomainFunctionValues().Strings(), ", "),
+	)
+}
+
 // StorageDomainStatus represents the status a domain can be in. Either this status field, or the
 // StorageDomainExternalStatus must be set.
 //
@@ -242,6 +464,395 @@ func (l StorageDomainExternalStatusList) Strings() []string {
 	return result
 }
 
+// NFSVersion is the NFS protocol version used to mount an NFS storage domain.
+type NFSVersion string
+
+const (
+	// NFSVersionAuto negotiates the highest NFS version supported by the host and the server.
+	NFSVersionAuto NFSVersion = "auto"
+	// NFSVersionV3 uses NFS version 3.
+	NFSVersionV3 NFSVersion = "v3"
+	// NFSVersionV4 uses NFS version 4.
+	NFSVersionV4 NFSVersion = "v4"
+	// NFSVersionV40 uses NFS version 4.0.
+	NFSVersionV40 NFSVersion = "v4_0"
+	// NFSVersionV41 uses NFS version 4.1.
+	NFSVersionV41 NFSVersion = "v4_1"
+	// NFSVersionV42 uses NFS version 4.2.
+	NFSVersionV42 NFSVersion = "v4_2"
+)
+
+// NFSVersionList is a list of NFSVersion values.
+type NFSVersionList []NFSVersion
+
+// NFSVersionValues returns all possible NFSVersion values.
+func NFSVersionValues() NFSVersionList {
+	return []NFSVersion{
+		NFSVersionAuto,
+		NFSVersionV3,
+		NFSVersionV4,
+		NFSVersionV40,
+		NFSVersionV41,
+		NFSVersionV42,
+	}
+}
+
+// Strings creates a string list of the values.
+func (l NFSVersionList) Strings() []string {
+	result := make([]string, len(l))
+	for i, version := range l {
+		result[i] = string(version)
+	}
+	return result
+}
+
+// Validate returns an error if the NFS version doesn't have a valid value.
+func (v NFSVersion) Validate() error {
+	for _, version := range NFSVersionValues() {
+		if version == v {
+			return nil
+		}
+	}
+	return newError(
+		EBadArgument,
+		"invalid NFS version: %s must be one of: %s",
+		v,
+		strings.Join(NFSVersionValues().Strings(), ", "),
+	)
+}
+
+// CreateStorageDomainParameters contains the parameters for StorageDomainClient.CreateStorageDomain and
+// StorageDomainClient.ImportStorageDomain. Which parameters are required depends on the storage type:
+//
+//   - StorageDomainTypeNFS requires an address and a path.
+//   - StorageDomainTypeLocalFS requires a path on the host.
+//   - StorageDomainTypeISCSI requires an iSCSI connection and at least one LUN ID.
+//   - StorageDomainTypeFCP requires at least one LUN ID.
+type CreateStorageDomainParameters interface {
+	// Function returns what the storage domain is used for. Defaults to StorageDomainFunctionData.
+	Function() StorageDomainFunction
+	// Description returns the description of the storage domain.
+	Description() string
+	// Address returns the address of the NFS server.
+	Address() string
+	// Path returns the exported path on the NFS server, or the directory on the host for local storage.
+	Path() string
+	// NFSVersion returns the NFS version to use. May be nil, in which case the oVirt Engine default is used.
+	NFSVersion() *NFSVersion
+	// MountOptions returns additional mount options for NFS storage domains.
+	MountOptions() string
+	// ISCSIConnection returns the connection details of the iSCSI target holding the LUNs. May be nil.
+	ISCSIConnection() *ISCSIConnection
+	// LUNIDs returns the IDs of the LUNs the storage domain is created on for iSCSI and FCP storage domains.
+	LUNIDs() []string
+}
+
+// BuildableCreateStorageDomainParameters is a buildable version of CreateStorageDomainParameters.
+type BuildableCreateStorageDomainParameters interface {
+	CreateStorageDomainParameters
+
+	// WithFunction sets what the storage domain is used for.
+	WithFunction(function StorageDomainFunction) (BuildableCreateStorageDomainParameters, error)
+	// MustWithFunction is identical to WithFunction, but panics instead of returning an error.
+	MustWithFunction(function StorageDomainFunction) BuildableCreateStorageDomainParameters
+
+	// WithDescription sets the description of the storage domain.
+	WithDescription(description string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableCreateStorageDomainParameters
+
+	// WithNFS sets the address of the NFS server and the exported path.
+	WithNFS(address string, path string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithNFS is identical to WithNFS, but panics instead of returning an error.
+	MustWithNFS(address string, path string) BuildableCreateStorageDomainParameters
+
+	// WithNFSVersion sets the NFS version to use.
+	WithNFSVersion(version NFSVersion) (BuildableCreateStorageDomainParameters, error)
+	// MustWithNFSVersion is identical to WithNFSVersion, but panics instead of returning an error.
+	MustWithNFSVersion(version NFSVersion) BuildableCreateStorageDomainParameters
+
+	// WithMountOptions sets additional mount options for NFS storage domains.
+	WithMountOptions(mountOptions string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithMountOptions is identical to WithMountOptions, but panics instead of returning an error.
+	MustWithMountOptions(mountOptions string) BuildableCreateStorageDomainParameters
+
+	// WithLocalPath sets the directory on the host used for local storage domains.
+	WithLocalPath(path string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithLocalPath is identical to WithLocalPath, but panics instead of returning an error.
+	MustWithLocalPath(path string) BuildableCreateStorageDomainParameters
+
+	// WithISCSIConnection sets the connection details of the iSCSI target holding the LUNs.
+	WithISCSIConnection(connection ISCSIConnection) (BuildableCreateStorageDomainParameters, error)
+	// MustWithISCSIConnection is identical to WithISCSIConnection, but panics instead of returning an error.
+	MustWithISCSIConnection(connection ISCSIConnection) BuildableCreateStorageDomainParameters
+
+	// WithLUNIDs sets the IDs of the LUNs the storage domain is created on.
+	WithLUNIDs(lunIDs ...string) (BuildableCreateStorageDomainParameters, error)
+	// MustWithLUNIDs is identical to WithLUNIDs, but panics instead of returning an error.
+	MustWithLUNIDs(lunIDs ...string) BuildableCreateStorageDomainParameters
+}
+
+// CreateStorageDomainParams creates a buildable set of parameters for creating or importing a storage domain.
+func CreateStorageDomainParams() BuildableCreateStorageDomainParameters {
+	return &createStorageDomainParams{
+		function: StorageDomainFunctionData,
+	}
+}
+
+type createStorageDomainParams struct {
+	function        StorageDomainFunction
+	description     string
+	address         string
+	path            string
+	nfsVersion      *NFSVersion
+	mountOptions    string
+	iscsiConnection *ISCSIConnection
+	lunIDs          []string
+}
+
+func (c *createStorageDomainParams) Function() StorageDomainFunction {
+	return c.function
+}
+
+func (c *createStorageDomainParams) Description() string {
+	return c.description
+}
+
+func (c *createStorageDomainParams) Address() string {
+	return c.address
+}
+
+func (c *createStorageDomainParams) Path() string {
+	return c.path
+}
+
+func (c *createStorageDomainParams) NFSVersion() *NFSVersion {
+	return c.nfsVersion
+}
+
+func (c *createStorageDomainParams) MountOptions() string {
+	return c.mountOptions
+}
+
+func (c *createStorageDomainParams) ISCSIConnection() *ISCSIConnection {
+	return c.iscsiConnection
+}
+
+func (c *createStorageDomainParams) LUNIDs() []string {
+	return c.lunIDs
+}
+
+func (c *createStorageDomainParams) WithFunction(
+	function StorageDomainFunction,
+) (BuildableCreateStorageDomainParameters, error) {
+	if err := function.Validate(); err != nil {
+		return nil, err
+	}
+	c.function = function
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithFunction(
+	function StorageDomainFunction,
+) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithFunction(function)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithDescription(
+	description string,
+) (BuildableCreateStorageDomainParameters, error) {
+	c.description = description
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithDescription(description string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithNFS(
+	address string,
+	path string,
+) (BuildableCreateStorageDomainParameters, error) {
+	if address == "" {
+		return nil, newError(EBadArgument, "the NFS server address must not be empty")
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, newError(EBadArgument, "the NFS export path must be absolute (%s given)", path)
+	}
+	c.address = address
+	c.path = path
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithNFS(address string, path string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithNFS(address, path)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithNFSVersion(version NFSVersion) (BuildableCreateStorageDomainParameters, error) {
+	if err := version.Validate(); err != nil {
+		return nil, err
+	}
+	c.nfsVersion = &version
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithNFSVersion(version NFSVersion) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithNFSVersion(version)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithMountOptions(
+	mountOptions string,
+) (BuildableCreateStorageDomainParameters, error) {
+	c.mountOptions = mountOptions
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithMountOptions(mountOptions string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithMountOptions(mountOptions)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithLocalPath(path string) (BuildableCreateStorageDomainParameters, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, newError(EBadArgument, "the local storage path must be absolute (%s given)", path)
+	}
+	c.address = ""
+	c.path = path
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithLocalPath(path string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithLocalPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithISCSIConnection(
+	connection ISCSIConnection,
+) (BuildableCreateStorageDomainParameters, error) {
+	if connection.Address == "" || connection.Target == "" {
+		return nil, newError(EBadArgument, "the iSCSI connection must have an address and a target")
+	}
+	if connection.Port == 0 {
+		connection.Port = defaultISCSIPort
+	}
+	c.iscsiConnection = &connection
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithISCSIConnection(
+	connection ISCSIConnection,
+) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithISCSIConnection(connection)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (c *createStorageDomainParams) WithLUNIDs(lunIDs ...string) (BuildableCreateStorageDomainParameters, error) {
+	if len(lunIDs) == 0 {
+		return nil, newError(EBadArgument, "at least one LUN ID must be passed")
+	}
+	for _, lunID := range lunIDs {
+		if lunID == "" {
+			return nil, newError(EBadArgument, "LUN IDs must not be empty")
+		}
+	}
+	c.lunIDs = append([]string(nil), lunIDs...)
+	return c, nil
+}
+
+func (c *createStorageDomainParams) MustWithLUNIDs(lunIDs ...string) BuildableCreateStorageDomainParameters {
+	builder, err := c.WithLUNIDs(lunIDs...)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// validateCreateStorageDomainParameters checks that the parameters contain the storage location required by the
+// storage type.
+func validateCreateStorageDomainParameters(
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+) error {
+	if name == "" {
+		return newError(EBadArgument, "the storage domain name must not be empty")
+	}
+	if params == nil {
+		return newError(
+			EBadArgument,
+			"the storage domain parameters must not be nil, use CreateStorageDomainParams() to create them",
+		)
+	}
+	if err := params.Function().Validate(); err != nil {
+		return err
+	}
+	switch storageType {
+	case StorageDomainTypeNFS:
+		if params.Address() == "" || params.Path() == "" {
+			return newError(EBadArgument, "NFS storage domains require an address and a path, use WithNFS to set them")
+		}
+	case StorageDomainTypeLocalFS:
+		if params.Path() == "" || params.Address() != "" {
+			return newError(EBadArgument, "local storage domains require a path, use WithLocalPath to set it")
+		}
+	case StorageDomainTypeISCSI:
+		if params.ISCSIConnection() == nil || len(params.LUNIDs()) == 0 {
+			return newError(
+				EBadArgument,
+				"iSCSI storage domains require an iSCSI connection and LUN IDs, use WithISCSIConnection and WithLUNIDs",
+			)
+		}
+	case StorageDomainTypeFCP:
+		if len(params.LUNIDs()) == 0 {
+			return newError(EBadArgument, "FCP storage domains require LUN IDs, use WithLUNIDs to set them")
+		}
+		if params.ISCSIConnection() != nil {
+			return newError(EBadArgument, "FCP storage domains do not use an iSCSI connection")
+		}
+	default:
+		return newError(
+			EBadArgument,
+			"creating %s storage domains is not supported, use one of: %s",
+			storageType,
+			strings.Join(
+				[]string{
+					string(StorageDomainTypeNFS),
+					string(StorageDomainTypeLocalFS),
+					string(StorageDomainTypeISCSI),
+					string(StorageDomainTypeFCP),
+				},
+				", ",
+			),
+		)
+	}
+	return nil
+}
+
 func convertSDKStorageDomain(sdkStorageDomain *ovirtsdk4.StorageDomain, client Client) (StorageDomain, error) {
 	id, ok := sdkStorageDomain.Id()
 	if !ok {
@@ -274,6 +885,28 @@ func convertSDKStorageDomain(sdkStorageDomain *ovirtsdk4.StorageDomain, client C
 	if status == "" && externalStatus == "" {
 		return nil, newError(EFieldMissing, "neither the status nor the external status is set for storage domain %s", id)
 	}
+	function, ok := sdkStorageDomain.Type()
+	if !ok {
+		return nil, newFieldNotFound("storage domain", "type")
+	}
+	var datacenterIDs []DatacenterID
+	if datacenters, ok := sdkStorageDomain.DataCenters(); ok {
+		for _, datacenter := range datacenters.Slice() {
+			if datacenterID, ok := datacenter.Id(); ok {
+				datacenterIDs = append(datacenterIDs, DatacenterID(datacenterID))
+			}
+		}
+	}
+	// The space usage is not reported for storage domains that are not accessible, such as unattached ones.
+	used, _ := sdkStorageDomain.Used()
+	committed, _ := sdkStorageDomain.Committed()
+	warningLowSpaceIndicator, _ := sdkStorageDomain.WarningLowSpaceIndicator()
+	criticalSpaceActionBlocker, _ := sdkStorageDomain.CriticalSpaceActionBlocker()
+	if used < 0 || committed < 0 || warningLowSpaceIndicator < 0 || criticalSpaceActionBlocker < 0 {
+		return nil, newError(EBug, "invalid space usage returned for storage domain %s", id)
+	}
+	backup, _ := sdkStorageDomain.Backup()
+	master, _ := sdkStorageDomain.Master()
 
 	return &storageDomain{
 		client: client,
@@ -284,6 +917,15 @@ func convertSDKStorageDomain(sdkStorageDomain *ovirtsdk4.StorageDomain, client C
 		storageType:    StorageDomainType(storageType),
 		status:         StorageDomainStatus(status),
 		externalStatus: StorageDomainExternalStatus(externalStatus),
+		function:       StorageDomainFunction(function),
+		datacenterIDs:  datacenterIDs,
+		used:           uint64(used),
+		committed:      uint64(committed),
+		// The oVirt Engine reports the threshold in GiB.
+		criticalSpaceActionBlocker: uint64(criticalSpaceActionBlocker) * 1024 * 1024 * 1024,
+		warningLowSpaceIndicator:   uint(warningLowSpaceIndicator),
+		backup:                     backup,
+		master:                     master,
 	}, nil
 }
 
@@ -296,6 +938,15 @@ type storageDomain struct {
 	storageType    StorageDomainType
 	status         StorageDomainStatus
 	externalStatus StorageDomainExternalStatus
+	function       StorageDomainFunction
+	datacenterIDs  []DatacenterID
+
+	used                       uint64
+	committed                  uint64
+	warningLowSpaceIndicator   uint
+	criticalSpaceActionBlocker uint64
+	backup                     bool
+	master                     bool
 }
 
 func (s storageDomain) ID() StorageDomainID {
@@ -322,6 +973,69 @@ func (s storageDomain) ExternalStatus() StorageDomainExternalStatus {
 	return s.externalStatus
 }
 
+func (s storageDomain) Function() StorageDomainFunction {
+	return s.function
+}
+
+func (s storageDomain) DatacenterIDs() []DatacenterID {
+	return append([]DatacenterID(nil), s.datacenterIDs...)
+}
+
+func (s storageDomain) Used() uint64 {
+	return s.used
+}
+
+func (s storageDomain) Committed() uint64 {
+	return s.committed
+}
+
+func (s storageDomain) WarningLowSpaceIndicator() uint {
+	return s.warningLowSpaceIndicator
+}
+
+func (s storageDomain) CriticalSpaceActionBlocker() uint64 {
+	return s.criticalSpaceActionBlocker
+}
+
+func (s storageDomain) Backup() bool {
+	return s.backup
+}
+
+func (s storageDomain) Master() bool {
+	return s.master
+}
+
+func (s storageDomain) Attach(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.AttachStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Activate(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.ActivateStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Deactivate(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.DeactivateStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Detach(datacenterID DatacenterID, retries ...RetryStrategy) error {
+	return s.client.DetachStorageDomain(s.id, datacenterID, retries...)
+}
+
+func (s storageDomain) Remove(hostID HostID, format bool, retries ...RetryStrategy) error {
+	return s.client.RemoveStorageDomain(s.id, hostID, format, retries...)
+}
+
+func (s storageDomain) WaitForStatus(status StorageDomainStatus, retries ...RetryStrategy) (StorageDomain, error) {
+	return s.client.WaitForStorageDomainStatus(s.id, status, retries...)
+}
+
+// withStatus returns a copy of the storage domain with the specified status. The mock replaces storage domains
+// instead of modifying them, as they may have been returned to callers.
+func (s storageDomain) withStatus(status StorageDomainStatus) *storageDomain {
+	s.status = status
+	return &s
+}
+
 type storageDomainDiskWait struct {
 	client        *oVirtClient
 	disk          Disk
@@ -0,0 +1,51 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ActivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("activating storage domain %s in datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("ActivateStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Activate().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) ActivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if !sd.attachedTo(datacenterID) {
+		return newError(ENotFound, "storage domain %s is not attached to datacenter %s", id, datacenterID)
+	}
+	switch sd.status {
+	case StorageDomainStatusActive:
+		return nil
+	case StorageDomainStatusMaintenance, StorageDomainStatusInactive, StorageDomainStatusUnknown:
+	default:
+		return newError(EConflict, "storage domain %s is in status %s and cannot be activated", id, sd.status)
+	}
+	m.transitionStorageDomain(id, StorageDomainStatusActivating, StorageDomainStatusActive, nil)
+	return nil
+}
@@ -0,0 +1,72 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) AttachStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("attaching storage domain %s to datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("AttachStorageDomain"),
+		retries,
+		func() error {
+			sdkStorageDomain, err := ovirtsdk4.NewStorageDomainBuilder().Id(string(id)).Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build storage domain %s", id)
+			}
+			_, err = o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				Add().
+				StorageDomain(sdkStorageDomain).
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) AttachStorageDomain(id StorageDomainID, datacenterID DatacenterID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	if sd.attachedTo(datacenterID) {
+		return newError(EConflict, "storage domain %s is already attached to datacenter %s", id, datacenterID)
+	}
+	if sd.function == StorageDomainFunctionData && len(sd.datacenterIDs) > 0 {
+		return newError(
+			EConflict,
+			"data storage domain %s is already attached to datacenter %s",
+			id,
+			sd.datacenterIDs[0],
+		)
+	}
+	if sd.status != StorageDomainStatusUnattached && sd.status != StorageDomainStatusActive {
+		return newError(EConflict, "storage domain %s is in status %s and cannot be attached", id, sd.status)
+	}
+	sd = sd.withStatus(sd.status)
+	sd.datacenterIDs = append(sd.DatacenterIDs(), datacenterID)
+	m.storageDomains[id] = sd
+	m.transitionStorageDomain(id, StorageDomainStatusLocked, StorageDomainStatusActive, func(sd *storageDomain) {
+		// The first data storage domain of a datacenter becomes its master storage domain.
+		if sd.function == StorageDomainFunctionData && !sd.backup && !m.hasMasterStorageDomain(datacenterID, id) {
+			sd.master = true
+		}
+	})
+	return nil
+}
@@ -0,0 +1,157 @@
+// This file contains tests for the storage domain capacity figures, which are built from internal storage domain
+// objects. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+const testGiB = 1024 * 1024 * 1024
+
+func TestStorageDomainListBestForDisk(t *testing.T) {
+	t.Parallel()
+
+	newSD := func(id StorageDomainID, available uint64, used uint64) *storageDomain {
+		return &storageDomain{
+			id:                         id,
+			status:                     StorageDomainStatusActive,
+			function:                   StorageDomainFunctionData,
+			available:                  available,
+			used:                       used,
+			warningLowSpaceIndicator:   10,
+			criticalSpaceActionBlocker: 5 * testGiB,
+		}
+	}
+	// Most available space, but drops below the warning threshold (8 of 100 GiB free after the disk).
+	large := newSD("large", 28*testGiB, 72*testGiB)
+	// Less available space, but stays above the warning threshold.
+	roomy := newSD("roomy", 25*testGiB, 5*testGiB)
+	backup := newSD("backup", 500*testGiB, 0)
+	backup.backup = true
+	maintenance := newSD("maintenance", 500*testGiB, 0)
+	maintenance.status = StorageDomainStatusMaintenance
+	iso := newSD("iso", 500*testGiB, 0)
+	iso.function = StorageDomainFunctionISO
+
+	list := StorageDomainList{large, roomy, backup, maintenance, iso}
+	best, err := list.BestForDisk(20 * testGiB)
+	if err != nil {
+		t.Fatalf("Failed to pick a storage domain (%v)", err)
+	}
+	if best.ID() != "roomy" {
+		t.Fatalf("The storage domain above the warning threshold was not preferred (%s picked).", best.ID())
+	}
+
+	best, err = StorageDomainList{large}.BestForDisk(20 * testGiB)
+	if err != nil {
+		t.Fatalf("Failed to pick a storage domain below the warning threshold (%v)", err)
+	}
+	if best.ID() != "large" {
+		t.Fatalf("Incorrect storage domain picked: %s", best.ID())
+	}
+
+	// 24 GiB would leave less than the 5 GiB critical space on both storage domains.
+	if _, err := list.BestForDisk(24 * testGiB); err == nil || !HasErrorCode(err, ENoSpace) {
+		t.Fatalf("Exceeding the critical space threshold did not return an ENoSpace error (%v)", err)
+	}
+}
+
+func TestMockStorageDomainUsage(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	_, datacenterID := mockStorageDomainTestIDs(t, client)
+	sdID := testStorageDomainID(client)
+
+	before, err := client.GetStorageDomain(sdID)
+	if err != nil {
+		t.Fatalf("Failed to get storage domain (%v)", err)
+	}
+	disk, err := client.CreateDisk(sdID, ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	after, err := client.GetStorageDomain(sdID)
+	if err != nil {
+		t.Fatalf("Failed to get storage domain (%v)", err)
+	}
+	if after.Used() != before.Used()+disk.TotalSize() {
+		t.Fatalf("Incorrect used space after creating a disk: %d", after.Used())
+	}
+	if after.Committed() != before.Committed()+disk.ProvisionedSize() {
+		t.Fatalf("Incorrect committed space after creating a disk: %d", after.Committed())
+	}
+	if after.Available() != before.Available()-disk.TotalSize() {
+		t.Fatalf("Incorrect available space after creating a disk: %d", after.Available())
+	}
+
+	if err := client.RemoveDisk(disk.ID()); err != nil {
+		t.Fatalf("Failed to remove disk (%v)", err)
+	}
+	removed, err := client.GetStorageDomain(sdID)
+	if err != nil {
+		t.Fatalf("Failed to get storage domain (%v)", err)
+	}
+	if removed.Used() != before.Used() || removed.Available() != before.Available() {
+		t.Fatalf("The space of the removed disk was not freed.")
+	}
+
+	masters := 0
+	storageDomains, err := client.ListDatacenterStorageDomains(datacenterID)
+	if err != nil {
+		t.Fatalf("Failed to list datacenter storage domains (%v)", err)
+	}
+	for _, sd := range storageDomains {
+		if sd.Master() {
+			masters++
+		}
+	}
+	if masters != 1 {
+		t.Fatalf("Incorrect number of master storage domains: %d", masters)
+	}
+	if _, err := client.FindStorageDomainForDisk(datacenterID, 1024*1024); err != nil {
+		t.Fatalf("Failed to find a storage domain for a disk (%v)", err)
+	}
+	if _, err := client.FindStorageDomainForDisk(datacenterID, 100*testGiB); err == nil || !HasErrorCode(err, ENoSpace) {
+		t.Fatalf("Finding a storage domain for a too large disk did not return an ENoSpace error (%v)", err)
+	}
+}
+
+func TestMockStorageDomainMasterMoves(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	_, datacenterID := mockStorageDomainTestIDs(t, client)
+
+	storageDomains, err := client.ListDatacenterStorageDomains(datacenterID)
+	if err != nil {
+		t.Fatalf("Failed to list datacenter storage domains (%v)", err)
+	}
+	var master StorageDomain
+	for _, sd := range storageDomains {
+		if sd.Master() {
+			master = sd
+		}
+	}
+	if master == nil {
+		t.Fatalf("The datacenter has no master storage domain.")
+	}
+	if err := master.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate master storage domain (%v)", err)
+	}
+	deactivated, err := master.WaitForStatus(StorageDomainStatusMaintenance)
+	if err != nil {
+		t.Fatalf("Failed to wait for storage domain maintenance (%v)", err)
+	}
+	if deactivated.Master() {
+		t.Fatalf("The storage domain in maintenance is still the master storage domain.")
+	}
+	storageDomains, err = client.ListDatacenterStorageDomains(datacenterID)
+	if err != nil {
+		t.Fatalf("Failed to list datacenter storage domains (%v)", err)
+	}
+	for _, sd := range storageDomains {
+		if sd.ID() != master.ID() && !sd.Master() {
+			t.Fatalf("The master role did not move to storage domain %s.", sd.ID())
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) CreateStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	retries ...RetryStrategy,
+) (StorageDomain, error) {
+	return o.addStorageDomain(hostID, name, storageType, params, false, retries)
+}
+
+func (o *oVirtClient) ImportStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	retries ...RetryStrategy,
+) (StorageDomain, error) {
+	return o.addStorageDomain(hostID, name, storageType, params, true, retries)
+}
+
+func (o *oVirtClient) addStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	imported bool,
+	retries []RetryStrategy,
+) (result StorageDomain, err error) {
+	if err := validateCreateStorageDomainParameters(name, storageType, params); err != nil {
+		return nil, err
+	}
+	sdkStorageDomain, err := buildSDKStorageDomain(hostID, name, storageType, params, imported)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build storage domain %s", name)
+	}
+	action := "creating"
+	if imported {
+		action = "importing"
+	}
+	// Preparing the storage is done synchronously by the host, which may take a while.
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("%s storage domain %s", action, name),
+		o.logger,
+		o.requestHooks("AddStorageDomain"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().StorageDomainsService().Add().StorageDomain(sdkStorageDomain).Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.StorageDomain()
+			if !ok {
+				return newError(EFieldMissing, "no storage domain returned after %s storage domain %s", action, name)
+			}
+			result, err = convertSDKStorageDomain(sdkObject, o)
+			if err != nil {
+				return wrap(err, EBug, "failed to convert storage domain %s", name)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func buildSDKStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	imported bool,
+) (*ovirtsdk4.StorageDomain, error) {
+	hostStorage := ovirtsdk4.NewHostStorageBuilder().Type(ovirtsdk4.StorageType(storageType))
+	switch storageType {
+	case StorageDomainTypeNFS:
+		hostStorage.Address(params.Address()).Path(params.Path())
+		if version := params.NFSVersion(); version != nil {
+			hostStorage.NfsVersion(ovirtsdk4.NfsVersion(*version))
+		}
+		if mountOptions := params.MountOptions(); mountOptions != "" {
+			hostStorage.MountOptions(mountOptions)
+		}
+	case StorageDomainTypeLocalFS:
+		hostStorage.Path(params.Path())
+	case StorageDomainTypeISCSI, StorageDomainTypeFCP:
+		logicalUnits := make([]ovirtsdk4.LogicalUnitBuilder, len(params.LUNIDs()))
+		for i, lunID := range params.LUNIDs() {
+			logicalUnit := ovirtsdk4.NewLogicalUnitBuilder().Id(lunID)
+			if connection := params.ISCSIConnection(); connection != nil {
+				logicalUnit.
+					Address(connection.Address).
+					Port(int64(connection.Port)).
+					Target(connection.Target)
+				if connection.Username != "" {
+					logicalUnit.Username(connection.Username).Password(connection.Password)
+				}
+			}
+			logicalUnits[i] = *logicalUnit
+		}
+		hostStorage.LogicalUnitsBuilderOfAny(logicalUnits...)
+	}
+	sdkHostStorage, err := hostStorage.Build()
+	if err != nil {
+		return nil, err
+	}
+	sdkHost, err := ovirtsdk4.NewHostBuilder().Id(string(hostID)).Build()
+	if err != nil {
+		return nil, err
+	}
+	builder := ovirtsdk4.NewStorageDomainBuilder().
+		Name(name).
+		Type(ovirtsdk4.StorageDomainType(params.Function())).
+		Host(sdkHost).
+		Storage(sdkHostStorage)
+	if description := params.Description(); description != "" {
+		builder.Description(description)
+	}
+	if imported {
+		builder.Import(true)
+	}
+	return builder.Build()
+}
+
+// mockStorageDomainSize is the available space of storage domains created in the mock.
+const mockStorageDomainSize = 100 * 1024 * 1024 * 1024
+
+// mockWarningLowSpaceIndicator and mockCriticalSpaceActionBlocker are the oVirt Engine defaults for the space
+// thresholds of new storage domains.
+const (
+	mockWarningLowSpaceIndicator   = 10
+	mockCriticalSpaceActionBlocker = 5 * 1024 * 1024 * 1024
+)
+
+func (m *mockClient) CreateStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	_ ...RetryStrategy,
+) (StorageDomain, error) {
+	return m.addStorageDomain(hostID, name, storageType, params, false)
+}
+
+func (m *mockClient) ImportStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	_ ...RetryStrategy,
+) (StorageDomain, error) {
+	return m.addStorageDomain(hostID, name, storageType, params, true)
+}
+
+func (m *mockClient) addStorageDomain(
+	hostID HostID,
+	name string,
+	storageType StorageDomainType,
+	params CreateStorageDomainParameters,
+	imported bool,
+) (StorageDomain, error) {
+	if err := validateCreateStorageDomainParameters(name, storageType, params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainHost(hostID); err != nil {
+		return nil, err
+	}
+	for _, sd := range m.storageDomains {
+		if sd.name == name {
+			return nil, newError(EConflict, "a storage domain named %s already exists (%s)", name, sd.id)
+		}
+	}
+	location := mockStorageLocation(hostID, storageType, params)
+	for id, existingLocation := range m.storageDomainLocations {
+		if existingLocation == location {
+			return nil, newError(EConflict, "the storage %s is already used by storage domain %s", location, id)
+		}
+	}
+	for _, lunID := range params.LUNIDs() {
+		for _, disk := range m.disks {
+			if disk.storageType == DiskStorageTypeLUN && disk.lunID == lunID {
+				return nil, newError(EConflict, "LUN %s is already used by disk %s", lunID, disk.id)
+			}
+		}
+	}
+
+	orphan, hasOrphan := m.orphanedStorageDomains[location]
+	var sd *storageDomain
+	if imported {
+		if !hasOrphan {
+			return nil, newError(ENotFound, "no storage domain found on the storage %s", location)
+		}
+		if orphan.function != params.Function() {
+			return nil, newError(
+				EBadArgument,
+				"the storage domain on the storage %s is a %s storage domain, not %s",
+				location,
+				orphan.function,
+				params.Function(),
+			)
+		}
+		sd = orphan.withStatus(StorageDomainStatusUnattached)
+		sd.name = name
+		sd.datacenterIDs = nil
+		sd.master = false
+		delete(m.orphanedStorageDomains, location)
+	} else {
+		if hasOrphan {
+			return nil, newError(
+				EConflict,
+				"the storage %s already contains storage domain %s, import it or remove it with formatting",
+				location,
+				orphan.id,
+			)
+		}
+		sd = &storageDomain{
+			client:         m,
+			id:             StorageDomainID(m.GenerateUUID()),
+			name:           name,
+			available:      mockStorageDomainSize,
+			storageType:    storageType,
+			status:         StorageDomainStatusUnattached,
+			externalStatus: StorageDomainExternalStatusNA,
+			function:       params.Function(),
+
+			warningLowSpaceIndicator:   mockWarningLowSpaceIndicator,
+			criticalSpaceActionBlocker: mockCriticalSpaceActionBlocker,
+		}
+	}
+	m.storageDomains[sd.id] = sd
+	m.storageDomainLocations[sd.id] = location
+	m.addDefaultDiskProfile(sd)
+	return m.storageDomainWithUsage(sd), nil
+}
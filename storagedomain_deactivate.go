@@ -0,0 +1,87 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) DeactivateStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("deactivating storage domain %s in datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("DeactivateStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Deactivate().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) DeactivateStorageDomain(id StorageDomainID, datacenterID DatacenterID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if !sd.attachedTo(datacenterID) {
+		return newError(ENotFound, "storage domain %s is not attached to datacenter %s", id, datacenterID)
+	}
+	switch sd.status {
+	case StorageDomainStatusMaintenance:
+		return nil
+	case StorageDomainStatusActive, StorageDomainStatusInactive, StorageDomainStatusUnknown:
+	default:
+		return newError(EConflict, "storage domain %s is in status %s and cannot be deactivated", id, sd.status)
+	}
+	if vmID, diskID, ok := m.findRunningVMOnStorageDomain(id); ok {
+		return newError(
+			EConflict,
+			"storage domain %s cannot be deactivated because disk %s is used by running VM %s",
+			id,
+			diskID,
+			vmID,
+		)
+	}
+	m.transitionStorageDomain(
+		id,
+		StorageDomainStatusPreparingForMaintenance,
+		StorageDomainStatusMaintenance,
+		func(sd *storageDomain) {
+			// The master role moves to another active data storage domain if there is one.
+			if sd.master {
+				m.electMasterStorageDomain(datacenterID, id)
+				sd.master = !m.hasMasterStorageDomain(datacenterID, id)
+			}
+		},
+	)
+	return nil
+}
+
+// findRunningVMOnStorageDomain returns a VM that is not down and uses a disk on the storage domain. The caller must
+// hold the lock.
+func (m *mockClient) findRunningVMOnStorageDomain(id StorageDomainID) (VMID, DiskID, bool) {
+	for _, disk := range m.disks {
+		if !disk.onStorageDomain(id) {
+			continue
+		}
+		for _, attachment := range m.vmDiskAttachmentsByDisk[disk.id] {
+			if vm, ok := m.vms[attachment.vmid]; ok && vm.status != VMStatusDown {
+				return vm.id, disk.id, true
+			}
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,71 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) DetachStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("detaching storage domain %s from datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("DetachStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Remove().
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) DetachStorageDomain(id StorageDomainID, datacenterID DatacenterID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if !sd.attachedTo(datacenterID) {
+		return newError(ENotFound, "storage domain %s is not attached to datacenter %s", id, datacenterID)
+	}
+	if sd.status != StorageDomainStatusMaintenance {
+		return newError(
+			EConflict,
+			"storage domain %s is in status %s, it must be in maintenance to be detached",
+			id,
+			sd.status,
+		)
+	}
+	var remaining []DatacenterID
+	for _, attachedID := range sd.datacenterIDs {
+		if attachedID != datacenterID {
+			remaining = append(remaining, attachedID)
+		}
+	}
+	final := StorageDomainStatusUnattached
+	if len(remaining) > 0 {
+		// Storage domains shared between datacenters, such as ISO domains, stay in use by the other datacenters.
+		final = StorageDomainStatusActive
+	} else if sd.function == StorageDomainFunctionData {
+		// The VMs and disks remain on the storage and can be registered again once the storage domain is attached.
+		if err := m.unregisterStorageDomainContents(id); err != nil {
+			return err
+		}
+	}
+	m.transitionStorageDomain(id, StorageDomainStatusDetaching, final, func(sd *storageDomain) {
+		sd.datacenterIDs = remaining
+		sd.master = false
+	})
+	return nil
+}
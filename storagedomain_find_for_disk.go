@@ -0,0 +1,25 @@
+package ovirtclient
+
+func (o *oVirtClient) FindStorageDomainForDisk(
+	datacenterID DatacenterID,
+	size uint64,
+	retries ...RetryStrategy,
+) (StorageDomain, error) {
+	storageDomains, err := o.ListDatacenterStorageDomains(datacenterID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return storageDomains.BestForDisk(size)
+}
+
+func (m *mockClient) FindStorageDomainForDisk(
+	datacenterID DatacenterID,
+	size uint64,
+	retries ...RetryStrategy,
+) (StorageDomain, error) {
+	storageDomains, err := m.ListDatacenterStorageDomains(datacenterID, retries...)
+	if err != nil {
+		return nil, err
+	}
+	return storageDomains.BestForDisk(size)
+}
@@ -11,6 +11,7 @@ func (o *oVirtClient) GetStorageDomain(id StorageDomainID, retries ...RetryStrat
 	err = retry(
 		fmt.Sprintf("getting storage domain %s", id),
 		o.logger,
+		o.requestHooks("GetStorageDomain"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().StorageDomainsService().StorageDomainService(string(id)).Get().Send()
@@ -43,7 +44,7 @@ func (m *mockClient) GetStorageDomain(id StorageDomainID, _ ...RetryStrategy) (S
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	if item, ok := m.storageDomains[id]; ok {
-		return item, nil
+		return m.storageDomainWithUsage(item), nil
 	}
 	return nil, newError(ENotFound, "storage domain with ID %s not found", id)
 }
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetDiskFromStorageDomain(id StorageDomainID, diskID DiskID
 	err = retry(
 		fmt.Sprintf("getting disk %s from storage domain %s", diskID, id),
 		o.logger,
+		o.requestHooks("GetDiskFromStorageDomain"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().StorageDomainsService().
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListStorageDomains(retries ...RetryStrategy) (result Stora
 	err = retry(
 		"listing storage domains",
 		o.logger,
+		o.requestHooks("ListStorageDomains"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().StorageDomainsService().List().Send()
@@ -36,7 +37,7 @@ func (m *mockClient) ListStorageDomains(_ ...RetryStrategy) (StorageDomainList,
 	result := make([]StorageDomain, len(m.storageDomains))
 	i := 0
 	for _, item := range m.storageDomains {
-		result[i] = item
+		result[i] = m.storageDomainWithUsage(item)
 		i++
 	}
 	return result, nil
@@ -0,0 +1,61 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListDatacenterStorageDomains(
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (result StorageDomainList, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing storage domains in datacenter %s", datacenterID),
+		o.logger,
+		o.requestHooks("ListDatacenterStorageDomains"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				List().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObjects, ok := response.StorageDomains()
+			if !ok {
+				return nil
+			}
+			result = make(StorageDomainList, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], err = convertSDKStorageDomain(sdkObject, o)
+				if err != nil {
+					return wrap(err, EBug, "failed to convert storage domain in datacenter %s", datacenterID)
+				}
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListDatacenterStorageDomains(
+	datacenterID DatacenterID,
+	_ ...RetryStrategy,
+) (StorageDomainList, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return nil, newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	var result StorageDomainList
+	for _, sd := range m.storageDomains {
+		if sd.attachedTo(datacenterID) {
+			result = append(result, m.storageDomainWithUsage(sd))
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListStorageDomainDisks(id StorageDomainID, retries ...RetryStrategy) ([]Disk, error) {
+	return o.listStorageDomainDisks(id, false, retries)
+}
+
+func (o *oVirtClient) ListUnregisteredStorageDomainDisks(
+	id StorageDomainID,
+	retries ...RetryStrategy,
+) ([]Disk, error) {
+	return o.listStorageDomainDisks(id, true, retries)
+}
+
+func (o *oVirtClient) listStorageDomainDisks(
+	id StorageDomainID,
+	unregistered bool,
+	retries []RetryStrategy,
+) (result []Disk, err error) {
+	description := "disks"
+	if unregistered {
+		description = "unregistered disks"
+	}
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing %s on storage domain %s", description, id),
+		o.logger,
+		o.requestHooks("ListStorageDomainDisks"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				DisksService().
+				List().
+				Unregistered(unregistered).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObjects, ok := response.Disks()
+			if !ok {
+				return nil
+			}
+			result = make([]Disk, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], err = convertSDKDisk(sdkObject, o)
+				if err != nil {
+					return wrap(err, EBug, "failed to convert disk on storage domain %s", id)
+				}
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListStorageDomainDisks(id StorageDomainID, _ ...RetryStrategy) ([]Disk, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[id]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	var result []Disk
+	for _, disk := range m.disks {
+		if disk.onStorageDomain(id) {
+			result = append(result, disk)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockClient) ListUnregisteredStorageDomainDisks(id StorageDomainID, _ ...RetryStrategy) ([]Disk, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	result := make([]Disk, len(m.unregisteredDisks[id]))
+	i := 0
+	for _, disk := range m.unregisteredDisks[id] {
+		result[i] = disk
+		i++
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListStorageDomainTemplates(id StorageDomainID, retries ...RetryStrategy) ([]Template, error) {
+	return o.listStorageDomainTemplates(id, false, retries)
+}
+
+func (o *oVirtClient) listStorageDomainTemplates(
+	id StorageDomainID,
+	unregistered bool,
+	retries []RetryStrategy,
+) (result []Template, err error) {
+	description := "templates"
+	if unregistered {
+		description = "unregistered templates"
+	}
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing %s on storage domain %s", description, id),
+		o.logger,
+		o.requestHooks("ListStorageDomainTemplates"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				TemplatesService().
+				List().
+				Unregistered(unregistered).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObjects, ok := response.Templates()
+			if !ok {
+				return nil
+			}
+			result = make([]Template, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], err = convertSDKTemplate(sdkObject, o)
+				if err != nil {
+					return wrap(err, EBug, "failed to convert template on storage domain %s", id)
+				}
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListStorageDomainTemplates(id StorageDomainID, _ ...RetryStrategy) ([]Template, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[id]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	var result []Template
+	for templateID, attachments := range m.templateDiskAttachmentsByTemplate {
+		for _, attachment := range attachments {
+			if disk, ok := m.disks[attachment.diskID]; ok && disk.onStorageDomain(id) {
+				result = append(result, m.templates[templateID])
+				break
+			}
+		}
+	}
+	for _, exported := range m.exportedTemplates[id] {
+		result = append(result, exported.template)
+	}
+	return result, nil
+}
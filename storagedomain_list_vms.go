@@ -0,0 +1,98 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) ListStorageDomainVMs(id StorageDomainID, retries ...RetryStrategy) ([]VM, error) {
+	return o.listStorageDomainVMs(id, false, retries)
+}
+
+func (o *oVirtClient) ListUnregisteredStorageDomainVMs(id StorageDomainID, retries ...RetryStrategy) ([]VM, error) {
+	return o.listStorageDomainVMs(id, true, retries)
+}
+
+func (o *oVirtClient) listStorageDomainVMs(
+	id StorageDomainID,
+	unregistered bool,
+	retries []RetryStrategy,
+) (result []VM, err error) {
+	description := "VMs"
+	if unregistered {
+		description = "unregistered VMs"
+	}
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("listing %s on storage domain %s", description, id),
+		o.logger,
+		o.requestHooks("ListStorageDomainVMs"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				VmsService().
+				List().
+				Unregistered(unregistered).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObjects, ok := response.Vm()
+			if !ok {
+				return nil
+			}
+			result = make([]VM, len(sdkObjects.Slice()))
+			for i, sdkObject := range sdkObjects.Slice() {
+				result[i], err = convertSDKVM(sdkObject, o)
+				if err != nil {
+					return wrap(err, EBug, "failed to convert VM on storage domain %s", id)
+				}
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListStorageDomainVMs(id StorageDomainID, _ ...RetryStrategy) ([]VM, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.storageDomains[id]; !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	var result []VM
+	for vmID, attachments := range m.vmDiskAttachmentsByVM {
+		for _, attachment := range attachments {
+			if disk, ok := m.disks[attachment.diskID]; ok && disk.onStorageDomain(id) {
+				result = append(result, m.vms[vmID])
+				break
+			}
+		}
+	}
+	for _, exported := range m.exportedVMs[id] {
+		result = append(result, exported.vm)
+	}
+	return result, nil
+}
+
+func (m *mockClient) ListUnregisteredStorageDomainVMs(id StorageDomainID, _ ...RetryStrategy) ([]VM, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return nil, newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if err := m.checkStorageDomainActive(sd); err != nil {
+		return nil, err
+	}
+	result := make([]VM, len(m.unregisteredVMs[id]))
+	i := 0
+	for _, unregistered := range m.unregisteredVMs[id] {
+		result[i] = unregistered.vm
+		i++
+	}
+	return result, nil
+}
@@ -0,0 +1,355 @@
+// This file contains tests for storage domain management in the mock client, as the live tests cannot provision
+// storage. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestMockStorageDomainLifecycle(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, datacenterID := mockStorageDomainTestIDs(t, client)
+	params := CreateStorageDomainParams().
+		MustWithNFS("192.0.2.1", "/exports/lab").
+		MustWithNFSVersion(NFSVersionV42)
+
+	sd, err := client.CreateStorageDomain(hostID, "lab", StorageDomainTypeNFS, params)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain (%v)", err)
+	}
+	if sd.Status() != StorageDomainStatusUnattached {
+		t.Fatalf("Incorrect status for new storage domain: %s", sd.Status())
+	}
+	if sd.Function() != StorageDomainFunctionData {
+		t.Fatalf("Incorrect function for new storage domain: %s", sd.Function())
+	}
+	if _, err := client.CreateDisk(sd.ID(), ImageFormatRaw, 1024*1024, nil); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Creating a disk on an unattached storage domain did not return an EConflict error (%v)", err)
+	}
+
+	if err := sd.Attach(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain (%v)", err)
+	}
+	sd, err = sd.WaitForStatus(StorageDomainStatusActive)
+	if err != nil {
+		t.Fatalf("Failed to wait for storage domain to become active (%v)", err)
+	}
+	if ids := sd.DatacenterIDs(); len(ids) != 1 || ids[0] != datacenterID {
+		t.Fatalf("Incorrect datacenters for attached storage domain: %v", ids)
+	}
+	if _, err := client.CreateDisk(sd.ID(), ImageFormatRaw, 1024*1024, nil); err != nil {
+		t.Fatalf("Failed to create disk on active storage domain (%v)", err)
+	}
+
+	if err := sd.Detach(datacenterID); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Detaching an active storage domain did not return an EConflict error (%v)", err)
+	}
+	if err := sd.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusMaintenance); err != nil {
+		t.Fatalf("Failed to wait for storage domain maintenance (%v)", err)
+	}
+	if err := sd.Activate(datacenterID); err != nil {
+		t.Fatalf("Failed to activate storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusActive); err != nil {
+		t.Fatalf("Failed to wait for storage domain to become active again (%v)", err)
+	}
+	if err := sd.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusMaintenance); err != nil {
+		t.Fatalf("Failed to wait for storage domain maintenance (%v)", err)
+	}
+	if err := sd.Detach(datacenterID); err != nil {
+		t.Fatalf("Failed to detach storage domain (%v)", err)
+	}
+	sd, err = sd.WaitForStatus(StorageDomainStatusUnattached)
+	if err != nil {
+		t.Fatalf("Failed to wait for storage domain to be detached (%v)", err)
+	}
+	if ids := sd.DatacenterIDs(); len(ids) != 0 {
+		t.Fatalf("Detached storage domain is still attached to datacenters: %v", ids)
+	}
+
+	if err := sd.Remove(hostID, true); err != nil {
+		t.Fatalf("Failed to remove storage domain (%v)", err)
+	}
+	if _, err := client.GetStorageDomain(sd.ID()); !HasErrorCode(err, ENotFound) {
+		t.Fatalf("Getting a removed storage domain did not return an ENotFound error (%v)", err)
+	}
+	if _, err := client.ImportStorageDomain(hostID, "lab", StorageDomainTypeNFS, params); !HasErrorCode(err, ENotFound) {
+		t.Fatalf("Importing a formatted storage domain did not return an ENotFound error (%v)", err)
+	}
+	if _, err := client.CreateStorageDomain(hostID, "lab", StorageDomainTypeNFS, params); err != nil {
+		t.Fatalf("Failed to create storage domain on formatted storage (%v)", err)
+	}
+}
+
+func TestMockStorageDomainImport(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, _ := mockStorageDomainTestIDs(t, client)
+	params := CreateStorageDomainParams().
+		MustWithISCSIConnection(ISCSIConnection{Address: "192.0.2.1", Target: "iqn.2003-01.org.example:lab"}).
+		MustWithLUNIDs("36001405aaaa", "36001405bbbb")
+
+	sd, err := client.CreateStorageDomain(hostID, "lab", StorageDomainTypeISCSI, params)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain (%v)", err)
+	}
+	if _, err := client.CreateStorageDomain(hostID, "lab-2", StorageDomainTypeISCSI, params); !HasErrorCode(
+		err,
+		EConflict,
+	) {
+		t.Fatalf("Creating a second storage domain on the same LUNs did not return an EConflict error (%v)", err)
+	}
+	if err := client.RemoveStorageDomain(sd.ID(), hostID, false); err != nil {
+		t.Fatalf("Failed to remove storage domain (%v)", err)
+	}
+	if _, err := client.CreateStorageDomain(hostID, "lab", StorageDomainTypeISCSI, params); !HasErrorCode(
+		err,
+		EConflict,
+	) {
+		t.Fatalf("Creating a storage domain over an unformatted one did not return an EConflict error (%v)", err)
+	}
+
+	imported, err := client.ImportStorageDomain(
+		hostID,
+		"imported",
+		StorageDomainTypeISCSI,
+		CreateStorageDomainParams().
+			MustWithISCSIConnection(ISCSIConnection{Address: "192.0.2.1", Target: "iqn.2003-01.org.example:lab"}).
+			MustWithLUNIDs("36001405bbbb", "36001405aaaa"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to import storage domain (%v)", err)
+	}
+	if imported.ID() != sd.ID() {
+		t.Fatalf("The imported storage domain has a different ID (%s instead of %s).", imported.ID(), sd.ID())
+	}
+	if imported.Name() != "imported" {
+		t.Fatalf("Incorrect name for imported storage domain: %s", imported.Name())
+	}
+	if imported.Status() != StorageDomainStatusUnattached {
+		t.Fatalf("Incorrect status for imported storage domain: %s", imported.Status())
+	}
+	if err := client.DestroyStorageDomain(imported.ID()); err != nil {
+		t.Fatalf("Failed to destroy storage domain (%v)", err)
+	}
+	if _, err := client.ImportStorageDomain(hostID, "imported", StorageDomainTypeISCSI, params); err != nil {
+		t.Fatalf("Failed to import destroyed storage domain (%v)", err)
+	}
+}
+
+func TestMockStorageDomainDeactivateWithRunningVM(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	_, datacenterID := mockStorageDomainTestIDs(t, client)
+	sdID := testStorageDomainID(client)
+	clusters, err := client.ListClusters()
+	if err != nil {
+		t.Fatalf("Failed to list clusters (%v)", err)
+	}
+
+	disk, err := client.CreateDisk(sdID, ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	vm, err := client.CreateVM(clusters[0].ID(), DefaultBlankTemplateID, "test", nil)
+	if err != nil {
+		t.Fatalf("Failed to create VM (%v)", err)
+	}
+	if _, err := vm.AttachDisk(disk.ID(), DiskInterfaceVirtIO, nil); err != nil {
+		t.Fatalf("Failed to attach disk (%v)", err)
+	}
+	if err := vm.Start(); err != nil {
+		t.Fatalf("Failed to start VM (%v)", err)
+	}
+
+	if err := client.DeactivateStorageDomain(sdID, datacenterID); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Deactivating a storage domain used by a running VM did not return an EConflict error (%v)", err)
+	}
+}
+
+func TestMockStorageDomainValidation(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, _ := mockStorageDomainTestIDs(t, client)
+
+	testCases := map[string]struct {
+		storageType StorageDomainType
+		params      CreateStorageDomainParameters
+	}{
+		"NFS without address": {
+			StorageDomainTypeNFS,
+			CreateStorageDomainParams().MustWithLocalPath("/data"),
+		},
+		"local without path": {
+			StorageDomainTypeLocalFS,
+			CreateStorageDomainParams(),
+		},
+		"iSCSI without connection": {
+			StorageDomainTypeISCSI,
+			CreateStorageDomainParams().MustWithLUNIDs("36001405aaaa"),
+		},
+		"FCP with iSCSI connection": {
+			StorageDomainTypeFCP,
+			CreateStorageDomainParams().
+				MustWithLUNIDs("36001405aaaa").
+				MustWithISCSIConnection(ISCSIConnection{Address: "192.0.2.1", Target: "iqn.2003-01.org.example:lab"}),
+		},
+		"unsupported type": {
+			StorageDomainTypeGlance,
+			CreateStorageDomainParams(),
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			_, err := client.CreateStorageDomain(hostID, "lab", testCase.storageType, testCase.params)
+			if !HasErrorCode(err, EBadArgument) {
+				t.Fatalf("Invalid storage domain parameters did not return an EBadArgument error (%v)", err)
+			}
+		})
+	}
+}
+
+func TestMockStorageDomainRegisterAfterImport(t *testing.T) {
+	t.Parallel()
+	client := NewMock()
+	hostID, datacenterID := mockStorageDomainTestIDs(t, client)
+	clusters, err := client.ListClusters()
+	if err != nil {
+		t.Fatalf("Failed to list clusters (%v)", err)
+	}
+	params := CreateStorageDomainParams().MustWithNFS("192.0.2.1", "/exports/dr")
+
+	sd, err := client.CreateStorageDomain(hostID, "dr", StorageDomainTypeNFS, params)
+	if err != nil {
+		t.Fatalf("Failed to create storage domain (%v)", err)
+	}
+	mockStorageDomainAttach(t, sd, datacenterID)
+	vmDisk, err := client.CreateDisk(sd.ID(), ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	floatingDisk, err := client.CreateDisk(sd.ID(), ImageFormatRaw, 1024*1024, nil)
+	if err != nil {
+		t.Fatalf("Failed to create disk (%v)", err)
+	}
+	vm, err := client.CreateVM(clusters[0].ID(), DefaultBlankTemplateID, "dr-vm", nil)
+	if err != nil {
+		t.Fatalf("Failed to create VM (%v)", err)
+	}
+	if _, err := vm.AttachDisk(vmDisk.ID(), DiskInterfaceVirtIO, nil); err != nil {
+		t.Fatalf("Failed to attach disk (%v)", err)
+	}
+	if vms, err := client.ListStorageDomainVMs(sd.ID()); err != nil || len(vms) != 1 || vms[0].ID() != vm.ID() {
+		t.Fatalf("Incorrect VMs listed on storage domain (%v)", err)
+	}
+	if disks, err := client.ListStorageDomainDisks(sd.ID()); err != nil || len(disks) != 2 {
+		t.Fatalf("Incorrect disks listed on storage domain (%v)", err)
+	}
+
+	mockStorageDomainDetach(t, sd, datacenterID)
+	if _, err := client.GetVM(vm.ID()); !HasErrorCode(err, ENotFound) {
+		t.Fatalf("The VM is still registered after detaching its storage domain (%v)", err)
+	}
+	if err := sd.Remove(hostID, false); err != nil {
+		t.Fatalf("Failed to remove storage domain (%v)", err)
+	}
+	sd, err = client.ImportStorageDomain(hostID, "dr", StorageDomainTypeNFS, params)
+	if err != nil {
+		t.Fatalf("Failed to import storage domain (%v)", err)
+	}
+	if _, err := client.ListUnregisteredStorageDomainVMs(sd.ID()); !HasErrorCode(err, EConflict) {
+		t.Fatalf("Listing unregistered VMs of an unattached storage domain did not return an EConflict error (%v)", err)
+	}
+	mockStorageDomainAttach(t, sd, datacenterID)
+
+	vms, err := client.ListUnregisteredStorageDomainVMs(sd.ID())
+	if err != nil {
+		t.Fatalf("Failed to list unregistered VMs (%v)", err)
+	}
+	if len(vms) != 1 || vms[0].ID() != vm.ID() {
+		t.Fatalf("Incorrect unregistered VMs: %v", vms)
+	}
+	disks, err := client.ListUnregisteredStorageDomainDisks(sd.ID())
+	if err != nil {
+		t.Fatalf("Failed to list unregistered disks (%v)", err)
+	}
+	if len(disks) != 1 || disks[0].ID() != floatingDisk.ID() {
+		t.Fatalf("Incorrect unregistered disks: %v", disks)
+	}
+
+	registeredVM, err := client.RegisterStorageDomainVM(sd.ID(), vm.ID(), clusters[0].ID())
+	if err != nil {
+		t.Fatalf("Failed to register VM (%v)", err)
+	}
+	if registeredVM.ID() != vm.ID() || registeredVM.Name() != "dr-vm" {
+		t.Fatalf("Incorrect registered VM: %s (%s)", registeredVM.ID(), registeredVM.Name())
+	}
+	attachments, err := registeredVM.ListDiskAttachments()
+	if err != nil {
+		t.Fatalf("Failed to list disk attachments of registered VM (%v)", err)
+	}
+	if len(attachments) != 1 || attachments[0].DiskID() != vmDisk.ID() {
+		t.Fatalf("The disks of the registered VM were not registered.")
+	}
+	registeredDisk, err := client.RegisterStorageDomainDisk(sd.ID(), floatingDisk.ID())
+	if err != nil {
+		t.Fatalf("Failed to register disk (%v)", err)
+	}
+	if registeredDisk.DiskProfileID() == nil {
+		t.Fatalf("The registered disk has no disk profile.")
+	}
+	if _, err := client.RegisterStorageDomainDisk(sd.ID(), floatingDisk.ID()); !HasErrorCode(err, ENotFound) {
+		t.Fatalf("Registering a disk twice did not return an ENotFound error (%v)", err)
+	}
+	if vms, err := client.ListUnregisteredStorageDomainVMs(sd.ID()); err != nil || len(vms) != 0 {
+		t.Fatalf("Registered VMs are still listed as unregistered (%v)", err)
+	}
+}
+
+// mockStorageDomainAttach attaches the storage domain to the datacenter and waits for it to become active.
+func mockStorageDomainAttach(t *testing.T, sd StorageDomain, datacenterID DatacenterID) {
+	if err := sd.Attach(datacenterID); err != nil {
+		t.Fatalf("Failed to attach storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusActive); err != nil {
+		t.Fatalf("Failed to wait for storage domain to become active (%v)", err)
+	}
+}
+
+// mockStorageDomainDetach puts the storage domain into maintenance and detaches it from the datacenter.
+func mockStorageDomainDetach(t *testing.T, sd StorageDomain, datacenterID DatacenterID) {
+	if err := sd.Deactivate(datacenterID); err != nil {
+		t.Fatalf("Failed to deactivate storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusMaintenance); err != nil {
+		t.Fatalf("Failed to wait for storage domain maintenance (%v)", err)
+	}
+	if err := sd.Detach(datacenterID); err != nil {
+		t.Fatalf("Failed to detach storage domain (%v)", err)
+	}
+	if _, err := sd.WaitForStatus(StorageDomainStatusUnattached); err != nil {
+		t.Fatalf("Failed to wait for storage domain to be detached (%v)", err)
+	}
+}
+
+// mockStorageDomainTestIDs returns the ID of a host and a datacenter to manage storage domains with.
+func mockStorageDomainTestIDs(t *testing.T, client MockClient) (HostID, DatacenterID) {
+	hosts, err := client.ListHosts()
+	if err != nil || len(hosts) == 0 {
+		t.Fatalf("Failed to list hosts (%v)", err)
+	}
+	datacenters, err := client.ListDatacenters()
+	if err != nil || len(datacenters) == 0 {
+		t.Fatalf("Failed to list datacenters (%v)", err)
+	}
+	return hosts[0].ID(), datacenters[0].ID()
+}
@@ -0,0 +1,276 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// mockStorageDomainTransitionTime is the time the mock takes to move a storage domain from an intermediate status,
+// such as StorageDomainStatusActivating, to the final status.
+const mockStorageDomainTransitionTime = 2 * time.Second
+
+// mockStorageLocation returns a key identifying the storage a storage domain resides on. Two storage domains cannot
+// share the same storage, and a storage domain removed without formatting remains on its storage under this key.
+func mockStorageLocation(hostID HostID, storageType StorageDomainType, params CreateStorageDomainParameters) string {
+	switch storageType {
+	case StorageDomainTypeNFS:
+		return fmt.Sprintf("%s:%s", params.Address(), params.Path())
+	case StorageDomainTypeLocalFS:
+		return fmt.Sprintf("%s on host %s", params.Path(), hostID)
+	default:
+		lunIDs := append([]string(nil), params.LUNIDs()...)
+		sort.Strings(lunIDs)
+		return fmt.Sprintf("%s LUNs %s", storageType, strings.Join(lunIDs, ", "))
+	}
+}
+
+// checkStorageDomainHost checks that the host used to access the storage exists and is up. The caller must hold the
+// lock.
+func (m *mockClient) checkStorageDomainHost(hostID HostID) error {
+	host, ok := m.hosts[hostID]
+	if !ok {
+		return newError(ENotFound, "host with ID %s not found", hostID)
+	}
+	if host.status != HostStatusUp {
+		return newError(EConflict, "host %s is in status %s, it must be up to access the storage", hostID, host.status)
+	}
+	return nil
+}
+
+// checkStorageDomainActive checks that disks can be placed on the storage domain. The caller must hold the lock.
+func (m *mockClient) checkStorageDomainActive(sd *storageDomain) error {
+	if sd.status != StorageDomainStatusActive {
+		return newError(
+			EConflict,
+			"storage domain %s is in status %s, it must be active to store disks",
+			sd.id,
+			sd.status,
+		)
+	}
+	return nil
+}
+
+// transitionStorageDomain puts the storage domain into the intermediate status and moves it to the final status
+// after a delay, as the oVirt Engine does. The update function, if passed, is applied together with the final
+// status. The caller must hold the lock.
+func (m *mockClient) transitionStorageDomain(
+	id StorageDomainID,
+	intermediate StorageDomainStatus,
+	final StorageDomainStatus,
+	update func(sd *storageDomain),
+) {
+	m.storageDomains[id] = m.storageDomains[id].withStatus(intermediate)
+	go func() {
+		time.Sleep(mockStorageDomainTransitionTime)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		sd, ok := m.storageDomains[id]
+		if !ok || sd.status != intermediate {
+			return
+		}
+		sd = sd.withStatus(final)
+		if update != nil {
+			update(sd)
+		}
+		m.storageDomains[id] = sd
+	}()
+}
+
+// attachedTo returns true if the storage domain is attached to the datacenter.
+func (s storageDomain) attachedTo(datacenterID DatacenterID) bool {
+	for _, id := range s.datacenterIDs {
+		if id == datacenterID {
+			return true
+		}
+	}
+	return false
+}
+
+// mockUnregisteredVM is a VM that is stored on a storage domain, but is not known to the oVirt Engine, for example
+// because the storage domain was detached. It can be registered again together with its disks and NICs.
+type mockUnregisteredVM struct {
+	vm          *vm
+	attachments []*diskAttachment
+	disks       []*diskWithData
+	nics        []*nic
+}
+
+// unregisterStorageDomainContents removes the VMs and disks stored on the storage domain from the mock and records
+// them as unregistered objects of the storage domain, as the oVirt Engine does when a data storage domain is
+// detached. The caller must hold the lock.
+func (m *mockClient) unregisterStorageDomainContents(id StorageDomainID) error {
+	if err := m.checkStorageDomainContentsUnregistrable(id); err != nil {
+		return err
+	}
+	if _, ok := m.unregisteredVMs[id]; !ok {
+		m.unregisteredVMs[id] = map[VMID]*mockUnregisteredVM{}
+	}
+	if _, ok := m.unregisteredDisks[id]; !ok {
+		m.unregisteredDisks[id] = map[DiskID]*diskWithData{}
+	}
+	for _, vmID := range m.storageDomainVMIDs(id) {
+		unregistered := &mockUnregisteredVM{
+			vm: m.vms[vmID],
+		}
+		for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+			unregistered.attachments = append(unregistered.attachments, attachment)
+			unregistered.disks = append(unregistered.disks, m.disks[attachment.diskID])
+			m.removeDiskAttachmentByDisk(attachment)
+			delete(m.disks, attachment.diskID)
+		}
+		for nicID, nic := range m.nics {
+			if nic.vmid == vmID {
+				unregistered.nics = append(unregistered.nics, nic)
+				delete(m.nics, nicID)
+			}
+		}
+		m.unregisteredVMs[id][vmID] = unregistered
+		delete(m.vmIPs, vmID)
+		delete(m.vmDiskAttachmentsByVM, vmID)
+		delete(m.graphicsConsolesByVM, vmID)
+		delete(m.cdromsByVM, vmID)
+		delete(m.snapshots, vmID)
+		delete(m.vms, vmID)
+	}
+	for diskID, disk := range m.disks {
+		if disk.onStorageDomain(id) {
+			m.unregisteredDisks[id][diskID] = disk
+			delete(m.disks, diskID)
+		}
+	}
+	return nil
+}
+
+// checkStorageDomainContentsUnregistrable checks that all VMs and disks on the storage domain can be moved to the
+// unregistered objects of the storage domain. The caller must hold the lock.
+func (m *mockClient) checkStorageDomainContentsUnregistrable(id StorageDomainID) error {
+	for _, disk := range m.disks {
+		if !disk.onStorageDomain(id) {
+			continue
+		}
+		if len(disk.storageDomainIDs) > 1 {
+			return newError(EConflict, "disk %s on storage domain %s also resides on other storage domains", disk.id, id)
+		}
+		if attachment, ok := m.templateDiskAttachmentsByDisk[disk.id]; ok {
+			return newError(
+				EConflict,
+				"disk %s on storage domain %s is used by template %s, remove the template first",
+				disk.id,
+				id,
+				attachment.templateID,
+			)
+		}
+		if len(m.vmDiskAttachmentsByDisk[disk.id]) > 1 {
+			return newError(EConflict, "disk %s on storage domain %s is attached to multiple VMs", disk.id, id)
+		}
+	}
+	for _, vmID := range m.storageDomainVMIDs(id) {
+		if status := m.vms[vmID].status; status != VMStatusDown {
+			return newError(EConflict, "VM %s on storage domain %s is in status %s, it must be down", vmID, id, status)
+		}
+		for _, attachment := range m.vmDiskAttachmentsByVM[vmID] {
+			if !m.disks[attachment.diskID].onStorageDomain(id) {
+				return newError(
+					EConflict,
+					"VM %s has disks on storage domain %s and on other storage domains",
+					vmID,
+					id,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// storageDomainVMIDs returns the IDs of the VMs with at least one disk on the storage domain. The caller must hold
+// the lock.
+func (m *mockClient) storageDomainVMIDs(id StorageDomainID) []VMID {
+	var result []VMID
+	for vmID, attachments := range m.vmDiskAttachmentsByVM {
+		for _, attachment := range attachments {
+			if disk, ok := m.disks[attachment.diskID]; ok && disk.onStorageDomain(id) {
+				result = append(result, vmID)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// storageDomainWithUsage returns a copy of the storage domain with the space used by the disks on it. The stored
+// storage domains hold the figures of an empty storage domain, so the usage is always in line with the disks. Disks
+// of unregistered VMs, unregistered disks and exported VMs and templates still take up space. The caller must hold
+// the lock.
+func (m *mockClient) storageDomainWithUsage(sd *storageDomain) *storageDomain {
+	var used, committed uint64
+	addDisk := func(disk *diskWithData) {
+		used += disk.totalSize
+		committed += disk.provisionedSize
+	}
+	for _, disk := range m.disks {
+		if disk.onStorageDomain(sd.id) {
+			addDisk(disk)
+		}
+	}
+	for _, disk := range m.unregisteredDisks[sd.id] {
+		addDisk(disk)
+	}
+	for _, unregistered := range m.unregisteredVMs[sd.id] {
+		for _, disk := range unregistered.disks {
+			addDisk(disk)
+		}
+	}
+	for _, exported := range m.exportedVMs[sd.id] {
+		for _, disk := range exported.disks {
+			addDisk(disk)
+		}
+	}
+	for _, exported := range m.exportedTemplates[sd.id] {
+		for _, disk := range exported.disks {
+			addDisk(disk)
+		}
+	}
+	result := *sd
+	result.used += used
+	result.committed += committed
+	if used > result.available {
+		used = result.available
+	}
+	result.available -= used
+	return &result
+}
+
+// electMasterStorageDomain makes an active data storage domain the master storage domain of the datacenter if the
+// datacenter has none, ignoring the storage domain with the excluded ID. The caller must hold the lock.
+func (m *mockClient) electMasterStorageDomain(datacenterID DatacenterID, excluded StorageDomainID) {
+	var candidate *storageDomain
+	for _, sd := range m.storageDomains {
+		if sd.id == excluded || sd.function != StorageDomainFunctionData || !sd.attachedTo(datacenterID) {
+			continue
+		}
+		if sd.master {
+			return
+		}
+		if candidate == nil && sd.status == StorageDomainStatusActive && !sd.backup {
+			candidate = sd
+		}
+	}
+	if candidate != nil {
+		master := candidate.withStatus(candidate.status)
+		master.master = true
+		m.storageDomains[master.id] = master
+	}
+}
+
+// hasMasterStorageDomain returns true if a storage domain other than the excluded one is the master storage domain
+// of the datacenter. The caller must hold the lock.
+func (m *mockClient) hasMasterStorageDomain(datacenterID DatacenterID, excluded StorageDomainID) bool {
+	for _, sd := range m.storageDomains {
+		if sd.id != excluded && sd.master && sd.attachedTo(datacenterID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"net"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) RegisterStorageDomainDisk(
+	id StorageDomainID,
+	diskID DiskID,
+	retries ...RetryStrategy,
+) (result Disk, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("registering disk %s from storage domain %s", diskID, id),
+		o.logger,
+		o.requestHooks("RegisterStorageDomainDisk"),
+		retries,
+		func() error {
+			sdkDisk, err := ovirtsdk4.NewDiskBuilder().Id(string(diskID)).Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build disk %s", diskID)
+			}
+			response, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				DisksService().
+				Add().
+				Unregistered(true).
+				Disk(sdkDisk).
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.Disk()
+			if !ok {
+				return newError(EFieldMissing, "no disk returned after registering disk %s", diskID)
+			}
+			result, err = convertSDKDisk(sdkObject, o)
+			if err != nil {
+				return wrap(err, EBug, "failed to convert disk %s", diskID)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (o *oVirtClient) RegisterStorageDomainVM(
+	id StorageDomainID,
+	vmID VMID,
+	clusterID ClusterID,
+	retries ...RetryStrategy,
+) (VM, error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err := retry(
+		fmt.Sprintf("registering VM %s from storage domain %s in cluster %s", vmID, id, clusterID),
+		o.logger,
+		o.requestHooks("RegisterStorageDomainVM"),
+		retries,
+		func() error {
+			cluster, err := ovirtsdk4.NewClusterBuilder().Id(string(clusterID)).Build()
+			if err != nil {
+				return wrap(err, EBug, "failed to build cluster %s", clusterID)
+			}
+			_, err = o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				VmsService().
+				VmService(string(vmID)).
+				Register().
+				Cluster(cluster).
+				Send()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return o.GetVM(vmID, retries...)
+}
+
+func (m *mockClient) RegisterStorageDomainDisk(id StorageDomainID, diskID DiskID, _ ...RetryStrategy) (Disk, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainRegistration(id); err != nil {
+		return nil, err
+	}
+	disk, ok := m.unregisteredDisks[id][diskID]
+	if !ok {
+		return nil, newError(ENotFound, "unregistered disk %s not found on storage domain %s", diskID, id)
+	}
+	if _, ok := m.disks[diskID]; ok {
+		return nil, newError(EConflict, "a disk with the ID %s is already registered", diskID)
+	}
+	registered := m.registerDisk(id, disk)
+	delete(m.unregisteredDisks[id], diskID)
+	return registered, nil
+}
+
+func (m *mockClient) RegisterStorageDomainVM(
+	id StorageDomainID,
+	vmID VMID,
+	clusterID ClusterID,
+	_ ...RetryStrategy,
+) (VM, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkStorageDomainRegistration(id); err != nil {
+		return nil, err
+	}
+	if _, ok := m.clusters[clusterID]; !ok {
+		return nil, newError(ENotFound, "cluster with ID %s not found", clusterID)
+	}
+	unregistered, ok := m.unregisteredVMs[id][vmID]
+	if !ok {
+		return nil, newError(ENotFound, "unregistered VM %s not found on storage domain %s", vmID, id)
+	}
+	if _, ok := m.vms[vmID]; ok {
+		return nil, newError(EConflict, "a VM with the ID %s is already registered", vmID)
+	}
+	for _, existing := range m.vms {
+		if existing.name == unregistered.vm.name {
+			return nil, newError(EConflict, "A VM with the name \"%s\" already exists.", existing.name)
+		}
+	}
+
+	registered := *unregistered.vm
+	registered.clusterID = clusterID
+	registered.status = VMStatusDown
+	registered.hostID = nil
+	if _, ok := m.templates[registered.templateID]; !ok {
+		registered.templateID = DefaultBlankTemplateID
+	}
+	m.vms[vmID] = &registered
+	m.vmDiskAttachmentsByVM[vmID] = make(map[DiskAttachmentID]*diskAttachment, len(unregistered.attachments))
+	for i, attachment := range unregistered.attachments {
+		m.registerDisk(id, unregistered.disks[i])
+		m.vmDiskAttachmentsByVM[vmID][attachment.id] = attachment
+		m.addDiskAttachmentByDisk(attachment.diskID, attachment)
+	}
+	for _, nic := range unregistered.nics {
+		m.nics[nic.id] = nic
+	}
+	m.vmIPs[vmID] = map[string][]net.IP{}
+	m.addGraphicsConsoles(&registered)
+	m.addCdroms(&registered)
+	delete(m.unregisteredVMs[id], vmID)
+	return &registered, nil
+}
+
+// checkStorageDomainRegistration checks that objects can be registered from the storage domain. The caller must hold
+// the lock.
+func (m *mockClient) checkStorageDomainRegistration(id StorageDomainID) error {
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	return m.checkStorageDomainActive(sd)
+}
+
+// registerDisk adds an unregistered disk back to the mock, using the default disk profile of the storage domain as
+// the disk profiles are not stored on the storage. The caller must hold the lock.
+func (m *mockClient) registerDisk(id StorageDomainID, disk *diskWithData) *diskWithData {
+	registered := *disk
+	registered.storageDomainIDs = []StorageDomainID{id}
+	registered.diskProfileID = nil
+	if diskProfileID, ok := m.defaultDiskProfiles[id]; ok {
+		registered.diskProfileID = &diskProfileID
+	}
+	m.disks[registered.id] = &registered
+	return &registered
+}
@@ -0,0 +1,149 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveStorageDomain(
+	id StorageDomainID,
+	hostID HostID,
+	format bool,
+	retries ...RetryStrategy,
+) (err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("removing storage domain %s", id),
+		o.logger,
+		o.requestHooks("RemoveStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Remove().
+				Host(string(hostID)).
+				Format(format).
+				Send()
+			return err
+		})
+	return
+}
+
+func (o *oVirtClient) DestroyStorageDomain(id StorageDomainID, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	err = retry(
+		fmt.Sprintf("destroying storage domain %s", id),
+		o.logger,
+		o.requestHooks("DestroyStorageDomain"),
+		retries,
+		func() error {
+			_, err := o.conn.SystemService().
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Remove().
+				Destroy(true).
+				Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) RemoveStorageDomain(id StorageDomainID, hostID HostID, format bool, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	if err := m.checkStorageDomainHost(hostID); err != nil {
+		return err
+	}
+	if sd.status != StorageDomainStatusUnattached {
+		return newError(
+			EConflict,
+			"storage domain %s is in status %s, it must be detached before it can be removed",
+			id,
+			sd.status,
+		)
+	}
+	return m.removeStorageDomain(sd, !format)
+}
+
+func (m *mockClient) DestroyStorageDomain(id StorageDomainID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sd, ok := m.storageDomains[id]
+	if !ok {
+		return newError(ENotFound, "storage domain with ID %s not found", id)
+	}
+	switch sd.status {
+	case StorageDomainStatusUnattached,
+		StorageDomainStatusMaintenance,
+		StorageDomainStatusInactive,
+		StorageDomainStatusUnknown:
+	default:
+		return newError(
+			EConflict,
+			"storage domain %s is in status %s, it must be in maintenance to be destroyed",
+			id,
+			sd.status,
+		)
+	}
+	return m.removeStorageDomain(sd, true)
+}
+
+// removeStorageDomain removes the storage domain, its disks and its disk profiles from the mock. If keepData is true,
+// the storage domain can be imported again from its storage together with its unregistered VMs and disks. The caller
+// must hold the lock.
+func (m *mockClient) removeStorageDomain(sd *storageDomain, keepData bool) error {
+	for _, disk := range m.disks {
+		if !disk.onStorageDomain(sd.id) {
+			continue
+		}
+		if len(m.vmDiskAttachmentsByDisk[disk.id]) > 0 {
+			return newError(EConflict, "disk %s on storage domain %s is attached to a VM", disk.id, sd.id)
+		}
+		if _, ok := m.templateDiskAttachmentsByDisk[disk.id]; ok {
+			return newError(EConflict, "disk %s on storage domain %s is used by a template", disk.id, sd.id)
+		}
+	}
+	for id, disk := range m.disks {
+		if !disk.onStorageDomain(sd.id) {
+			continue
+		}
+		if len(disk.storageDomainIDs) == 1 {
+			delete(m.disks, id)
+			continue
+		}
+		var storageDomainIDs []StorageDomainID
+		for _, storageDomainID := range disk.storageDomainIDs {
+			if storageDomainID != sd.id {
+				storageDomainIDs = append(storageDomainIDs, storageDomainID)
+			}
+		}
+		disk.storageDomainIDs = storageDomainIDs
+	}
+	for id, profile := range m.diskProfiles {
+		if profile.storageDomainID == sd.id {
+			delete(m.diskProfiles, id)
+		}
+	}
+	delete(m.defaultDiskProfiles, sd.id)
+	delete(m.storageDomains, sd.id)
+	if location, ok := m.storageDomainLocations[sd.id]; ok {
+		delete(m.storageDomainLocations, sd.id)
+		if keepData {
+			orphan := sd.withStatus(StorageDomainStatusUnattached)
+			orphan.datacenterIDs = nil
+			m.orphanedStorageDomains[location] = orphan
+			return nil
+		}
+	}
+	delete(m.unregisteredDisks, sd.id)
+	delete(m.unregisteredVMs, sd.id)
+	delete(m.exportedVMs, sd.id)
+	delete(m.exportedTemplates, sd.id)
+	return nil
+}
@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveDiskFromStorageDomain(id StorageDomainID, diskID Dis
 	err = retry(
 		fmt.Sprintf("removing disk %s from storage domain %s", diskID, id),
 		o.logger,
+		o.requestHooks("RemoveDiskFromStorageDomain"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().StorageDomainsService().
@@ -0,0 +1,47 @@
+package ovirtclient_test
+
+import (
+	"testing"
+)
+
+func TestStorageDomainInventory(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	client := helper.GetClient()
+
+	vm := assertCanCreateVM(t, helper, helper.GenerateTestResourceName(t), nil)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanAttachDisk(t, vm, disk)
+
+	disks, err := client.ListStorageDomainDisks(helper.GetStorageDomainID())
+	if err != nil {
+		t.Fatalf("Failed to list disks on storage domain (%v)", err)
+	}
+	foundDisk := false
+	for _, d := range disks {
+		if d.ID() == disk.ID() {
+			foundDisk = true
+		}
+	}
+	if !foundDisk {
+		t.Fatalf("Disk %s not found on storage domain %s.", disk.ID(), helper.GetStorageDomainID())
+	}
+
+	vms, err := client.ListStorageDomainVMs(helper.GetStorageDomainID())
+	if err != nil {
+		t.Fatalf("Failed to list VMs on storage domain (%v)", err)
+	}
+	foundVM := false
+	for _, v := range vms {
+		if v.ID() == vm.ID() {
+			foundVM = true
+		}
+	}
+	if !foundVM {
+		t.Fatalf("VM %s not found on storage domain %s.", vm.ID(), helper.GetStorageDomainID())
+	}
+
+	if _, err := client.ListStorageDomainTemplates(helper.GetStorageDomainID()); err != nil {
+		t.Fatalf("Failed to list templates on storage domain (%v)", err)
+	}
+}
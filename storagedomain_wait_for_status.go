@@ -0,0 +1,114 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForStorageDomainStatus(
+	id StorageDomainID,
+	status StorageDomainStatus,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for storage domain %s to enter status \"%s\"", id, status),
+		o.logger,
+		o.waitHooks("WaitForStorageDomainStatus"),
+		retries,
+		func() error {
+			// The nested calls use their own read timeouts, so a single poll cannot block for the whole wait.
+			result, err = o.GetStorageDomain(id)
+			if err != nil {
+				return err
+			}
+			// The status of attached storage domains is only reported in the context of the datacenter.
+			if datacenterIDs := result.DatacenterIDs(); len(datacenterIDs) > 0 {
+				result, err = o.getAttachedStorageDomain(id, datacenterIDs[0])
+				if err != nil {
+					return err
+				}
+			}
+			if result.Status() != status {
+				return newError(
+					EPending,
+					"Storage domain %s status is \"%s\", not \"%s\".",
+					id,
+					result.Status(),
+					status,
+				)
+			}
+			return nil
+		})
+	return
+}
+
+// getAttachedStorageDomain fetches a storage domain in the context of the datacenter it is attached to.
+func (o *oVirtClient) getAttachedStorageDomain(
+	id StorageDomainID,
+	datacenterID DatacenterID,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting storage domain %s in datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("GetAttachedStorageDomain"),
+		retries,
+		func() error {
+			response, err := o.conn.SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				StorageDomainsService().
+				StorageDomainService(string(id)).
+				Get().
+				Send()
+			if err != nil {
+				return err
+			}
+			sdkObject, ok := response.StorageDomain()
+			if !ok {
+				return newError(
+					ENotFound,
+					"no storage domain returned when getting storage domain %s in datacenter %s",
+					id,
+					datacenterID,
+				)
+			}
+			result, err = convertSDKStorageDomain(sdkObject, o)
+			if err != nil {
+				return wrap(err, EBug, "failed to convert storage domain %s", id)
+			}
+			return nil
+		})
+	return
+}
+
+func (m *mockClient) WaitForStorageDomainStatus(
+	id StorageDomainID,
+	status StorageDomainStatus,
+	retries ...RetryStrategy,
+) (result StorageDomain, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for storage domain %s to enter status \"%s\"", id, status),
+		nil,
+		nil,
+		retries,
+		func() error {
+			result, err = m.GetStorageDomain(id, retries...)
+			if err != nil {
+				return err
+			}
+			if result.Status() != status {
+				return newError(
+					EPending,
+					"Storage domain %s status is \"%s\", not \"%s\".",
+					id,
+					result.Status(),
+					status,
+				)
+			}
+			return nil
+		})
+	return
+}
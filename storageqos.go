@@ -0,0 +1,450 @@
+package ovirtclient
+
+import (
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// StorageQoSID is the identifier of a storage QoS entry.
+type StorageQoSID string
+
+// StorageQoSClient contains the functions to manage storage quality of service entries. Storage QoS entries belong
+// to a datacenter and limit the throughput and IOPS of the disks using a disk profile that references them.
+type StorageQoSClient interface {
+	// CreateStorageQoS creates a new storage QoS entry in the specified datacenter.
+	CreateStorageQoS(
+		datacenterID DatacenterID,
+		name string,
+		params StorageQoSParameters,
+		retries ...RetryStrategy,
+	) (StorageQoS, error)
+	// GetStorageQoS returns a single storage QoS entry from a datacenter.
+	GetStorageQoS(datacenterID DatacenterID, id StorageQoSID, retries ...RetryStrategy) (StorageQoS, error)
+	// ListStorageQoS lists the storage QoS entries of a datacenter. QoS entries of other types, such as network or
+	// CPU QoS, are not returned.
+	ListStorageQoS(datacenterID DatacenterID, retries ...RetryStrategy) ([]StorageQoS, error)
+	// UpdateStorageQoS changes the limits of a storage QoS entry. Parameters that are not set are left unchanged.
+	UpdateStorageQoS(
+		datacenterID DatacenterID,
+		id StorageQoSID,
+		params StorageQoSParameters,
+		retries ...RetryStrategy,
+	) (StorageQoS, error)
+	// RemoveStorageQoS removes a storage QoS entry from a datacenter. Disk profiles using the entry are left without
+	// limits.
+	RemoveStorageQoS(datacenterID DatacenterID, id StorageQoSID, retries ...RetryStrategy) error
+}
+
+// StorageQoSLimits contains the limits of a storage QoS entry. A limit of 0 means unlimited. The total throughput and
+// IOPS limits cannot be combined with the respective read and write limits.
+type StorageQoSLimits interface {
+	// MaxThroughput returns the maximum total throughput in MB/s.
+	MaxThroughput() uint64
+	// MaxReadThroughput returns the maximum read throughput in MB/s.
+	MaxReadThroughput() uint64
+	// MaxWriteThroughput returns the maximum write throughput in MB/s.
+	MaxWriteThroughput() uint64
+	// MaxIOPS returns the maximum total I/O operations per second.
+	MaxIOPS() uint64
+	// MaxReadIOPS returns the maximum read I/O operations per second.
+	MaxReadIOPS() uint64
+	// MaxWriteIOPS returns the maximum write I/O operations per second.
+	MaxWriteIOPS() uint64
+}
+
+// StorageQoSData is the core of StorageQoS, providing only data access functions.
+type StorageQoSData interface {
+	StorageQoSLimits
+
+	// ID returns the identifier of the storage QoS entry.
+	ID() StorageQoSID
+	// Name returns the name of the storage QoS entry. It is unique within the datacenter.
+	Name() string
+	// Description returns the description of the storage QoS entry.
+	Description() string
+	// DatacenterID returns the ID of the datacenter the storage QoS entry belongs to.
+	DatacenterID() DatacenterID
+}
+
+// StorageQoS is a set of throughput and IOPS limits that can be applied to disks via disk profiles.
+type StorageQoS interface {
+	StorageQoSData
+
+	// Update changes the limits of the storage QoS entry.
+	Update(params StorageQoSParameters, retries ...RetryStrategy) (StorageQoS, error)
+	// Remove removes the storage QoS entry.
+	Remove(retries ...RetryStrategy) error
+}
+
+// StorageQoSParameters contains the parameters for creating or updating a storage QoS entry. Each function returns
+// nil if the value is not set. When creating a storage QoS entry, unset limits are unlimited. When updating, unset
+// values are left unchanged.
+type StorageQoSParameters interface {
+	// Description returns the description of the storage QoS entry.
+	Description() *string
+	// MaxThroughput returns the maximum total throughput in MB/s.
+	MaxThroughput() *uint64
+	// MaxReadThroughput returns the maximum read throughput in MB/s.
+	MaxReadThroughput() *uint64
+	// MaxWriteThroughput returns the maximum write throughput in MB/s.
+	MaxWriteThroughput() *uint64
+	// MaxIOPS returns the maximum total I/O operations per second.
+	MaxIOPS() *uint64
+	// MaxReadIOPS returns the maximum read I/O operations per second.
+	MaxReadIOPS() *uint64
+	// MaxWriteIOPS returns the maximum write I/O operations per second.
+	MaxWriteIOPS() *uint64
+}
+
+// BuildableStorageQoSParameters is a buildable version of StorageQoSParameters.
+type BuildableStorageQoSParameters interface {
+	StorageQoSParameters
+
+	// WithDescription sets the description of the storage QoS entry.
+	WithDescription(description string) (BuildableStorageQoSParameters, error)
+	// MustWithDescription is identical to WithDescription, but panics instead of returning an error.
+	MustWithDescription(description string) BuildableStorageQoSParameters
+
+	// WithMaxThroughput sets the maximum total throughput in MB/s. Set it to 0 for unlimited.
+	WithMaxThroughput(mbps uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxThroughput is identical to WithMaxThroughput, but panics instead of returning an error.
+	MustWithMaxThroughput(mbps uint64) BuildableStorageQoSParameters
+
+	// WithMaxReadThroughput sets the maximum read throughput in MB/s. Set it to 0 for unlimited.
+	WithMaxReadThroughput(mbps uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxReadThroughput is identical to WithMaxReadThroughput, but panics instead of returning an error.
+	MustWithMaxReadThroughput(mbps uint64) BuildableStorageQoSParameters
+
+	// WithMaxWriteThroughput sets the maximum write throughput in MB/s. Set it to 0 for unlimited.
+	WithMaxWriteThroughput(mbps uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxWriteThroughput is identical to WithMaxWriteThroughput, but panics instead of returning an error.
+	MustWithMaxWriteThroughput(mbps uint64) BuildableStorageQoSParameters
+
+	// WithMaxIOPS sets the maximum total I/O operations per second. Set it to 0 for unlimited.
+	WithMaxIOPS(iops uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxIOPS is identical to WithMaxIOPS, but panics instead of returning an error.
+	MustWithMaxIOPS(iops uint64) BuildableStorageQoSParameters
+
+	// WithMaxReadIOPS sets the maximum read I/O operations per second. Set it to 0 for unlimited.
+	WithMaxReadIOPS(iops uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxReadIOPS is identical to WithMaxReadIOPS, but panics instead of returning an error.
+	MustWithMaxReadIOPS(iops uint64) BuildableStorageQoSParameters
+
+	// WithMaxWriteIOPS sets the maximum write I/O operations per second. Set it to 0 for unlimited.
+	WithMaxWriteIOPS(iops uint64) (BuildableStorageQoSParameters, error)
+	// MustWithMaxWriteIOPS is identical to WithMaxWriteIOPS, but panics instead of returning an error.
+	MustWithMaxWriteIOPS(iops uint64) BuildableStorageQoSParameters
+}
+
+// StorageQoSParams creates a builder for the parameters of creating or updating a storage QoS entry.
+func StorageQoSParams() BuildableStorageQoSParameters {
+	return &storageQoSParams{}
+}
+
+type storageQoSParams struct {
+	description        *string
+	maxThroughput      *uint64
+	maxReadThroughput  *uint64
+	maxWriteThroughput *uint64
+	maxIOPS            *uint64
+	maxReadIOPS        *uint64
+	maxWriteIOPS       *uint64
+}
+
+func (s *storageQoSParams) Description() *string {
+	return s.description
+}
+
+func (s *storageQoSParams) WithDescription(description string) (BuildableStorageQoSParameters, error) {
+	s.description = &description
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithDescription(description string) BuildableStorageQoSParameters {
+	builder, err := s.WithDescription(description)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxThroughput() *uint64 {
+	return s.maxThroughput
+}
+
+func (s *storageQoSParams) WithMaxThroughput(mbps uint64) (BuildableStorageQoSParameters, error) {
+	s.maxThroughput = &mbps
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxThroughput(mbps uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxThroughput(mbps)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxReadThroughput() *uint64 {
+	return s.maxReadThroughput
+}
+
+func (s *storageQoSParams) WithMaxReadThroughput(mbps uint64) (BuildableStorageQoSParameters, error) {
+	s.maxReadThroughput = &mbps
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxReadThroughput(mbps uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxReadThroughput(mbps)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxWriteThroughput() *uint64 {
+	return s.maxWriteThroughput
+}
+
+func (s *storageQoSParams) WithMaxWriteThroughput(mbps uint64) (BuildableStorageQoSParameters, error) {
+	s.maxWriteThroughput = &mbps
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxWriteThroughput(mbps uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxWriteThroughput(mbps)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxIOPS() *uint64 {
+	return s.maxIOPS
+}
+
+func (s *storageQoSParams) WithMaxIOPS(iops uint64) (BuildableStorageQoSParameters, error) {
+	s.maxIOPS = &iops
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxIOPS(iops uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxIOPS(iops)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxReadIOPS() *uint64 {
+	return s.maxReadIOPS
+}
+
+func (s *storageQoSParams) WithMaxReadIOPS(iops uint64) (BuildableStorageQoSParameters, error) {
+	s.maxReadIOPS = &iops
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxReadIOPS(iops uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxReadIOPS(iops)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (s *storageQoSParams) MaxWriteIOPS() *uint64 {
+	return s.maxWriteIOPS
+}
+
+func (s *storageQoSParams) WithMaxWriteIOPS(iops uint64) (BuildableStorageQoSParameters, error) {
+	s.maxWriteIOPS = &iops
+	return s, nil
+}
+
+func (s *storageQoSParams) MustWithMaxWriteIOPS(iops uint64) BuildableStorageQoSParameters {
+	builder, err := s.WithMaxWriteIOPS(iops)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// validateStorageQoSLimits checks that the total limits are not combined with the respective read and write limits,
+// which the engine rejects.
+func validateStorageQoSLimits(limits StorageQoSLimits) error {
+	if limits.MaxThroughput() > 0 && (limits.MaxReadThroughput() > 0 || limits.MaxWriteThroughput() > 0) {
+		return newError(
+			EBadArgument,
+			"the total throughput limit cannot be combined with the read and write throughput limits",
+		)
+	}
+	if limits.MaxIOPS() > 0 && (limits.MaxReadIOPS() > 0 || limits.MaxWriteIOPS() > 0) {
+		return newError(
+			EBadArgument,
+			"the total IOPS limit cannot be combined with the read and write IOPS limits",
+		)
+	}
+	return nil
+}
+
+// buildSDKStorageQoS creates the SDK object for creating or updating a storage QoS entry. The name is only set if it
+// is not empty.
+func buildSDKStorageQoS(name string, params StorageQoSParameters) (*ovirtsdk.Qos, error) {
+	builder := ovirtsdk.NewQosBuilder().Type(ovirtsdk.QOSTYPE_STORAGE)
+	if name != "" {
+		builder.Name(name)
+	}
+	if params == nil {
+		return builder.Build()
+	}
+	if description := params.Description(); description != nil {
+		builder.Description(*description)
+	}
+	for _, limit := range []struct {
+		value *uint64
+		set   func(int64) *ovirtsdk.QosBuilder
+	}{
+		{params.MaxThroughput(), builder.MaxThroughput},
+		{params.MaxReadThroughput(), builder.MaxReadThroughput},
+		{params.MaxWriteThroughput(), builder.MaxWriteThroughput},
+		{params.MaxIOPS(), builder.MaxIops},
+		{params.MaxReadIOPS(), builder.MaxReadIops},
+		{params.MaxWriteIOPS(), builder.MaxWriteIops},
+	} {
+		if limit.value != nil {
+			limit.set(int64(*limit.value))
+		}
+	}
+	return builder.Build()
+}
+
+func convertSDKStorageQoS(sdkObject *ovirtsdk.Qos, datacenterID DatacenterID, client Client) (StorageQoS, error) {
+	id, ok := sdkObject.Id()
+	if !ok {
+		return nil, newFieldNotFound("storage QoS", "ID")
+	}
+	name, ok := sdkObject.Name()
+	if !ok {
+		return nil, newFieldNotFound("storage QoS", "name")
+	}
+	description, _ := sdkObject.Description()
+	if sdkDatacenter, ok := sdkObject.DataCenter(); ok {
+		if sdkDatacenterID, ok := sdkDatacenter.Id(); ok {
+			datacenterID = DatacenterID(sdkDatacenterID)
+		}
+	}
+	result := &storageQoS{
+		client:       client,
+		id:           StorageQoSID(id),
+		name:         name,
+		description:  description,
+		datacenterID: datacenterID,
+	}
+	for _, limit := range []struct {
+		value func() (int64, bool)
+		field *uint64
+	}{
+		{sdkObject.MaxThroughput, &result.maxThroughput},
+		{sdkObject.MaxReadThroughput, &result.maxReadThroughput},
+		{sdkObject.MaxWriteThroughput, &result.maxWriteThroughput},
+		{sdkObject.MaxIops, &result.maxIOPS},
+		{sdkObject.MaxReadIops, &result.maxReadIOPS},
+		{sdkObject.MaxWriteIops, &result.maxWriteIOPS},
+	} {
+		// The engine reports unlimited values as missing or negative.
+		if value, ok := limit.value(); ok && value > 0 {
+			*limit.field = uint64(value)
+		}
+	}
+	return result, nil
+}
+
+type storageQoS struct {
+	client Client
+
+	id                 StorageQoSID
+	name               string
+	description        string
+	datacenterID       DatacenterID
+	maxThroughput      uint64
+	maxReadThroughput  uint64
+	maxWriteThroughput uint64
+	maxIOPS            uint64
+	maxReadIOPS        uint64
+	maxWriteIOPS       uint64
+}
+
+func (s *storageQoS) ID() StorageQoSID {
+	return s.id
+}
+
+func (s *storageQoS) Name() string {
+	return s.name
+}
+
+func (s *storageQoS) Description() string {
+	return s.description
+}
+
+func (s *storageQoS) DatacenterID() DatacenterID {
+	return s.datacenterID
+}
+
+func (s *storageQoS) MaxThroughput() uint64 {
+	return s.maxThroughput
+}
+
+func (s *storageQoS) MaxReadThroughput() uint64 {
+	return s.maxReadThroughput
+}
+
+func (s *storageQoS) MaxWriteThroughput() uint64 {
+	return s.maxWriteThroughput
+}
+
+func (s *storageQoS) MaxIOPS() uint64 {
+	return s.maxIOPS
+}
+
+func (s *storageQoS) MaxReadIOPS() uint64 {
+	return s.maxReadIOPS
+}
+
+func (s *storageQoS) MaxWriteIOPS() uint64 {
+	return s.maxWriteIOPS
+}
+
+func (s *storageQoS) Update(params StorageQoSParameters, retries ...RetryStrategy) (StorageQoS, error) {
+	return s.client.UpdateStorageQoS(s.datacenterID, s.id, params, retries...)
+}
+
+func (s *storageQoS) Remove(retries ...RetryStrategy) error {
+	return s.client.RemoveStorageQoS(s.datacenterID, s.id, retries...)
+}
+
+// withParams returns a copy of the storage QoS entry with the parameters applied. It is used by the mock client.
+func (s *storageQoS) withParams(params StorageQoSParameters) *storageQoS {
+	result := *s
+	if params == nil {
+		return &result
+	}
+	if description := params.Description(); description != nil {
+		result.description = *description
+	}
+	for _, limit := range []struct {
+		value *uint64
+		field *uint64
+	}{
+		{params.MaxThroughput(), &result.maxThroughput},
+		{params.MaxReadThroughput(), &result.maxReadThroughput},
+		{params.MaxWriteThroughput(), &result.maxWriteThroughput},
+		{params.MaxIOPS(), &result.maxIOPS},
+		{params.MaxReadIOPS(), &result.maxReadIOPS},
+		{params.MaxWriteIOPS(), &result.maxWriteIOPS},
+	} {
+		if limit.value != nil {
+			*limit.field = *limit.value
+		}
+	}
+	return &result
+}
@@ -0,0 +1,85 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) CreateStorageQoS(
+	datacenterID DatacenterID,
+	name string,
+	params StorageQoSParameters,
+	retries ...RetryStrategy,
+) (result StorageQoS, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if err := validateStorageQoSCreationParameters(name, params); err != nil {
+		return nil, err
+	}
+	sdkQoS, err := buildSDKStorageQoS(name, params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build storage QoS object")
+	}
+	err = retry(
+		fmt.Sprintf("creating storage QoS %s in datacenter %s", name, datacenterID),
+		o.logger,
+		o.requestHooks("CreateStorageQoS"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				QossService().
+				Add().
+				Qos(sdkQoS).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Qos()
+			if !ok {
+				return newFieldNotFound("response from storage QoS creation", "QoS")
+			}
+			result, e = convertSDKStorageQoS(sdkObject, datacenterID, o)
+			return e
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) CreateStorageQoS(
+	datacenterID DatacenterID,
+	name string,
+	params StorageQoSParameters,
+	_ ...RetryStrategy,
+) (StorageQoS, error) {
+	if err := validateStorageQoSCreationParameters(name, params); err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return nil, newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	for _, qos := range m.storageQoS {
+		if qos.datacenterID == datacenterID && qos.name == name {
+			return nil, newError(EConflict, "storage QoS with the name %s already exists in datacenter %s", name, datacenterID)
+		}
+	}
+	qos := (&storageQoS{
+		client:       m,
+		id:           StorageQoSID(m.GenerateUUID()),
+		name:         name,
+		datacenterID: datacenterID,
+	}).withParams(params)
+	m.storageQoS[qos.id] = qos
+	return qos, nil
+}
+
+func validateStorageQoSCreationParameters(name string, params StorageQoSParameters) error {
+	if name == "" {
+		return newError(EBadArgument, "name cannot be empty for storage QoS creation")
+	}
+	return validateStorageQoSLimits((&storageQoS{}).withParams(params))
+}
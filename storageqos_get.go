@@ -0,0 +1,57 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) GetStorageQoS(
+	datacenterID DatacenterID,
+	id StorageQoSID,
+	retries ...RetryStrategy,
+) (result StorageQoS, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	err = retry(
+		fmt.Sprintf("getting storage QoS %s in datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("GetStorageQoS"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				QossService().
+				QosService(string(id)).
+				Get().
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Qos()
+			if !ok {
+				return newError(ENotFound, "no QoS returned when getting storage QoS ID %s", id)
+			}
+			if qosType, ok := sdkObject.Type(); ok && qosType != ovirtsdk.QOSTYPE_STORAGE {
+				return newError(ENotFound, "QoS %s is a %s QoS, not a storage QoS", id, qosType)
+			}
+			result, e = convertSDKStorageQoS(sdkObject, datacenterID, o)
+			if e != nil {
+				return wrap(e, EBug, "failed to convert storage QoS %s", id)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) GetStorageQoS(datacenterID DatacenterID, id StorageQoSID, _ ...RetryStrategy) (StorageQoS, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if qos, ok := m.storageQoS[id]; ok && qos.datacenterID == datacenterID {
+		return qos, nil
+	}
+	return nil, newError(ENotFound, "storage QoS with ID %s not found in datacenter %s", id, datacenterID)
+}
@@ -0,0 +1,63 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) ListStorageQoS(datacenterID DatacenterID, retries ...RetryStrategy) (result []StorageQoS, err error) {
+	retries = defaultRetries(retries, defaultReadTimeouts(o))
+	result = []StorageQoS{}
+	err = retry(
+		fmt.Sprintf("listing storage QoS in datacenter %s", datacenterID),
+		o.logger,
+		o.requestHooks("ListStorageQoS"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				QossService().
+				List().
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObjects, ok := response.Qoss()
+			if !ok {
+				return nil
+			}
+			result = []StorageQoS{}
+			for i, sdkObject := range sdkObjects.Slice() {
+				if qosType, ok := sdkObject.Type(); !ok || qosType != ovirtsdk.QOSTYPE_STORAGE {
+					continue
+				}
+				qos, e := convertSDKStorageQoS(sdkObject, datacenterID, o)
+				if e != nil {
+					return wrap(e, EBug, "failed to convert storage QoS during listing item #%d", i)
+				}
+				result = append(result, qos)
+			}
+			return nil
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) ListStorageQoS(datacenterID DatacenterID, _ ...RetryStrategy) ([]StorageQoS, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.dataCenters[datacenterID]; !ok {
+		return nil, newError(ENotFound, "datacenter with ID %s not found", datacenterID)
+	}
+	result := []StorageQoS{}
+	for _, qos := range m.storageQoS {
+		if qos.datacenterID == datacenterID {
+			result = append(result, qos)
+		}
+	}
+	return result, nil
+}
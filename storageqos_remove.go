@@ -0,0 +1,45 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) RemoveStorageQoS(datacenterID DatacenterID, id StorageQoSID, retries ...RetryStrategy) error {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	return retry(
+		fmt.Sprintf("removing storage QoS %s from datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("RemoveStorageQoS"),
+		retries,
+		func() error {
+			_, err := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				QossService().
+				QosService(string(id)).
+				Remove().
+				Send()
+			return err
+		},
+	)
+}
+
+func (m *mockClient) RemoveStorageQoS(datacenterID DatacenterID, id StorageQoSID, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	qos, ok := m.storageQoS[id]
+	if !ok || qos.datacenterID != datacenterID {
+		return newError(ENotFound, "storage QoS with ID %s not found in datacenter %s", id, datacenterID)
+	}
+	delete(m.storageQoS, id)
+	for profileID, profile := range m.diskProfiles {
+		if profile.qosID != nil && *profile.qosID == id {
+			updated := *profile
+			updated.qosID = nil
+			m.diskProfiles[profileID] = &updated
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestStorageQoSCRUD(t *testing.T) {
+	helper := getHelper(t)
+	client := helper.GetClient()
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+
+	qos := assertCanCreateStorageQoS(
+		t,
+		helper,
+		datacenterID,
+		ovirtclient.StorageQoSParams().MustWithMaxThroughput(100).MustWithMaxIOPS(1000),
+	)
+	if qos.MaxThroughput() != 100 || qos.MaxIOPS() != 1000 {
+		t.Fatalf(
+			"Incorrect limits on new storage QoS (throughput: %d MB/s, IOPS: %d)",
+			qos.MaxThroughput(),
+			qos.MaxIOPS(),
+		)
+	}
+
+	qosList, err := client.ListStorageQoS(datacenterID)
+	if err != nil {
+		t.Fatalf("Failed to list storage QoS entries (%v)", err)
+	}
+	found := false
+	for _, item := range qosList {
+		if item.ID() == qos.ID() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Storage QoS %s not found in the list of the datacenter.", qos.ID())
+	}
+
+	updated, err := qos.Update(ovirtclient.StorageQoSParams().MustWithMaxIOPS(0).MustWithMaxReadIOPS(500))
+	if err != nil {
+		t.Fatalf("Failed to update storage QoS %s (%v)", qos.ID(), err)
+	}
+	if updated.MaxThroughput() != 100 || updated.MaxIOPS() != 0 || updated.MaxReadIOPS() != 500 {
+		t.Fatalf(
+			"Incorrect limits on updated storage QoS (throughput: %d MB/s, IOPS: %d, read IOPS: %d)",
+			updated.MaxThroughput(),
+			updated.MaxIOPS(),
+			updated.MaxReadIOPS(),
+		)
+	}
+
+	if err := updated.Remove(); err != nil {
+		t.Fatalf("Failed to remove storage QoS %s (%v)", qos.ID(), err)
+	}
+	if _, err := client.GetStorageQoS(datacenterID, qos.ID()); !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+		t.Fatalf("Fetching the removed storage QoS did not return an ENotFound error (%v)", err)
+	}
+}
+
+func TestStorageQoSRejectsConflictingLimits(t *testing.T) {
+	helper := getHelper(t)
+	datacenterID := assertCanFindTestDatacenter(t, helper)
+
+	_, err := helper.GetClient().CreateStorageQoS(
+		datacenterID,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.StorageQoSParams().MustWithMaxThroughput(100).MustWithMaxReadThroughput(50),
+	)
+	if err == nil {
+		t.Fatalf("Creating a storage QoS with both total and read throughput limits did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EBadArgument) {
+		t.Fatalf("Conflicting storage QoS limits did not result in an EBadArgument error (%v)", err)
+	}
+}
+
+func assertCanCreateStorageQoS(
+	t *testing.T,
+	helper ovirtclient.TestHelper,
+	datacenterID ovirtclient.DatacenterID,
+	params ovirtclient.StorageQoSParameters,
+) ovirtclient.StorageQoS {
+	qos, err := helper.GetClient().CreateStorageQoS(datacenterID, helper.GenerateTestResourceName(t), params)
+	if err != nil {
+		t.Fatalf("Failed to create storage QoS (%v)", err)
+	}
+	t.Cleanup(func() {
+		if err := qos.Remove(); err != nil && !ovirtclient.HasErrorCode(err, ovirtclient.ENotFound) {
+			t.Fatalf("Failed to remove storage QoS %s (%v)", qos.ID(), err)
+		}
+	})
+	return qos
+}
+
+// assertCanFindTestDatacenter returns the datacenter of the test cluster.
+func assertCanFindTestDatacenter(t *testing.T, helper ovirtclient.TestHelper) ovirtclient.DatacenterID {
+	datacenters, err := helper.GetClient().ListDatacenters()
+	if err != nil {
+		t.Fatalf("Failed to list datacenters (%v)", err)
+	}
+	for _, datacenter := range datacenters {
+		hasCluster, err := datacenter.HasCluster(helper.GetClusterID())
+		if err != nil {
+			t.Fatalf("Failed to list clusters of datacenter %s (%v)", datacenter.ID(), err)
+		}
+		if hasCluster {
+			return datacenter.ID()
+		}
+	}
+	t.Fatalf("No datacenter found for cluster %s.", helper.GetClusterID())
+	return ""
+}
@@ -0,0 +1,73 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) UpdateStorageQoS(
+	datacenterID DatacenterID,
+	id StorageQoSID,
+	params StorageQoSParameters,
+	retries ...RetryStrategy,
+) (result StorageQoS, err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	existing, err := o.GetStorageQoS(datacenterID, id, retries...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateStorageQoSLimits(existing.(*storageQoS).withParams(params)); err != nil {
+		return nil, err
+	}
+	sdkQoS, err := buildSDKStorageQoS("", params)
+	if err != nil {
+		return nil, wrap(err, EBug, "failed to build storage QoS object")
+	}
+	err = retry(
+		fmt.Sprintf("updating storage QoS %s in datacenter %s", id, datacenterID),
+		o.logger,
+		o.requestHooks("UpdateStorageQoS"),
+		retries,
+		func() error {
+			response, e := o.conn.
+				SystemService().
+				DataCentersService().
+				DataCenterService(string(datacenterID)).
+				QossService().
+				QosService(string(id)).
+				Update().
+				Qos(sdkQoS).
+				Send()
+			if e != nil {
+				return e
+			}
+			sdkObject, ok := response.Qos()
+			if !ok {
+				return newFieldNotFound("response from storage QoS update", "QoS")
+			}
+			result, e = convertSDKStorageQoS(sdkObject, datacenterID, o)
+			return e
+		},
+	)
+	return result, err
+}
+
+func (m *mockClient) UpdateStorageQoS(
+	datacenterID DatacenterID,
+	id StorageQoSID,
+	params StorageQoSParameters,
+	_ ...RetryStrategy,
+) (StorageQoS, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	qos, ok := m.storageQoS[id]
+	if !ok || qos.datacenterID != datacenterID {
+		return nil, newError(ENotFound, "storage QoS with ID %s not found in datacenter %s", id, datacenterID)
+	}
+	updated := qos.withParams(params)
+	if err := validateStorageQoSLimits(updated); err != nil {
+		return nil, err
+	}
+	m.storageQoS[id] = updated
+	return updated, nil
+}
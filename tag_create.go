@@ -13,6 +13,7 @@ func (o *oVirtClient) CreateTag(name string, params CreateTagParams, retries ...
 	err = retry(
 		"creating tag",
 		o.logger,
+		o.requestHooks("CreateTag"),
 		retries,
 		func() error {
 			tagBuilder := ovirtsdk.NewTagBuilder().Name(name)
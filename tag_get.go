@@ -11,6 +11,7 @@ func (o *oVirtClient) GetTag(id TagID, retries ...RetryStrategy) (result Tag, er
 	err = retry(
 		fmt.Sprintf("getting tag %s", id),
 		o.logger,
+		o.requestHooks("GetTag"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().TagsService().TagService(string(id)).Get().Send()
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListTags(retries ...RetryStrategy) (result []Tag, err erro
 	err = retry(
 		"listing tags",
 		o.logger,
+		o.requestHooks("ListTags"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().TagsService().List().Send()
@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveTag(tagID TagID, retries ...RetryStrategy) (err erro
 	err = retry(
 		fmt.Sprintf("removing tag %s", tagID),
 		o.logger,
+		o.requestHooks("RemoveTag"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().TagsService().TagService(string(tagID)).Remove().Send()
@@ -26,15 +26,19 @@ func (o *oVirtClient) StartCopyTemplateDiskToStorageDomain(
 	storageDomainID StorageDomainID,
 	retries ...RetryStrategy) (DiskUpdate, error) {
 	retries = defaultRetries(retries, defaultWriteTimeouts(o))
-	correlationID := fmt.Sprintf("template_disk_copy_%s", generateRandomID(5, o.nonSecureRandom))
+	correlationID, err := correlationIDFor(o.ctx, "template_disk_copy_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	sdkStorageDomain := ovirtsdk.NewStorageDomainBuilder().Id(string(storageDomainID))
 	sdkDisk := ovirtsdk.NewDiskBuilder().Id(string(diskID))
 	storageDomain, _ := o.GetStorageDomain(storageDomainID)
 	disk, _ := o.GetDisk(diskID)
 
-	err := retry(
+	err = retry(
 		fmt.Sprintf("copying disk %s to storage domain %s", diskID, storageDomainID),
 		o.logger,
+		o.requestHooks("StartCopyTemplateDiskToStorageDomain"),
 		retries,
 		func() error {
 			_, err := o.conn.
@@ -72,6 +76,10 @@ func (m *mockClient) CopyTemplateDiskToStorageDomain(
 	retries ...RetryStrategy) (result Disk, err error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	correlationID, err := correlationIDFor(m.ctx, "template_disk_copy_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	disk, ok := m.disks[diskID]
 
 	if !ok {
@@ -86,6 +94,8 @@ func (m *mockClient) CopyTemplateDiskToStorageDomain(
 		storageDomainID: storageDomainID,
 		done:            make(chan struct{}),
 	}
+	copyJob := m.startJob(fmt.Sprintf("Copying Disk %s to storage domain %s", disk.alias, storageDomainID), correlationID)
+	defer m.endJob(copyJob, JobStatusFinished)
 	defer update.do()
 	return disk, nil
 }
@@ -17,9 +17,14 @@ func (o *oVirtClient) CreateTemplate(
 	if params == nil {
 		params = &templateCreateParameters{}
 	}
+	correlationID, err := correlationIDFor(o.ctx, "template_create_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	err = retry(
 		fmt.Sprintf("creating template from VM %s", vmID),
 		o.logger,
+		o.requestHooks("CreateTemplate"),
 		retries,
 		func() error {
 			tpl := ovirtsdk.NewTemplateBuilder()
@@ -28,7 +33,13 @@ func (o *oVirtClient) CreateTemplate(
 			if desc := params.Description(); desc != nil {
 				tpl.Description(*desc)
 			}
-			response, err := o.conn.SystemService().TemplatesService().Add().Template(tpl.MustBuild()).Send()
+			response, err := o.conn.
+				SystemService().
+				TemplatesService().
+				Add().
+				Template(tpl.MustBuild()).
+				Query("correlation_id", correlationID).
+				Send()
 			if err != nil {
 				return err
 			}
@@ -63,6 +74,10 @@ func (m *mockClient) CreateTemplate(
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	correlationID, err := correlationIDFor(m.ctx, "template_create_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	vm, ok := m.vms[vmID]
 	if !ok {
 		return nil, newError(ENotFound, "VM with ID %s not found", vmID)
@@ -97,16 +112,18 @@ func (m *mockClient) CreateTemplate(
 	)
 	m.attachTemplateDisks(vmID, tpl)
 
-	go m.handlePostTemplateCreation(tpl)
+	creationJob := m.startJob(fmt.Sprintf("Creation of Template %s from VM %s", name, vm.name), correlationID)
+	go m.handlePostTemplateCreation(tpl, creationJob)
 	return tpl, nil
 }
 
-func (m *mockClient) handlePostTemplateCreation(tpl *template) {
+func (m *mockClient) handlePostTemplateCreation(tpl *template, creationJob *mockJob) {
 	func() {
 		time.Sleep(2 * time.Second)
 		m.lock.Lock()
 		defer m.lock.Unlock()
 		if tpl.status == TemplateStatusIllegal {
+			m.endJob(creationJob, JobStatusFailed)
 			return
 		}
 		for _, attachment := range m.templateDiskAttachmentsByTemplate[tpl.id] {
@@ -114,6 +131,7 @@ func (m *mockClient) handlePostTemplateCreation(tpl *template) {
 			disk.Unlock()
 		}
 		tpl.status = TemplateStatusOK
+		m.endJob(creationJob, JobStatusFinished)
 	}()
 }
 
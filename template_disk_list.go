@@ -12,6 +12,7 @@ func (o *oVirtClient) ListTemplateDiskAttachments(
 	err = retry(
 		fmt.Sprintf("listing disk attachments for template %s", templateID),
 		o.logger,
+		o.requestHooks("ListTemplateDiskAttachments"),
 		retries,
 		func() error {
 			res, err := o.conn.
@@ -11,6 +11,7 @@ func (o *oVirtClient) GetTemplate(id TemplateID, retries ...RetryStrategy) (resu
 	err = retry(
 		fmt.Sprintf("getting template %s", id),
 		o.logger,
+		o.requestHooks("GetTemplate"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().TemplatesService().TemplateService(string(id)).Get().Send()
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetTemplateByName(templateName string, retries ...RetryStr
 	err = retry(
 		fmt.Sprintf("getting template by Name %s", templateName),
 		o.logger,
+		o.requestHooks("GetTemplateByName"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().TemplatesService().List().Search("name=" + templateName).Send()
@@ -8,6 +8,7 @@ func (o *oVirtClient) ListTemplates(retries ...RetryStrategy) (result []Template
 	err = retry(
 		"listing templates",
 		o.logger,
+		o.requestHooks("ListTemplates"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().TemplatesService().List().Send()
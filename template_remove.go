@@ -12,6 +12,7 @@ func (o *oVirtClient) RemoveTemplate(
 	err = retry(
 		fmt.Sprintf("removing template %s", templateID),
 		o.logger,
+		o.requestHooks("RemoveTemplate"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().TemplatesService().TemplateService(string(templateID)).Remove().Send()
@@ -25,6 +26,7 @@ func (m *mockClient) RemoveTemplate(id TemplateID, retries ...RetryStrategy) (er
 	err = retry(
 		fmt.Sprintf("removing template %s", id),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
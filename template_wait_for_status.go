@@ -13,6 +13,7 @@ func (o *oVirtClient) WaitForTemplateStatus(
 	err = retry(
 		fmt.Sprintf("waiting for template %s to enter status \"%s\"", id, status),
 		o.logger,
+		o.waitHooks("WaitForTemplateStatus"),
 		retries,
 		func() error {
 			result, err = o.GetTemplate(id, retries...)
@@ -36,6 +37,7 @@ func (m *mockClient) WaitForTemplateStatus(
 	err = retry(
 		fmt.Sprintf("waiting for template %s to enter status \"%s\"", id, status),
 		nil,
+		nil,
 		retries,
 		func() error {
 			result, err = m.GetTemplate(id, retries...)
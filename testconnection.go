@@ -11,6 +11,7 @@ func (o *oVirtClient) Test(retries ...RetryStrategy) error {
 	return retry(
 		"testing oVirt engine connection",
 		o.logger,
+		o.requestHooks("Test"),
 		retries,
 		func() error {
 			return o.conn.SystemService().Connection().Test()
@@ -23,6 +24,7 @@ func (m *mockClient) Test(retries ...RetryStrategy) error {
 	return retry(
 		"testing oVirt engine connection",
 		nil,
+		nil,
 		retries,
 		func() error {
 			return nil
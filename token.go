@@ -0,0 +1,80 @@
+package ovirtclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Token is an SSO access token issued by the oVirt Engine.
+type Token struct {
+	// AccessToken is the bearer token sent to the engine.
+	AccessToken string `json:"access_token"`
+	// ExpiresAt is the time the token expires. It is the zero time if the engine did not report an expiry.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// ExpiresWithin returns true if the token expires within the specified duration from now. Tokens without an expiry
+// never expire.
+func (t Token) ExpiresWithin(d time.Duration) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(d).After(t.ExpiresAt)
+}
+
+// TokenStore persists SSO access tokens so they can be reused by other clients and processes instead of opening a
+// new SSO session every time. Tokens are stored per engine URL and username. Implementations must be safe for
+// concurrent use.
+//
+// Use NewMemoryTokenStore to share tokens within a process, or NewFileTokenStore to share them across processes.
+type TokenStore interface {
+	// Load returns the token stored for the engine URL and username, or nil if no token is stored.
+	Load(url string, username string) (*Token, error)
+	// Store saves the token for the engine URL and username, replacing any previously stored token.
+	Store(url string, username string, token Token) error
+	// Delete removes the token stored for the engine URL and username. It does not return an error if no token is
+	// stored.
+	Delete(url string, username string) error
+}
+
+// NewMemoryTokenStore creates a TokenStore that keeps tokens in memory. It can be used to share an SSO session
+// between multiple clients in the same process.
+func NewMemoryTokenStore() TokenStore {
+	return &memoryTokenStore{
+		lock:   &sync.Mutex{},
+		tokens: map[string]Token{},
+	}
+}
+
+type memoryTokenStore struct {
+	lock   *sync.Mutex
+	tokens map[string]Token
+}
+
+func (m *memoryTokenStore) Load(url string, username string) (*Token, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	token, ok := m.tokens[tokenStoreKey(url, username)]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (m *memoryTokenStore) Store(url string, username string, token Token) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.tokens[tokenStoreKey(url, username)] = token
+	return nil
+}
+
+func (m *memoryTokenStore) Delete(url string, username string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.tokens, tokenStoreKey(url, username))
+	return nil
+}
+
+func tokenStoreKey(url string, username string) string {
+	return username + "@" + url
+}
@@ -0,0 +1,113 @@
+package ovirtclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// NewFileTokenStore creates a TokenStore that persists tokens in a JSON file at the specified path, so short-lived
+// processes, such as CLI invocations, can reuse the same SSO session. The file is created with permissions that only
+// allow the current user to read it, since the tokens grant access to the engine.
+//
+// Concurrent writes from multiple processes are not coordinated, the last write wins. This may cause a process to
+// open a new SSO session, but never to use an invalid token.
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{
+		lock: &sync.Mutex{},
+		path: path,
+	}
+}
+
+type fileTokenStore struct {
+	lock *sync.Mutex
+	path string
+}
+
+func (f *fileTokenStore) Load(url string, username string) (*Token, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[tokenStoreKey(url, username)]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (f *fileTokenStore) Store(url string, username string, token Token) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	tokens[tokenStoreKey(url, username)] = token
+	return f.write(tokens)
+}
+
+func (f *fileTokenStore) Delete(url string, username string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	key := tokenStoreKey(url, username)
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return f.write(tokens)
+}
+
+func (f *fileTokenStore) read() (map[string]Token, error) {
+	tokens := map[string]Token{}
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return tokens, nil
+		}
+		return nil, wrap(err, EFileReadFailed, "failed to read token store %s", f.path)
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, wrap(err, EFileReadFailed, "failed to parse token store %s", f.path)
+	}
+	return tokens, nil
+}
+
+// write replaces the token file atomically so concurrent readers never see a partially written file.
+func (f *fileTokenStore) write(tokens map[string]Token) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return wrap(err, EBug, "failed to encode tokens")
+	}
+	tmpFile, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return wrap(err, ELocalIO, "failed to create temporary file for token store %s", f.path)
+	}
+	tmpName := tmpFile.Name()
+	defer func() {
+		_ = os.Remove(tmpName)
+	}()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return wrap(err, ELocalIO, "failed to write token store %s", f.path)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return wrap(err, ELocalIO, "failed to write token store %s", f.path)
+	}
+	if err := os.Rename(tmpName, f.path); err != nil {
+		return wrap(err, ELocalIO, "failed to replace token store %s", f.path)
+	}
+	return nil
+}
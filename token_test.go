@@ -0,0 +1,91 @@
+package ovirtclient_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	t.Parallel()
+	const url = "https://localhost/ovirt-engine/api"
+	const username = "admin@internal"
+	path := filepath.Join(t.TempDir(), "tokens.json")
+
+	assertTokenStoreRoundTrip(t, ovirtclient.NewFileTokenStore(path))
+
+	// A second store on the same file, as used by a later process, must see the token.
+	token := ovirtclient.Token{AccessToken: "persisted"}
+	if err := ovirtclient.NewFileTokenStore(path).Store(url, username, token); err != nil {
+		t.Fatalf("Failed to store token (%v)", err)
+	}
+	loaded, err := ovirtclient.NewFileTokenStore(path).Load(url, username)
+	if err != nil {
+		t.Fatalf("Failed to load token (%v)", err)
+	}
+	if loaded == nil || loaded.AccessToken != token.AccessToken {
+		t.Fatalf("Token was not persisted to %s.", path)
+	}
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	t.Parallel()
+	assertTokenStoreRoundTrip(t, ovirtclient.NewMemoryTokenStore())
+}
+
+func TestTokenExpiresWithin(t *testing.T) {
+	t.Parallel()
+	if (ovirtclient.Token{AccessToken: "test"}).ExpiresWithin(time.Hour) {
+		t.Fatalf("Token without an expiry reported to expire.")
+	}
+	token := ovirtclient.Token{AccessToken: "test", ExpiresAt: time.Now().Add(30 * time.Second)}
+	if !token.ExpiresWithin(time.Minute) {
+		t.Fatalf("Token expiring in 30 seconds not reported to expire within a minute.")
+	}
+	if token.ExpiresWithin(time.Second) {
+		t.Fatalf("Token expiring in 30 seconds reported to expire within a second.")
+	}
+}
+
+func assertTokenStoreRoundTrip(t *testing.T, store ovirtclient.TokenStore) {
+	const url = "https://localhost/ovirt-engine/api"
+	const username = "admin@internal"
+
+	loaded, err := store.Load(url, username)
+	if err != nil {
+		t.Fatalf("Failed to load token from empty store (%v)", err)
+	}
+	if loaded != nil {
+		t.Fatalf("Empty store returned a token.")
+	}
+
+	token := ovirtclient.Token{
+		AccessToken: "test",
+		ExpiresAt:   time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	if err := store.Store(url, username, token); err != nil {
+		t.Fatalf("Failed to store token (%v)", err)
+	}
+	loaded, err = store.Load(url, username)
+	if err != nil {
+		t.Fatalf("Failed to load token (%v)", err)
+	}
+	if loaded == nil || loaded.AccessToken != token.AccessToken || !loaded.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Fatalf("Loaded token does not match the stored token (%v)", loaded)
+	}
+	if other, err := store.Load(url, "other@internal"); err != nil || other != nil {
+		t.Fatalf("Token returned for a different user (%v, %v)", other, err)
+	}
+
+	if err := store.Delete(url, username); err != nil {
+		t.Fatalf("Failed to delete token (%v)", err)
+	}
+	if loaded, err := store.Load(url, username); err != nil || loaded != nil {
+		t.Fatalf("Token still present after deletion (%v, %v)", loaded, err)
+	}
+	if err := store.Delete(url, username); err != nil {
+		t.Fatalf("Deleting a missing token failed (%v)", err)
+	}
+}
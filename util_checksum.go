@@ -0,0 +1,77 @@
+package ovirtclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+)
+
+// imageChecksumAlgorithm is the algorithm requested from the ImageIO checksum API. It is supported by all ImageIO
+// versions that offer the checksum API and does not require a dependency for the default blake2b algorithm.
+const imageChecksumAlgorithm = "sha256"
+
+// defaultImageChecksumBlockSize is the block size ImageIO uses for checksums if none is requested.
+const defaultImageChecksumBlockSize = 4 * 1024 * 1024
+
+// imageChecksum is the result of the ImageIO checksum API.
+type imageChecksum struct {
+	Algorithm string `json:"algorithm"`
+	BlockSize uint64 `json:"block_size"`
+	Checksum  string `json:"checksum"`
+}
+
+// blockChecksum computes an image checksum the same way as ImageIO: the image is split into blocks, each block is
+// hashed, and the checksum is the hash of the concatenated block hashes. This allows ImageIO to compute the hashes of
+// blocks in parallel and to skip reading zero blocks.
+type blockChecksum struct {
+	blockSize uint64
+	outer     hash.Hash
+	block     hash.Hash
+	// blockFill is the number of bytes written to the current block.
+	blockFill uint64
+}
+
+// newBlockChecksum creates a SHA-256 based block checksum with the specified block size.
+func newBlockChecksum(blockSize uint64) *blockChecksum {
+	if blockSize == 0 {
+		blockSize = defaultImageChecksumBlockSize
+	}
+	return &blockChecksum{
+		blockSize: blockSize,
+		outer:     sha256.New(),
+		block:     sha256.New(),
+	}
+}
+
+func (b *blockChecksum) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		length := b.blockSize - b.blockFill
+		if length > uint64(len(p)) {
+			length = uint64(len(p))
+		}
+		_, _ = b.block.Write(p[:length])
+		b.blockFill += length
+		p = p[length:]
+		if b.blockFill == b.blockSize {
+			b.finishBlock()
+		}
+	}
+	return n, nil
+}
+
+// finishBlock adds the hash of the current block to the checksum and starts a new block.
+func (b *blockChecksum) finishBlock() {
+	_, _ = b.outer.Write(b.block.Sum(nil))
+	b.block.Reset()
+	b.blockFill = 0
+}
+
+// Checksum returns the hex-encoded checksum of the data written so far. The last block may be shorter than the block
+// size. It must only be called once all data has been written.
+func (b *blockChecksum) Checksum() string {
+	if b.blockFill > 0 {
+		b.finishBlock()
+	}
+	return hex.EncodeToString(b.outer.Sum(nil))
+}
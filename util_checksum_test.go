@@ -0,0 +1,31 @@
+// This file contains tests for the internal block checksum. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestBlockChecksum(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 10*1024+5)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	// The expected value is the hash of the SHA-256 hashes of the 4 KiB blocks, the last block being shorter.
+	const expected = "3c73f680ac571a9efed4dea5d5ea74fe3bd5610ac8d474b2b0213e7ebab4d586"
+
+	checksum := newBlockChecksum(4096)
+	// Write in pieces that do not align with the blocks.
+	for offset := 0; offset < len(data); offset += 3000 {
+		end := offset + 3000
+		if end > len(data) {
+			end = len(data)
+		}
+		_, _ = checksum.Write(data[offset:end])
+	}
+	if result := checksum.Checksum(); result != expected {
+		t.Fatalf("Incorrect block checksum (%s instead of %s).", result, expected)
+	}
+}
@@ -0,0 +1,117 @@
+package ovirtclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// imageExtent describes a region of a disk image. It matches the format of the ImageIO extents API. Dirty is only
+// reported for the extents of incremental backups.
+type imageExtent struct {
+	Start  uint64 `json:"start"`
+	Length uint64 `json:"length"`
+	Zero   bool   `json:"zero"`
+	Hole   bool   `json:"hole"`
+	Dirty  bool   `json:"dirty"`
+}
+
+// zeroBlock is compared against when detecting zero regions.
+var zeroBlock = make([]byte, 64*1024) //nolint:gochecknoglobals
+
+// isZero returns true if data only contains zero bytes.
+func isZero(data []byte) bool {
+	for len(data) > 0 {
+		n := len(data)
+		if n > len(zeroBlock) {
+			n = len(zeroBlock)
+		}
+		if !bytes.Equal(data[:n], zeroBlock[:n]) {
+			return false
+		}
+		data = data[n:]
+	}
+	return true
+}
+
+// seekDataHoleWhence returns the whence values for SEEK_DATA and SEEK_HOLE on the current platform. The values are
+// not part of the syscall package, and ok is false on platforms that don't support them.
+func seekDataHoleWhence() (seekData int, seekHole int, ok bool) {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "illumos", "solaris":
+		return 3, 4, true
+	case "darwin":
+		return 4, 3, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// fileHoles returns the holes of a sparse file between start and size using SEEK_DATA and SEEK_HOLE. It returns nil
+// if the reader is not a file or the file system does not report holes. The read position of the file is changed.
+func fileHoles(reader io.Reader, start uint64, size uint64) []imageExtent {
+	file, ok := reader.(*os.File)
+	if !ok {
+		return nil
+	}
+	seekData, seekHole, ok := seekDataHoleWhence()
+	if !ok {
+		return nil
+	}
+	var holes []imageExtent
+	offset := int64(start)
+	for offset < int64(size) {
+		data, err := file.Seek(offset, seekData)
+		if err != nil {
+			if errors.Is(err, syscall.ENXIO) {
+				// There is no data after the offset, the rest of the file is a hole.
+				holes = append(holes, imageExtent{Start: uint64(offset), Length: size - uint64(offset), Hole: true})
+				break
+			}
+			return nil
+		}
+		if data >= int64(size) {
+			holes = append(holes, imageExtent{Start: uint64(offset), Length: size - uint64(offset), Hole: true})
+			break
+		}
+		if data > offset {
+			holes = append(holes, imageExtent{Start: uint64(offset), Length: uint64(data - offset), Hole: true})
+		}
+		hole, err := file.Seek(data, seekHole)
+		if err != nil {
+			return nil
+		}
+		offset = hole
+	}
+	return holes
+}
+
+// inHole returns true if the range starting at offset with the specified length lies entirely within one of the
+// holes.
+func inHole(holes []imageExtent, offset uint64, length uint64) bool {
+	for _, hole := range holes {
+		if offset >= hole.Start && offset+length <= hole.Start+hole.Length {
+			return true
+		}
+	}
+	return false
+}
+
+// writeZeroes writes length zero bytes to w at the specified offset.
+func writeZeroes(w io.WriterAt, offset uint64, length uint64) error {
+	for length > 0 {
+		n := uint64(len(zeroBlock))
+		if n > length {
+			n = length
+		}
+		if _, err := w.WriteAt(zeroBlock[:n], int64(offset)); err != nil {
+			return wrap(err, ELocalIO, "failed to write zeroes at offset %d", offset)
+		}
+		offset += n
+		length -= n
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// This file contains tests for the internal sparse file helpers. It is therefore excluded from the testpackage check.
+
+package ovirtclient //nolint:testpackage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsZero(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 200*1024)
+	if !isZero(data) {
+		t.Fatalf("Zero data was not detected as zero.")
+	}
+	data[len(data)-1] = 1
+	if isZero(data) {
+		t.Fatalf("Non-zero data was detected as zero.")
+	}
+}
+
+func TestFileHoles(t *testing.T) {
+	t.Parallel()
+
+	const size = 4 * 1024 * 1024
+	dir, err := ioutil.TempDir("", "ovirtclient-sparse-")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory (%v)", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	fh, err := os.Create(filepath.Join(dir, "sparse.img"))
+	if err != nil {
+		t.Fatalf("Failed to create sparse file (%v)", err)
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+	if _, err := fh.Write([]byte("data")); err != nil {
+		t.Fatalf("Failed to write sparse file (%v)", err)
+	}
+	if err := fh.Truncate(size); err != nil {
+		t.Fatalf("Failed to extend sparse file (%v)", err)
+	}
+
+	holes := fileHoles(fh, 0, size)
+	if holes == nil {
+		t.Skipf("The file system does not report holes.")
+	}
+	if inHole(holes, 0, 4) {
+		t.Fatalf("The data region was reported as a hole: %v", holes)
+	}
+	if !inHole(holes, size-1024*1024, 1024*1024) {
+		t.Fatalf("The end of the file was not reported as a hole: %v", holes)
+	}
+}
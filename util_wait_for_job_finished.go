@@ -25,6 +25,7 @@ func (o *oVirtClient) waitForJobFinished(correlationID string, retries []RetrySt
 	return retry(
 		fmt.Sprintf("waiting for job with correlation ID %s to finish", correlationID),
 		o.logger,
+		o.requestHooks("WaitForJobFinished"),
 		retries,
 		func() error {
 			jobResp, err := o.conn.SystemService().JobsService().List().Search(fmt.Sprintf("correlation_id=%s", correlationID)).Send()
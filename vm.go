@@ -46,6 +46,12 @@ type VMClient interface {
 	ShutdownVM(id VMID, force bool, retries ...RetryStrategy) error
 	// WaitForVMStatus waits for the VM to reach the desired status.
 	WaitForVMStatus(id VMID, status VMStatus, retries ...RetryStrategy) (VM, error)
+	// MigrateVM triggers a live migration of a running VM to a different host. The actual migration will take time
+	// and should be waited for via the WaitForVMMigration call. Use NewMigrateVMParams to obtain a builder for the
+	// params.
+	MigrateVM(id VMID, params MigrateVMParameters, retries ...RetryStrategy) error
+	// WaitForVMMigration waits for the VM to leave the specified source host and return to the VMStatusUp status.
+	WaitForVMMigration(id VMID, sourceHostID HostID, retries ...RetryStrategy) (VM, error)
 	// ListVMs returns a list of all virtual machines.
 	ListVMs(retries ...RetryStrategy) ([]VM, error)
 	// SearchVMs lists all virtual machines matching a certain criteria specified in params.
@@ -587,6 +593,12 @@ type VM interface {
 	// specified amount of retries, an error will be returned. If the VM enters the desired state, an updated VM
 	// object will be returned.
 	WaitForStatus(status VMStatus, retries ...RetryStrategy) (VM, error)
+	// Migrate will cause the VM to be live migrated to a different host. The migration takes some time and should be
+	// checked via WaitForMigration.
+	Migrate(params MigrateVMParameters, retries ...RetryStrategy) error
+	// WaitForMigration waits until the VM has left the host it was running on when this VM object was fetched and has
+	// returned to the VMStatusUp status.
+	WaitForMigration(retries ...RetryStrategy) (VM, error)
 
 	// CreateNIC creates a network interface on the current VM. This involves an API call and may be slow.
 	CreateNIC(name string, vnicProfileID VNICProfileID, params OptionalNICParameters, retries ...RetryStrategy) (NIC, error)
@@ -1768,6 +1780,103 @@ func (u *updateVMParams) WithMemory(memory int64) (BuildableUpdateVMParameters,
 	return u, nil
 }
 
+// MigrateVMParameters contains the optional parameters for migrating a VM to a different host.
+type MigrateVMParameters interface {
+	// HostID returns the ID of the host the VM should be migrated to. If nil, the engine picks a suitable host.
+	HostID() *HostID
+	// ClusterID returns the ID of the cluster the VM should be migrated to. If nil, the VM stays in its current
+	// cluster.
+	ClusterID() *ClusterID
+	// Force returns true if the VM should be migrated even if its placement policy marks it as not migratable.
+	Force() *bool
+}
+
+// BuildableMigrateVMParameters is a buildable version of MigrateVMParameters.
+type BuildableMigrateVMParameters interface {
+	MigrateVMParameters
+
+	// WithHostID sets the host the VM should be migrated to.
+	WithHostID(hostID HostID) (BuildableMigrateVMParameters, error)
+	// MustWithHostID is identical to WithHostID, but panics instead of returning an error.
+	MustWithHostID(hostID HostID) BuildableMigrateVMParameters
+	// WithClusterID sets the cluster the VM should be migrated to.
+	WithClusterID(clusterID ClusterID) (BuildableMigrateVMParameters, error)
+	// MustWithClusterID is identical to WithClusterID, but panics instead of returning an error.
+	MustWithClusterID(clusterID ClusterID) BuildableMigrateVMParameters
+	// WithForce sets if the VM should be migrated even if it is marked as not migratable.
+	WithForce(force bool) (BuildableMigrateVMParameters, error)
+	// MustWithForce is identical to WithForce, but panics instead of returning an error.
+	MustWithForce(force bool) BuildableMigrateVMParameters
+}
+
+// NewMigrateVMParams returns a buildable set of parameters for VM migration.
+func NewMigrateVMParams() BuildableMigrateVMParameters {
+	return &migrateVMParams{}
+}
+
+type migrateVMParams struct {
+	hostID    *HostID
+	clusterID *ClusterID
+	force     *bool
+}
+
+func (m *migrateVMParams) HostID() *HostID {
+	return m.hostID
+}
+
+func (m *migrateVMParams) ClusterID() *ClusterID {
+	return m.clusterID
+}
+
+func (m *migrateVMParams) Force() *bool {
+	return m.force
+}
+
+func (m *migrateVMParams) WithHostID(hostID HostID) (BuildableMigrateVMParameters, error) {
+	if hostID == "" {
+		return nil, newError(EBadArgument, "host ID must not be empty for VM migration")
+	}
+	m.hostID = &hostID
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithHostID(hostID HostID) BuildableMigrateVMParameters {
+	builder, err := m.WithHostID(hostID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (m *migrateVMParams) WithClusterID(clusterID ClusterID) (BuildableMigrateVMParameters, error) {
+	if clusterID == "" {
+		return nil, newError(EBadArgument, "cluster ID must not be empty for VM migration")
+	}
+	m.clusterID = &clusterID
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithClusterID(clusterID ClusterID) BuildableMigrateVMParameters {
+	builder, err := m.WithClusterID(clusterID)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+func (m *migrateVMParams) WithForce(force bool) (BuildableMigrateVMParameters, error) {
+	m.force = &force
+	return m, nil
+}
+
+func (m *migrateVMParams) MustWithForce(force bool) BuildableMigrateVMParameters {
+	builder, err := m.WithForce(force)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 // NewCreateVMParams creates a set of BuildableVMParameters that can be used to construct the optional VM parameters.
 func NewCreateVMParams() BuildableVMParameters {
 	return &vmParams{
@@ -2218,6 +2327,17 @@ func (v *vm) WaitForStatus(status VMStatus, retries ...RetryStrategy) (VM, error
 	return v.client.WaitForVMStatus(v.id, status, retries...)
 }
 
+func (v *vm) Migrate(params MigrateVMParameters, retries ...RetryStrategy) error {
+	return v.client.MigrateVM(v.id, params, retries...)
+}
+
+func (v *vm) WaitForMigration(retries ...RetryStrategy) (VM, error) {
+	if v.hostID == nil {
+		return nil, newError(EConflict, "VM %s is not running on a host", v.id)
+	}
+	return v.client.WaitForVMMigration(v.id, *v.hostID, retries...)
+}
+
 func (v *vm) CPU() VMCPU {
 	return v.cpu
 }
@@ -139,13 +139,18 @@ func (o *oVirtClient) CreateVM(clusterID ClusterID, templateID TemplateID, name
 	if err != nil {
 		return nil, err
 	}
+	correlationID, err := correlationIDFor(o.ctx, "vm_create_", o.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 
 	err = retry(
 		message,
 		o.logger,
+		o.requestHooks("CreateVM"),
 		retries,
 		func() error {
-			vmCreateRequest := o.conn.SystemService().VmsService().Add().Vm(vm)
+			vmCreateRequest := o.conn.SystemService().VmsService().Add().Vm(vm).Query("correlation_id", correlationID)
 			if clone := params.Clone(); clone != nil {
 				vmCreateRequest.Clone(*clone)
 			}
@@ -363,9 +368,14 @@ func (m *mockClient) CreateVM(
 	if name == "" {
 		return nil, newError(EBadArgument, "The name parameter is required for VM creation.")
 	}
+	correlationID, err := correlationIDFor(m.ctx, "vm_create_", m.nonSecureRandom)
+	if err != nil {
+		return nil, err
+	}
 	err = retry(
 		fmt.Sprintf("creating VM %s", name),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
@@ -399,6 +409,8 @@ func (m *mockClient) CreateVM(
 
 			m.vmIPs[vm.id] = map[string][]net.IP{}
 			m.addGraphicsConsoles(vm)
+			m.addCdroms(vm)
+			m.endJob(m.startJob(fmt.Sprintf("Creating VM %s from Template", name), correlationID), JobStatusFinished)
 
 			result = vm
 			return nil
@@ -568,7 +580,7 @@ func (m *mockClient) attachVMDisksFromTemplate(tpl *template, vm *vm, params Opt
 			active:        attachment.active,
 		}
 		m.vmDiskAttachmentsByVM[vm.id][diskAttachment.id] = diskAttachment
-		m.vmDiskAttachmentsByDisk[disk.id] = diskAttachment
+		m.addDiskAttachmentByDisk(newDisk.ID(), diskAttachment)
 	}
 }
 
@@ -9,6 +9,7 @@ func (o *oVirtClient) GetVM(id VMID, retries ...RetryStrategy) (result VM, err e
 	err = retry(
 		fmt.Sprintf("getting vm %s", id),
 		o.logger,
+		o.requestHooks("GetVM"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VmsService().VmService(string(id)).Get().Send()
@@ -10,6 +10,7 @@ func (o *oVirtClient) GetVMByName(name string, retries ...RetryStrategy) (result
 	err = retry(
 		fmt.Sprintf("getting vm name %s", name),
 		o.logger,
+		o.requestHooks("GetVMByName"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VmsService().List().Search("name=" + name).Send()
@@ -7,6 +7,7 @@ func (o *oVirtClient) ListVMGraphicsConsoles(vmID VMID, retries ...RetryStrategy
 	err = retry(
 		fmt.Sprintf("listing graphics consoles for VM %s", vmID),
 		o.logger,
+		o.requestHooks("ListVMGraphicsConsoles"),
 		retries,
 		func() error {
 			resp, err := o.conn.SystemService().VmsService().VmService(string(vmID)).GraphicsConsolesService().List().Send()
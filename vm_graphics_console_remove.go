@@ -11,6 +11,7 @@ func (o *oVirtClient) RemoveVMGraphicsConsole(
 	return retry(
 		fmt.Sprintf("removing graphics consoles %s from VM %s", graphicsConsoleID, vmID),
 		o.logger,
+		o.requestHooks("RemoveVMGraphicsConsole"),
 		retries,
 		func() error {
 			_, err = o.conn.
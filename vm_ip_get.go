@@ -27,6 +27,7 @@ func (o *oVirtClient) GetVMIPAddresses(id VMID, params VMIPSearchParams, retries
 	err = retry(
 		fmt.Sprintf("getting IP addresses for VM %s", id),
 		o.logger,
+		o.requestHooks("GetVMIPAddresses"),
 		retries,
 		func() error {
 			reportedDevicesResponse, err := o.conn.SystemService().VmsService().VmService(string(id)).ReportedDevicesService().List().Send()
@@ -6,9 +6,9 @@ func (m *mockClient) GetVMNonLocalIPAddresses(id VMID, retries ...RetryStrategy)
 	result map[string][]net.IP,
 	err error,
 ) {
-	return waitForIPAddresses(id, nonLocalIPSearchParams, retries, m.logger, m)
+	return waitForIPAddresses(id, nonLocalIPSearchParams, retries, m.logger, nil, m)
 }
 
 func (o *oVirtClient) GetVMNonLocalIPAddresses(id VMID, retries ...RetryStrategy) (map[string][]net.IP, error) {
-	return waitForIPAddresses(id, nonLocalIPSearchParams, retries, o.logger, o)
+	return waitForIPAddresses(id, nonLocalIPSearchParams, retries, o.logger, o.waitHooks("GetVMNonLocalIPAddresses"), o)
 }
@@ -10,7 +10,7 @@ func (m *mockClient) WaitForVMIPAddresses(
 	params VMIPSearchParams,
 	retries ...RetryStrategy,
 ) (result map[string][]net.IP, err error) {
-	return waitForIPAddresses(id, params, retries, m.logger, m)
+	return waitForIPAddresses(id, params, retries, m.logger, nil, m)
 }
 
 func (o *oVirtClient) WaitForVMIPAddresses(
@@ -18,7 +18,7 @@ func (o *oVirtClient) WaitForVMIPAddresses(
 	params VMIPSearchParams,
 	retries ...RetryStrategy,
 ) (map[string][]net.IP, error) {
-	return waitForIPAddresses(id, params, retries, o.logger, o)
+	return waitForIPAddresses(id, params, retries, o.logger, o.waitHooks("WaitForVMIPAddresses"), o)
 }
 
 var errNoIPAddressesReportedYet = newError(EPending, "no IP addresses reported yet")
@@ -28,6 +28,7 @@ func waitForIPAddresses(
 	params VMIPSearchParams,
 	retries []RetryStrategy,
 	logger Logger,
+	hooks *requestHooks,
 	client Client,
 ) (result map[string][]net.IP, err error) {
 	retries = defaultRetries(retries, defaultLongTimeouts(client))
@@ -35,6 +36,7 @@ func waitForIPAddresses(
 	err = retry(
 		fmt.Sprintf("waiting for IP addresses on VM %s", id),
 		logger,
+		hooks,
 		retries,
 		func() error {
 			result, err = client.GetVMIPAddresses(id, params, retries...)
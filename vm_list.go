@@ -6,6 +6,7 @@ func (o *oVirtClient) ListVMs(retries ...RetryStrategy) (result []VM, err error)
 	err = retry(
 		"listing vms",
 		o.logger,
+		o.requestHooks("ListVMs"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().VmsService().List().Send()
@@ -0,0 +1,142 @@
+package ovirtclient
+
+import (
+	"fmt"
+	"time"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+func (o *oVirtClient) MigrateVM(id VMID, params MigrateVMParameters, retries ...RetryStrategy) (err error) {
+	retries = defaultRetries(retries, defaultWriteTimeouts(o))
+	if params == nil {
+		params = NewMigrateVMParams()
+	}
+	err = retry(
+		fmt.Sprintf("migrating VM %s", id),
+		o.logger,
+		o.requestHooks("MigrateVM"),
+		retries,
+		func() error {
+			request := o.conn.SystemService().VmsService().VmService(string(id)).Migrate()
+			if hostID := params.HostID(); hostID != nil {
+				request.Host(ovirtsdk.NewHostBuilder().Id(string(*hostID)).MustBuild())
+			}
+			if clusterID := params.ClusterID(); clusterID != nil {
+				request.Cluster(ovirtsdk.NewClusterBuilder().Id(string(*clusterID)).MustBuild())
+			}
+			if force := params.Force(); force != nil {
+				request.Force(*force)
+			}
+			_, err := request.Send()
+			return err
+		})
+	return
+}
+
+func (m *mockClient) MigrateVM(id VMID, params MigrateVMParameters, _ ...RetryStrategy) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if params == nil {
+		params = NewMigrateVMParams()
+	}
+	item, ok := m.vms[id]
+	if !ok {
+		return newError(ENotFound, "vm with ID %s not found", id)
+	}
+	if err := m.checkVMNotLocked(id); err != nil {
+		return err
+	}
+	if item.status != VMStatusUp || item.hostID == nil {
+		return newError(EConflict, "VM %s is in status %s and cannot be migrated", id, item.status)
+	}
+	if clusterID := params.ClusterID(); clusterID != nil && *clusterID != item.clusterID {
+		return newError(
+			EVMNotMigratable,
+			"VM %s cannot be migrated from cluster %s to cluster %s",
+			id,
+			item.clusterID,
+			*clusterID,
+		)
+	}
+	force := false
+	if f := params.Force(); f != nil {
+		force = *f
+	}
+	if err := checkMockVMMigratable(item, force); err != nil {
+		return err
+	}
+
+	targetHostID, err := m.findMigrationTargetHost(item, params.HostID())
+	if err != nil {
+		return err
+	}
+
+	item.status = VMStatusMigrating
+	go func() {
+		time.Sleep(2 * time.Second)
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if item.status != VMStatusMigrating {
+			return
+		}
+		item.hostID = &targetHostID
+		item.status = VMStatusUp
+	}()
+	return nil
+}
+
+// checkMockVMMigratable checks if the placement policy of the VM allows a manual migration. VMs with the
+// VMAffinityUserMigratable affinity can be migrated manually, so only pinned VMs are rejected unless forced.
+func checkMockVMMigratable(item *vm, force bool) error {
+	if item.placementPolicy == nil || item.placementPolicy.affinity == nil {
+		return nil
+	}
+	if *item.placementPolicy.affinity == VMAffinityPinned && !force {
+		return newError(EVMNotMigratable, "VM %s is pinned to its host and cannot be migrated", item.id)
+	}
+	return nil
+}
+
+// findMigrationTargetHost returns the requested host after validating it, or picks a suitable host in the cluster of
+// the VM if none is requested. Both follow the same placement and affinity rules as starting the VM. The caller must
+// hold the mock lock.
+func (m *mockClient) findMigrationTargetHost(item *vm, requestedHostID *HostID) (HostID, error) {
+	if requestedHostID == nil {
+		targetHostID, err := m.findSuitableHost(item.id, *item.hostID)
+		if err != nil {
+			return "", wrap(err, EConflict, "no suitable host found to migrate VM %s to", item.id)
+		}
+		return targetHostID, nil
+	}
+
+	targetHost, ok := m.hosts[*requestedHostID]
+	if !ok {
+		return "", newError(ENotFound, "host with ID %s not found", *requestedHostID)
+	}
+	if targetHost.id == *item.hostID {
+		return "", newError(EBadArgument, "VM %s is already running on host %s", item.id, targetHost.id)
+	}
+	if targetHost.clusterID != item.clusterID {
+		return "", newError(
+			EVMNotMigratable,
+			"host %s is not in the cluster %s of VM %s",
+			targetHost.id,
+			item.clusterID,
+			item.id,
+		)
+	}
+	if targetHost.status != HostStatusUp {
+		return "", newError(EConflict, "host %s is in status %s, not %s", targetHost.id, targetHost.status, HostStatusUp)
+	}
+	if !m.hostSuitableFor(item.id, targetHost) {
+		return "", newError(
+			EVMNotMigratable,
+			"host %s is not allowed by the placement policy or affinity groups of VM %s",
+			targetHost.id,
+			item.id,
+		)
+	}
+	return targetHost.id, nil
+}
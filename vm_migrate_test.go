@@ -0,0 +1,89 @@
+package ovirtclient_test
+
+import (
+	"testing"
+
+	ovirtclient "github.com/yosefsatrioaji/go-ovirt-client/v3"
+)
+
+func TestVMMigration(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateBootableVM(t, helper)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+	sourceHostID := *vm.HostID()
+
+	if err := vm.Migrate(nil); err != nil {
+		t.Fatalf("Failed to migrate VM %s (%v)", vm.ID(), err)
+	}
+	vm, err := vm.WaitForMigration()
+	if err != nil {
+		t.Fatalf("Failed to wait for VM %s to migrate (%v)", vm.ID(), err)
+	}
+	if *vm.HostID() == sourceHostID {
+		t.Fatalf("VM %s is still on host %s after migration.", vm.ID(), sourceHostID)
+	}
+}
+
+func TestPinnedVMMigrationFails(t *testing.T) {
+	t.Parallel()
+	helper := getHelper(t)
+	hosts := assertHasMultipleHosts(t, helper)
+
+	vm := assertCanCreateVM(
+		t,
+		helper,
+		helper.GenerateTestResourceName(t),
+		ovirtclient.NewCreateVMParams().WithPlacementPolicy(
+			ovirtclient.
+				NewVMPlacementPolicyParameters().
+				MustWithAffinity(ovirtclient.VMAffinityPinned).
+				MustWithHostIDs([]ovirtclient.HostID{hosts[0].ID()}),
+		),
+	)
+	disk := assertCanCreateDisk(t, helper)
+	assertCanUploadDiskImage(t, helper, disk)
+	assertCanAttachDiskWithParams(
+		t,
+		vm,
+		disk,
+		ovirtclient.CreateDiskAttachmentParams().MustWithBootable(true).MustWithActive(true),
+	)
+	assertCanStartVM(t, helper, vm)
+	vm = assertVMWillStart(t, vm)
+
+	err := vm.Migrate(ovirtclient.NewMigrateVMParams().MustWithHostID(hosts[1].ID()))
+	if err == nil {
+		t.Fatalf("Migrating a pinned VM did not fail.")
+	}
+	if !ovirtclient.HasErrorCode(err, ovirtclient.EVMNotMigratable) {
+		t.Fatalf("Migrating a pinned VM did not return an EVMNotMigratable error (%v).", err)
+	}
+}
+
+// assertHasMultipleHosts returns the hosts in the test cluster and skips the test if there are fewer than two. The mock
+// client only has a single host by default, so a second one is added to it.
+func assertHasMultipleHosts(t *testing.T, helper ovirtclient.TestHelper) []ovirtclient.Host {
+	if mock, ok := helper.GetClient().(ovirtclient.MockClient); ok {
+		if _, err := mock.AddHost(helper.GetClusterID()); err != nil {
+			t.Fatalf("Failed to add a second host to the mock (%v).", err)
+		}
+	}
+	hosts, err := helper.GetClient().ListHosts()
+	if err != nil {
+		t.Fatalf("Failed to list hosts (%v).", err)
+	}
+	var clusterHosts []ovirtclient.Host
+	for _, host := range hosts {
+		if host.ClusterID() == helper.GetClusterID() && host.Status() == ovirtclient.HostStatusUp {
+			clusterHosts = append(clusterHosts, host)
+		}
+	}
+	if len(clusterHosts) < 2 {
+		t.Skipf("Fewer than two hosts available in cluster %s, skipping migration test.", helper.GetClusterID())
+	}
+	return clusterHosts
+}
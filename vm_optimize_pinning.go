@@ -7,6 +7,7 @@ func (o *oVirtClient) AutoOptimizeVMCPUPinningSettings(id VMID, optimize bool, r
 	return retry(
 		fmt.Sprintf("optimizing CPU pinning settings for VM %s", id),
 		o.logger,
+		o.requestHooks("AutoOptimizeVMCPUPinningSettings"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().
@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveVM(id VMID, retries ...RetryStrategy) (err error) {
 	err = retry(
 		fmt.Sprintf("removing VM %s", id),
 		o.logger,
+		o.requestHooks("RemoveVM"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Remove().Send()
@@ -27,6 +28,7 @@ func (m *mockClient) RemoveVM(id VMID, retries ...RetryStrategy) error {
 	return retry(
 		fmt.Sprintf("removing VM %s", id),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			m.lock.Lock()
@@ -35,13 +37,21 @@ func (m *mockClient) RemoveVM(id VMID, retries ...RetryStrategy) error {
 			if _, ok := m.vms[id]; !ok {
 				return newError(ENotFound, "VM with ID %s not found", id)
 			}
+			if err := m.checkVMNotLocked(id); err != nil {
+				return err
+			}
 
 			for _, diskAttachment := range m.vmDiskAttachmentsByVM[id] {
 				if m.disks[diskAttachment.DiskID()].status == DiskStatusLocked {
 					return newError(EConflict, "Cannot delete VM, disk %s is locked.", diskAttachment.DiskID())
 				}
-				delete(m.disks, diskAttachment.DiskID())
-				delete(m.vmDiskAttachmentsByDisk, diskAttachment.DiskID())
+			}
+			for _, diskAttachment := range m.vmDiskAttachmentsByVM[id] {
+				m.removeDiskAttachmentByDisk(diskAttachment)
+				// Like the engine, shareable disks are never removed with a VM, only detached.
+				if !m.disks[diskAttachment.DiskID()].shareable {
+					delete(m.disks, diskAttachment.DiskID())
+				}
 			}
 			for nicID, nic := range m.nics {
 				if nic.VMID() == id {
@@ -51,6 +61,8 @@ func (m *mockClient) RemoveVM(id VMID, retries ...RetryStrategy) error {
 			delete(m.vmIPs, id)
 			delete(m.vmDiskAttachmentsByVM, id)
 			delete(m.graphicsConsolesByVM, id)
+			delete(m.cdromsByVM, id)
+			delete(m.snapshots, id)
 			delete(m.vms, id)
 
 			return nil
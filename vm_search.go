@@ -91,6 +91,7 @@ func (o *oVirtClient) SearchVMs(params VMSearchParameters, retries ...RetryStrat
 	err = retry(
 		"searching for VMs",
 		o.logger,
+		o.requestHooks("SearchVMs"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().VmsService().List().Search(qs).Send()
@@ -10,6 +10,7 @@ func (o *oVirtClient) ShutdownVM(id VMID, force bool, retries ...RetryStrategy)
 	err = retry(
 		fmt.Sprintf("shutting down VM %s", id),
 		o.logger,
+		o.requestHooks("ShutdownVM"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Shutdown().Force(force).Send()
@@ -22,16 +23,21 @@ func (m *mockClient) ShutdownVM(id VMID, force bool, _ ...RetryStrategy) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	if item, ok := m.vms[id]; ok {
+		if err := m.checkVMNotLocked(id); err != nil {
+			return err
+		}
 		if (item.status == VMStatusSavingState || item.status == VMStatusRestoringState) && !force {
 			return newError(EConflict, "VM is currently backing up or restoring.")
 		}
 		if item.status != VMStatusDown {
 			item.status = VMStatusPoweringDown
+			m.emitVMEvent(EventCodeVMShutdownInitiated, item, "VM shutdown of %s initiated.", item.name)
 			go func() {
 				time.Sleep(2 * time.Second)
 				m.lock.Lock()
 				defer m.lock.Unlock()
 				item.status = VMStatusDown
+				m.emitVMEvent(EventCodeVMDown, item, "VM %s is down.", item.name)
 			}()
 		}
 		return nil
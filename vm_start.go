@@ -11,6 +11,7 @@ func (o *oVirtClient) StartVM(id VMID, retries ...RetryStrategy) (err error) {
 	err = retry(
 		fmt.Sprintf("starting VM %s", id),
 		o.logger,
+		o.requestHooks("StartVM"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Start().Send()
@@ -30,6 +31,9 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 	if item.Status() == VMStatusUp {
 		return nil
 	}
+	if err := m.checkVMNotLocked(id); err != nil {
+		return err
+	}
 
 	hostID, err := m.findSuitableHost(id)
 	if err != nil {
@@ -37,6 +41,7 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 	}
 	item.hostID = &hostID
 	item.status = VMStatusWaitForLaunch
+	m.resetCdroms(id)
 	go func() {
 		time.Sleep(2 * time.Second)
 		m.lock.Lock()
@@ -53,6 +58,7 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 			return
 		}
 		item.status = VMStatusUp
+		m.emitVMEvent(EventCodeVMStarted, item, "VM %s started on host %s.", item.name, *item.hostID)
 		m.lock.Unlock()
 		time.Sleep(10 * time.Second)
 		m.lock.Lock()
@@ -79,7 +85,47 @@ func (m *mockClient) StartVM(id VMID, _ ...RetryStrategy) error {
 	return nil
 }
 
-func (m *mockClient) findSuitableHost(vmID VMID) (HostID, error) {
+// findSuitableHost returns a host the VM may run on, skipping the excluded hosts. The caller must hold the lock.
+func (m *mockClient) findSuitableHost(vmID VMID, excludedHostIDs ...HostID) (HostID, error) {
+	// Try to find a host that is suitable.
+	for _, host := range m.hosts {
+		excluded := false
+		for _, excludedHostID := range excludedHostIDs {
+			if excludedHostID == host.id {
+				excluded = true
+			}
+		}
+		if !excluded && m.hostSuitableFor(vmID, host) {
+			return host.ID(), nil
+		}
+	}
+	return "", newError(EConflict, "no suitable host found matching affinity group rules")
+}
+
+// hostSuitableFor checks if the host is up and the VM may run on it according to its placement policy and the
+// enforcing affinity groups it is a member of. The caller must hold the lock.
+func (m *mockClient) hostSuitableFor(vmID VMID, host *host) bool {
+	if host.status != HostStatusUp {
+		return false
+	}
+	var allowedHostIDs []HostID
+	if vm, ok := m.vms[vmID]; ok {
+		if host.clusterID != vm.clusterID {
+			return false
+		}
+		if vm.placementPolicy != nil {
+			allowedHostIDs = vm.placementPolicy.hostIDs
+		}
+	}
+	hostSuitable := len(allowedHostIDs) == 0
+	for _, allowedHostID := range allowedHostIDs {
+		if allowedHostID == host.id {
+			hostSuitable = true
+		}
+	}
+	if !hostSuitable {
+		return false
+	}
 	var affectedAffinityGroups []*affinityGroup
 	for _, clusterAffinityGroups := range m.affinityGroups {
 		for _, affinityGroup := range clusterAffinityGroups {
@@ -88,31 +134,16 @@ func (m *mockClient) findSuitableHost(vmID VMID) (HostID, error) {
 			}
 		}
 	}
-	// Try to find a host that is suitable.
-	var foundHost *host
-	for _, host := range m.hosts {
-		hostSuitable := true
-	loop:
-		for _, vm := range m.vms {
-			if vm.hostID != nil && *vm.hostID == host.id {
-				// If the VM resides on the current host
-				for _, ag := range affectedAffinityGroups {
-					// Check if the VM is a member of the AGs we care about
-					if ag.hasVM(vm.id) {
-						hostSuitable = false
-						break loop
-					}
+	for _, vm := range m.vms {
+		// If another VM resides on the current host
+		if vm.id != vmID && vm.hostID != nil && *vm.hostID == host.id {
+			for _, ag := range affectedAffinityGroups {
+				// Check if the VM is a member of the AGs we care about
+				if ag.hasVM(vm.id) {
+					return false
 				}
 			}
 		}
-		if hostSuitable {
-			foundHost = host
-			break
-		}
-	}
-	if foundHost == nil {
-		return "", newError(EConflict, "no suitable host found matching affinity group rules")
 	}
-	hostID := foundHost.ID()
-	return hostID, nil
+	return true
 }
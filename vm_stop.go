@@ -11,6 +11,7 @@ func (o *oVirtClient) StopVM(id VMID, force bool, retries ...RetryStrategy) (err
 	err = retry(
 		fmt.Sprintf("stopping VM %s", id),
 		o.logger,
+		o.requestHooks("StopVM"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).Stop().Force(force).Send()
@@ -23,12 +24,16 @@ func (m *mockClient) StopVM(id VMID, force bool, _ ...RetryStrategy) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	if item, ok := m.vms[id]; ok {
+		if err := m.checkVMNotLocked(id); err != nil {
+			return err
+		}
 		if (item.status == VMStatusSavingState || item.status == VMStatusRestoringState) && !force {
 			return newError(EConflict, "VM is currently backing up or restoring.")
 		}
 		m.vmIPs[id] = map[string][]net.IP{}
 		if item.status != VMStatusDown {
 			item.status = VMStatusPoweringDown
+			m.emitVMEvent(EventCodeVMStopped, item, "VM %s powered off.", item.name)
 			go func() {
 				time.Sleep(2 * time.Second)
 				m.lock.Lock()
@@ -37,6 +42,7 @@ func (m *mockClient) StopVM(id VMID, force bool, _ ...RetryStrategy) error {
 					return
 				}
 				item.status = VMStatusDown
+				m.emitVMEvent(EventCodeVMDown, item, "VM %s is down.", item.name)
 				item.hostID = nil
 			}()
 		}
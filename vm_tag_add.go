@@ -11,6 +11,7 @@ func (o *oVirtClient) AddTagToVM(id VMID, tagID TagID, retries ...RetryStrategy)
 	err = retry(
 		fmt.Sprintf("adding tag %s to VM %s", tagID, id),
 		o.logger,
+		o.requestHooks("AddTagToVM"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).TagsService().Add().
@@ -46,6 +47,7 @@ func (o *oVirtClient) AddTagToVMByName(id VMID, tagName string, retries ...Retry
 	err = retry(
 		fmt.Sprintf("adding tag %s to VM %s", tagName, id),
 		o.logger,
+		o.requestHooks("AddTagToVMByName"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VmsService().VmService(string(id)).TagsService().Add().
@@ -9,6 +9,7 @@ func (o *oVirtClient) ListVMTags(id VMID, retries ...RetryStrategy) (result []Ta
 	err = retry(
 		fmt.Sprintf("listing tags for vm %s", id),
 		o.logger,
+		o.requestHooks("ListVMTags"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VmsService().VmService(string(id)).TagsService().List().Send()
@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveTagFromVM(id VMID, tagID TagID, retries ...RetryStra
 	err = retry(
 		fmt.Sprintf("removing tag from VM %s", id),
 		o.logger,
+		o.requestHooks("RemoveTagFromVM"),
 		retries,
 		func() error {
 			_, err := o.conn.
@@ -41,6 +41,7 @@ func (o *oVirtClient) UpdateVM(
 	err = retry(
 		fmt.Sprintf("updating vm %s", id),
 		o.logger,
+		o.requestHooks("UpdateVM"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VmsService().VmService(string(id)).Update().Vm(vm).Send()
@@ -72,6 +73,9 @@ func (m *mockClient) UpdateVM(id VMID, params UpdateVMParameters, _ ...RetryStra
 	if _, ok := m.vms[id]; !ok {
 		return nil, newError(ENotFound, "VM with ID %s not found", id)
 	}
+	if err := m.checkVMNotLocked(id); err != nil {
+		return nil, err
+	}
 
 	vm := m.vms[id]
 	if name := params.Name(); name != nil {
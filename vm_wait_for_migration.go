@@ -0,0 +1,53 @@
+package ovirtclient
+
+import (
+	"fmt"
+)
+
+func (o *oVirtClient) WaitForVMMigration(id VMID, sourceHostID HostID, retries ...RetryStrategy) (vm VM, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(o))
+	err = retry(
+		fmt.Sprintf("waiting for VM %s to migrate away from host %s", id, sourceHostID),
+		o.logger,
+		o.waitHooks("WaitForVMMigration"),
+		retries,
+		func() error {
+			vm, err = o.GetVM(id, retries...)
+			if err != nil {
+				return err
+			}
+			return checkVMMigrated(vm, sourceHostID)
+		})
+	return
+}
+
+func (m *mockClient) WaitForVMMigration(id VMID, sourceHostID HostID, retries ...RetryStrategy) (vm VM, err error) {
+	retries = defaultRetries(retries, defaultLongTimeouts(m))
+	err = retry(
+		fmt.Sprintf("waiting for VM %s to migrate away from host %s", id, sourceHostID),
+		m.logger,
+		nil,
+		retries,
+		func() error {
+			vm, err = m.GetVM(id, retries...)
+			if err != nil {
+				return err
+			}
+			return checkVMMigrated(vm, sourceHostID)
+		})
+	return
+}
+
+func checkVMMigrated(vm VM, sourceHostID HostID) error {
+	if vm.Status() != VMStatusUp {
+		return newError(EPending, "VM status is %s, not %s", vm.Status(), VMStatusUp)
+	}
+	hostID := vm.HostID()
+	if hostID == nil {
+		return newError(EPending, "VM %s has no host yet", vm.ID())
+	}
+	if *hostID == sourceHostID {
+		return newError(EPending, "VM %s is still running on host %s", vm.ID(), sourceHostID)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ func (o *oVirtClient) WaitForVMStatus(id VMID, status VMStatus, retries ...Retry
 	err = retry(
 		fmt.Sprintf("waiting for VM %s status %s", id, status),
 		o.logger,
+		o.waitHooks("WaitForVMStatus"),
 		retries,
 		func() error {
 			vm, err = o.GetVM(id, retries...)
@@ -28,6 +29,7 @@ func (m *mockClient) WaitForVMStatus(id VMID, status VMStatus, retries ...RetryS
 	err = retry(
 		fmt.Sprintf("waiting for VM %s status %s", id, status),
 		m.logger,
+		nil,
 		retries,
 		func() error {
 			vm, err = m.GetVM(id, retries...)
@@ -22,6 +22,7 @@ func (o *oVirtClient) CreateVNICProfile(
 	err = retry(
 		fmt.Sprintf("creating VNIC profile %s", name),
 		o.logger,
+		o.requestHooks("CreateVNICProfile"),
 		retries,
 		func() error {
 			profileBuilder := ovirtsdk.NewVnicProfileBuilder()
@@ -11,6 +11,7 @@ func (o *oVirtClient) GetVNICProfile(id VNICProfileID, retries ...RetryStrategy)
 	err = retry(
 		fmt.Sprintf("getting VNIC profile %s", id),
 		o.logger,
+		o.requestHooks("GetVNICProfile"),
 		retries,
 		func() error {
 			response, err := o.conn.SystemService().VnicProfilesService().ProfileService(string(id)).Get().Send()
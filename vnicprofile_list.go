@@ -8,6 +8,7 @@ func (o *oVirtClient) ListVNICProfiles(retries ...RetryStrategy) (result []VNICP
 	err = retry(
 		"listing VNIC profiles",
 		o.logger,
+		o.requestHooks("ListVNICProfiles"),
 		retries,
 		func() error {
 			response, e := o.conn.SystemService().VnicProfilesService().List().Send()
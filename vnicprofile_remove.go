@@ -9,6 +9,7 @@ func (o *oVirtClient) RemoveVNICProfile(id VNICProfileID, retries ...RetryStrate
 	err = retry(
 		fmt.Sprintf("removing VNIC profile %s", id),
 		o.logger,
+		o.requestHooks("RemoveVNICProfile"),
 		retries,
 		func() error {
 			_, err := o.conn.SystemService().VnicProfilesService().ProfileService(string(id)).Remove().Send()